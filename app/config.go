@@ -74,6 +74,7 @@ var (
 	FS_PUBLIC_DIR_PATH = "storages"
 	FS_END_POINT       = "s3.amazonaws.com"
 	FS_PORT            = 443
+	FS_USE_SSL         = true
 	FS_REGION          = "ap-southeast-3"
 	FS_BUCKET_NAME     = "attachments"
 	FS_ACCESS_KEY      = ""
@@ -81,6 +82,14 @@ var (
 
 	TELEGRAM_ALERT_TOKEN   = ""
 	TELEGRAM_ALERT_USER_ID = ""
+
+	WARRANTY_EXPIRY_NOTIFY_DAYS = 30 // days before the warranty end date to raise an expiry notification
+	LICENSE_EXPIRY_NOTIFY_DAYS  = 30 // days before the license expiry date to raise an expiry notification
+
+	DEPRECIATION_CONVENTION = "full_month" // default depreciation convention of the first month (full_month, mid_month, actual_days or start_next_month), a category may override it
+
+	JOURNAL_CLEARING_ACCOUNT = ""   // GL account of the disposal proceeds on the journal export
+	DEFERRED_TAX_RATE        = 22.0 // income tax rate (%) of the deferred tax on the book difference report
 )
 
 // config is a pointer to a configUtil instance.
@@ -147,8 +156,12 @@ func (*configUtil) configure() {
 	grest.LoadEnv("REDIS_USERNAME", &REDIS_USERNAME)
 	grest.LoadEnv("REDIS_PASSWORD", &REDIS_PASSWORD)
 
+	grest.LoadEnv("FS_DRIVER", &FS_DRIVER)
+	grest.LoadEnv("FS_LOCAL_DIR_PATH", &FS_LOCAL_DIR_PATH)
+	grest.LoadEnv("FS_PUBLIC_DIR_PATH", &FS_PUBLIC_DIR_PATH)
 	grest.LoadEnv("FS_END_POINT", &FS_END_POINT)
 	grest.LoadEnv("FS_PORT", &FS_PORT)
+	grest.LoadEnv("FS_USE_SSL", &FS_USE_SSL)
 	grest.LoadEnv("FS_REGION", &FS_REGION)
 	grest.LoadEnv("FS_BUCKET_NAME", &FS_BUCKET_NAME)
 	grest.LoadEnv("FS_ACCESS_KEY", &FS_ACCESS_KEY)
@@ -156,4 +169,12 @@ func (*configUtil) configure() {
 
 	grest.LoadEnv("TELEGRAM_ALERT_TOKEN", &TELEGRAM_ALERT_TOKEN)
 	grest.LoadEnv("TELEGRAM_ALERT_USER_ID", &TELEGRAM_ALERT_USER_ID)
+
+	grest.LoadEnv("WARRANTY_EXPIRY_NOTIFY_DAYS", &WARRANTY_EXPIRY_NOTIFY_DAYS)
+	grest.LoadEnv("LICENSE_EXPIRY_NOTIFY_DAYS", &LICENSE_EXPIRY_NOTIFY_DAYS)
+
+	grest.LoadEnv("DEPRECIATION_CONVENTION", &DEPRECIATION_CONVENTION)
+
+	grest.LoadEnv("JOURNAL_CLEARING_ACCOUNT", &JOURNAL_CLEARING_ACCOUNT)
+	grest.LoadEnv("DEFERRED_TAX_RATE", &DEFERRED_TAX_RATE)
 }
@@ -15,9 +15,10 @@ type Ctx struct {
 	Action    Action // informasi umum terkait request
 	Err       error
 
-	IsAsync  bool     // for async use, autocommit
-	mainTx   *gorm.DB // for normal use, commit & rollback from middleware
-	FiberCtx *fiber.Ctx
+	IsAsync     bool      // for async use, autocommit
+	mainTx      *gorm.DB  // for normal use, commit & rollback from middleware
+	afterCommit *[]func() // called after mainTx is committed, shared by the copies of the ctx
+	FiberCtx    *fiber.Ctx
 }
 
 type Action struct {
@@ -39,6 +40,7 @@ func (c *Ctx) TxBegin() error {
 		return err
 	}
 	c.mainTx = mainTx.Begin()
+	c.afterCommit = &[]func(){}
 	return nil
 }
 
@@ -46,12 +48,15 @@ func (c *Ctx) TxBegin() error {
 // Called in middleware when there is no error (http status code is 2xx).
 // It does nothing if there is no active transaction.
 func (c *Ctx) TxCommit() {
-	if c.mainTx != nil {
-		c.mainTx.Commit()
+	if c.mainTx != nil && c.mainTx.Commit().Error == nil && c.afterCommit != nil {
+		for _, fn := range *c.afterCommit {
+			fn()
+		}
 	}
 
 	// reset to nil to use gorm autocommit if use goroutine, etc
 	c.mainTx = nil
+	c.afterCommit = nil
 }
 
 // TxRollback rolls back the current transaction if it exists (mainTx is not nil).
@@ -63,6 +68,18 @@ func (c *Ctx) TxRollback() {
 	}
 	// reset to nil to use gorm autocommit if use goroutine, etc
 	c.mainTx = nil
+	c.afterCommit = nil
+}
+
+// OnCommit registers a function which is called after the current transaction is committed, e.g. to delete a stored file
+// which must survive a rollback. It is not called when the transaction is rolled back, and it is called immediately
+// when there is no active transaction (autocommit).
+func (c Ctx) OnCommit(fn func()) {
+	if c.IsAsync || c.mainTx == nil || c.afterCommit == nil {
+		fn()
+		return
+	}
+	*c.afterCommit = append(*c.afterCommit, fn)
 }
 
 // Trans translates a given key using the language specified in the context (c.Lang).
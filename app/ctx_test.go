@@ -0,0 +1,26 @@
+package app
+
+import (
+	"testing"
+
+	"gorm.io/gorm"
+)
+
+func TestOnCommit(t *testing.T) {
+	called := 0
+	fn := func() { called++ }
+
+	// without a transaction the function is called immediately
+	Ctx{}.OnCommit(fn)
+	if called != 1 {
+		t.Errorf("Expected called [1], got [%v]", called)
+	}
+
+	// with a transaction the function waits for the commit, also when registered on a copy of the ctx
+	ctx := Ctx{mainTx: &gorm.DB{}, afterCommit: &[]func(){}}
+	copied := ctx
+	copied.OnCommit(fn)
+	if called != 1 || len(*ctx.afterCommit) != 1 {
+		t.Errorf("Expected the function to wait for the commit, got called [%v] and registered [%v]", called, len(*ctx.afterCommit))
+	}
+}
@@ -3,7 +3,9 @@ package app
 import (
 	"context"
 	"io"
+	"net"
 	"os"
+	"strconv"
 
 	"github.com/jinzhu/copier"
 	"github.com/minio/minio-go/v7"
@@ -34,6 +36,7 @@ type fsUtil struct {
 	PublicDirPath string
 	EndPoint      string
 	Port          int
+	UseSSL        bool
 	Region        string
 	BucketName    string
 	AccessKey     string
@@ -57,17 +60,26 @@ func (f fsUtil) configure() *fsUtil {
 	f.Driver = FS_DRIVER
 	f.EndPoint = FS_END_POINT
 	f.Port = FS_PORT
+	f.UseSSL = FS_USE_SSL
 	f.Region = FS_REGION
 	f.BucketName = FS_BUCKET_NAME
 	f.AccessKey = FS_ACCESS_KEY
 	f.SecretKey = FS_SECRET_KEY
-	f.mClient, f.err = minio.New(f.EndPoint, &minio.Options{
+
+	// the port is added to the end point unless it is the default port or the end point already has one, e.g. a MinIO server
+	endPoint := f.EndPoint
+	if _, _, err := net.SplitHostPort(endPoint); err != nil && f.Port > 0 && f.Port != 80 && f.Port != 443 {
+		endPoint = net.JoinHostPort(endPoint, strconv.Itoa(f.Port))
+	}
+	f.mClient, f.err = minio.New(endPoint, &minio.Options{
 		Creds:  credentials.NewStaticV4(f.AccessKey, f.SecretKey, ""),
-		Secure: true,
+		Secure: f.UseSSL,
+		Region: f.Region,
 	})
 	if f.err != nil {
 		return f.setLocalFS()
 	}
+	f.EndPoint = endPoint
 	f.ctx = context.Background()
 	isBucketExists := false
 	isBucketExists, f.err = f.mClient.BucketExists(f.ctx, f.BucketName)
@@ -119,13 +131,29 @@ func (f fsUtil) createLocalDirPath() {
 
 // GetFileUrl constructs and returns the URL for accessing a file.
 // It considers the configuration of the filesystem utility and the provided filename and path.
-// The resulting URL depends on the storage driver and the endpoint being used.
+// The resulting URL depends on the storage driver and the endpoint being used,
+// AWS S3 uses the virtual hosted style and the other S3 compatible storages like MinIO use the path style.
 func (f *fsUtil) GetFileUrl(fileName string, path ...string) string {
-	res := APP_URL + "/" + f.PublicDirPath
+	res := APP_URL
 	if f.EndPoint == "s3.amazonaws.com" {
-		res = "https://" + f.BucketName + ".s3." + f.Region + ".amazonaws.com/"
+		res = "https://" + f.BucketName + ".s3." + f.Region + ".amazonaws.com"
 	} else if f.Driver != "local" {
-		res = "https://" + f.BucketName + "." + f.EndPoint + "/"
+		res = "http://" + f.EndPoint
+		if f.UseSSL {
+			res = "https://" + f.EndPoint
+		}
+	}
+	return res + f.GetFilePath(fileName, path...)
+}
+
+// GetFilePath constructs and returns the path of a file relative to the host of its URL,
+// it is the public dir path for the local filesystem, the bucket for a path style storage and the root for AWS S3.
+func (f *fsUtil) GetFilePath(fileName string, path ...string) string {
+	res := "/"
+	if f.Driver == "local" {
+		res += f.PublicDirPath + "/"
+	} else if f.EndPoint != "s3.amazonaws.com" {
+		res += f.BucketName + "/"
 	}
 
 	for _, p := range path {
@@ -144,7 +172,7 @@ func (f *fsUtil) Upload(fileName string, src io.Reader, fileSize int64, opts ...
 	if f.Driver == "local" {
 		dst, err := os.Create(f.LocalDirPath + "/" + fileName)
 		if err != nil {
-			return FileUploadInfo{}, nil
+			return FileUploadInfo{}, err
 		}
 		defer dst.Close()
 		_, err = io.Copy(dst, src)
@@ -152,10 +180,13 @@ func (f *fsUtil) Upload(fileName string, src io.Reader, fileSize int64, opts ...
 	}
 
 	opt := minio.PutObjectOptions{}
-	opt.UserMetadata = map[string]string{"x-amz-acl": "public-read"}
 	if len(opts) > 0 {
-		copier.Copy(opt, opts[0])
+		copier.Copy(&opt, &opts[0].PutObjectOptions)
+	}
+	if opt.UserMetadata == nil {
+		opt.UserMetadata = map[string]string{}
 	}
+	opt.UserMetadata["x-amz-acl"] = "public-read"
 	info, err := f.mClient.PutObject(f.ctx, f.BucketName, fileName, src, fileSize, opt)
 	return FileUploadInfo{info}, err
 }
@@ -172,7 +203,7 @@ func (f *fsUtil) Delete(fileName string, opts ...FileDeleteOption) error {
 	opt := minio.RemoveObjectOptions{}
 	opt.GovernanceBypass = true
 	if len(opts) > 0 {
-		copier.Copy(opt, opts[0])
+		copier.Copy(&opt, &opts[0].RemoveObjectOptions)
 	}
 	return f.mClient.RemoveObject(f.ctx, f.BucketName, fileName, opt)
 }
@@ -0,0 +1,185 @@
+package app
+
+import (
+	"bufio"
+	"bytes"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+)
+
+// fakeObjectStore is an in-process S3 compatible server that keeps the buckets and objects in memory.
+type fakeObjectStore struct {
+	mu      sync.Mutex
+	buckets map[string]bool
+	objects map[string][]byte
+}
+
+func (s *fakeObjectStore) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	bucket, key, _ := strings.Cut(strings.TrimPrefix(r.URL.Path, "/"), "/")
+	if key == "" {
+		switch r.Method {
+		case http.MethodHead:
+			if !s.buckets[bucket] {
+				w.WriteHeader(http.StatusNotFound)
+			}
+		case http.MethodPut:
+			s.buckets[bucket] = true
+		default:
+			w.WriteHeader(http.StatusMethodNotAllowed)
+		}
+		return
+	}
+
+	if !s.buckets[bucket] {
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+	switch r.Method {
+	case http.MethodPut:
+		body, err := readObjectBody(r)
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		s.objects[bucket+"/"+key] = body
+		w.Header().Set("ETag", `"`+strconv.Itoa(len(body))+`"`)
+	case http.MethodGet:
+		body, ok := s.objects[bucket+"/"+key]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.Write(body)
+	case http.MethodDelete:
+		delete(s.objects, bucket+"/"+key)
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		w.WriteHeader(http.StatusMethodNotAllowed)
+	}
+}
+
+// readObjectBody reads the uploaded object, decoding the aws-chunked body used by the streaming signature.
+func readObjectBody(r *http.Request) ([]byte, error) {
+	if !strings.HasPrefix(r.Header.Get("X-Amz-Content-Sha256"), "STREAMING-") {
+		return io.ReadAll(r.Body)
+	}
+	body := []byte{}
+	br := bufio.NewReader(r.Body)
+	for {
+		line, err := br.ReadString('\n')
+		if err != nil {
+			return nil, err
+		}
+		sizeHex, _, _ := strings.Cut(strings.TrimSpace(line), ";")
+		size, err := strconv.ParseInt(sizeHex, 16, 64)
+		if err != nil {
+			return nil, err
+		}
+		if size == 0 {
+			return body, nil
+		}
+		chunk := make([]byte, size)
+		_, err = io.ReadFull(br, chunk)
+		if err != nil {
+			return nil, err
+		}
+		body = append(body, chunk...)
+		_, err = br.Discard(2)
+		if err != nil {
+			return nil, err
+		}
+	}
+}
+
+func setupFakeObjectStore(t *testing.T) *fakeObjectStore {
+	store := &fakeObjectStore{buckets: map[string]bool{}, objects: map[string][]byte{}}
+	server := httptest.NewServer(store)
+
+	driver, endPoint, port, useSSL := FS_DRIVER, FS_END_POINT, FS_PORT, FS_USE_SSL
+	region, bucketName, accessKey, secretKey := FS_REGION, FS_BUCKET_NAME, FS_ACCESS_KEY, FS_SECRET_KEY
+	t.Cleanup(func() {
+		server.Close()
+		FS_DRIVER, FS_END_POINT, FS_PORT, FS_USE_SSL = driver, endPoint, port, useSSL
+		FS_REGION, FS_BUCKET_NAME, FS_ACCESS_KEY, FS_SECRET_KEY = region, bucketName, accessKey, secretKey
+		fsClient = nil
+	})
+
+	FS_DRIVER = "minio"
+	FS_END_POINT = strings.TrimPrefix(server.URL, "http://")
+	FS_PORT = 0
+	FS_USE_SSL = false
+	FS_REGION = "us-east-1"
+	FS_BUCKET_NAME = "attachments"
+	FS_ACCESS_KEY = "access-key"
+	FS_SECRET_KEY = "secret-key"
+	fsClient = nil
+	return store
+}
+
+func TestFSConfigureCreatesBucket(t *testing.T) {
+	store := setupFakeObjectStore(t)
+	if FS().Driver != "minio" {
+		t.Fatalf("Expected driver [minio], got [%v], error [%v]", FS().Driver, FS().err)
+	}
+	if !store.buckets["attachments"] {
+		t.Errorf("Expected bucket [attachments] to be created")
+	}
+}
+
+func TestFSUploadAndDelete(t *testing.T) {
+	store := setupFakeObjectStore(t)
+	content := []byte("hello attachment")
+
+	opt := FileUploadOption{}
+	opt.ContentType = "text/plain"
+	_, err := FS().Upload("hello.txt", bytes.NewReader(content), int64(len(content)), opt)
+	if err != nil {
+		t.Fatalf("Error occurred [%v]", err)
+	}
+	if got := store.objects["attachments/hello.txt"]; !bytes.Equal(got, content) {
+		t.Errorf("Expected object [%s], got [%s]", content, got)
+	}
+
+	err = FS().Delete("hello.txt")
+	if err != nil {
+		t.Fatalf("Error occurred [%v]", err)
+	}
+	if _, ok := store.objects["attachments/hello.txt"]; ok {
+		t.Errorf("Expected object [hello.txt] to be deleted")
+	}
+}
+
+func TestFSGetFileUrl(t *testing.T) {
+	setupFakeObjectStore(t)
+	content := []byte("hello attachment")
+	_, err := FS().Upload("hello.txt", bytes.NewReader(content), int64(len(content)))
+	if err != nil {
+		t.Fatalf("Error occurred [%v]", err)
+	}
+
+	expected := "http://" + FS_END_POINT + "/attachments/hello.txt"
+	if got := FS().GetFileUrl("hello.txt"); got != expected {
+		t.Errorf("Expected url [%v], got [%v]", expected, got)
+	}
+	if got := FS().GetFilePath("hello.txt"); got != "/attachments/hello.txt" {
+		t.Errorf("Expected path [/attachments/hello.txt], got [%v]", got)
+	}
+
+	res, err := http.Get(FS().GetFileUrl("hello.txt"))
+	if err != nil {
+		t.Fatalf("Error occurred [%v]", err)
+	}
+	defer res.Body.Close()
+	got, _ := io.ReadAll(res.Body)
+	if !bytes.Equal(got, content) {
+		t.Errorf("Expected file [%s], got [%s]", content, got)
+	}
+}
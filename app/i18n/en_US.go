@@ -2,15 +2,95 @@ package i18n
 
 func EnUS() map[string]string {
 	return map[string]string{
-		"400_bad_request":              "The request cannot be performed because of malformed or missing parameters.",
-		"401_unauthorized":             "Unauthorized. Please Re-Login",
-		"403_forbidden":                "The user does not have permission to :action.",
-		"404_not_found":                "The resource you have specified cannot be found.",
-		"500_internal_error":           "Failed to connect to the server, please try again later.",
-		"invalid_username_or_password": "Invalid username or password",
-		"duplicate_entity_key_value":   "The :entity with :key :value already exists",
-		`required_key`:                 `:key is required!`,
-		`not_found`:                    `Not Found`,
-		`entity_key_value_not_found`:   `:entity data with :key = :value cannot be found.`,
+		"400_bad_request":                         "The request cannot be performed because of malformed or missing parameters.",
+		"401_unauthorized":                        "Unauthorized. Please Re-Login",
+		"403_forbidden":                           "The user does not have permission to :action.",
+		"404_not_found":                           "The resource you have specified cannot be found.",
+		"500_internal_error":                      "Failed to connect to the server, please try again later.",
+		"invalid_username_or_password":            "Invalid username or password",
+		"duplicate_entity_key_value":              "The :entity with :key :value already exists",
+		`required_key`:                            `:key is required!`,
+		`not_found`:                               `Not Found`,
+		`entity_key_value_not_found`:              `:entity data with :key = :value cannot be found.`,
+		`purchase_request_not_approved`:           `The purchase request :code must be approved before it can be ordered.`,
+		`purchase_order_not_editable`:             `The purchase order :code with status :status can no longer be changed.`,
+		`purchase_order_not_receivable`:           `The purchase order :code with status :status cannot be received.`,
+		`purchase_order_transition_invalid`:       `The purchase order :code can not be changed from :from to :to.`,
+		`goods_receipt_assets_in_use`:             `The goods receipt :code can not be deleted because its asset :asset is :status or has been assigned.`,
+		`receive_qty_exceeds_remaining`:           `Received quantity :qty for :item exceeds the remaining ordered quantity :remaining.`,
+		`warranty_invalid_period`:                 `The warranty end date must not be earlier than the start date.`,
+		`category_attribute_invalid`:              `The attribute :name is duplicated or has no options for the enum type.`,
+		`asset_attribute_invalid`:                 `The attribute :name must be a valid :type value.`,
+		`asset_attribute_unknown`:                 `The attribute :name is not defined in the category :category.`,
+		`asset_parent_cycle`:                      `The asset :code can not be the parent because it is the asset itself or one of its descendants.`,
+		`employee_asset_child_assigned`:           `The child asset :asset is still assigned to another employee, return it before assigning its parent.`,
+		`stock_insufficient`:                      `Insufficient stock of :item on branch :branch, only :qty left.`,
+		`stock_movement_qty_positive`:             `The quantity of a :type movement must be greater than zero.`,
+		`stock_transfer_branch_invalid`:           `The destination branch of a transfer must be filled and differ from the source branch.`,
+		`stock_issue_recipient_invalid`:           `Only an issue can be made to a department, and an issue is made to either an employee or a department.`,
+		`stock_movement_part_locked`:              `Stock movement :code is the issue of a maintenance spare part, change the maintenance instead.`,
+		`license_invalid_period`:                  `The license expiry date must not be earlier than the purchase date.`,
+		`license_seats_below_used`:                `The seats can not be reduced to :seats because :used seats are still assigned.`,
+		`license_in_use`:                          `The license :product can not be deleted because :used seats are still assigned.`,
+		`license_seat_assignee_required`:          `A license seat must be assigned to either an employee or an asset.`,
+		`license_seat_already_assigned`:           `The license :product is already assigned to :assignee.`,
+		`license_seat_exceeded`:                   `All :seats seats of the license :product are already assigned.`,
+		`license_seat_invalid_period`:             `The released date must not be earlier than the assigned date.`,
+		`location_parent_invalid`:                 `A location of type :type must be placed under a :parent_type.`,
+		`location_in_use`:                         `The location :name can not be deleted because it still has child locations or assets.`,
+		`asset_tagged`:                            `:count assets have been tagged.`,
+		`asset_untagged`:                          `:count assets have been untagged.`,
+		`fiscal_period_invalid`:                   `The fiscal period end date must not be earlier than the start date.`,
+		`fiscal_period_overlap`:                   `The fiscal period overlaps with the fiscal period :code.`,
+		`fiscal_period_status_invalid`:            `The fiscal period :code is :status.`,
+		`fiscal_period_closed`:                    `The fiscal period :code is closed, the data on :date can not be changed.`,
+		`asset_disposal_date_invalid`:             `The disposal date must not be earlier than the input date.`,
+		`journal_account_required`:                `The :account account of the category :category is not set.`,
+		`journal_unbalanced`:                      `The journal entry :reference is not balanced.`,
+		`asset_disposed`:                          `The asset :code has been disposed.`,
+		`asset_revaluation_date_invalid`:          `The revaluation date must not be earlier than :date.`,
+		`asset_revaluation_date_future`:           `The revaluation date must not be later than today.`,
+		`asset_revaluation_locked`:                `The revaluation can not be deleted because the depreciation has been posted after it.`,
+		`asset_depreciation_closed`:               `The depreciation of asset :code is posted in a closed fiscal period on :date, its price, input date and convention can no longer be changed.`,
+		`asset_depreciation_adjusted`:             `The depreciation of asset :code has a revaluation, an impairment or a capitalization on :date, remove it before changing the depreciation.`,
+		`asset_revaluation_immutable`:             `The type, date, amount and asset of the revaluation can not be changed, delete it and create a new one.`,
+		`asset_impairment_amount_invalid`:         `The impairment amount must be lower than the current carrying amount :amount.`,
+		`depreciation_book_invalid`:               `The depreciation book :book is invalid, use commercial or tax.`,
+		`maintenance_plan_target_invalid`:         `The maintenance plan must apply to either an asset or a category.`,
+		`work_order_closed`:                       `The work order :code is :status and can not be changed.`,
+		`work_order_status_invalid`:               `The work order :code can not be set to :status directly.`,
+		`work_order_transition_invalid`:           `The work order :code can not be changed from :from to :to.`,
+		`work_order_not_deletable`:                `The work order :code is :status, only an open work order can be deleted.`,
+		`work_order_asset_immutable`:              `The asset of the work order :code can not be changed.`,
+		`asset_deleted`:                           `The asset :code has been deleted.`,
+		`maintenance_amount_invalid`:              `The maintenance amount can not be negative.`,
+		`maintenance_life_extension_invalid`:      `The life extension is only allowed for a capitalized maintenance.`,
+		`maintenance_capitalization_date_future`:  `The date of a capitalized maintenance must not be later than today.`,
+		`maintenance_capitalization_date_invalid`: `The date of a capitalized maintenance must not be earlier than :date.`,
+		`maintenance_capitalization_locked`:       `The capitalized maintenance can not be changed or deleted because the depreciation has been posted after it.`,
+		`meter_depreciation_exists`:               `The category already has the depreciation meter :code.`,
+		`meter_category_invalid`:                  `The meter :meter is not an active meter of the category of the asset :asset.`,
+		`meter_reading_immutable`:                 `The asset and the meter of the meter reading can not be changed, delete it and create a new one.`,
+		`meter_reading_locked`:                    `Only the last reading of the meter can be changed or deleted.`,
+		`meter_reading_date_future`:               `The reading date must not be later than now.`,
+		`meter_reading_date_invalid`:              `The reading date must not be earlier than the last reading on :date.`,
+		`meter_reading_decreased`:                 `The reading must not be lower than the last reading :value unless the meter is replaced.`,
+		`maintenance_plan_meter_invalid`:          `The meter :meter is not a meter of the category of the maintenance plan.`,
+		`maintenance_part_invalid`:                `The consumable :code is not an active spare part.`,
+		`report_group_invalid`:                    `The report can not be grouped by :group, use asset, category or vendor.`,
+		`incident_closed`:                         `The incident :code is :status and can not be changed.`,
+		`incident_status_invalid`:                 `The incident :code can not be set to :status directly.`,
+		`incident_transition_invalid`:             `The incident :code can not be changed from :from to :to.`,
+		`incident_not_deletable`:                  `The incident :code is :status, only a new incident can be deleted.`,
+		`incident_asset_immutable`:                `The asset and the employee of the incident :code can not be changed.`,
+		`incident_asset_not_held`:                 `The asset :asset is not currently assigned to :employee.`,
+		`incident_not_convertible`:                `The incident :code is a loss and can not be converted into a work order.`,
+		`liability_case_closed`:                   `The liability case :code is :status and can not be changed.`,
+		`liability_case_status_invalid`:           `The liability case :code can not be set to :status directly.`,
+		`liability_case_transition_invalid`:       `The liability case :code can not be changed from :from to :to.`,
+		`liability_case_not_deletable`:            `The liability case :code is :status, only a draft case can be deleted.`,
+		`liability_case_date_invalid`:             `The incident date must not be in the future and must be within the assignment of :asset to :employee.`,
+		`liability_charge_invalid`:                `The charge amount must not exceed the book value :amount.`,
+		`liability_payment_invalid`:               `The repayment must not exceed the outstanding amount :amount.`,
 	}
 }
@@ -2,15 +2,95 @@ package i18n
 
 func IdID() map[string]string {
 	return map[string]string{
-		"400_bad_request":              "Permintaan tidak dapat dilakukan karena ada parameter yang salah atau tidak lengkap.",
-		"401_unauthorized":             "Token otentikasi tidak valid. Silakan logout dan login ulang",
-		"403_forbidden":                "Pengguna tidak memiliki izin untuk :action.",
-		"404_not_found":                "The resource you have specified cannot be found.",
-		"500_internal_error":           "Gagal terhubung ke server, silakan coba lagi nanti.",
-		"invalid_username_or_password": "Username atau kata sandi tidak valid",
-		`duplicate_entity_key_value`:   `Data :entity dengan :key = :value sudah ada.`,
-		`required_key`:                 `:key wajib diisi!`,
-		`not_found`:                    `tidak ditemukan`,
-		`entity_key_value_not_found`:   `Data :entity dengan :key = :value tidak ditemukan.`,
+		"400_bad_request":                         "Permintaan tidak dapat dilakukan karena ada parameter yang salah atau tidak lengkap.",
+		"401_unauthorized":                        "Token otentikasi tidak valid. Silakan logout dan login ulang",
+		"403_forbidden":                           "Pengguna tidak memiliki izin untuk :action.",
+		"404_not_found":                           "The resource you have specified cannot be found.",
+		"500_internal_error":                      "Gagal terhubung ke server, silakan coba lagi nanti.",
+		"invalid_username_or_password":            "Username atau kata sandi tidak valid",
+		`duplicate_entity_key_value`:              `Data :entity dengan :key = :value sudah ada.`,
+		`required_key`:                            `:key wajib diisi!`,
+		`not_found`:                               `tidak ditemukan`,
+		`entity_key_value_not_found`:              `Data :entity dengan :key = :value tidak ditemukan.`,
+		`purchase_request_not_approved`:           `Permintaan pembelian :code harus disetujui sebelum dapat dipesan.`,
+		`purchase_order_not_editable`:             `Pesanan pembelian :code dengan status :status tidak dapat diubah lagi.`,
+		`purchase_order_not_receivable`:           `Pesanan pembelian :code dengan status :status tidak dapat diterima.`,
+		`purchase_order_transition_invalid`:       `Pesanan pembelian :code tidak dapat diubah dari :from menjadi :to.`,
+		`goods_receipt_assets_in_use`:             `Penerimaan barang :code tidak dapat dihapus karena aset :asset berstatus :status atau sudah pernah diserahkan.`,
+		`receive_qty_exceeds_remaining`:           `Jumlah diterima :qty untuk :item melebihi sisa jumlah pesanan :remaining.`,
+		`warranty_invalid_period`:                 `Tanggal akhir garansi tidak boleh lebih awal dari tanggal mulai.`,
+		`category_attribute_invalid`:              `Atribut :name duplikat atau tidak memiliki pilihan untuk tipe enum.`,
+		`asset_attribute_invalid`:                 `Atribut :name harus berupa nilai :type yang valid.`,
+		`asset_attribute_unknown`:                 `Atribut :name tidak terdefinisi pada kategori :category.`,
+		`asset_parent_cycle`:                      `Asset :code tidak dapat menjadi parent karena merupakan asset itu sendiri atau salah satu turunannya.`,
+		`employee_asset_child_assigned`:           `Aset anak :asset masih diserahkan ke karyawan lain, kembalikan terlebih dahulu sebelum menyerahkan induknya.`,
+		`stock_insufficient`:                      `Stok :item pada branch :branch tidak mencukupi, hanya tersisa :qty.`,
+		`stock_movement_qty_positive`:             `Jumlah pada mutasi :type harus lebih besar dari nol.`,
+		`stock_transfer_branch_invalid`:           `Branch tujuan transfer harus diisi dan berbeda dengan branch asal.`,
+		`stock_issue_recipient_invalid`:           `Hanya mutasi issue yang dapat dibuat ke departemen, dan issue dibuat ke karyawan atau departemen saja.`,
+		`stock_movement_part_locked`:              `Mutasi stok :code adalah pengeluaran spare part maintenance, ubah maintenance tersebut.`,
+		`license_invalid_period`:                  `Tanggal berakhir lisensi tidak boleh lebih awal dari tanggal pembelian.`,
+		`license_seats_below_used`:                `Jumlah seat tidak dapat dikurangi menjadi :seats karena masih ada :used seat yang terpakai.`,
+		`license_in_use`:                          `Lisensi :product tidak dapat dihapus karena masih ada :used seat yang terpakai.`,
+		`license_seat_assignee_required`:          `Seat lisensi harus diberikan ke salah satu dari employee atau asset.`,
+		`license_seat_already_assigned`:           `Lisensi :product sudah diberikan ke :assignee.`,
+		`license_seat_exceeded`:                   `Seluruh :seats seat lisensi :product sudah terpakai.`,
+		`license_seat_invalid_period`:             `Tanggal pelepasan tidak boleh lebih awal dari tanggal pemberian.`,
+		`location_parent_invalid`:                 `Lokasi dengan tipe :type harus berada di bawah :parent_type.`,
+		`location_in_use`:                         `Lokasi :name tidak dapat dihapus karena masih memiliki lokasi turunan atau asset.`,
+		`asset_tagged`:                            `:count asset berhasil diberi tag.`,
+		`asset_untagged`:                          `Tag berhasil dihapus dari :count asset.`,
+		`fiscal_period_invalid`:                   `Tanggal akhir periode fiskal tidak boleh lebih awal dari tanggal mulai.`,
+		`fiscal_period_overlap`:                   `Periode fiskal bertumpang tindih dengan periode fiskal :code.`,
+		`fiscal_period_status_invalid`:            `Periode fiskal :code berstatus :status.`,
+		`fiscal_period_closed`:                    `Periode fiskal :code sudah ditutup, data pada tanggal :date tidak dapat diubah.`,
+		`asset_disposal_date_invalid`:             `Tanggal pelepasan tidak boleh lebih awal dari tanggal input.`,
+		`journal_account_required`:                `Akun :account pada kategori :category belum diatur.`,
+		`journal_unbalanced`:                      `Entri jurnal :reference tidak seimbang.`,
+		`asset_disposed`:                          `Asset :code sudah dilepas.`,
+		`asset_revaluation_date_invalid`:          `Tanggal revaluasi tidak boleh lebih awal dari :date.`,
+		`asset_revaluation_date_future`:           `Tanggal revaluasi tidak boleh melebihi hari ini.`,
+		`asset_revaluation_locked`:                `Revaluasi tidak dapat dihapus karena penyusutan sudah diposting setelahnya.`,
+		`asset_depreciation_closed`:               `Penyusutan aset :code sudah diposting pada periode fiskal yang ditutup tanggal :date, harga, tanggal input dan konvensinya tidak dapat diubah lagi.`,
+		`asset_depreciation_adjusted`:             `Penyusutan aset :code memiliki revaluasi, impairment atau kapitalisasi pada tanggal :date, hapus terlebih dahulu sebelum mengubah penyusutan.`,
+		`asset_revaluation_immutable`:             `Jenis, tanggal, nilai dan asset revaluasi tidak dapat diubah, hapus lalu buat revaluasi baru.`,
+		`asset_impairment_amount_invalid`:         `Nilai penurunan harus lebih rendah dari nilai tercatat saat ini :amount.`,
+		`depreciation_book_invalid`:               `Buku penyusutan :book tidak valid, gunakan commercial atau tax.`,
+		`maintenance_plan_target_invalid`:         `Rencana pemeliharaan harus berlaku untuk satu aset atau satu kategori.`,
+		`work_order_closed`:                       `Perintah kerja :code berstatus :status dan tidak dapat diubah.`,
+		`work_order_status_invalid`:               `Perintah kerja :code tidak dapat langsung diubah menjadi :status.`,
+		`work_order_transition_invalid`:           `Perintah kerja :code tidak dapat diubah dari :from menjadi :to.`,
+		`work_order_not_deletable`:                `Perintah kerja :code berstatus :status, hanya perintah kerja yang masih open yang dapat dihapus.`,
+		`work_order_asset_immutable`:              `Aset pada perintah kerja :code tidak dapat diubah.`,
+		`asset_deleted`:                           `Asset :code sudah dihapus.`,
+		`maintenance_amount_invalid`:              `Biaya pemeliharaan tidak boleh negatif.`,
+		`maintenance_life_extension_invalid`:      `Perpanjangan umur hanya diperbolehkan untuk pemeliharaan yang dikapitalisasi.`,
+		`maintenance_capitalization_date_future`:  `Tanggal pemeliharaan yang dikapitalisasi tidak boleh lebih dari hari ini.`,
+		`maintenance_capitalization_date_invalid`: `Tanggal pemeliharaan yang dikapitalisasi tidak boleh kurang dari :date.`,
+		`maintenance_capitalization_locked`:       `Pemeliharaan yang dikapitalisasi tidak dapat diubah atau dihapus karena penyusutan telah diposting setelahnya.`,
+		`meter_depreciation_exists`:               `Kategori sudah memiliki meter penyusutan :code.`,
+		`meter_category_invalid`:                  `Meter :meter bukan meter aktif dari kategori aset :asset.`,
+		`meter_reading_immutable`:                 `Aset dan meter pada pembacaan meter tidak dapat diubah, hapus lalu buat yang baru.`,
+		`meter_reading_locked`:                    `Hanya pembacaan terakhir dari meter yang dapat diubah atau dihapus.`,
+		`meter_reading_date_future`:               `Tanggal pembacaan tidak boleh lebih dari sekarang.`,
+		`meter_reading_date_invalid`:              `Tanggal pembacaan tidak boleh kurang dari pembacaan terakhir pada :date.`,
+		`meter_reading_decreased`:                 `Pembacaan tidak boleh lebih rendah dari pembacaan terakhir :value kecuali meter diganti.`,
+		`maintenance_plan_meter_invalid`:          `Meter :meter bukan meter dari kategori rencana pemeliharaan.`,
+		`maintenance_part_invalid`:                `Barang habis pakai :code bukan suku cadang yang aktif.`,
+		`report_group_invalid`:                    `Laporan tidak dapat dikelompokkan berdasarkan :group, gunakan asset, category atau vendor.`,
+		`incident_closed`:                         `Insiden :code berstatus :status dan tidak dapat diubah.`,
+		`incident_status_invalid`:                 `Insiden :code tidak dapat diubah menjadi :status secara langsung.`,
+		`incident_transition_invalid`:             `Insiden :code tidak dapat diubah dari :from menjadi :to.`,
+		`incident_not_deletable`:                  `Insiden :code berstatus :status, hanya insiden baru yang dapat dihapus.`,
+		`incident_asset_immutable`:                `Asset dan karyawan pada insiden :code tidak dapat diubah.`,
+		`incident_asset_not_held`:                 `Asset :asset tidak sedang dipegang oleh :employee.`,
+		`incident_not_convertible`:                `Insiden :code adalah kehilangan dan tidak dapat dijadikan perintah kerja.`,
+		`liability_case_closed`:                   `Kasus ganti rugi :code berstatus :status dan tidak dapat diubah.`,
+		`liability_case_status_invalid`:           `Kasus ganti rugi :code tidak dapat diubah menjadi :status secara langsung.`,
+		`liability_case_transition_invalid`:       `Kasus ganti rugi :code tidak dapat diubah dari :from menjadi :to.`,
+		`liability_case_not_deletable`:            `Kasus ganti rugi :code berstatus :status, hanya kasus draft yang dapat dihapus.`,
+		`liability_case_date_invalid`:             `Tanggal kejadian tidak boleh di masa depan dan harus dalam masa penyerahan asset :asset kepada :employee.`,
+		`liability_charge_invalid`:                `Nilai tagihan tidak boleh melebihi nilai buku :amount.`,
+		`liability_payment_invalid`:               `Pembayaran tidak boleh melebihi sisa tagihan :amount.`,
 	}
 }
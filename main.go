@@ -6,6 +6,7 @@ import (
 
 	"github.com/maulanar/go_asset_tracking_management/app"
 	"github.com/maulanar/go_asset_tracking_management/src"
+	"github.com/maulanar/go_asset_tracking_management/src/attachment"
 )
 
 //go:embed all:docs
@@ -19,6 +20,18 @@ func main() {
 		app.OpenAPI().Configure().Generate()
 		os.Exit(0)
 	}
+	if len(os.Args) == 2 && os.Args[1] == "migrate-local-files" {
+		app.Logger()
+		app.FS()
+		app.DB()
+		migrated, err := attachment.MigrateLocalFiles()
+		app.DB().Close()
+		if err != nil {
+			app.Logger().Fatal().Err(err).Send()
+		}
+		app.Logger().Info().Int("migrated", migrated).Msg("local files migrated")
+		os.Exit(0)
+	}
 
 	app.Logger()
 	app.Cache()
@@ -33,7 +46,9 @@ func main() {
 	src.Router()
 	app.Server().AddOpenAPIDoc("/api/docs", f)
 
-	app.Server().Fiber.Static("/storages", "./storages")
+	if app.FS().Driver == "local" {
+		app.Server().Fiber.Static("/"+app.FS().PublicDirPath, app.FS().LocalDirPath)
+	}
 
 	src.Migrator()
 	src.Seeder()
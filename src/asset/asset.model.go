@@ -10,6 +10,7 @@ type Asset struct {
 	Name           app.NullString  `json:"name"                   db:"m.name"                   gorm:"column:name"`
 	InputDate      app.NullDate    `json:"input_date"             db:"m.input_date"             gorm:"column:input_date"`
 	Price          app.NullFloat64 `json:"price"                  db:"m.price"                  gorm:"column:price"`
+	Attributes     app.NullJSON    `json:"attributes"             db:"m.attributes"             gorm:"column:attributes;type:jsonb"`
 	AttachmentID   app.NullUUID    `json:"attachment.id"          db:"m.attachment_id"          gorm:"column:attachment_id"`
 	AttachmentName app.NullText    `json:"attachment.name"        db:"att.name"                 gorm:"-"`
 	AttachmentPath app.NullText    `json:"attachment.path"        db:"att.path"                 gorm:"-"`
@@ -21,6 +22,34 @@ type Asset struct {
 	CategoryEconomicAges app.NullInt64  `json:"category.economic_age"  db:"cat.economic_age"         gorm:"-"`
 	CategoryDescription  app.NullText   `json:"category.description"   db:"cat.description"          gorm:"-"`
 
+	CategoryDepreciationMethod app.NullString  `json:"category.depreciation.method"           db:"cat.depreciation_method" gorm:"-"`
+	CategoryDecliningFactor    app.NullFloat64 `json:"category.depreciation.declining_factor" db:"cat.declining_factor"    gorm:"-"`
+	CategoryTaxGroup           app.NullInt64   `json:"category.tax.group"                     db:"cat.tax_group"           gorm:"-"`
+	CategoryTaxMethod          app.NullString  `json:"category.tax.method"                    db:"cat.tax_method"          gorm:"-"`
+
+	ParentID   app.NullUUID   `json:"parent.id"              db:"m.parent_id"              gorm:"column:parent_id"`
+	ParentCode app.NullString `json:"parent.code"            db:"par.code"                 gorm:"-"`
+	ParentName app.NullString `json:"parent.name"            db:"par.name"                 gorm:"-"`
+
+	LocationID   app.NullUUID   `json:"location.id"            db:"m.location_id"            gorm:"column:location_id"`
+	LocationCode app.NullString `json:"location.code"          db:"loc.code"                 gorm:"-"`
+	LocationName app.NullString `json:"location.name"          db:"loc.name"                 gorm:"-"`
+	LocationType app.NullString `json:"location.type"          db:"loc.type"                 gorm:"-"`
+	LocationPath app.NullText   `json:"location.path"          db:"loc.path"                 gorm:"-"`
+
+	Tags app.NullJSON `json:"tags"                   db:"tg.tags"                  gorm:"-"`
+
+	PurchaseOrderLineID app.NullUUID   `json:"purchase_order_line.id" db:"m.purchase_order_line_id" gorm:"column:purchase_order_line_id"`
+	PurchaseOrderID     app.NullUUID   `json:"purchase_order.id"      db:"pol.purchase_order_id"    gorm:"-"`
+	PurchaseOrderCode   app.NullString `json:"purchase_order.code"    db:"po.code"                  gorm:"-"`
+	GoodsReceiptID      app.NullUUID   `json:"goods_receipt.id"       db:"m.goods_receipt_id"       gorm:"column:goods_receipt_id"`
+	GoodsReceiptCode    app.NullString `json:"goods_receipt.code"     db:"gr.code"                  gorm:"-"`
+
+	WarrantyID       app.NullUUID   `json:"warranty.id"            db:"war.id"                   gorm:"-"`
+	WarrantyProvider app.NullString `json:"warranty.provider"      db:"war.provider"             gorm:"-"`
+	WarrantyEndDate  app.NullDate   `json:"warranty.end_date"      db:"war.end_date"             gorm:"-"`
+	WarrantyStatus   app.NullString `json:"warranty.status"        db:"war.status"               gorm:"-"`
+
 	AssignDate app.NullDate `json:"assign_date"            db:"emp_ass.assign_date"      gorm:"-"`
 
 	ConditionID          app.NullUUID   `json:"condition.id"           db:"emp_ass.condition_id"     gorm:"-"`
@@ -46,7 +75,7 @@ type Asset struct {
 	BranchName    app.NullString `json:"branch.name"            db:"emp_ass_brc.name"         gorm:"-"`
 	BranchAddress app.NullText   `json:"branch.address"         db:"emp_ass_brc.address"      gorm:"-"`
 
-	Status    app.NullString   `json:"status"                 db:"m.status"                 gorm:"column:status"              validate:"omitempty,oneof=available unavailable"`
+	Status    app.NullString   `json:"status"                 db:"m.status"                 gorm:"column:status"              validate:"omitempty,oneof=available unavailable in_maintenance disposed"`
 	CreatedAt app.NullDateTime `json:"created_at"             db:"m.created_at"             gorm:"column:created_at"`
 	UpdatedAt app.NullDateTime `json:"updated_at"             db:"m.updated_at"             gorm:"column:updated_at"`
 	DeletedAt app.NullDateTime `json:"deleted_at"             db:"m.deleted_at,hide"        gorm:"column:deleted_at"`
@@ -55,6 +84,18 @@ type Asset struct {
 	DepreciationAmountPerMonth app.NullFloat64 `json:"depreciation.per_month"    db:"m.depreciation_amount_per_month"    gorm:"column:depreciation_amount_per_month"`
 	SalvageAmount              app.NullFloat64 `json:"salvage.amount"         db:"m.salvage_amount"         gorm:"column:salvage_amount"`
 	CurrentValue               app.NullFloat64 `json:"current.amount"         db:"m.current_amount"         gorm:"column:current_amount"`
+
+	DepreciationMethod      app.NullString  `json:"depreciation.method"       db:"m.depreciation_method" gorm:"column:depreciation_method" validate:"omitempty,oneof=straight_line declining_balance double_declining sum_of_years_digits units_of_production"`
+	DepreciationEconomicAge app.NullInt64   `json:"depreciation.economic_age" db:"m.economic_age"        gorm:"column:economic_age"        validate:"omitempty,gt=0"`
+	DepreciationUnitsTotal  app.NullFloat64 `json:"depreciation.units_total"  db:"m.units_total"         gorm:"column:units_total"         validate:"omitempty,gt=0"`
+	DepreciationUnitsUsed   app.NullFloat64 `json:"depreciation.units_used"   db:"m.units_used"          gorm:"column:units_used"          validate:"omitempty,gte=0"`
+
+	TaxGroup  app.NullInt64  `json:"tax.group"  db:"m.tax_group"  gorm:"column:tax_group"  validate:"omitempty,oneof=1 2 3 4"`
+	TaxMethod app.NullString `json:"tax.method" db:"m.tax_method" gorm:"column:tax_method" validate:"omitempty,oneof=straight_line declining_balance"`
+
+	DisposalDate      app.NullDate    `json:"disposal.date"       db:"m.disposal_date"       gorm:"column:disposal_date"`
+	DisposalAmount    app.NullFloat64 `json:"disposal.amount"     db:"m.disposal_amount"     gorm:"column:disposal_amount"     validate:"omitempty,gte=0"`
+	DisposalBookValue app.NullFloat64 `json:"disposal.book_value" db:"m.disposal_book_value" gorm:"column:disposal_book_value"`
 }
 
 // EndPoint returns the Asset end point, it used for cache key, etc.
@@ -65,7 +106,7 @@ func (Asset) EndPoint() string {
 // TableVersion returns the versions of the Asset table in the database.
 // Change this value with date format YY.MM.DDHHii when any table structure changes.
 func (Asset) TableVersion() string {
-	return "25.09.290100"
+	return "26.10.192000"
 }
 
 // TableName returns the name of the Asset table in the database.
@@ -83,6 +124,37 @@ func (m *Asset) GetRelations() map[string]map[string]any {
 	m.AddRelation("left", "categories", "cat", []map[string]any{{"column1": "cat.id", "column2": "m.category_id"}})
 	m.AddRelation("left", "departments", "dep", []map[string]any{{"column1": "dep.id", "column2": "m.department_id"}})
 	m.AddRelation("left", "attachments", "att", []map[string]any{{"column1": "att.id", "column2": "m.attachment_id"}})
+	m.AddRelation("left", "assets", "par", []map[string]any{{"column1": "par.id", "column2": "m.parent_id"}})
+	m.AddRelation("left", "locations", "loc", []map[string]any{{"column1": "loc.id", "column2": "m.location_id"}})
+	m.AddRelation("left", "purchase_order_lines", "pol", []map[string]any{{"column1": "pol.id", "column2": "m.purchase_order_line_id"}})
+	m.AddRelation("left", "purchase_orders", "po", []map[string]any{{"column1": "po.id", "column2": "pol.purchase_order_id"}})
+	m.AddRelation("left", "goods_receipts", "gr", []map[string]any{{"column1": "gr.id", "column2": "m.goods_receipt_id"}})
+
+	// tag names of the asset
+	m.AddRelation("left", `(
+  SELECT at.asset_id, jsonb_agg(t.name ORDER BY t.name) AS tags
+  FROM asset_tags at
+  JOIN tags t ON t.id = at.tag_id AND t.deleted_at IS NULL
+  GROUP BY at.asset_id
+)`, "tg", []map[string]any{{"column1": "tg.asset_id", "column2": "m.id"}})
+
+	// latest warranty of the asset
+	m.AddRelation("left", `(
+  SELECT DISTINCT ON (w.asset_id)
+         w.id,
+         w.asset_id,
+         w.provider,
+         w.end_date,
+         CASE
+           WHEN w.end_date < CURRENT_DATE THEN 'expired'
+           WHEN w.start_date > CURRENT_DATE THEN 'upcoming'
+           WHEN w.end_date - COALESCE(w.notify_days_before, 0) <= CURRENT_DATE THEN 'expiring'
+           ELSE 'active'
+         END AS status
+  FROM warranties w
+  WHERE w.deleted_at IS NULL
+  ORDER BY w.asset_id, w.end_date DESC, w.id DESC
+)`, "war", []map[string]any{{"column1": "war.asset_id", "column2": "m.id"}})
 
 	// search to employee_assets
 	m.AddRelation("left", `(
@@ -93,6 +165,7 @@ func (m *Asset) GetRelations() map[string]map[string]any {
          ea.employee_id
   FROM employee_assets ea
   WHERE ea.deleted_at IS NULL
+    AND ea.return_date IS NULL
   ORDER BY ea.asset_id, ea.assign_date DESC, ea.id DESC
 )`, "emp_ass", []map[string]any{{"column1": "emp_ass.asset_id", "column2": "m.id"}})
 	m.AddRelation("left", "conditions", "emp_ass_cond", []map[string]any{{"column1": "emp_ass_cond.id", "column2": "emp_ass.condition_id"}})
@@ -133,7 +206,21 @@ func (Asset) OpenAPISchemaName() string {
 
 // GetOpenAPISchema returns the Open API Schema of the Asset in the open api documentation.
 func (m *Asset) GetOpenAPISchema() map[string]any {
-	return m.SetOpenAPISchema(m)
+	schema := m.SetOpenAPISchema(m)
+	if props, ok := schema["properties"].(map[string]any); ok {
+		props["tags"] = map[string]any{
+			"type":        "array",
+			"description": "Tag names of the asset, use the bulk tag and untag endpoints to change them.",
+			"items":       map[string]any{"type": "string"},
+			"readOnly":    true,
+		}
+		props["attributes"] = map[string]any{
+			"type":                 "object",
+			"description":          "Custom attribute values, validated against the attribute schema of the category.",
+			"additionalProperties": map[string]any{"oneOf": []map[string]any{{"type": "string"}, {"type": "number"}, {"type": "boolean"}}},
+		}
+	}
+	return schema
 }
 
 type AssetList struct {
@@ -170,11 +257,46 @@ type ParamDelete struct {
 	UseCaseHandler
 }
 
+// ParamAttach is the expected parameters for attach the Asset data to a parent asset.
+type ParamAttach struct {
+	UseCaseHandler
+	ParentID app.NullUUID `json:"parent.id"              db:"m.parent_id"              gorm:"column:parent_id"              validate:"required"`
+}
+
+// ParamDetach is the expected parameters for detach the Asset data from its parent asset.
+type ParamDetach struct {
+	UseCaseHandler
+}
+
+// ParamTag is the expected parameters for bulk tag or untag the Asset data.
+type ParamTag struct {
+	AssetIDs []string `json:"asset_ids" validate:"required,min=1,dive,uuid"`
+	Tags     []string `json:"tags"      validate:"required,min=1,dive,required"`
+}
+
+// Statuses of the Asset data, an asset is in maintenance while it has a scheduled or started work order,
+// and the depreciation of a disposed asset stops on the disposal date.
+const (
+	StatusAvailable     = "available"
+	StatusUnavailable   = "unavailable"
+	StatusInMaintenance = "in_maintenance"
+	StatusDisposed      = "disposed"
+)
+
+// Statuses of the DepreciationList entry.
+const (
+	DepreciationPosted    = "posted"
+	DepreciationProjected = "projected"
+)
+
 type DepreciationList struct {
 	Date               app.NullDate    `json:"date"`
 	Month              app.NullInt64   `json:"month"`
+	Type               app.NullString  `json:"type"`
+	Status             app.NullString  `json:"status"`
 	InitialAmount      app.NullFloat64 `json:"initial_amount"`
 	AssetAmount        app.NullFloat64 `json:"asset_amount"`
 	DepreciationAmount app.NullFloat64 `json:"depreciation_amount"`
 	EconomicAmount     app.NullFloat64 `json:"economic_amount"`
+	AccumulatedAmount  app.NullFloat64 `json:"accumulated_amount"`
 }
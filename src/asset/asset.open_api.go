@@ -52,7 +52,27 @@ func (o *OpenAPIOperation) Get() *OpenAPIOperation {
 	o.Base()
 	o.Summary = "Get Asset"
 	o.Description = "Use this method to get list of Asset"
-	o.QueryParams = []map[string]any{{"$ref": "#/components/parameters/queryParam.Any"}}
+	o.QueryParams = []map[string]any{
+		{"$ref": "#/components/parameters/queryParam.Any"},
+		{
+			"name":        "attributes.{name}",
+			"in":          "query",
+			"description": "Filter by custom attribute value, e.g. `attributes.ram=16`.",
+			"schema":      map[string]any{"type": "string"},
+		},
+		{
+			"name":        "tags",
+			"in":          "query",
+			"description": "Filter by comma separated tag names, e.g. `tags=loaner,project-x` returns the assets which have any of the tags.",
+			"schema":      map[string]any{"type": "string"},
+		},
+		{
+			"name":        "location.subtree",
+			"in":          "query",
+			"description": "Filter by the location id, including the assets placed on any of its descendant locations.",
+			"schema":      map[string]any{"type": "string", "format": "uuid"},
+		},
+	}
 	o.Responses = map[string]map[string]any{
 		"200": {
 			"description": "Success",
@@ -85,8 +105,11 @@ func (o *OpenAPIOperation) GetDepreciationByID() *OpenAPIOperation {
 
 	o.BaseDepreciation()
 	o.Summary = "Get Depreciations Asset By ID"
-	o.Description = "Use this method to get List depreciation of asset by id"
+	o.Description = "Use this method to get List depreciation of asset by id, the value history of the posted depreciation, revaluation, impairment and capitalized maintenance entries followed by the projected entries calculated with the depreciation method of the asset or its category"
 	o.PathParams = []map[string]any{{"$ref": "#/components/parameters/pathParam.ID"}}
+	o.QueryParams = []map[string]any{
+		{"name": "book", "in": "query", "description": "Default to commercial, the tax book follows the Indonesian fiscal group of the asset or its category.", "schema": map[string]any{"type": "string", "enum": []string{"commercial", "tax"}}},
+	}
 	return o
 }
 
@@ -144,3 +167,56 @@ func (o *OpenAPIOperation) DeleteByID() *OpenAPIOperation {
 	o.Body = map[string]any{"application/json": &ParamDelete{}}
 	return o
 }
+
+// AttachByID is detail of `POST /api/v1/assets/{id}/attach` open api document component.
+func (o *OpenAPIOperation) AttachByID() *OpenAPIOperation {
+	if !app.IS_GENERATE_OPEN_API_DOC {
+		return o // skip for efficiency
+	}
+
+	o.Base()
+	o.Summary = "Attach Asset To Parent"
+	o.Description = "Use this method to attach Asset by id as a child of the parent asset"
+	o.PathParams = []map[string]any{{"$ref": "#/components/parameters/pathParam.ID"}}
+	o.Body = map[string]any{"application/json": &ParamAttach{}}
+	return o
+}
+
+// DetachByID is detail of `POST /api/v1/assets/{id}/detach` open api document component.
+func (o *OpenAPIOperation) DetachByID() *OpenAPIOperation {
+	if !app.IS_GENERATE_OPEN_API_DOC {
+		return o // skip for efficiency
+	}
+
+	o.Base()
+	o.Summary = "Detach Asset From Parent"
+	o.Description = "Use this method to detach Asset by id from its parent asset"
+	o.PathParams = []map[string]any{{"$ref": "#/components/parameters/pathParam.ID"}}
+	return o
+}
+
+// TagByIDs is detail of `POST /api/v1/assets/tag` open api document component.
+func (o *OpenAPIOperation) TagByIDs() *OpenAPIOperation {
+	if !app.IS_GENERATE_OPEN_API_DOC {
+		return o // skip for efficiency
+	}
+
+	o.Base()
+	o.Summary = "Bulk Tag Assets"
+	o.Description = "Use this method to add the tags (id or name) to the assets, an unknown tag name is created automatically"
+	o.Body = map[string]any{"application/json": &ParamTag{}}
+	return o
+}
+
+// UntagByIDs is detail of `POST /api/v1/assets/untag` open api document component.
+func (o *OpenAPIOperation) UntagByIDs() *OpenAPIOperation {
+	if !app.IS_GENERATE_OPEN_API_DOC {
+		return o // skip for efficiency
+	}
+
+	o.Base()
+	o.Summary = "Bulk Untag Assets"
+	o.Description = "Use this method to remove the tags (id or name) from the assets"
+	o.Body = map[string]any{"application/json": &ParamTag{}}
+	return o
+}
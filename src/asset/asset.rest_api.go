@@ -2,6 +2,7 @@ package asset
 
 import (
 	"net/http"
+	"strconv"
 
 	"github.com/gofiber/fiber/v2"
 
@@ -202,7 +203,7 @@ func (r *RESTAPIHandler) GetDepreciationByID(c *fiber.Ctx) error {
 	if err != nil {
 		return app.Error().Handler(c, err)
 	}
-	res, err := r.UseCase.GetDepreciation(c.Params("id"))
+	res, err := r.UseCase.GetDepreciation(c.Params("id"), c.Query("book"))
 	if err != nil {
 		return app.Error().Handler(c, err)
 	}
@@ -211,3 +212,84 @@ func (r *RESTAPIHandler) GetDepreciationByID(c *fiber.Ctx) error {
 	}
 	return c.JSON(app.NewJSON(res).ToStructured().Data)
 }
+
+// AttachByID is the REST API handler for `POST /api/assets/{id}/attach`.
+func (r *RESTAPIHandler) AttachByID(c *fiber.Ctx) error {
+	err := r.injectDeps(c)
+	if err != nil {
+		return app.Error().Handler(c, err)
+	}
+	p := ParamAttach{}
+	if err := app.BindJSON(c.Body(), &p); err != nil {
+		return app.Error().Handler(c, app.Error().New(http.StatusBadRequest, err.Error()))
+	}
+
+	err = r.UseCase.AttachByID(c.Params("id"), &p)
+	if err != nil {
+		return app.Error().Handler(c, err)
+	}
+	return r.GetByID(c)
+}
+
+// DetachByID is the REST API handler for `POST /api/assets/{id}/detach`.
+func (r *RESTAPIHandler) DetachByID(c *fiber.Ctx) error {
+	err := r.injectDeps(c)
+	if err != nil {
+		return app.Error().Handler(c, err)
+	}
+	p := ParamDetach{}
+
+	err = r.UseCase.DetachByID(c.Params("id"), &p)
+	if err != nil {
+		return app.Error().Handler(c, err)
+	}
+	return r.GetByID(c)
+}
+
+// TagByIDs is the REST API handler for `POST /api/assets/tag`.
+func (r *RESTAPIHandler) TagByIDs(c *fiber.Ctx) error {
+	err := r.injectDeps(c)
+	if err != nil {
+		return app.Error().Handler(c, err)
+	}
+	p := ParamTag{}
+	if err := app.BindJSON(c.Body(), &p); err != nil {
+		return app.Error().Handler(c, app.Error().New(http.StatusBadRequest, err.Error()))
+	}
+
+	err = r.UseCase.TagByIDs(&p)
+	if err != nil {
+		return app.Error().Handler(c, err)
+	}
+	res := map[string]any{
+		"code": http.StatusOK,
+		"message": r.UseCase.Ctx.Trans("asset_tagged", map[string]string{
+			"count": strconv.Itoa(len(p.AssetIDs)),
+		}),
+	}
+	return c.JSON(res)
+}
+
+// UntagByIDs is the REST API handler for `POST /api/assets/untag`.
+func (r *RESTAPIHandler) UntagByIDs(c *fiber.Ctx) error {
+	err := r.injectDeps(c)
+	if err != nil {
+		return app.Error().Handler(c, err)
+	}
+	p := ParamTag{}
+	if err := app.BindJSON(c.Body(), &p); err != nil {
+		return app.Error().Handler(c, app.Error().New(http.StatusBadRequest, err.Error()))
+	}
+
+	err = r.UseCase.UntagByIDs(&p)
+	if err != nil {
+		return app.Error().Handler(c, err)
+	}
+	res := map[string]any{
+		"code": http.StatusOK,
+		"message": r.UseCase.Ctx.Trans("asset_untagged", map[string]string{
+			"count": strconv.Itoa(len(p.AssetIDs)),
+		}),
+	}
+	return c.JSON(res)
+}
@@ -1,13 +1,26 @@
 package asset
 
 import (
+	"encoding/json"
 	"net/http"
 	"net/url"
+	"slices"
+	"strings"
 	"time"
 
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"grest.dev/grest"
+
 	"github.com/maulanar/go_asset_tracking_management/app"
 	"github.com/maulanar/go_asset_tracking_management/src/attachment"
 	"github.com/maulanar/go_asset_tracking_management/src/category"
+	"github.com/maulanar/go_asset_tracking_management/src/depreciation"
+	"github.com/maulanar/go_asset_tracking_management/src/depreciationentry"
+	"github.com/maulanar/go_asset_tracking_management/src/fiscalperiod"
+	"github.com/maulanar/go_asset_tracking_management/src/jobrun"
+	"github.com/maulanar/go_asset_tracking_management/src/location"
+	"github.com/maulanar/go_asset_tracking_management/src/tag"
 )
 
 // UseCase returns a UseCaseHandler for expected use case functional.
@@ -98,6 +111,24 @@ func (u UseCaseHandler) Get() (app.ListModel, error) {
 		return res, app.Error().New(http.StatusInternalServerError, err.Error())
 	}
 
+	// filter by custom attributes, e.g. ?attributes.ram=16
+	err = u.setAttributesFilter(tx)
+	if err != nil {
+		return res, err
+	}
+
+	// filter by tag names, e.g. ?tags=loaner,project-x
+	err = u.setTagFilter(tx)
+	if err != nil {
+		return res, err
+	}
+
+	// filter by location subtree, e.g. ?location.subtree={building_id}
+	err = u.setLocationFilter(tx)
+	if err != nil {
+		return res, err
+	}
+
 	// set pagination info
 	res.Results.PageContext.Count,
 		res.Results.PageContext.Page,
@@ -157,6 +188,12 @@ func (u *UseCaseHandler) Create(p *ParamCreate) error {
 		return app.Error().New(http.StatusInternalServerError, err.Error())
 	}
 
+	// post the depreciation of the saved asset to the ledger
+	err = u.SetCurrentValue()
+	if err != nil {
+		return err
+	}
+
 	// invalidate cache
 	app.Cache().Invalidate(u.EndPoint())
 
@@ -204,6 +241,12 @@ func (u UseCaseHandler) UpdateByID(id string, p *ParamUpdate) error {
 		return app.Error().New(http.StatusInternalServerError, err.Error())
 	}
 
+	// dispose the child assets together with their parent
+	err = u.cascadeDispose(old)
+	if err != nil {
+		return err
+	}
+
 	// invalidate cache
 	app.Cache().Invalidate(u.EndPoint(), old.ID.String)
 
@@ -251,6 +294,12 @@ func (u UseCaseHandler) PartiallyUpdateByID(id string, p *ParamPartiallyUpdate)
 		return app.Error().New(http.StatusInternalServerError, err.Error())
 	}
 
+	// dispose the child assets together with their parent
+	err = u.cascadeDispose(old)
+	if err != nil {
+		return err
+	}
+
 	// invalidate cache
 	app.Cache().Invalidate(u.EndPoint(), old.ID.String)
 
@@ -300,6 +349,179 @@ func (u UseCaseHandler) DeleteByID(id string, p *ParamDelete) error {
 	return nil
 }
 
+// AttachByID attaches the Asset data for the specified ID to the specified parent asset.
+func (u UseCaseHandler) AttachByID(id string, p *ParamAttach) error {
+
+	// check permission
+	err := u.Ctx.ValidatePermission("assets.edit")
+	if err != nil {
+		return err
+	}
+
+	// validate param
+	err = u.Ctx.ValidateParam(p)
+	if err != nil {
+		return err
+	}
+
+	// get previous data
+	old, err := u.GetByID(id)
+	if err != nil {
+		return err
+	}
+
+	// validate parent
+	u.ParentID = p.ParentID
+	err = u.validateParent(old.ID.String)
+	if err != nil {
+		return err
+	}
+
+	// prepare db for current ctx
+	tx, err := u.Ctx.DB()
+	if err != nil {
+		return app.Error().New(http.StatusInternalServerError, err.Error())
+	}
+
+	// update data on the db
+	err = tx.Model(&Asset{}).Where("id = ?", old.ID).Update("parent_id", u.ParentID).Error
+	if err != nil {
+		return app.Error().New(http.StatusInternalServerError, err.Error())
+	}
+
+	// invalidate cache
+	app.Cache().Invalidate(u.EndPoint(), old.ID.String)
+
+	// save history (user activity), send webhook, etc
+	go u.Ctx.Hook("POST", "Attach", old.ID.String, old)
+	return nil
+}
+
+// DetachByID detaches the Asset data for the specified ID from its parent asset.
+func (u UseCaseHandler) DetachByID(id string, p *ParamDetach) error {
+
+	// check permission
+	err := u.Ctx.ValidatePermission("assets.edit")
+	if err != nil {
+		return err
+	}
+
+	// get previous data
+	old, err := u.GetByID(id)
+	if err != nil {
+		return err
+	}
+
+	// prepare db for current ctx
+	tx, err := u.Ctx.DB()
+	if err != nil {
+		return app.Error().New(http.StatusInternalServerError, err.Error())
+	}
+
+	// update data on the db
+	err = tx.Model(&Asset{}).Where("id = ?", old.ID).Update("parent_id", nil).Error
+	if err != nil {
+		return app.Error().New(http.StatusInternalServerError, err.Error())
+	}
+
+	// invalidate cache
+	app.Cache().Invalidate(u.EndPoint(), old.ID.String)
+
+	// save history (user activity), send webhook, etc
+	go u.Ctx.Hook("POST", "Detach", old.ID.String, old)
+	return nil
+}
+
+// GetChildren returns the direct child assets of the specified parent asset.
+func (u UseCaseHandler) GetChildren(parentID string) ([]Asset, error) {
+	res := []Asset{}
+
+	// prepare db for current ctx
+	tx, err := u.Ctx.DB()
+	if err != nil {
+		return res, app.Error().New(http.StatusInternalServerError, err.Error())
+	}
+
+	query := url.Values{}
+	query.Add("parent.id", parentID)
+	query.Add(grest.QueryDisablePagination, "true")
+	data, err := app.Query().Find(tx, &Asset{}, query)
+	if err != nil {
+		return res, app.Error().New(http.StatusInternalServerError, err.Error())
+	}
+
+	jByte, err := json.Marshal(data)
+	if err != nil {
+		return res, app.Error().New(http.StatusInternalServerError, err.Error())
+	}
+	err = app.BindJSON(jByte, &res)
+	if err != nil {
+		return res, app.Error().New(http.StatusInternalServerError, err.Error())
+	}
+	return res, nil
+}
+
+// cascadeDispose disposes the child assets of the newly disposed asset on the same date, without proceeds.
+func (u *UseCaseHandler) cascadeDispose(old Asset) error {
+	if !u.DisposalDate.Valid || old.DisposalDate.Valid {
+		return nil
+	}
+	children, err := u.GetChildren(old.ID.String)
+	if err != nil {
+		return err
+	}
+	for _, child := range children {
+		if child.DisposalDate.Valid {
+			continue
+		}
+		childUC := UseCase(*u.Ctx, url.Values{})
+		childUC.DisposalDate = u.DisposalDate
+		childUC.DisposalAmount.Set(0)
+		err = childUC.PartiallyUpdateByID(child.ID.String, &ParamPartiallyUpdate{})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// validateParent validates u.ParentID, the parent must exist and must not be the asset itself or one of its descendants.
+func (u *UseCaseHandler) validateParent(id string) error {
+	parent, err := UseCase(*u.Ctx, url.Values{}).GetByID(u.ParentID.String)
+	if err != nil {
+		return err
+	}
+	u.ParentID = parent.ID
+	if id == "" {
+		return nil
+	}
+
+	// prepare db for current ctx
+	tx, err := u.Ctx.DB()
+	if err != nil {
+		return app.Error().New(http.StatusInternalServerError, err.Error())
+	}
+
+	// walk up from the new parent, a cycle occurs when the asset itself is one of the ancestors
+	count := int64(0)
+	err = tx.Raw(`
+WITH RECURSIVE ancestors AS (
+  SELECT a.id, a.parent_id FROM assets a WHERE a.id = ?
+  UNION
+  SELECT p.id, p.parent_id FROM assets p JOIN ancestors anc ON p.id = anc.parent_id
+)
+SELECT COUNT(*) FROM ancestors WHERE id = ?`, parent.ID.String, id).Scan(&count).Error
+	if err != nil {
+		return app.Error().New(http.StatusInternalServerError, err.Error())
+	}
+	if count > 0 {
+		return app.Error().New(http.StatusBadRequest, u.Ctx.Trans("asset_parent_cycle", map[string]string{
+			"code": parent.Code.String,
+		}))
+	}
+	return nil
+}
+
 // setDefaultValue set default value of undefined field when create or update Asset data.
 func (u *UseCaseHandler) setDefaultValue(old Asset) error {
 
@@ -319,14 +541,45 @@ func (u *UseCaseHandler) setDefaultValue(old Asset) error {
 		if err != nil {
 			return err
 		}
+		u.CategoryID = cat.ID
+	}
 
-		//update depreciation price based on category economic age
-		if cat.Ages.Int64 > 0 && u.Price.Float64 > 0 {
-			// Depresiasi per bulan = (Harga Asset - Salvage Amount) / Umur Ekonomis (bulan)
-			depreciation := (u.Price.Float64 - u.SalvageAmount.Float64) / float64(cat.Ages.Int64)
-			u.DepreciationAmountPerMonth.Set(depreciation)
-		} else {
-			u.DepreciationAmountPerMonth.Set(0)
+	// validate parent asset
+	if u.ParentID.Valid && u.ParentID.String != "" && u.ParentID.String != old.ParentID.String {
+		err := u.validateParent(old.ID.String)
+		if err != nil {
+			return err
+		}
+	}
+
+	// validate location
+	if u.LocationID.Valid && u.LocationID.String != "" {
+		loc, err := location.UseCase(*u.Ctx, url.Values{}).GetByID(u.LocationID.String)
+		if err != nil {
+			return err
+		}
+		u.LocationID = loc.ID
+	}
+
+	// validate custom attributes based on the category attribute schema,
+	// the current attributes must also match the schema of a changed category
+	isCategoryChanged := old.ID.Valid && u.CategoryID.Valid && u.CategoryID.String != "" && u.CategoryID.String != old.CategoryID.String
+	if u.Attributes.Valid || !old.ID.Valid || isCategoryChanged {
+		if !u.Attributes.Valid {
+			u.Attributes = old.Attributes
+		}
+		if !u.CategoryID.Valid || u.CategoryID.String == "" {
+			u.CategoryID = old.CategoryID
+		}
+		if u.CategoryID.Valid && u.CategoryID.String != "" {
+			cat, err := category.UseCaseHandler{Ctx: u.Ctx, Query: url.Values{}}.GetByID(u.CategoryID.String)
+			if err != nil {
+				return err
+			}
+			err = u.validateAttributes(cat)
+			if err != nil {
+				return err
+			}
 		}
 	}
 
@@ -387,183 +640,1286 @@ func (u *UseCaseHandler) setDefaultValue(old Asset) error {
 		}
 	}
 
-	//hitung nilai depresiasi & current value
-	err := u.SetCurrentValue()
+	// prices and dates inside a closed fiscal period are locked
+	err := u.validateFiscalPeriod(old)
 	if err != nil {
 		return err
 	}
 
-	return nil
-}
-
-func (u *UseCaseHandler) SetCurrentValue() error {
-	if !u.InputDate.Valid || u.Price.Float64 <= 0 || u.DepreciationAmountPerMonth.Float64 <= 0 {
-		return nil
+	// disposal
+	if !u.DisposalDate.Valid {
+		u.DisposalDate = old.DisposalDate
 	}
-
-	// Hitung jumlah bulan berlalu sejak InputDate
-	start := u.InputDate.Time
-	now := time.Now().UTC()
-	months := int((now.Year()-start.Year())*12 + int(now.Month()) - int(start.Month()))
-	if now.Day() < start.Day() {
-		months--
+	if !u.DisposalAmount.Valid {
+		u.DisposalAmount = old.DisposalAmount
 	}
-	if months < 0 {
-		months = 0
+	if u.DisposalDate.Valid {
+		inputDate := old.InputDate
+		if u.InputDate.Valid {
+			inputDate = u.InputDate
+		}
+		if inputDate.Valid && u.DisposalDate.Time.Before(inputDate.Time) {
+			return app.Error().New(http.StatusBadRequest, u.Ctx.Trans("asset_disposal_date_invalid"))
+		}
+		u.Status.Set(StatusDisposed)
+		if !u.DisposalAmount.Valid {
+			u.DisposalAmount.Set(0)
+		}
 	}
 
-	// Hitung total depresiasi
-	totalDepreciation := float64(months) * u.DepreciationAmountPerMonth.Float64
+	// depreciation needs the complete data, fall back to the previous value on partial update
+	if !u.CategoryID.Valid || u.CategoryID.String == "" {
+		u.CategoryID = old.CategoryID
+	}
+	if !u.Price.Valid {
+		u.Price = old.Price
+	}
+	if !u.InputDate.Valid {
+		u.InputDate = old.InputDate
+	}
+	if !u.DepreciationMethod.Valid {
+		u.DepreciationMethod = old.DepreciationMethod
+	}
+	if !u.DepreciationEconomicAge.Valid {
+		u.DepreciationEconomicAge = old.DepreciationEconomicAge
+	}
+	if !u.DepreciationUnitsTotal.Valid {
+		u.DepreciationUnitsTotal = old.DepreciationUnitsTotal
+	}
+	if !u.DepreciationUnitsUsed.Valid {
+		u.DepreciationUnitsUsed = old.DepreciationUnitsUsed
+	}
+	if !u.TaxGroup.Valid {
+		u.TaxGroup = old.TaxGroup
+	}
+	if !u.TaxMethod.Valid {
+		u.TaxMethod = old.TaxMethod
+	}
 
-	// Hitung nilai ekonomis saat ini, minimal salvage amount
-	currentValue := u.Price.Float64 - totalDepreciation
-	if currentValue < u.SalvageAmount.Float64 {
-		currentValue = u.SalvageAmount.Float64
+	// the posted depreciation follows the changed depreciation input
+	err = u.rebaseDepreciation(old)
+	if err != nil {
+		return err
 	}
 
-	// Set hasil ke struct (pastikan field CurrentValue & TotalDepreciation ada)
-	u.CurrentValue.Set(currentValue)
-	u.DepreciationAmount.Set(totalDepreciation)
+	//hitung nilai depresiasi & current value, depresiasi asset baru diposting oleh Create setelah asset tersimpan
+	if old.ID.Valid {
+		err = u.SetCurrentValue()
+	} else {
+		err = u.setNewValue()
+	}
+	if err != nil {
+		return err
+	}
+	if u.DisposalDate.Valid {
+		u.DisposalBookValue = u.CurrentValue
+	}
 
 	return nil
 }
 
-func JobUpdateAssetValue() {
-	tx, err := app.DB().Conn("main")
-	if err != nil {
-		return
+// validateFiscalPeriod rejects the changes of the price, the input date and the disposal inside a closed fiscal period.
+func (u *UseCaseHandler) validateFiscalPeriod(old Asset) error {
+	dates := []app.NullDate{}
+	if !old.ID.Valid {
+		dates = append(dates, u.InputDate, u.DisposalDate)
+	} else {
+		if (u.Price.Valid && u.Price.Float64 != old.Price.Float64) ||
+			(u.InputDate.Valid && !u.InputDate.Time.Equal(old.InputDate.Time)) {
+			dates = append(dates, old.InputDate, u.InputDate)
+		}
+		if (u.DisposalAmount.Valid && u.DisposalAmount.Float64 != old.DisposalAmount.Float64) ||
+			(u.DisposalDate.Valid && !u.DisposalDate.Time.Equal(old.DisposalDate.Time)) {
+			dates = append(dates, old.DisposalDate, u.DisposalDate)
+		}
 	}
+	return fiscalperiod.UseCase(*u.Ctx, url.Values{}).ValidateOpen(dates...)
+}
 
-	// get all assets
-	assets := []Asset{}
-	err = tx.Find(&assets).Error
+// rebaseDepreciation removes the posted entries of both books of the asset which no longer match the changed depreciation input,
+// so SetCurrentValue posts them again on the new input. See repostFrom for the entries which are removed.
+func (u *UseCaseHandler) rebaseDepreciation(old Asset) error {
+	if !old.ID.Valid || !old.CategoryID.Valid || !u.CategoryID.Valid || u.CategoryID.String == "" {
+		return nil
+	}
+	oldCat, err := category.UseCaseHandler{Ctx: u.Ctx, Query: url.Values{}}.GetByID(old.CategoryID.String)
 	if err != nil {
-		return
+		return err
 	}
-
-	for _, u := range assets {
-		if !u.SalvageAmount.Valid {
-			u.SalvageAmount.Set(0)
+	cat := oldCat
+	if u.CategoryID.String != old.CategoryID.String {
+		cat, err = category.UseCaseHandler{Ctx: u.Ctx, Query: url.Values{}}.GetByID(u.CategoryID.String)
+		if err != nil {
+			return err
 		}
+	}
 
-		if u.CategoryID.Valid {
-			cat := category.Category{}
-			err = tx.Model(&category.Category{}).Where("id = ?", u.CategoryID.String).First(&cat).Error
-			if err != nil {
-				return
-			}
-
-			//update depreciation price based on category economic age
-			if cat.Ages.Int64 > 0 && u.Price.Float64 > 0 {
-				// Depresiasi per bulan = (Harga Asset - Salvage Amount) / Umur Ekonomis (bulan)
-				depreciation := (u.Price.Float64 - u.SalvageAmount.Float64) / float64(cat.Ages.Int64)
-				u.DepreciationAmountPerMonth.Set(depreciation)
-			} else {
-				u.DepreciationAmountPerMonth.Set(0)
-			}
-		}
+	// prepare db for current ctx
+	tx, err := u.Ctx.DB()
+	if err != nil {
+		return app.Error().New(http.StatusInternalServerError, err.Error())
+	}
+	closed, err := fiscalperiod.Closed(tx)
+	if err != nil {
+		return app.Error().New(http.StatusInternalServerError, err.Error())
+	}
 
-		// Hitung jumlah bulan berlalu sejak InputDate
-		if !u.InputDate.Valid {
-			u.InputDate.Set(u.CreatedAt.Time)
+	now := time.Now().UTC()
+	for _, book := range []string{depreciation.BookCommercial, depreciation.BookTax} {
+		oldIn, in := BookInput(old, oldCat, book, now), BookInput(u.Asset, cat, book, now)
+		if sameDepreciationInput(oldIn, in) {
+			continue
 		}
-
-		start := u.InputDate.Time
-		now := time.Now().UTC()
-		months := int((now.Year()-start.Year())*12 + int(now.Month()) - int(start.Month()))
-		if now.Day() < start.Day() {
-			months--
+		entries := []depreciationentry.DepreciationEntry{}
+		err = tx.Where("asset_id = ?", u.ID).
+			Where("book = ?", book).
+			Where("deleted_at IS NULL").
+			Order("period ASC, date ASC, posted_at ASC").
+			Find(&entries).Error
+		if err != nil {
+			return app.Error().New(http.StatusInternalServerError, err.Error())
 		}
-		if months < 0 {
-			months = 0
+		from, key := repostFrom(oldIn, in, entries, func(date time.Time) bool { return fiscalperiod.IsClosed(closed, date) })
+		if key != "" {
+			return app.Error().New(http.StatusBadRequest, u.Ctx.Trans(key, map[string]string{
+				"code": old.Code.String,
+				"date": entries[from].Date.Time.Format("2006-01-02"),
+			}))
 		}
-
-		// Hitung total depresiasi
-		totalDepreciation := float64(0)
-		if u.DepreciationAmountPerMonth.Valid {
-			totalDepreciation = float64(months) * u.DepreciationAmountPerMonth.Float64
+		ids := []string{}
+		for _, e := range entries[from:] {
+			ids = append(ids, e.ID.String)
 		}
-
-		// Hitung nilai ekonomis saat ini, minimal salvage amount
-		currentValue := u.Price.Float64 - totalDepreciation
-		if currentValue < u.SalvageAmount.Float64 {
-			currentValue = u.SalvageAmount.Float64
+		if len(ids) == 0 {
+			continue
 		}
-
-		// Set hasil ke struct (pastikan field CurrentValue & TotalDepreciation ada)
-		u.CurrentValue.Set(currentValue)
-		u.DepreciationAmount.Set(totalDepreciation)
-
-		// save data to db
-		err = tx.Model(&u).Where("id = ?", u.ID.String).Updates(&u).Error
+		err = tx.Model(&depreciationentry.DepreciationEntry{}).Where("id IN ?", ids).Update("deleted_at", now).Error
 		if err != nil {
-			return
+			return app.Error().New(http.StatusInternalServerError, err.Error())
 		}
+		app.Cache().DeleteWithPrefix(depreciationentry.DepreciationEntry{}.EndPoint())
 	}
+	return nil
+}
 
-	app.Cache().DeleteWithPrefix(Asset{}.EndPoint())
+// repostFrom returns the index of the first posted entry of a book which has to be posted again when the depreciation input
+// changes from old to in. A changed cost, start date or convention changes every period, so every entry is posted again,
+// the other inputs change the periods after the last entry inside a closed fiscal period only.
+// The entries inside a closed fiscal period and the revaluation, impairment and capitalization entries are never removed,
+// so the change is rejected with the returned translation key when one of them would be, and the index is the rejected entry.
+func repostFrom(old, in depreciation.Input, entries []depreciationentry.DepreciationEntry, isClosed func(time.Time) bool) (int, string) {
+	from := 0
+	for i, e := range entries {
+		if isClosed(e.Date.Time) {
+			from = i + 1
+		}
+	}
+	if from > 0 && (old.Cost != in.Cost || !old.StartDate.Equal(in.StartDate) || old.Convention != in.Convention) {
+		return from - 1, "asset_depreciation_closed"
+	}
+	for i := from; i < len(entries); i++ {
+		if entries[i].Type.String != depreciationentry.TypeDepreciation {
+			return i, "asset_depreciation_adjusted"
+		}
+	}
+	return from, ""
 }
 
-func (u UseCaseHandler) GetDepreciation(id string) ([]DepreciationList, error) {
-	res := []DepreciationList{}
+// sameDepreciationInput reports whether the inputs result in the same depreciation, the units used are excluded
+// because the new units are depreciated in the next periods.
+func sameDepreciationInput(a, b depreciation.Input) bool {
+	return a.Method == b.Method && a.Cost == b.Cost && a.Salvage == b.Salvage && a.LifeMonths == b.LifeMonths &&
+		a.StartDate.Equal(b.StartDate) && a.Convention == b.Convention && a.Factor == b.Factor && a.TotalUnits == b.TotalUnits
+}
 
-	// get data asset
-	asset, err := u.GetByID(id)
+// validateAttributes validates the custom attributes of the Asset against the attribute schema of the category.
+func (u *UseCaseHandler) validateAttributes(cat category.Category) error {
+	schema, err := cat.GetAttributes()
 	if err != nil {
-		return res, err
+		return app.Error().New(http.StatusInternalServerError, err.Error())
 	}
 
-	// generate depreciation list
-	if !asset.InputDate.Valid || asset.Price.Float64 <= 0 || asset.DepreciationAmountPerMonth.Float64 <= 0 {
-		return res, nil
+	values := map[string]any{}
+	if u.Attributes.Valid {
+		jByte, err := json.Marshal(&u.Attributes)
+		if err != nil {
+			return app.Error().New(http.StatusBadRequest, err.Error())
+		}
+		err = json.Unmarshal(jByte, &values)
+		if err != nil {
+			return app.Error().New(http.StatusBadRequest, err.Error())
+		}
 	}
 
-	// economic age in months
-	economicAge := int64(0)
-	if asset.CategoryEconomicAges.Valid {
-		economicAge = asset.CategoryEconomicAges.Int64
-	}
-	if economicAge == 0 {
+	attrs := map[string]category.Attribute{}
+	for _, attr := range schema {
+		attrs[attr.Name] = attr
+		value, ok := values[attr.Name]
+		if !ok || value == nil {
+			if attr.Required {
+				return app.Error().New(http.StatusBadRequest, u.Ctx.Trans("required_key", map[string]string{"key": "attributes." + attr.Name}))
+			}
+			continue
+		}
+
+		isValid := false
+		switch attr.Type {
+		case "string":
+			_, isValid = value.(string)
+		case "number":
+			_, isValid = value.(float64)
+		case "boolean":
+			_, isValid = value.(bool)
+		case "date":
+			str, ok := value.(string)
+			if ok {
+				_, err = time.Parse("2006-01-02", str)
+				isValid = err == nil
+			}
+		case "enum":
+			str, ok := value.(string)
+			isValid = ok && slices.Contains(attr.Options, str)
+		}
+		if !isValid {
+			return app.Error().New(http.StatusBadRequest, u.Ctx.Trans("asset_attribute_invalid", map[string]string{
+				"name": attr.Name,
+				"type": attr.Type,
+			}))
+		}
+	}
+
+	for name := range values {
+		if _, ok := attrs[name]; !ok {
+			return app.Error().New(http.StatusBadRequest, u.Ctx.Trans("asset_attribute_unknown", map[string]string{
+				"name":     name,
+				"category": cat.Name.String,
+			}))
+		}
+	}
+	return nil
+}
+
+// setAttributesFilter converts the attributes.{name} query params into an id filter,
+// since the custom attributes are stored as jsonb and can not be filtered directly.
+func (u *UseCaseHandler) setAttributesFilter(tx *gorm.DB) error {
+	q := tx.Table(u.TableName()).Where("deleted_at IS NULL")
+	isFiltered := false
+	for key := range u.Query {
+		name, ok := strings.CutPrefix(key, "attributes.")
+		if !ok || name == "" {
+			continue
+		}
+		q = q.Where("attributes ->> ? = ?", name, u.Query.Get(key))
+		u.Query.Del(key)
+		isFiltered = true
+	}
+	if !isFiltered {
+		return nil
+	}
+
+	ids := []string{}
+	err := q.Pluck("id", &ids).Error
+	if err != nil {
+		return app.Error().New(http.StatusInternalServerError, err.Error())
+	}
+	u.addIDFilter(ids)
+	return nil
+}
+
+// setLocationFilter converts the location.subtree query param into an id filter,
+// so the result contains the assets placed on the location or any of its descendants.
+func (u *UseCaseHandler) setLocationFilter(tx *gorm.DB) error {
+	locationID := u.Query.Get("location.subtree")
+	if locationID == "" {
+		return nil
+	}
+	u.Query.Del("location.subtree")
+
+	locationIDs, err := location.UseCase(*u.Ctx, url.Values{}).GetSubtreeIDs(locationID)
+	if err != nil {
+		return err
+	}
+
+	ids := []string{}
+	err = tx.Table(u.TableName()).
+		Where("deleted_at IS NULL").
+		Where("location_id IN ?", locationIDs).
+		Pluck("id", &ids).Error
+	if err != nil {
+		return app.Error().New(http.StatusInternalServerError, err.Error())
+	}
+	u.addIDFilter(ids)
+	return nil
+}
+
+// setTagFilter converts the tags query param into an id filter,
+// the result contains the assets which have any of the comma separated tag names.
+func (u *UseCaseHandler) setTagFilter(tx *gorm.DB) error {
+	param := u.Query.Get("tags")
+	u.Query.Del("tags")
+	names := []string{}
+	for _, name := range strings.Split(param, ",") {
+		name = tag.NormalizeName(name)
+		if name != "" {
+			names = append(names, name)
+		}
+	}
+	if len(names) == 0 {
+		return nil
+	}
+
+	ids := []string{}
+	err := tx.Table("asset_tags at").
+		Joins("JOIN tags t ON t.id = at.tag_id AND t.deleted_at IS NULL").
+		Where("t.name IN ?", names).
+		Distinct("at.asset_id").
+		Pluck("at.asset_id", &ids).Error
+	if err != nil {
+		return app.Error().New(http.StatusInternalServerError, err.Error())
+	}
+	u.addIDFilter(ids)
+	return nil
+}
+
+// addIDFilter limits the query result to the specified asset ids,
+// intersected with the id.$in filter which is already set by the client or the other filters.
+func (u *UseCaseHandler) addIDFilter(ids []string) {
+	if existing := u.Query.Get("id.$in"); existing != "" {
+		allowed := map[string]bool{}
+		for _, id := range strings.Split(existing, ",") {
+			allowed[id] = true
+		}
+		filtered := []string{}
+		for _, id := range ids {
+			if allowed[id] {
+				filtered = append(filtered, id)
+			}
+		}
+		ids = filtered
+	}
+	if len(ids) == 0 {
+		ids = []string{"00000000-0000-0000-0000-000000000000"}
+	}
+	u.Query.Set("id.$in", strings.Join(ids, ","))
+}
+
+// TagByIDs adds the tags to the assets, a tag is created when the name does not exist yet.
+func (u UseCaseHandler) TagByIDs(p *ParamTag) error {
+
+	// check permission
+	err := u.Ctx.ValidatePermission("assets.edit")
+	if err != nil {
+		return err
+	}
+
+	// validate param
+	err = u.Ctx.ValidateParam(p)
+	if err != nil {
+		return err
+	}
+
+	assets, err := u.getByIDs(p.AssetIDs)
+	if err != nil {
+		return err
+	}
+	tags, err := tag.UseCase(*u.Ctx, url.Values{}).Resolve(p.Tags, true)
+	if err != nil {
+		return err
+	}
+
+	// prepare db for current ctx
+	tx, err := u.Ctx.DB()
+	if err != nil {
+		return app.Error().New(http.StatusInternalServerError, err.Error())
+	}
+
+	// save data to db, the existing link is kept as is
+	for _, a := range assets {
+		for _, t := range tags {
+			err = tx.Exec(`
+INSERT INTO asset_tags (id, asset_id, tag_id, created_at)
+SELECT ?, ?, ?, ?
+WHERE NOT EXISTS (SELECT 1 FROM asset_tags WHERE asset_id = ? AND tag_id = ?)`,
+				app.NewNullUUID().String, a.ID.String, t.ID.String, time.Now().UTC(), a.ID.String, t.ID.String).Error
+			if err != nil {
+				return app.Error().New(http.StatusInternalServerError, err.Error())
+			}
+		}
+	}
+
+	// invalidate cache
+	u.invalidateTagCache(assets)
+
+	// save history (user activity), send webhook, etc
+	go u.Ctx.Hook("POST", "Tag", "", p)
+	return nil
+}
+
+// UntagByIDs removes the tags from the assets.
+func (u UseCaseHandler) UntagByIDs(p *ParamTag) error {
+
+	// check permission
+	err := u.Ctx.ValidatePermission("assets.edit")
+	if err != nil {
+		return err
+	}
+
+	// validate param
+	err = u.Ctx.ValidateParam(p)
+	if err != nil {
+		return err
+	}
+
+	assets, err := u.getByIDs(p.AssetIDs)
+	if err != nil {
+		return err
+	}
+	tags, err := tag.UseCase(*u.Ctx, url.Values{}).Resolve(p.Tags, false)
+	if err != nil {
+		return err
+	}
+
+	// prepare db for current ctx
+	tx, err := u.Ctx.DB()
+	if err != nil {
+		return app.Error().New(http.StatusInternalServerError, err.Error())
+	}
+
+	assetIDs, tagIDs := []string{}, []string{}
+	for _, a := range assets {
+		assetIDs = append(assetIDs, a.ID.String)
+	}
+	for _, t := range tags {
+		tagIDs = append(tagIDs, t.ID.String)
+	}
+
+	// delete data from db
+	err = tx.Where("asset_id IN ?", assetIDs).Where("tag_id IN ?", tagIDs).Delete(&tag.AssetTag{}).Error
+	if err != nil {
+		return app.Error().New(http.StatusInternalServerError, err.Error())
+	}
+
+	// invalidate cache
+	u.invalidateTagCache(assets)
+
+	// save history (user activity), send webhook, etc
+	go u.Ctx.Hook("POST", "Untag", "", p)
+	return nil
+}
+
+// getByIDs returns the Asset data for each of the specified IDs.
+func (u UseCaseHandler) getByIDs(ids []string) ([]Asset, error) {
+	res := []Asset{}
+	seen := map[string]bool{}
+	for _, id := range ids {
+		if seen[id] {
+			continue
+		}
+		seen[id] = true
+		a, err := UseCase(*u.Ctx, url.Values{}).GetByID(id)
+		if err != nil {
+			return res, err
+		}
+		res = append(res, a)
+	}
+	return res, nil
+}
+
+// invalidateTagCache invalidates the cache of the tagged assets and the tag asset count.
+func (u UseCaseHandler) invalidateTagCache(assets []Asset) {
+	ids := []string{}
+	for _, a := range assets {
+		ids = append(ids, a.ID.String)
+	}
+	app.Cache().Invalidate(u.EndPoint(), ids...)
+	app.Cache().DeleteWithPrefix(tag.Tag{}.EndPoint())
+}
+
+// SetCurrentValue posts the depreciation of the asset up to today to the ledger,
+// then sets the depreciation amount and the current value of the asset from the ledger.
+// The asset must be saved first, a new asset is valued by setNewValue before it is saved.
+func (u *UseCaseHandler) SetCurrentValue() error {
+	cat, err := u.depreciationCategory()
+	if err != nil || !cat.ID.Valid {
+		return err
+	}
+	tx, err := u.Ctx.DB()
+	if err != nil {
+		return app.Error().New(http.StatusInternalServerError, err.Error())
+	}
+	err = PostDepreciation(tx, &u.Asset, cat, time.Now().UTC())
+	if err != nil {
+		return app.Error().New(http.StatusInternalServerError, err.Error())
+	}
+	return nil
+}
+
+// setNewValue sets the depreciation amount and the current value of the new asset from the entries which are posted
+// by SetCurrentValue once the asset is saved, without posting them.
+func (u *UseCaseHandler) setNewValue() error {
+	cat, err := u.depreciationCategory()
+	if err != nil || !cat.ID.Valid {
+		return err
+	}
+	tx, err := u.Ctx.DB()
+	if err != nil {
+		return app.Error().New(http.StatusInternalServerError, err.Error())
+	}
+	closed, err := fiscalperiod.Closed(tx)
+	if err != nil {
+		return app.Error().New(http.StatusInternalServerError, err.Error())
+	}
+	date := time.Now().UTC()
+	if u.DisposalDate.Valid && u.DisposalDate.Time.Before(date) {
+		date = u.DisposalDate.Time
+	}
+	last := depreciation.Entry{}
+	if u.InputDate.Valid && u.Price.Float64 > 0 {
+		due := dueEntries(DepreciationInput(u.Asset, cat, date), last, date, func(date time.Time) bool { return fiscalperiod.IsClosed(closed, date) })
+		if len(due) > 0 {
+			last = due[len(due)-1]
+		}
+	}
+	ApplyDepreciation(&u.Asset, cat, last, date)
+	return nil
+}
+
+// depreciationCategory returns the category of the asset with the depreciation settings, or an empty category when the asset has none.
+// A units-of-production asset must have its estimated lifetime units.
+func (u *UseCaseHandler) depreciationCategory() (category.Category, error) {
+	if !u.CategoryID.Valid || u.CategoryID.String == "" {
+		return category.Category{}, nil
+	}
+	cat, err := category.UseCaseHandler{Ctx: u.Ctx, Query: url.Values{}}.GetByID(u.CategoryID.String)
+	if err != nil {
+		return cat, err
+	}
+	if DepreciationInput(u.Asset, cat, time.Now().UTC()).Method == depreciation.UnitsOfProduction && u.DepreciationUnitsTotal.Float64 <= 0 {
+		return cat, app.Error().New(http.StatusBadRequest, u.Ctx.Trans("required_key", map[string]string{"key": "depreciation.units_total"}))
+	}
+	return cat, nil
+}
+
+// BookValueAt returns the commercial book value of the asset on the specified date (or the disposal date of a disposed asset),
+// the last entry posted on or before the date is used and the periods after it are projected by the depreciation engine.
+func (u UseCaseHandler) BookValueAt(id string, date time.Time) (float64, error) {
+	a, err := u.GetByID(id)
+	if err != nil {
+		return 0, err
+	}
+	if !a.InputDate.Valid || a.Price.Float64 <= 0 || date.Before(a.InputDate.Time) {
+		return a.Price.Float64, nil
+	}
+	if a.DisposalDate.Valid && a.DisposalDate.Time.Before(date) {
+		date = a.DisposalDate.Time
+	}
+	tx, err := u.Ctx.DB()
+	if err != nil {
+		return 0, app.Error().New(http.StatusInternalServerError, err.Error())
+	}
+	last := depreciationentry.DepreciationEntry{}
+	err = tx.Where("asset_id = ?", a.ID).
+		Where("book = ?", depreciation.BookCommercial).
+		Where("date <= ?", date.Format("2006-01-02")).
+		Where("deleted_at IS NULL").
+		Order("period DESC, date DESC, posted_at DESC").
+		Limit(1).Find(&last).Error
+	if err != nil {
+		return 0, app.Error().New(http.StatusInternalServerError, err.Error())
+	}
+	value, entry := a.Price.Float64, depreciation.Entry{}
+	if last.ID.Valid {
+		entry = ledgerEntry(last)
+		value = entry.Closing
+	}
+	if !a.CategoryID.Valid || a.CategoryID.String == "" {
+		return value, nil
+	}
+	cat, err := category.UseCaseHandler{Ctx: u.Ctx, Query: url.Values{}}.GetByID(a.CategoryID.String)
+	if err != nil {
+		return 0, err
+	}
+	for _, e := range depreciation.Project(DepreciationInput(a, cat, date), entry) {
+		if e.Date.After(date) {
+			break
+		}
+		value = e.Closing
+	}
+	return value, nil
+}
+
+// DepreciationInput returns the depreciation engine input of the asset,
+// the method and the economic age of the asset override the category, and the convention of the category overrides the app setting.
+func DepreciationInput(a Asset, cat category.Category, date time.Time) depreciation.Input {
+	in := depreciation.Input{
+		Method:     cat.DepreciationMethod.String,
+		Cost:       a.Price.Float64,
+		Salvage:    a.SalvageAmount.Float64,
+		LifeMonths: cat.Ages.Int64,
+		StartDate:  a.InputDate.Time,
+		Factor:     cat.DecliningFactor.Float64,
+		TotalUnits: a.DepreciationUnitsTotal.Float64,
+		Convention: app.DEPRECIATION_CONVENTION,
+	}
+	if cat.DepreciationConvention.Valid && cat.DepreciationConvention.String != "" {
+		in.Convention = cat.DepreciationConvention.String
+	}
+	if a.DepreciationMethod.Valid && a.DepreciationMethod.String != "" {
+		in.Method = a.DepreciationMethod.String
+	}
+	if a.DepreciationEconomicAge.Valid && a.DepreciationEconomicAge.Int64 > 0 {
+		in.LifeMonths = a.DepreciationEconomicAge.Int64
+	}
+
+	// only the units used on the date are known, the units which are not depreciated yet are recognized in the last elapsed period
+	if in.Method == depreciation.UnitsOfProduction {
+		in.Units = depreciation.CumulativeUnits(in, date, a.DepreciationUnitsUsed.Float64, nil)
+	}
+	return in
+}
+
+// meterDepreciationInput returns the depreciation engine input of the asset like DepreciationInput,
+// but the units-of-production method takes the units of each period from the readings of the depreciation meter of the asset.
+func meterDepreciationInput(tx *gorm.DB, a Asset, cat category.Category, date time.Time) (depreciation.Input, error) {
+	in := DepreciationInput(a, cat, date)
+	if in.Method != depreciation.UnitsOfProduction {
+		return in, nil
+	}
+	readings := []depreciation.Reading{}
+	err := tx.Raw(`
+SELECT mr.read_at AS date, mr.cumulative_usage AS units
+FROM meter_readings mr
+JOIN meters mtr ON mtr.id = mr.meter_id AND mtr.is_depreciation_meter = true
+WHERE mr.asset_id = ? AND mr.deleted_at IS NULL
+ORDER BY mr.read_at, mr.created_at`, a.ID).Scan(&readings).Error
+	if err != nil {
+		return in, err
+	}
+	in.Units = depreciation.CumulativeUnits(in, date, a.DepreciationUnitsUsed.Float64, readings)
+	return in, nil
+}
+
+// TaxDepreciationInput returns the depreciation engine input of the tax book of the asset, the fiscal group and the method
+// of the asset override the category. The fiscal group prescribes the useful life and the rate, and the tax book has no salvage value.
+// The tax depreciation starts in the month of the acquisition, so the tax book always uses the full month convention.
+// The cost is empty when the asset has no fiscal group, so nothing is posted to its tax book.
+func TaxDepreciationInput(a Asset, cat category.Category, date time.Time) depreciation.Input {
+	group, method := cat.TaxGroup.Int64, cat.TaxMethod.String
+	if a.TaxGroup.Valid && a.TaxGroup.Int64 > 0 {
+		group = a.TaxGroup.Int64
+	}
+	if a.TaxMethod.Valid && a.TaxMethod.String != "" {
+		method = a.TaxMethod.String
+	}
+	tg, ok := depreciation.TaxGroups[group]
+	if !ok {
+		return depreciation.Input{}
+	}
+	in := depreciation.Input{
+		Method:     depreciation.StraightLine,
+		Cost:       a.Price.Float64,
+		LifeMonths: tg.LifeMonths,
+		StartDate:  a.InputDate.Time,
+		Convention: depreciation.FullMonth,
+	}
+	if method == depreciation.DecliningBalance {
+		in.Method = depreciation.TaxDecliningBalance
+		in.Factor = tg.DecliningRate * float64(tg.LifeMonths) / 12
+	}
+	return in
+}
+
+// BookInput returns the depreciation engine input of the specified book of the asset.
+func BookInput(a Asset, cat category.Category, book string, date time.Time) depreciation.Input {
+	if book == depreciation.BookTax {
+		return TaxDepreciationInput(a, cat, date)
+	}
+	return DepreciationInput(a, cat, date)
+}
+
+// ApplyDepreciation sets the depreciation fields and the current value of the asset from the last posted entry,
+// the depreciation per month is the depreciation of the next unposted period, or zero for a disposed asset.
+func ApplyDepreciation(a *Asset, cat category.Category, last depreciation.Entry, date time.Time) {
+	if !a.InputDate.Valid || a.Price.Float64 <= 0 {
+		return
+	}
+	a.DepreciationAmount.Set(last.Accumulated)
+	a.CurrentValue.Set(a.Price.Float64)
+	if !last.Date.IsZero() {
+		a.CurrentValue.Set(last.Closing)
+	}
+	a.DepreciationAmountPerMonth.Set(0)
+	next := depreciation.Project(DepreciationInput(*a, cat, date), last)
+	if len(next) > 0 && !a.DisposalDate.Valid {
+		a.DepreciationAmountPerMonth.Set(next[0].Depreciation)
+	}
+}
+
+// LastPostedEntry returns the last posted entry of a book of the depreciation ledger of the asset, or an empty entry when nothing is posted yet.
+// A revaluation, an impairment or a capitalization keeps the period of the preceding depreciation, so it is ordered after it by the date.
+func LastPostedEntry(tx *gorm.DB, assetID, book string) (depreciation.Entry, error) {
+	last := depreciationentry.DepreciationEntry{}
+	err := tx.Where("asset_id = ?", assetID).
+		Where("book = ?", book).
+		Where("deleted_at IS NULL").
+		Order("period DESC, date DESC, posted_at DESC").
+		Limit(1).Find(&last).Error
+	if err != nil || !last.ID.Valid {
+		return depreciation.Entry{}, err
+	}
+	return ledgerEntry(last), nil
+}
+
+// lastPostedEntries returns the last posted entry of a book of the depreciation ledger of the assets by the asset id,
+// it is the set-based LastPostedEntry used by the scheduled jobs.
+func lastPostedEntries(tx *gorm.DB, assetIDs []string, book string) (map[string]depreciation.Entry, error) {
+	res := map[string]depreciation.Entry{}
+	if len(assetIDs) == 0 {
 		return res, nil
 	}
+	entries := []depreciationentry.DepreciationEntry{}
+	err := tx.Raw(`
+SELECT DISTINCT ON (asset_id) *
+FROM depreciation_entries
+WHERE asset_id IN @ids AND book = @book AND deleted_at IS NULL
+ORDER BY asset_id, period DESC, date DESC, posted_at DESC`, map[string]any{"ids": assetIDs, "book": book}).Scan(&entries).Error
+	if err != nil {
+		return res, err
+	}
+	for _, e := range entries {
+		res[e.AssetID.String] = ledgerEntry(e)
+	}
+	return res, nil
+}
 
-	initialAmount := asset.Price.Float64
-	assetAmount := asset.Price.Float64
-	salvageAmount := asset.SalvageAmount.Float64
-	depPerMonth := asset.DepreciationAmountPerMonth.Float64
+// ledgerEntry converts the depreciation ledger row to the depreciation engine entry.
+func ledgerEntry(e depreciationentry.DepreciationEntry) depreciation.Entry {
+	return depreciation.Entry{
+		Period:       e.Period.Int64,
+		Date:         e.Date.Time,
+		Opening:      e.OpeningAmount.Float64,
+		Depreciation: e.DepreciationAmount.Float64,
+		Accumulated:  e.AccumulatedAmount.Float64,
+		Closing:      e.ClosingAmount.Float64,
+		Units:        e.UnitsUsed.Float64,
+	}
+}
 
-	for month := int64(1); month <= economicAge; month++ {
-		if month != 1 {
-			assetAmount -= depPerMonth
-			if salvageAmount != 0 {
-				if assetAmount < salvageAmount {
-					assetAmount = salvageAmount
-				}
-			} else {
-				if assetAmount < 0 {
-					assetAmount = 0
+// PostDepreciation posts the depreciation entries of both books of the asset which are due on or before the specified date
+// (or the disposal date of a disposed asset), then applies the last posted entry of the commercial book to the asset.
+// Posting is idempotent, the asset row is locked and only the periods after the last posted entry are posted,
+// so running it more than once never duplicates a period. Nothing is posted inside a closed fiscal period,
+// the depreciation of its periods is rolled forward to the first open period which is due.
+func PostDepreciation(tx *gorm.DB, a *Asset, cat category.Category, date time.Time) error {
+	locked := Asset{}
+	err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+		Select("id").
+		Where("id = ?", a.ID).
+		Limit(1).Find(&locked).Error
+	if err != nil {
+		return err
+	}
+	if a.DisposalDate.Valid && a.DisposalDate.Time.Before(date) {
+		date = a.DisposalDate.Time
+	}
+	closed, err := fiscalperiod.Closed(tx)
+	if err != nil {
+		return err
+	}
+	isClosed := func(date time.Time) bool { return fiscalperiod.IsClosed(closed, date) }
+	_, err = postBook(tx, *a, TaxDepreciationInput(*a, cat, date), depreciation.BookTax, date, isClosed)
+	if err != nil {
+		return err
+	}
+	in, err := meterDepreciationInput(tx, *a, cat, date)
+	if err != nil {
+		return err
+	}
+	last, err := postBook(tx, *a, in, depreciation.BookCommercial, date, isClosed)
+	if err != nil {
+		return err
+	}
+	ApplyDepreciation(a, cat, last, date)
+	return nil
+}
+
+// postBook posts the depreciation entries of a book of the asset which are due on or before the specified date
+// and returns the last posted entry of the book, the entries inside a closed fiscal period are rolled forward.
+func postBook(tx *gorm.DB, a Asset, in depreciation.Input, book string, date time.Time, isClosed func(time.Time) bool) (depreciation.Entry, error) {
+	last, err := LastPostedEntry(tx, a.ID.String, book)
+	if err != nil || !a.InputDate.Valid || a.Price.Float64 <= 0 {
+		return last, err
+	}
+	postedAt := time.Now().UTC()
+	for _, e := range dueEntries(in, last, date, isClosed) {
+		entry := depreciationentry.DepreciationEntry{
+			ID:                 app.NewNullUUID(),
+			AssetID:            a.ID,
+			Book:               app.NewNullString(book),
+			Type:               app.NewNullString(depreciationentry.TypeDepreciation),
+			Period:             app.NewNullInt64(e.Period),
+			Date:               app.NewNullDate(e.Date),
+			Method:             app.NewNullString(in.Method),
+			OpeningAmount:      app.NewNullFloat64(e.Opening),
+			DepreciationAmount: app.NewNullFloat64(e.Depreciation),
+			AccumulatedAmount:  app.NewNullFloat64(e.Accumulated),
+			ClosingAmount:      app.NewNullFloat64(e.Closing),
+			UnitsUsed:          app.NewNullFloat64(e.Units),
+			PostedAt:           app.NewNullDateTime(postedAt),
+		}
+		err = tx.Create(&entry).Error
+		if err != nil {
+			return last, err
+		}
+		last = e
+	}
+	return last, nil
+}
+
+// dueEntries returns the entries of the depreciation input after the last entry which are due on or before the specified date,
+// the entries inside a closed fiscal period are rolled forward.
+func dueEntries(in depreciation.Input, last depreciation.Entry, date time.Time, isClosed func(time.Time) bool) []depreciation.Entry {
+	due := []depreciation.Entry{}
+	for _, e := range depreciation.Project(in, last) {
+		if e.Date.After(date) {
+			break
+		}
+		due = append(due, e)
+	}
+	return depreciation.RollForward(due, isClosed)
+}
+
+// jobBatchSize is the number of the assets loaded per batch by the scheduled jobs.
+const jobBatchSize = 500
+
+// JobPostDepreciation posts the monthly depreciation of all assets to the ledger and updates their current value.
+// The assets are processed in batches, each asset in its own transaction, a failed asset is logged and the rest continue.
+func JobPostDepreciation() {
+	tx, err := app.DB().Conn("main")
+	if err != nil {
+		app.Logger().Error().Err(err).Msg("Failed to connect to the database.")
+		return
+	}
+	run := jobrun.Start(tx, "asset.post_depreciation")
+
+	now := time.Now().UTC()
+	ids := []string{}
+	cats := map[string]category.Category{}
+	assets := []Asset{}
+	err = tx.Where("deleted_at IS NULL").FindInBatches(&assets, jobBatchSize, func(_ *gorm.DB, _ int) error {
+		err := loadCategories(tx, assets, cats)
+		if err != nil {
+			return err
+		}
+		for _, a := range assets {
+			run.ProcessedCount.Set(run.ProcessedCount.Int64 + 1)
+			cat, ok := cats[a.CategoryID.String]
+			if !ok || !a.InputDate.Valid {
+				run.SkippedCount.Set(run.SkippedCount.Int64 + 1)
+				continue
+			}
+			err = tx.Transaction(func(tx *gorm.DB) error {
+				err := PostDepreciation(tx, &a, cat, now)
+				if err != nil {
+					return err
 				}
+				return saveDepreciation(tx, a)
+			})
+			if err != nil {
+				app.Logger().Error().Err(err).Str("asset_id", a.ID.String).Msg("Failed to post the asset depreciation.")
+				run.FailedCount.Set(run.FailedCount.Int64 + 1)
+				continue
 			}
+			run.UpdatedCount.Set(run.UpdatedCount.Int64 + 1)
+			ids = append(ids, a.ID.String)
 		}
-		date := asset.InputDate.Time.AddDate(0, int(month-1), 0)
-		depreciation := depPerMonth
-		economicAmount := assetAmount - depreciation
-		if salvageAmount != 0 {
-			if economicAmount < salvageAmount {
-				economicAmount = salvageAmount
+		return nil
+	}).Error
+	if err != nil {
+		app.Logger().Error().Err(err).Msg("Failed to get the assets.")
+	}
+	run.Finish(tx, err)
+
+	if len(ids) > 0 {
+		app.Cache().Invalidate(Asset{}.EndPoint(), ids...)
+		app.Cache().DeleteWithPrefix(depreciationentry.DepreciationEntry{}.EndPoint())
+	}
+}
+
+// loadCategories loads the categories of the assets which are not loaded yet to the cats map by the category id.
+func loadCategories(tx *gorm.DB, assets []Asset, cats map[string]category.Category) error {
+	catIDs := []string{}
+	for _, a := range assets {
+		if _, ok := cats[a.CategoryID.String]; !ok && a.CategoryID.Valid && !slices.Contains(catIDs, a.CategoryID.String) {
+			catIDs = append(catIDs, a.CategoryID.String)
+		}
+	}
+	if len(catIDs) == 0 {
+		return nil
+	}
+	res := []category.Category{}
+	err := tx.Model(&category.Category{}).Where("id IN ?", catIDs).Find(&res).Error
+	if err != nil {
+		return err
+	}
+	for _, cat := range res {
+		cats[cat.ID.String] = cat
+	}
+	return nil
+}
+
+// saveDepreciation saves the depreciation fields and the current value of the asset.
+func saveDepreciation(tx *gorm.DB, a Asset) error {
+	return tx.Model(&Asset{}).Where("id = ?", a.ID).Updates(map[string]any{
+		"depreciation_amount":           a.DepreciationAmount,
+		"depreciation_amount_per_month": a.DepreciationAmountPerMonth,
+		"current_amount":                a.CurrentValue,
+	}).Error
+}
+
+// extendLife extends the economic age of the asset by the specified months (or shortens it when negative),
+// the extended economic age overrides the category from then on.
+func extendLife(tx *gorm.DB, a *Asset, cat category.Category, date time.Time, months int64) error {
+	if months == 0 {
+		return nil
+	}
+	age := DepreciationInput(*a, cat, date).LifeMonths + months
+	if age < 1 {
+		age = 1
+	}
+	a.DepreciationEconomicAge.Set(age)
+	return tx.Model(&Asset{}).Where("id = ?", a.ID).Update("economic_age", age).Error
+}
+
+// Revalue posts a revaluation or an impairment entry to the commercial book of the asset and returns the previous carrying amount.
+// The depreciation up to the specified date is posted first, the carrying amount is reset to the specified amount,
+// then the depreciation of the next periods continues from it over the remaining life.
+func (u UseCaseHandler) Revalue(id, entryType, referenceID string, date time.Time, amount float64) (float64, error) {
+	return u.adjust(id, entryType, referenceID, date, func(float64) float64 { return amount }, 0)
+}
+
+// Capitalize posts a capitalization entry of the maintenance cost to the commercial book of the asset and returns the previous carrying amount.
+// The cost is added to the carrying amount on the maintenance date and the economic age of the asset is extended by the specified months,
+// so the depreciation of the next periods continues from the increased carrying amount over the extended remaining life.
+func (u UseCaseHandler) Capitalize(id, referenceID string, date time.Time, amount float64, lifeExtension int64) (float64, error) {
+	return u.adjust(id, depreciationentry.TypeCapitalization, referenceID, date, func(previous float64) float64 { return previous + amount }, lifeExtension)
+}
+
+// adjust posts an entry which resets the carrying amount of the commercial book of the asset to the amount returned by the closing func,
+// optionally extending the economic age of the asset, and returns the previous carrying amount.
+func (u UseCaseHandler) adjust(id, entryType, referenceID string, date time.Time, closing func(previous float64) float64, lifeExtension int64) (float64, error) {
+	a, err := u.GetByID(id)
+	if err != nil {
+		return 0, err
+	}
+	if a.DisposalDate.Valid {
+		return 0, app.Error().New(http.StatusBadRequest, u.Ctx.Trans("asset_disposed", map[string]string{"code": a.Code.String}))
+	}
+	if !a.CategoryID.Valid || !a.InputDate.Valid {
+		return 0, app.Error().New(http.StatusBadRequest, u.Ctx.Trans("required_key", map[string]string{"key": "asset.category.id"}))
+	}
+	cat, err := category.UseCaseHandler{Ctx: u.Ctx, Query: url.Values{}}.GetByID(a.CategoryID.String)
+	if err != nil {
+		return 0, err
+	}
+
+	// prepare db for current ctx
+	tx, err := u.Ctx.DB()
+	if err != nil {
+		return 0, app.Error().New(http.StatusInternalServerError, err.Error())
+	}
+
+	// the posted entries are never changed, so the date can not be earlier than the last posted entry
+	err = PostDepreciation(tx, &a, cat, date)
+	if err != nil {
+		return 0, app.Error().New(http.StatusInternalServerError, err.Error())
+	}
+	last, err := LastPostedEntry(tx, a.ID.String, depreciation.BookCommercial)
+	if err != nil {
+		return 0, app.Error().New(http.StatusInternalServerError, err.Error())
+	}
+	minDate := a.InputDate.Time
+	if last.Date.After(minDate) {
+		minDate = last.Date
+	}
+	if date.Before(minDate) {
+		key := "asset_revaluation_date_invalid"
+		if entryType == depreciationentry.TypeCapitalization {
+			key = "maintenance_capitalization_date_invalid"
+		}
+		return 0, app.Error().New(http.StatusBadRequest, u.Ctx.Trans(key, map[string]string{
+			"date": minDate.Format("2006-01-02"),
+		}))
+	}
+
+	previous := a.CurrentValue.Float64
+	entry := depreciationentry.DepreciationEntry{
+		ID:                 app.NewNullUUID(),
+		AssetID:            a.ID,
+		Book:               app.NewNullString(depreciation.BookCommercial),
+		Type:               app.NewNullString(entryType),
+		ReferenceID:        app.NewNullUUID(referenceID),
+		Period:             app.NewNullInt64(last.Period),
+		Date:               app.NewNullDate(date),
+		Method:             app.NewNullString(DepreciationInput(a, cat, date).Method),
+		OpeningAmount:      app.NewNullFloat64(previous),
+		DepreciationAmount: app.NewNullFloat64(0),
+		AccumulatedAmount:  app.NewNullFloat64(last.Accumulated),
+		ClosingAmount:      app.NewNullFloat64(closing(previous)),
+		UnitsUsed:          app.NewNullFloat64(last.Units),
+		PostedAt:           app.NewNullDateTime(time.Now().UTC()),
+	}
+	err = tx.Create(&entry).Error
+	if err != nil {
+		return 0, app.Error().New(http.StatusInternalServerError, err.Error())
+	}
+	err = extendLife(tx, &a, cat, date, lifeExtension)
+	if err != nil {
+		return 0, app.Error().New(http.StatusInternalServerError, err.Error())
+	}
+
+	// continue the depreciation from the new carrying amount up to today
+	err = PostDepreciation(tx, &a, cat, time.Now().UTC())
+	if err == nil {
+		err = saveDepreciation(tx, a)
+	}
+	if err != nil {
+		return 0, app.Error().New(http.StatusInternalServerError, err.Error())
+	}
+
+	// invalidate cache
+	app.Cache().Invalidate(u.EndPoint(), a.ID.String)
+	app.Cache().DeleteWithPrefix(depreciationentry.DepreciationEntry{}.EndPoint())
+	return previous, nil
+}
+
+// Unrevalue removes the revaluation or the impairment entry from the depreciation ledger of the asset,
+// it is only allowed while the entry is the last posted entry, i.e. no depreciation is posted after it.
+func (u UseCaseHandler) Unrevalue(id, referenceID string) error {
+	return u.unadjust(id, depreciationentry.TypeRevaluation, referenceID, 0)
+}
+
+// Uncapitalize removes the capitalization entry of the maintenance cost from the depreciation ledger of the asset
+// and shortens the economic age of the asset by the extended months, like Unrevalue it is only allowed while the entry is the last posted entry.
+func (u UseCaseHandler) Uncapitalize(id, referenceID string, lifeExtension int64) error {
+	return u.unadjust(id, depreciationentry.TypeCapitalization, referenceID, lifeExtension)
+}
+
+// unadjust removes the entry posted by adjust from the depreciation ledger of the asset and reverts the extension of its economic age.
+func (u UseCaseHandler) unadjust(id, entryType, referenceID string, lifeExtension int64) error {
+	a, err := u.GetByID(id)
+	if err != nil {
+		return err
+	}
+
+	// prepare db for current ctx
+	tx, err := u.Ctx.DB()
+	if err != nil {
+		return app.Error().New(http.StatusInternalServerError, err.Error())
+	}
+
+	locked := Asset{}
+	err = tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+		Select("id").
+		Where("id = ?", a.ID).
+		Limit(1).Find(&locked).Error
+	if err != nil {
+		return app.Error().New(http.StatusInternalServerError, err.Error())
+	}
+	last := depreciationentry.DepreciationEntry{}
+	err = tx.Where("asset_id = ?", a.ID).
+		Where("book = ?", depreciation.BookCommercial).
+		Where("deleted_at IS NULL").
+		Order("period DESC, date DESC, posted_at DESC").
+		Limit(1).Find(&last).Error
+	if err != nil {
+		return app.Error().New(http.StatusInternalServerError, err.Error())
+	}
+	if last.ReferenceID.String != referenceID {
+		key := "asset_revaluation_locked"
+		if entryType == depreciationentry.TypeCapitalization {
+			key = "maintenance_capitalization_locked"
+		}
+		return app.Error().New(http.StatusBadRequest, u.Ctx.Trans(key))
+	}
+	err = tx.Model(&depreciationentry.DepreciationEntry{}).Where("id = ?", last.ID).Update("deleted_at", time.Now().UTC()).Error
+	if err != nil {
+		return app.Error().New(http.StatusInternalServerError, err.Error())
+	}
+
+	// the carrying amount goes back to the previous entry
+	cat := category.Category{}
+	if a.CategoryID.Valid {
+		cat, err = category.UseCaseHandler{Ctx: u.Ctx, Query: url.Values{}}.GetByID(a.CategoryID.String)
+		if err != nil {
+			return err
+		}
+	}
+	err = extendLife(tx, &a, cat, time.Now().UTC(), -lifeExtension)
+	if err != nil {
+		return app.Error().New(http.StatusInternalServerError, err.Error())
+	}
+	previous, err := LastPostedEntry(tx, a.ID.String, depreciation.BookCommercial)
+	if err != nil {
+		return app.Error().New(http.StatusInternalServerError, err.Error())
+	}
+	ApplyDepreciation(&a, cat, previous, time.Now().UTC())
+	err = saveDepreciation(tx, a)
+	if err != nil {
+		return app.Error().New(http.StatusInternalServerError, err.Error())
+	}
+
+	// invalidate cache
+	app.Cache().Invalidate(u.EndPoint(), a.ID.String)
+	app.Cache().DeleteWithPrefix(depreciationentry.DepreciationEntry{}.EndPoint())
+	return nil
+}
+
+// JobUpdateAssetValue synchronizes the depreciation fields and the current value of the assets with the last posted entry
+// of their commercial book, it repairs the assets left stale by a failed posting without posting anything itself.
+// The assets are processed in batches with a single query for the categories and the ledger of each batch,
+// only the assets whose values changed are saved and invalidated, and a failed asset is logged and the rest continue.
+func JobUpdateAssetValue() {
+	tx, err := app.DB().Conn("main")
+	if err != nil {
+		app.Logger().Error().Err(err).Msg("Failed to connect to the database.")
+		return
+	}
+	run := jobrun.Start(tx, "asset.update_value")
+
+	// the depreciation of an asset without the input date starts from its creation
+	ids := []string{}
+	err = tx.Raw(`
+UPDATE assets SET input_date = created_at::date
+WHERE input_date IS NULL AND created_at IS NOT NULL AND deleted_at IS NULL
+RETURNING id`).Scan(&ids).Error
+	if err != nil {
+		app.Logger().Error().Err(err).Msg("Failed to set the input date of the assets.")
+		run.Finish(tx, err)
+		return
+	}
+
+	now := time.Now().UTC()
+	cats := map[string]category.Category{}
+	assets := []Asset{}
+	err = tx.Where("deleted_at IS NULL").FindInBatches(&assets, jobBatchSize, func(_ *gorm.DB, _ int) error {
+		err := loadCategories(tx, assets, cats)
+		if err != nil {
+			return err
+		}
+		assetIDs := []string{}
+		for _, a := range assets {
+			assetIDs = append(assetIDs, a.ID.String)
+		}
+		lasts, err := lastPostedEntries(tx, assetIDs, depreciation.BookCommercial)
+		if err != nil {
+			return err
+		}
+		for _, a := range assets {
+			run.ProcessedCount.Set(run.ProcessedCount.Int64 + 1)
+			old := a
+			ApplyDepreciation(&a, cats[a.CategoryID.String], lasts[a.ID.String], now)
+			if a.DepreciationAmount == old.DepreciationAmount &&
+				a.DepreciationAmountPerMonth == old.DepreciationAmountPerMonth &&
+				a.CurrentValue == old.CurrentValue {
+				run.SkippedCount.Set(run.SkippedCount.Int64 + 1)
+				continue
 			}
-		} else {
-			if economicAmount < 0 {
-				economicAmount = 0
+			err = saveDepreciation(tx, a)
+			if err != nil {
+				app.Logger().Error().Err(err).Str("asset_id", a.ID.String).Msg("Failed to update the asset value.")
+				run.FailedCount.Set(run.FailedCount.Int64 + 1)
+				continue
+			}
+			run.UpdatedCount.Set(run.UpdatedCount.Int64 + 1)
+			if !slices.Contains(ids, a.ID.String) {
+				ids = append(ids, a.ID.String)
 			}
 		}
+		return nil
+	}).Error
+	if err != nil {
+		app.Logger().Error().Err(err).Msg("Failed to get the assets.")
+	}
+	run.Finish(tx, err)
+
+	if len(ids) > 0 {
+		app.Cache().Invalidate(Asset{}.EndPoint(), ids...)
+	}
+}
+
+// GetDepreciation returns the value history of a book of the asset (default to the commercial book), the posted depreciation,
+// revaluation, impairment and capitalization entries of the ledger followed by the projected depreciation of the remaining periods.
+func (u UseCaseHandler) GetDepreciation(id, book string) ([]DepreciationList, error) {
+	res := []DepreciationList{}
+	if book == "" {
+		book = depreciation.BookCommercial
+	}
+	if book != depreciation.BookCommercial && book != depreciation.BookTax {
+		return res, app.Error().New(http.StatusBadRequest, u.Ctx.Trans("depreciation_book_invalid", map[string]string{"book": book}))
+	}
+
+	// get data asset
+	asset, err := u.GetByID(id)
+	if err != nil {
+		return res, err
+	}
+	if !asset.InputDate.Valid || asset.Price.Float64 <= 0 || !asset.CategoryID.Valid {
+		return res, nil
+	}
+	cat, err := category.UseCaseHandler{Ctx: u.Ctx, Query: url.Values{}}.GetByID(asset.CategoryID.String)
+	if err != nil {
+		return res, err
+	}
+
+	// prepare db for current ctx
+	tx, err := u.Ctx.DB()
+	if err != nil {
+		return res, app.Error().New(http.StatusInternalServerError, err.Error())
+	}
+
+	// posted entries
+	posted := []depreciationentry.DepreciationEntry{}
+	err = tx.Where("asset_id = ?", asset.ID).
+		Where("book = ?", book).
+		Where("deleted_at IS NULL").
+		Order("period ASC, date ASC, posted_at ASC").
+		Find(&posted).Error
+	if err != nil {
+		return res, app.Error().New(http.StatusInternalServerError, err.Error())
+	}
+	last := depreciation.Entry{}
+	for _, e := range posted {
+		res = append(res, DepreciationList{
+			Date:               e.Date,
+			Month:              e.Period,
+			Type:               e.Type,
+			Status:             app.NewNullString(DepreciationPosted),
+			InitialAmount:      asset.Price,
+			AssetAmount:        e.OpeningAmount,
+			DepreciationAmount: e.DepreciationAmount,
+			EconomicAmount:     e.ClosingAmount,
+			AccumulatedAmount:  e.AccumulatedAmount,
+		})
+		last = ledgerEntry(e)
+	}
+
+	// projected entries, a disposed asset has nothing left to depreciate
+	if asset.DisposalDate.Valid {
+		return res, nil
+	}
+	in := BookInput(asset, cat, book, time.Now().UTC())
+	for _, e := range depreciation.Project(in, last) {
 		res = append(res, DepreciationList{
-			Date:               app.NewNullDate(date),
-			Month:              app.NewNullInt64(month),
-			InitialAmount:      app.NewNullFloat64(initialAmount),
-			AssetAmount:        app.NewNullFloat64(assetAmount),
-			DepreciationAmount: app.NewNullFloat64(depreciation),
-			EconomicAmount:     app.NewNullFloat64(economicAmount),
+			Date:               app.NewNullDate(e.Date),
+			Month:              app.NewNullInt64(e.Period),
+			Type:               app.NewNullString(depreciationentry.TypeDepreciation),
+			Status:             app.NewNullString(DepreciationProjected),
+			InitialAmount:      app.NewNullFloat64(in.Cost),
+			AssetAmount:        app.NewNullFloat64(e.Opening),
+			DepreciationAmount: app.NewNullFloat64(e.Depreciation),
+			EconomicAmount:     app.NewNullFloat64(e.Closing),
+			AccumulatedAmount:  app.NewNullFloat64(e.Accumulated),
 		})
 	}
 
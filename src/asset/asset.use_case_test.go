@@ -0,0 +1,91 @@
+package asset
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/maulanar/go_asset_tracking_management/app"
+	"github.com/maulanar/go_asset_tracking_management/src/depreciation"
+	"github.com/maulanar/go_asset_tracking_management/src/depreciationentry"
+)
+
+// postedEntries returns the ledger rows of the depreciation periods of the input which are due on or before the date.
+func postedEntries(in depreciation.Input, date time.Time) []depreciationentry.DepreciationEntry {
+	res := []depreciationentry.DepreciationEntry{}
+	for _, e := range depreciation.Schedule(in) {
+		if e.Date.After(date) {
+			break
+		}
+		res = append(res, depreciationentry.DepreciationEntry{
+			ID:                 app.NewNullUUID(),
+			Type:               app.NewNullString(depreciationentry.TypeDepreciation),
+			Period:             app.NewNullInt64(e.Period),
+			Date:               app.NewNullDate(e.Date),
+			OpeningAmount:      app.NewNullFloat64(e.Opening),
+			DepreciationAmount: app.NewNullFloat64(e.Depreciation),
+			AccumulatedAmount:  app.NewNullFloat64(e.Accumulated),
+			ClosingAmount:      app.NewNullFloat64(e.Closing),
+		})
+	}
+	return res
+}
+
+// closedUntil returns a closed fiscal period check of the dates on or before the specified date.
+func closedUntil(until time.Time) func(time.Time) bool {
+	return func(date time.Time) bool { return !date.After(until) }
+}
+
+func TestRepostFromPriceChange(t *testing.T) {
+	in := depreciation.Input{Method: depreciation.StraightLine, Cost: 1200, LifeMonths: 12, StartDate: time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)}
+	now := time.Date(2025, 3, 31, 0, 0, 0, 0, time.UTC)
+	entries := postedEntries(in, now)
+	changed := in
+	changed.Cost = 2400
+
+	// every period is posted again on the new price, so the book value matches the schedule of the new price
+	from, key := repostFrom(in, changed, entries, closedUntil(time.Time{}))
+	if from != 0 || key != "" {
+		t.Fatalf("expected to repost every entry, got from %d and key %q", from, key)
+	}
+	last := depreciation.Entry{}
+	for _, e := range depreciation.Schedule(changed) {
+		if e.Date.After(now) {
+			break
+		}
+		last = e
+	}
+	if expected := depreciation.ValueAt(changed, now).BookValue; math.Abs(last.Closing-expected) > 0.005 || math.Abs(last.Closing-1800) > 0.005 {
+		t.Errorf("expected the reposted book value %.2f, got %.2f", expected, last.Closing)
+	}
+
+	// the price can not change once a period is closed
+	_, key = repostFrom(in, changed, entries, closedUntil(time.Date(2025, 1, 31, 0, 0, 0, 0, time.UTC)))
+	if key != "asset_depreciation_closed" {
+		t.Errorf("expected the change to be rejected in a closed period, got %q", key)
+	}
+}
+
+func TestRepostFromEstimateChange(t *testing.T) {
+	in := depreciation.Input{Method: depreciation.StraightLine, Cost: 1200, LifeMonths: 12, StartDate: time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)}
+	entries := postedEntries(in, time.Date(2025, 3, 31, 0, 0, 0, 0, time.UTC))
+	changed := in
+	changed.Salvage = 200
+
+	// the periods after the closed period are posted again from the closed book value
+	from, key := repostFrom(in, changed, entries, closedUntil(time.Date(2025, 1, 31, 0, 0, 0, 0, time.UTC)))
+	if from != 1 || key != "" {
+		t.Fatalf("expected to repost from the second entry, got from %d and key %q", from, key)
+	}
+	next := depreciation.Project(changed, ledgerEntry(entries[0]))
+	if len(next) != 11 || math.Abs(next[0].Depreciation-(1100-200)/11.0) > 0.005 {
+		t.Errorf("expected the remaining depreciable amount over 11 periods, got %+v", next[0])
+	}
+
+	// a revaluation after the closed period is never removed
+	entries[2].Type.Set(depreciationentry.TypeRevaluation)
+	from, key = repostFrom(in, changed, entries, closedUntil(time.Date(2025, 1, 31, 0, 0, 0, 0, time.UTC)))
+	if from != 2 || key != "asset_depreciation_adjusted" {
+		t.Errorf("expected the change to be rejected by the revaluation, got from %d and key %q", from, key)
+	}
+}
@@ -0,0 +1,2 @@
+// assetrevaluation is a package related to assetrevaluation data.
+package assetrevaluation
@@ -0,0 +1,128 @@
+package assetrevaluation
+
+import "github.com/maulanar/go_asset_tracking_management/app"
+
+// AssetRevaluation is the main model of AssetRevaluation data. It provides a convenient interface for app.ModelInterface
+type AssetRevaluation struct {
+	app.Model
+	ID               app.NullUUID    `json:"id"                db:"m.id"                gorm:"column:id;primaryKey"`
+	Code             app.NullString  `json:"code"              db:"m.code"              gorm:"column:code"`
+	Type             app.NullString  `json:"type"              db:"m.type"              gorm:"column:type"              validate:"omitempty,oneof=revaluation impairment"`
+	Date             app.NullDate    `json:"date"              db:"m.date"              gorm:"column:date"`
+	PreviousAmount   app.NullFloat64 `json:"previous_amount"   db:"m.previous_amount"   gorm:"column:previous_amount"`
+	NewAmount        app.NullFloat64 `json:"new_amount"        db:"m.new_amount"        gorm:"column:new_amount"        validate:"omitempty,gte=0"`
+	AdjustmentAmount app.NullFloat64 `json:"adjustment_amount" db:"m.adjustment_amount" gorm:"column:adjustment_amount"`
+	Reason           app.NullText    `json:"reason"            db:"m.reason"            gorm:"column:reason"`
+
+	AssetID   app.NullUUID   `json:"asset.id"          db:"m.asset_id"          gorm:"column:asset_id"`
+	AssetCode app.NullString `json:"asset.code"        db:"ass.code"            gorm:"-"`
+	AssetName app.NullString `json:"asset.name"        db:"ass.name"            gorm:"-"`
+
+	AttachmentID   app.NullUUID `json:"attachment.id"     db:"m.attachment_id"     gorm:"column:attachment_id"`
+	AttachmentName app.NullText `json:"attachment.name"   db:"att.name"            gorm:"-"`
+	AttachmentPath app.NullText `json:"attachment.path"   db:"att.path"            gorm:"-"`
+	AttachmentURL  app.NullText `json:"attachment.url"    db:"att.url"             gorm:"-"`
+
+	CreatedAt app.NullDateTime `json:"created_at"        db:"m.created_at"        gorm:"column:created_at"`
+	UpdatedAt app.NullDateTime `json:"updated_at"        db:"m.updated_at"        gorm:"column:updated_at"`
+	DeletedAt app.NullDateTime `json:"deleted_at"        db:"m.deleted_at,hide"   gorm:"column:deleted_at"`
+}
+
+// EndPoint returns the AssetRevaluation end point, it used for cache key, etc.
+func (AssetRevaluation) EndPoint() string {
+	return "asset_revaluations"
+}
+
+// TableVersion returns the versions of the AssetRevaluation table in the database.
+// Change this value with date format YY.MM.DDHHii when any table structure changes.
+func (AssetRevaluation) TableVersion() string {
+	return "26.10.191900"
+}
+
+// TableName returns the name of the AssetRevaluation table in the database.
+func (AssetRevaluation) TableName() string {
+	return "asset_revaluations"
+}
+
+// TableAliasName returns the table alias name of the AssetRevaluation table, used for querying.
+func (AssetRevaluation) TableAliasName() string {
+	return "m"
+}
+
+// GetRelations returns the relations of the AssetRevaluation data in the database, used for querying.
+func (m *AssetRevaluation) GetRelations() map[string]map[string]any {
+	m.AddRelation("left", "assets", "ass", []map[string]any{{"column1": "ass.id", "column2": "m.asset_id"}})
+	m.AddRelation("left", "attachments", "att", []map[string]any{{"column1": "att.id", "column2": "m.attachment_id"}})
+	return m.Relations
+}
+
+// GetFilters returns the filter of the AssetRevaluation data in the database, used for querying.
+func (m *AssetRevaluation) GetFilters() []map[string]any {
+	m.AddFilter(map[string]any{"column1": "m.deleted_at", "operator": "=", "value": nil})
+	return m.Filters
+}
+
+// GetSorts returns the default sort of the AssetRevaluation data in the database, used for querying.
+func (m *AssetRevaluation) GetSorts() []map[string]any {
+	m.AddSort(map[string]any{"column": "m.date", "direction": "desc"})
+	return m.Sorts
+}
+
+// GetFields returns list of the field of the AssetRevaluation data in the database, used for querying.
+func (m *AssetRevaluation) GetFields() map[string]map[string]any {
+	m.SetFields(m)
+	return m.Fields
+}
+
+// GetSchema returns the AssetRevaluation schema, used for querying.
+func (m *AssetRevaluation) GetSchema() map[string]any {
+	return m.SetSchema(m)
+}
+
+// OpenAPISchemaName returns the name of the AssetRevaluation schema in the open api documentation.
+func (AssetRevaluation) OpenAPISchemaName() string {
+	return "AssetRevaluation"
+}
+
+// GetOpenAPISchema returns the Open API Schema of the AssetRevaluation in the open api documentation.
+func (m *AssetRevaluation) GetOpenAPISchema() map[string]any {
+	return m.SetOpenAPISchema(m)
+}
+
+type AssetRevaluationList struct {
+	app.ListModel
+}
+
+// OpenAPISchemaName returns the name of the AssetRevaluationList schema in the open api documentation.
+func (AssetRevaluationList) OpenAPISchemaName() string {
+	return "AssetRevaluationList"
+}
+
+// GetOpenAPISchema returns the Open API Schema of the AssetRevaluationList in the open api documentation.
+func (p *AssetRevaluationList) GetOpenAPISchema() map[string]any {
+	return p.SetOpenAPISchema(&AssetRevaluation{})
+}
+
+// ParamCreate is the expected parameters for create a new AssetRevaluation data.
+type ParamCreate struct {
+	UseCaseHandler
+	Type      app.NullString  `json:"type"              db:"m.type"              gorm:"column:type"              validate:"required,oneof=revaluation impairment"`
+	Date      app.NullDate    `json:"date"              db:"m.date"              gorm:"column:date"              validate:"required"`
+	NewAmount app.NullFloat64 `json:"new_amount"        db:"m.new_amount"        gorm:"column:new_amount"        validate:"required,gte=0"`
+	AssetID   app.NullUUID    `json:"asset.id"          db:"m.asset_id"          gorm:"column:asset_id"          validate:"required"`
+}
+
+// ParamUpdate is the expected parameters for update the AssetRevaluation data.
+type ParamUpdate struct {
+	UseCaseHandler
+}
+
+// ParamPartiallyUpdate is the expected parameters for partially update the AssetRevaluation data.
+type ParamPartiallyUpdate struct {
+	UseCaseHandler
+}
+
+// ParamDelete is the expected parameters for delete the AssetRevaluation data.
+type ParamDelete struct {
+	UseCaseHandler
+}
@@ -0,0 +1,119 @@
+package assetrevaluation
+
+import "github.com/maulanar/go_asset_tracking_management/app"
+
+// OpenAPI is constructor for *openAPI, to autogenerate open api document.
+func OpenAPI() *OpenAPIOperation {
+	return &OpenAPIOperation{}
+}
+
+// OpenAPIOperation embed from app.OpenAPIOperation for simplicity, used for autogenerate open api document.
+type OpenAPIOperation struct {
+	app.OpenAPIOperation
+}
+
+// Base is common detail of asset_revaluations open api document component.
+func (o *OpenAPIOperation) Base() {
+	o.Tags = []string{"AssetRevaluation"}
+	o.HeaderParams = []map[string]any{{"$ref": "#/components/parameters/headerParam.Accept-Language"}}
+	o.Responses = map[string]map[string]any{
+		"200": {
+			"description": "Success",
+			"content":     map[string]any{"application/json": &AssetRevaluation{}}, // will auto create schema $ref: '#/components/schemas/AssetRevaluation' if not exists
+		},
+		"400": app.OpenAPIError().BadRequest(),
+		"401": app.OpenAPIError().Unauthorized(),
+		"403": app.OpenAPIError().Forbidden(),
+	}
+	o.Securities = []map[string][]string{}
+}
+
+// Get is detail of `GET /api/v3/asset_revaluations` open api document component.
+func (o *OpenAPIOperation) Get() *OpenAPIOperation {
+	if !app.IS_GENERATE_OPEN_API_DOC {
+		return o // skip for efficiency
+	}
+
+	o.Base()
+	o.Summary = "Get AssetRevaluation"
+	o.Description = "Use this method to get list of AssetRevaluation"
+	o.QueryParams = []map[string]any{{"$ref": "#/components/parameters/queryParam.Any"}}
+	o.Responses = map[string]map[string]any{
+		"200": {
+			"description": "Success",
+			"content":     map[string]any{"application/json": &AssetRevaluationList{}}, // will auto create schema $ref: '#/components/schemas/AssetRevaluation.List' if not exists
+		},
+		"400": app.OpenAPIError().BadRequest(),
+		"401": app.OpenAPIError().Unauthorized(),
+		"403": app.OpenAPIError().Forbidden(),
+	}
+	return o
+}
+
+// GetByID is detail of `GET /api/v3/asset_revaluations/{id}` open api document component.
+func (o *OpenAPIOperation) GetByID() *OpenAPIOperation {
+	if !app.IS_GENERATE_OPEN_API_DOC {
+		return o // skip for efficiency
+	}
+
+	o.Base()
+	o.Summary = "Get AssetRevaluation By ID"
+	o.Description = "Use this method to get AssetRevaluation by id"
+	o.PathParams = []map[string]any{{"$ref": "#/components/parameters/pathParam.ID"}}
+	return o
+}
+
+// Create is detail of `POST /api/v3/asset_revaluations` open api document component.
+func (o *OpenAPIOperation) Create() *OpenAPIOperation {
+	if !app.IS_GENERATE_OPEN_API_DOC {
+		return o // skip for efficiency
+	}
+
+	o.Base()
+	o.Summary = "Create AssetRevaluation"
+	o.Description = "Use this method to create AssetRevaluation, the new carrying amount resets the depreciable base of the asset and the depreciation continues from it over the remaining life"
+	o.Body = map[string]any{"application/json": &ParamCreate{}}
+	return o
+}
+
+// UpdateByID is detail of `PUT /api/v3/asset_revaluations/{id}` open api document component.
+func (o *OpenAPIOperation) UpdateByID() *OpenAPIOperation {
+	if !app.IS_GENERATE_OPEN_API_DOC {
+		return o // skip for efficiency
+	}
+
+	o.Base()
+	o.Summary = "Update AssetRevaluation By ID"
+	o.Description = "Use this method to update AssetRevaluation by id, only the reason and the attachment can be changed"
+	o.PathParams = []map[string]any{{"$ref": "#/components/parameters/pathParam.ID"}}
+	o.Body = map[string]any{"application/json": &ParamUpdate{}}
+	return o
+}
+
+// PartiallyUpdateByID is detail of `PATCH /api/v3/asset_revaluations/{id}` open api document component.
+func (o *OpenAPIOperation) PartiallyUpdateByID() *OpenAPIOperation {
+	if !app.IS_GENERATE_OPEN_API_DOC {
+		return o // skip for efficiency
+	}
+
+	o.Base()
+	o.Summary = "Partially Update AssetRevaluation By ID"
+	o.Description = "Use this method to partially update AssetRevaluation by id, only the reason and the attachment can be changed"
+	o.PathParams = []map[string]any{{"$ref": "#/components/parameters/pathParam.ID"}}
+	o.Body = map[string]any{"application/json": &ParamPartiallyUpdate{}}
+	return o
+}
+
+// DeleteByID is detail of `DELETE /api/v3/asset_revaluations/{id}` open api document component.
+func (o *OpenAPIOperation) DeleteByID() *OpenAPIOperation {
+	if !app.IS_GENERATE_OPEN_API_DOC {
+		return o // skip for efficiency
+	}
+
+	o.Base()
+	o.Summary = "Delete AssetRevaluation By ID"
+	o.Description = "Use this method to delete AssetRevaluation by id, allowed while no depreciation is posted after it"
+	o.PathParams = []map[string]any{{"$ref": "#/components/parameters/pathParam.ID"}}
+	o.Body = map[string]any{"application/json": &ParamDelete{}}
+	return o
+}
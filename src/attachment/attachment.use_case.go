@@ -2,6 +2,7 @@ package attachment
 
 import (
 	"fmt"
+	"mime"
 	"net/http"
 	"net/url"
 	"os"
@@ -9,6 +10,8 @@ import (
 	"strings"
 	"time"
 
+	"gorm.io/gorm"
+
 	"github.com/maulanar/go_asset_tracking_management/app"
 )
 
@@ -282,9 +285,10 @@ func (u UseCaseHandler) DeleteByID(id string, p *ParamDelete) error {
 		return app.Error().New(http.StatusInternalServerError, err.Error())
 	}
 
-	// delete file
-	path, err := os.Getwd()
-	os.Remove(fmt.Sprintf(path+"/storages/%s", old.Name.String))
+	// delete file once the deletion is committed
+	if old.Name.Valid {
+		u.deleteFileOnCommit(old.Name.String)
+	}
 
 	// invalidate cache
 	app.Cache().Invalidate(u.EndPoint(), old.ID.String)
@@ -305,29 +309,100 @@ func (u *UseCaseHandler) setDefaultValue(old Attachment) (err error) {
 	// handle file upload
 	u.File, err = u.Ctx.FiberCtx.FormFile("file")
 	if err == nil {
-		dir := "./storages"
-		if _, err := os.Stat(dir); os.IsNotExist(err) {
-			err = os.MkdirAll(dir, 0775)
-			if err != nil {
-				return app.Error().New(http.StatusInternalServerError, err.Error())
-			}
+		src, err := u.File.Open()
+		if err != nil {
+			return app.Error().New(http.StatusInternalServerError, err.Error())
 		}
+		defer src.Close()
 
-		// rename & save file
+		// rename & upload file
 		t := time.Now().Format("2006-01-02-15-04-05")
 		extension := filepath.Ext(u.File.Filename)
 		newName := fmt.Sprintf("%s%s%s", t, "_", filepath.Base(u.File.Filename))
 		newName = strings.Replace(newName, " ", "", -1)
 
-		err = u.Ctx.FiberCtx.SaveFile(u.File, fmt.Sprintf("./storages/%s", newName))
+		opt := app.FileUploadOption{}
+		opt.ContentType = u.File.Header.Get("Content-Type")
+		_, err = app.FS().Upload(newName, src, u.File.Size, opt)
 		if err != nil {
-			return err
+			return app.Error().New(http.StatusInternalServerError, err.Error())
 		}
 		u.Name.Set(newName)
-		u.Path.Set("/storages/" + newName)
+		u.Path.Set(app.FS().GetFilePath(newName))
 		u.Extension.Set(extension)
-		u.Url.Set(app.APP_URL + u.Path.String)
+		u.Url.Set(app.FS().GetFileUrl(newName))
+
+		// the replaced file is no longer referenced once the update is committed
+		if old.Name.Valid && old.Name.String != newName {
+			u.deleteFileOnCommit(old.Name.String)
+		}
 	}
 
 	return nil
 }
+
+// deleteFileOnCommit deletes the stored file after the current transaction is committed,
+// so a rolled back request never leaves the attachment pointing to a deleted file.
+func (u *UseCaseHandler) deleteFileOnCommit(fileName string) {
+	u.Ctx.OnCommit(func() {
+		err := app.FS().Delete(fileName)
+		if err != nil {
+			app.Logger().Error().Err(err).Str("file", fileName).Msg("failed to delete attachment file")
+		}
+	})
+}
+
+// MigrateLocalFiles uploads the attachment files stored on the local disk to the configured app.FS storage
+// and updates their path and url, attachments which already point to the storage are skipped so it can be run again.
+func MigrateLocalFiles() (migrated int, err error) {
+	if app.FS().Driver == "local" {
+		return 0, app.Error().New(http.StatusBadRequest, "the file storage driver is local, set FS_DRIVER to migrate the local files")
+	}
+	tx, err := app.DB().Conn("main")
+	if err != nil {
+		return 0, err
+	}
+
+	attachments := []Attachment{}
+	err = tx.Model(&Attachment{}).Where("deleted_at IS NULL AND name IS NOT NULL").Order("created_at").Find(&attachments).Error
+	if err != nil {
+		return 0, err
+	}
+	for _, a := range attachments {
+		fileUrl := app.FS().GetFileUrl(a.Name.String)
+		if a.Url.String == fileUrl {
+			continue
+		}
+		err = migrateLocalFile(tx, a, fileUrl)
+		if err != nil {
+			app.Logger().Error().Err(err).Str("attachment_id", a.ID.String).Str("file", a.Name.String).Msg("failed to migrate attachment file")
+			continue
+		}
+		migrated++
+	}
+	return migrated, nil
+}
+
+// migrateLocalFile uploads the local file of the attachment and points the attachment to the uploaded file.
+func migrateLocalFile(tx *gorm.DB, a Attachment, fileUrl string) error {
+	src, err := os.Open(filepath.Join(app.FS_LOCAL_DIR_PATH, a.Name.String))
+	if err != nil {
+		return err
+	}
+	defer src.Close()
+	stat, err := src.Stat()
+	if err != nil {
+		return err
+	}
+
+	opt := app.FileUploadOption{}
+	opt.ContentType = mime.TypeByExtension(filepath.Ext(a.Name.String))
+	_, err = app.FS().Upload(a.Name.String, src, stat.Size(), opt)
+	if err != nil {
+		return err
+	}
+	return tx.Model(&Attachment{}).Where("id = ?", a.ID).Updates(map[string]any{
+		"path": app.FS().GetFilePath(a.Name.String),
+		"url":  fileUrl,
+	}).Error
+}
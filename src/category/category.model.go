@@ -1,6 +1,10 @@
 package category
 
-import "github.com/maulanar/go_asset_tracking_management/app"
+import (
+	"encoding/json"
+
+	"github.com/maulanar/go_asset_tracking_management/app"
+)
 
 // Category is the main model of Category data. It provides a convenient interface for app.ModelInterface
 type Category struct {
@@ -11,6 +15,21 @@ type Category struct {
 	Description app.NullText   `json:"description"  db:"m.description"     gorm:"column:description"`
 	Ages        app.NullInt64  `json:"economic_age" db:"m.economic_age"    gorm:"column:economic_age;default:60"`
 	IsActive    app.NullBool   `json:"is_active"    db:"m.is_active"       gorm:"column:is_active;default:true"`
+	Attributes  app.NullJSON   `json:"attributes"   db:"m.attributes"      gorm:"column:attributes;type:jsonb"`
+
+	DepreciationMethod     app.NullString  `json:"depreciation.method"           db:"m.depreciation_method"     gorm:"column:depreciation_method;default:straight_line" validate:"omitempty,oneof=straight_line declining_balance double_declining sum_of_years_digits units_of_production"`
+	DecliningFactor        app.NullFloat64 `json:"depreciation.declining_factor" db:"m.declining_factor"        gorm:"column:declining_factor"                          validate:"omitempty,gt=0"`
+	DepreciationConvention app.NullString  `json:"depreciation.convention"       db:"m.depreciation_convention" gorm:"column:depreciation_convention"                   validate:"omitempty,oneof=full_month mid_month actual_days start_next_month"`
+
+	TaxGroup  app.NullInt64  `json:"tax.group"  db:"m.tax_group"  gorm:"column:tax_group"                     validate:"omitempty,oneof=1 2 3 4"`
+	TaxMethod app.NullString `json:"tax.method" db:"m.tax_method" gorm:"column:tax_method;default:straight_line" validate:"omitempty,oneof=straight_line declining_balance"`
+
+	GLAssetAccount                   app.NullString `json:"gl.asset_account"                    db:"m.gl_asset_account"                    gorm:"column:gl_asset_account"`
+	GLAccumulatedDepreciationAccount app.NullString `json:"gl.accumulated_depreciation_account" db:"m.gl_accumulated_depreciation_account" gorm:"column:gl_accumulated_depreciation_account"`
+	GLDepreciationExpenseAccount     app.NullString `json:"gl.depreciation_expense_account"     db:"m.gl_depreciation_expense_account"     gorm:"column:gl_depreciation_expense_account"`
+	GLDisposalGainLossAccount        app.NullString `json:"gl.disposal_gain_loss_account"       db:"m.gl_disposal_gain_loss_account"       gorm:"column:gl_disposal_gain_loss_account"`
+	GLRevaluationReserveAccount      app.NullString `json:"gl.revaluation_reserve_account"      db:"m.gl_revaluation_reserve_account"      gorm:"column:gl_revaluation_reserve_account"`
+	GLImpairmentLossAccount          app.NullString `json:"gl.impairment_loss_account"          db:"m.gl_impairment_loss_account"          gorm:"column:gl_impairment_loss_account"`
 
 	CreatedAt app.NullDateTime `json:"created_at"   db:"m.created_at"      gorm:"column:created_at"`
 	UpdatedAt app.NullDateTime `json:"updated_at"   db:"m.updated_at"      gorm:"column:updated_at"`
@@ -25,7 +44,7 @@ func (Category) EndPoint() string {
 // TableVersion returns the versions of the Category table in the database.
 // Change this value with date format YY.MM.DDHHii when any table structure changes.
 func (Category) TableVersion() string {
-	return "25.09.280100"
+	return "26.10.192800"
 }
 
 // TableName returns the name of the Category table in the database.
@@ -75,7 +94,48 @@ func (Category) OpenAPISchemaName() string {
 
 // GetOpenAPISchema returns the Open API Schema of the Category in the open api documentation.
 func (m *Category) GetOpenAPISchema() map[string]any {
-	return m.SetOpenAPISchema(m)
+	schema := m.SetOpenAPISchema(m)
+	if props, ok := schema["properties"].(map[string]any); ok {
+		props["attributes"] = map[string]any{
+			"type":        "array",
+			"description": "Custom attribute schema of the assets in this category.",
+			"items": map[string]any{
+				"type": "object",
+				"properties": map[string]any{
+					"name":     map[string]any{"type": "string"},
+					"type":     map[string]any{"type": "string", "enum": AttributeTypes},
+					"required": map[string]any{"type": "boolean"},
+					"options":  map[string]any{"type": "array", "items": map[string]any{"type": "string"}},
+				},
+			},
+		}
+	}
+	return schema
+}
+
+// AttributeTypes is the list of supported custom attribute types.
+var AttributeTypes = []string{"string", "number", "boolean", "date", "enum"}
+
+// Attribute is the definition of a custom attribute of the assets in a category.
+type Attribute struct {
+	Name     string   `json:"name"     validate:"required"`
+	Type     string   `json:"type"     validate:"required,oneof=string number boolean date enum"`
+	Required bool     `json:"required"`
+	Options  []string `json:"options"`
+}
+
+// GetAttributes returns the custom attribute schema of the Category.
+func (m Category) GetAttributes() ([]Attribute, error) {
+	res := []Attribute{}
+	if !m.Attributes.Valid {
+		return res, nil
+	}
+	jByte, err := json.Marshal(&m.Attributes)
+	if err != nil {
+		return res, err
+	}
+	err = json.Unmarshal(jByte, &res)
+	return res, err
 }
 
 type CategoryList struct {
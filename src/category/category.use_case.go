@@ -6,6 +6,7 @@ import (
 	"time"
 
 	"github.com/maulanar/go_asset_tracking_management/app"
+	"github.com/maulanar/go_asset_tracking_management/src/depreciation"
 )
 
 // UseCase returns a UseCaseHandler for expected use case functional.
@@ -331,10 +332,37 @@ func (u *UseCaseHandler) setDefaultValue(old Category) error {
 		}
 	}
 
+	// validate custom attribute schema
+	if u.Attributes.Valid {
+		attrs, err := u.GetAttributes()
+		if err != nil {
+			return app.Error().New(http.StatusBadRequest, err.Error())
+		}
+		names := map[string]bool{}
+		for _, attr := range attrs {
+			err = u.Ctx.ValidateParam(attr)
+			if err != nil {
+				return err
+			}
+			if names[attr.Name] || (attr.Type == "enum" && len(attr.Options) == 0) {
+				return app.Error().New(http.StatusBadRequest, u.Ctx.Trans("category_attribute_invalid", map[string]string{
+					"name": attr.Name,
+				}))
+			}
+			names[attr.Name] = true
+		}
+	}
+
 	if u.Ctx.Action.Method == "POST" {
 		if !u.IsActive.Valid {
 			u.IsActive.Set(true)
 		}
+		if !u.DepreciationMethod.Valid || u.DepreciationMethod.String == "" {
+			u.DepreciationMethod.Set(depreciation.StraightLine)
+		}
+		if !u.TaxMethod.Valid || u.TaxMethod.String == "" {
+			u.TaxMethod.Set(depreciation.StraightLine)
+		}
 
 	} else {
 
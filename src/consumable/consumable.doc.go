@@ -0,0 +1,2 @@
+// consumable is a package related to consumable data.
+package consumable
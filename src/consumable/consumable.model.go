@@ -0,0 +1,128 @@
+package consumable
+
+import "github.com/maulanar/go_asset_tracking_management/app"
+
+// Consumable is the main model of Consumable data. It provides a convenient interface for app.ModelInterface
+type Consumable struct {
+	app.Model
+	ID          app.NullUUID    `json:"id"            db:"m.id"              gorm:"column:id;primaryKey"`
+	Code        app.NullString  `json:"code"          db:"m.code"            gorm:"column:code"`
+	Name        app.NullString  `json:"name"          db:"m.name"            gorm:"column:name"`
+	Type        app.NullString  `json:"type"          db:"m.type"            gorm:"column:type;default:consumable" validate:"omitempty,oneof=consumable spare_part"`
+	PartNumber  app.NullString  `json:"part_number"   db:"m.part_number"     gorm:"column:part_number"`
+	Unit        app.NullString  `json:"unit"          db:"m.unit"            gorm:"column:unit"`
+	UnitCost    app.NullFloat64 `json:"unit_cost"     db:"m.unit_cost"       gorm:"column:unit_cost"               validate:"omitempty,gte=0"`
+	Description app.NullText    `json:"description"   db:"m.description"     gorm:"column:description"`
+	MinStock    app.NullFloat64 `json:"min_stock"     db:"m.min_stock"       gorm:"column:min_stock"               validate:"omitempty,gte=0"`
+	IsActive    app.NullBool    `json:"is_active"     db:"m.is_active"       gorm:"column:is_active;default:true"`
+
+	CategoryID   app.NullUUID   `json:"category.id"   db:"m.category_id"     gorm:"column:category_id"`
+	CategoryCode app.NullString `json:"category.code" db:"cat.code"          gorm:"-"`
+	CategoryName app.NullString `json:"category.name" db:"cat.name"          gorm:"-"`
+
+	CreatedAt app.NullDateTime `json:"created_at"    db:"m.created_at"      gorm:"column:created_at"`
+	UpdatedAt app.NullDateTime `json:"updated_at"    db:"m.updated_at"      gorm:"column:updated_at"`
+	DeletedAt app.NullDateTime `json:"deleted_at"    db:"m.deleted_at,hide" gorm:"column:deleted_at"`
+}
+
+// EndPoint returns the Consumable end point, it used for cache key, etc.
+func (Consumable) EndPoint() string {
+	return "consumables"
+}
+
+// TableVersion returns the versions of the Consumable table in the database.
+// Change this value with date format YY.MM.DDHHii when any table structure changes.
+func (Consumable) TableVersion() string {
+	return "26.10.192500"
+}
+
+// TableName returns the name of the Consumable table in the database.
+func (Consumable) TableName() string {
+	return "consumables"
+}
+
+// TableAliasName returns the table alias name of the Consumable table, used for querying.
+func (Consumable) TableAliasName() string {
+	return "m"
+}
+
+// GetRelations returns the relations of the Consumable data in the database, used for querying.
+func (m *Consumable) GetRelations() map[string]map[string]any {
+	m.AddRelation("left", "categories", "cat", []map[string]any{{"column1": "cat.id", "column2": "m.category_id"}})
+	return m.Relations
+}
+
+// GetFilters returns the filter of the Consumable data in the database, used for querying.
+func (m *Consumable) GetFilters() []map[string]any {
+	m.AddFilter(map[string]any{"column1": "m.deleted_at", "operator": "=", "value": nil})
+	return m.Filters
+}
+
+// GetSorts returns the default sort of the Consumable data in the database, used for querying.
+func (m *Consumable) GetSorts() []map[string]any {
+	m.AddSort(map[string]any{"column": "m.updated_at", "direction": "desc"})
+	return m.Sorts
+}
+
+// GetFields returns list of the field of the Consumable data in the database, used for querying.
+func (m *Consumable) GetFields() map[string]map[string]any {
+	m.SetFields(m)
+	return m.Fields
+}
+
+// GetSchema returns the Consumable schema, used for querying.
+func (m *Consumable) GetSchema() map[string]any {
+	return m.SetSchema(m)
+}
+
+// OpenAPISchemaName returns the name of the Consumable schema in the open api documentation.
+func (Consumable) OpenAPISchemaName() string {
+	return "Consumable"
+}
+
+// GetOpenAPISchema returns the Open API Schema of the Consumable in the open api documentation.
+func (m *Consumable) GetOpenAPISchema() map[string]any {
+	return m.SetOpenAPISchema(m)
+}
+
+type ConsumableList struct {
+	app.ListModel
+}
+
+// OpenAPISchemaName returns the name of the ConsumableList schema in the open api documentation.
+func (ConsumableList) OpenAPISchemaName() string {
+	return "ConsumableList"
+}
+
+// GetOpenAPISchema returns the Open API Schema of the ConsumableList in the open api documentation.
+func (p *ConsumableList) GetOpenAPISchema() map[string]any {
+	return p.SetOpenAPISchema(&Consumable{})
+}
+
+// Types of the Consumable data, a spare part is consumed by the maintenance of the assets at its unit cost.
+const (
+	TypeConsumable = "consumable"
+	TypeSparePart  = "spare_part"
+)
+
+// ParamCreate is the expected parameters for create a new Consumable data.
+type ParamCreate struct {
+	UseCaseHandler
+	Name app.NullString `json:"name"          db:"m.name"            gorm:"column:name"            validate:"required"`
+	Unit app.NullString `json:"unit"          db:"m.unit"            gorm:"column:unit"            validate:"required"`
+}
+
+// ParamUpdate is the expected parameters for update the Consumable data.
+type ParamUpdate struct {
+	UseCaseHandler
+}
+
+// ParamPartiallyUpdate is the expected parameters for partially update the Consumable data.
+type ParamPartiallyUpdate struct {
+	UseCaseHandler
+}
+
+// ParamDelete is the expected parameters for delete the Consumable data.
+type ParamDelete struct {
+	UseCaseHandler
+}
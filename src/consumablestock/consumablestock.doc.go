@@ -0,0 +1,2 @@
+// consumablestock is a package related to consumablestock data.
+package consumablestock
@@ -0,0 +1,126 @@
+package consumablestock
+
+import "github.com/maulanar/go_asset_tracking_management/app"
+
+// ConsumableStock is the main model of ConsumableStock data. It provides a convenient interface for app.ModelInterface
+type ConsumableStock struct {
+	app.Model
+	ID         app.NullUUID    `json:"id"               db:"m.id"              gorm:"column:id;primaryKey"`
+	Qty        app.NullFloat64 `json:"qty"              db:"m.qty"             gorm:"column:qty"`
+	MinStock   app.NullFloat64 `json:"min_stock"        db:"m.min_stock"       gorm:"column:min_stock"       validate:"omitempty,gte=0"`
+	IsLowStock app.NullBool    `json:"is_low_stock"     db:"sts.is_low_stock"  gorm:"-"`
+
+	ConsumableID   app.NullUUID   `json:"consumable.id"    db:"m.consumable_id"   gorm:"column:consumable_id"`
+	ConsumableCode app.NullString `json:"consumable.code"  db:"cns.code"          gorm:"-"`
+	ConsumableName app.NullString `json:"consumable.name"  db:"cns.name"          gorm:"-"`
+	ConsumableUnit app.NullString `json:"consumable.unit"  db:"cns.unit"          gorm:"-"`
+
+	BranchID   app.NullUUID   `json:"branch.id"        db:"m.branch_id"       gorm:"column:branch_id"`
+	BranchCode app.NullString `json:"branch.code"      db:"brc.code"          gorm:"-"`
+	BranchName app.NullString `json:"branch.name"      db:"brc.name"          gorm:"-"`
+
+	CreatedAt app.NullDateTime `json:"created_at"       db:"m.created_at"      gorm:"column:created_at"`
+	UpdatedAt app.NullDateTime `json:"updated_at"       db:"m.updated_at"      gorm:"column:updated_at"`
+	DeletedAt app.NullDateTime `json:"deleted_at"       db:"m.deleted_at,hide" gorm:"column:deleted_at"`
+}
+
+// EndPoint returns the ConsumableStock end point, it used for cache key, etc.
+func (ConsumableStock) EndPoint() string {
+	return "consumable_stocks"
+}
+
+// TableVersion returns the versions of the ConsumableStock table in the database.
+// Change this value with date format YY.MM.DDHHii when any table structure changes.
+func (ConsumableStock) TableVersion() string {
+	return "26.10.191300"
+}
+
+// TableName returns the name of the ConsumableStock table in the database.
+func (ConsumableStock) TableName() string {
+	return "consumable_stocks"
+}
+
+// TableAliasName returns the table alias name of the ConsumableStock table, used for querying.
+func (ConsumableStock) TableAliasName() string {
+	return "m"
+}
+
+// GetRelations returns the relations of the ConsumableStock data in the database, used for querying.
+func (m *ConsumableStock) GetRelations() map[string]map[string]any {
+	m.AddRelation("left", "consumables", "cns", []map[string]any{{"column1": "cns.id", "column2": "m.consumable_id"}})
+	m.AddRelation("left", "branches", "brc", []map[string]any{{"column1": "brc.id", "column2": "m.branch_id"}})
+
+	// low stock flag
+	m.AddRelation("left", `(
+  SELECT cs.id, COALESCE(cs.qty, 0) <= COALESCE(cs.min_stock, 0) AS is_low_stock
+  FROM consumable_stocks cs
+)`, "sts", []map[string]any{{"column1": "sts.id", "column2": "m.id"}})
+	return m.Relations
+}
+
+// GetFilters returns the filter of the ConsumableStock data in the database, used for querying.
+func (m *ConsumableStock) GetFilters() []map[string]any {
+	m.AddFilter(map[string]any{"column1": "m.deleted_at", "operator": "=", "value": nil})
+	return m.Filters
+}
+
+// GetSorts returns the default sort of the ConsumableStock data in the database, used for querying.
+func (m *ConsumableStock) GetSorts() []map[string]any {
+	m.AddSort(map[string]any{"column": "cns.name", "direction": "asc"})
+	return m.Sorts
+}
+
+// GetFields returns list of the field of the ConsumableStock data in the database, used for querying.
+func (m *ConsumableStock) GetFields() map[string]map[string]any {
+	m.SetFields(m)
+	return m.Fields
+}
+
+// GetSchema returns the ConsumableStock schema, used for querying.
+func (m *ConsumableStock) GetSchema() map[string]any {
+	return m.SetSchema(m)
+}
+
+// OpenAPISchemaName returns the name of the ConsumableStock schema in the open api documentation.
+func (ConsumableStock) OpenAPISchemaName() string {
+	return "ConsumableStock"
+}
+
+// GetOpenAPISchema returns the Open API Schema of the ConsumableStock in the open api documentation.
+func (m *ConsumableStock) GetOpenAPISchema() map[string]any {
+	return m.SetOpenAPISchema(m)
+}
+
+type ConsumableStockList struct {
+	app.ListModel
+}
+
+// OpenAPISchemaName returns the name of the ConsumableStockList schema in the open api documentation.
+func (ConsumableStockList) OpenAPISchemaName() string {
+	return "ConsumableStockList"
+}
+
+// GetOpenAPISchema returns the Open API Schema of the ConsumableStockList in the open api documentation.
+func (p *ConsumableStockList) GetOpenAPISchema() map[string]any {
+	return p.SetOpenAPISchema(&ConsumableStock{})
+}
+
+// ParamCreate is the expected parameters for create a new ConsumableStock data.
+type ParamCreate struct {
+	UseCaseHandler
+}
+
+// ParamUpdate is the expected parameters for update the ConsumableStock data.
+type ParamUpdate struct {
+	UseCaseHandler
+}
+
+// ParamPartiallyUpdate is the expected parameters for partially update the ConsumableStock data.
+type ParamPartiallyUpdate struct {
+	UseCaseHandler
+}
+
+// ParamDelete is the expected parameters for delete the ConsumableStock data.
+type ParamDelete struct {
+	UseCaseHandler
+}
@@ -0,0 +1,399 @@
+package consumablestock
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+
+	"gorm.io/gorm/clause"
+
+	"github.com/maulanar/go_asset_tracking_management/app"
+	"github.com/maulanar/go_asset_tracking_management/src/branch"
+	"github.com/maulanar/go_asset_tracking_management/src/consumable"
+	"github.com/maulanar/go_asset_tracking_management/src/notification"
+)
+
+// UseCase returns a UseCaseHandler for expected use case functional.
+func UseCase(ctx app.Ctx, query ...url.Values) UseCaseHandler {
+	u := UseCaseHandler{
+		Ctx:   &ctx,
+		Query: url.Values{},
+	}
+	if len(query) > 0 {
+		u.Query = query[0]
+	}
+	return u
+}
+
+// UseCaseHandler provides a convenient interface for ConsumableStock use case, use UseCase to access UseCaseHandler.
+type UseCaseHandler struct {
+	ConsumableStock
+
+	// injectable dependencies
+	Ctx   *app.Ctx   `json:"-" db:"-" gorm:"-"`
+	Query url.Values `json:"-" db:"-" gorm:"-"`
+}
+
+// Async return UseCaseHandler with async process.
+func (u UseCaseHandler) Async(ctx app.Ctx, query ...url.Values) UseCaseHandler {
+	ctx.IsAsync = true
+	return UseCase(ctx, query...)
+}
+
+// GetByID returns the ConsumableStock data for the specified ID.
+func (u UseCaseHandler) GetByID(id string) (ConsumableStock, error) {
+	res := ConsumableStock{}
+
+	// check permission
+	err := u.Ctx.ValidatePermission("consumable_stocks.detail")
+	if err != nil {
+		return res, err
+	}
+
+	// get from cache and return if exists
+	cacheKey := u.EndPoint() + "." + id
+	app.Cache().Get(cacheKey, &res)
+	if res.ID.Valid {
+		return res, err
+	}
+
+	// prepare db for current ctx
+	tx, err := u.Ctx.DB()
+	if err != nil {
+		return res, app.Error().New(http.StatusInternalServerError, err.Error())
+	}
+
+	// get from db
+	key := "id"
+	if !app.Validator().IsValid(id, "uuid") {
+		key = "code"
+	}
+	u.Query.Add(key, id)
+	err = app.Query().First(tx, &res, u.Query)
+	if err != nil {
+		return res, u.Ctx.NotFoundError(err, u.EndPoint(), key, id)
+	}
+
+	// save to cache and return if exists
+	app.Cache().Set(cacheKey, res)
+	return res, err
+}
+
+// Get returns the list of ConsumableStock data.
+func (u UseCaseHandler) Get() (app.ListModel, error) {
+	res := app.ListModel{}
+
+	// check permission
+	err := u.Ctx.ValidatePermission("consumable_stocks.list")
+	if err != nil {
+		return res, err
+	}
+	// get from cache and return if exists
+	cacheKey := u.EndPoint() + "?" + u.Query.Encode()
+	err = app.Cache().Get(cacheKey, &res)
+	if err == nil {
+		return res, err
+	}
+
+	// prepare db for current ctx
+	tx, err := u.Ctx.DB()
+	if err != nil {
+		return res, app.Error().New(http.StatusInternalServerError, err.Error())
+	}
+
+	// set pagination info
+	res.Results.PageContext.Count,
+		res.Results.PageContext.Page,
+		res.Results.PageContext.PerPage,
+		res.Results.PageContext.PageCount,
+		err = app.Query().PaginationInfo(tx, &ConsumableStock{}, u.Query)
+	if err != nil {
+		return res, app.Error().New(http.StatusInternalServerError, err.Error())
+	}
+	// return data count if $per_page set to 0
+	if res.Results.PageContext.PerPage == 0 {
+		return res, err
+	}
+
+	// find data
+	data, err := app.Query().Find(tx, &ConsumableStock{}, u.Query)
+	if err != nil {
+		return res, app.Error().New(http.StatusInternalServerError, err.Error())
+	}
+	res.SetData(data, u.Query)
+
+	// save to cache and return if exists
+	app.Cache().Set(cacheKey, res)
+	return res, err
+}
+
+// Create creates a new data ConsumableStock with specified parameters.
+func (u *UseCaseHandler) Create(p *ParamCreate) error {
+
+	// check permission
+	err := u.Ctx.ValidatePermission("consumable_stocks.create")
+	if err != nil {
+		return err
+	}
+
+	// validate param
+	err = u.Ctx.ValidateParam(p)
+	if err != nil {
+		return err
+	}
+
+	// set default value for undefined field
+	err = u.setDefaultValue(ConsumableStock{})
+	if err != nil {
+		return err
+	}
+
+	// prepare db for current ctx
+	tx, err := u.Ctx.DB()
+	if err != nil {
+		return app.Error().New(http.StatusInternalServerError, err.Error())
+	}
+
+	// save data to db
+	err = tx.Model(&u).Create(&u).Error
+	if err != nil {
+		return app.Error().New(http.StatusInternalServerError, err.Error())
+	}
+
+	// invalidate cache
+	app.Cache().Invalidate(u.EndPoint())
+
+	// save history (user activity), send webhook, etc
+	go u.Ctx.Hook("POST", "create", p.ID.String, p)
+	return nil
+}
+
+// UpdateByID updates the ConsumableStock data for the specified ID with specified parameters.
+func (u UseCaseHandler) UpdateByID(id string, p *ParamUpdate) error {
+
+	// check permission
+	err := u.Ctx.ValidatePermission("consumable_stocks.edit")
+	if err != nil {
+		return err
+	}
+
+	// validate param
+	err = u.Ctx.ValidateParam(p)
+	if err != nil {
+		return err
+	}
+
+	// get previous data
+	old, err := u.GetByID(id)
+	if err != nil {
+		return err
+	}
+
+	// set default value for undefined field
+	err = u.setDefaultValue(old)
+	if err != nil {
+		return err
+	}
+
+	// prepare db for current ctx
+	tx, err := u.Ctx.DB()
+	if err != nil {
+		return app.Error().New(http.StatusInternalServerError, err.Error())
+	}
+
+	// update data on the db
+	err = tx.Model(&u).Where("id = ?", old.ID).Updates(u).Error
+	if err != nil {
+		return app.Error().New(http.StatusInternalServerError, err.Error())
+	}
+
+	// invalidate cache
+	app.Cache().Invalidate(u.EndPoint(), old.ID.String)
+
+	// save history (user activity), send webhook, etc
+	go u.Ctx.Hook("PUT", "Update", old.ID.String, old)
+	return nil
+}
+
+// PartiallyUpdateByID updates the ConsumableStock data for the specified ID with specified parameters.
+func (u UseCaseHandler) PartiallyUpdateByID(id string, p *ParamPartiallyUpdate) error {
+
+	// check permission
+	err := u.Ctx.ValidatePermission("consumable_stocks.edit")
+	if err != nil {
+		return err
+	}
+
+	// validate param
+	err = u.Ctx.ValidateParam(p)
+	if err != nil {
+		return err
+	}
+
+	// get previous data
+	old, err := u.GetByID(id)
+	if err != nil {
+		return err
+	}
+
+	// set default value for undefined field
+	err = u.setDefaultValue(old)
+	if err != nil {
+		return err
+	}
+
+	// prepare db for current ctx
+	tx, err := u.Ctx.DB()
+	if err != nil {
+		return app.Error().New(http.StatusInternalServerError, err.Error())
+	}
+
+	// update data on the db
+	err = tx.Model(&u).Where("id = ?", old.ID).Updates(u).Error
+	if err != nil {
+		return app.Error().New(http.StatusInternalServerError, err.Error())
+	}
+
+	// invalidate cache
+	app.Cache().Invalidate(u.EndPoint(), old.ID.String)
+
+	// save history (user activity), send webhook, etc
+	go u.Ctx.Hook("PATCH", "Partially Update", old.ID.String, old)
+	return nil
+}
+
+// DeleteByID deletes the ConsumableStock data for the specified ID.
+func (u UseCaseHandler) DeleteByID(id string, p *ParamDelete) error {
+
+	// check permission
+	err := u.Ctx.ValidatePermission("consumable_stocks.delete")
+	if err != nil {
+		return err
+	}
+
+	// validate param
+	err = u.Ctx.ValidateParam(p)
+	if err != nil {
+		return err
+	}
+
+	// get previous data
+	old, err := u.GetByID(id)
+	if err != nil {
+		return err
+	}
+
+	// prepare db for current ctx
+	tx, err := u.Ctx.DB()
+	if err != nil {
+		return app.Error().New(http.StatusInternalServerError, err.Error())
+	}
+
+	// update data on the db
+	err = tx.Model(&p).Where("id = ?", old.ID).Update("deleted_at", time.Now().UTC()).Error
+	if err != nil {
+		return app.Error().New(http.StatusInternalServerError, err.Error())
+	}
+
+	// invalidate cache
+	app.Cache().Invalidate(u.EndPoint(), old.ID.String)
+
+	// save history (user activity), send webhook, etc
+	go u.Ctx.Hook("DELETE", "DELETE", old.ID.String, old)
+	return nil
+}
+
+// setDefaultValue set default value of undefined field when create or update ConsumableStock data.
+func (u *UseCaseHandler) setDefaultValue(old ConsumableStock) error {
+	if !old.ID.Valid {
+		u.ID = app.NewNullUUID()
+	} else {
+		u.ID = old.ID
+	}
+
+	// stock qty is only changed through stock movements
+	u.Qty = old.Qty
+
+	return nil
+}
+
+// AddQty adds qty (negative to subtract) to the stock of the consumable on the branch and returns the stock after the change.
+// A low stock notification is raised when the stock falls to or below the minimum stock.
+func (u UseCaseHandler) AddQty(consumableID, branchID string, qty float64) (ConsumableStock, error) {
+	stock := ConsumableStock{}
+
+	// prepare db for current ctx
+	tx, err := u.Ctx.DB()
+	if err != nil {
+		return stock, app.Error().New(http.StatusInternalServerError, err.Error())
+	}
+
+	cns, err := consumable.UseCase(*u.Ctx, url.Values{}).GetByID(consumableID)
+	if err != nil {
+		return stock, err
+	}
+	brc, err := branch.UseCase(*u.Ctx, url.Values{}).GetByID(branchID)
+	if err != nil {
+		return stock, err
+	}
+
+	// lock the stock row until the transaction ends
+	err = tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where("consumable_id = ?", cns.ID).
+		Where("branch_id = ?", brc.ID).
+		Where("deleted_at IS NULL").
+		Limit(1).Find(&stock).Error
+	if err != nil {
+		return stock, app.Error().New(http.StatusInternalServerError, err.Error())
+	}
+	if !stock.ID.Valid {
+		stock.ID = app.NewNullUUID()
+		stock.ConsumableID = cns.ID
+		stock.BranchID = brc.ID
+		stock.Qty.Set(0)
+		stock.MinStock = cns.MinStock
+		err = tx.Create(&stock).Error
+		if err != nil {
+			return stock, app.Error().New(http.StatusInternalServerError, err.Error())
+		}
+	}
+
+	before := stock.Qty.Float64
+	after := before + qty
+	if after < 0 {
+		return stock, app.Error().New(http.StatusBadRequest, u.Ctx.Trans("stock_insufficient", map[string]string{
+			"item":   cns.Name.String,
+			"branch": brc.Name.String,
+			"qty":    strconv.FormatFloat(before, 'f', -1, 64),
+		}))
+	}
+	stock.Qty.Set(after)
+	err = tx.Model(&ConsumableStock{}).Where("id = ?", stock.ID).Update("qty", after).Error
+	if err != nil {
+		return stock, app.Error().New(http.StatusInternalServerError, err.Error())
+	}
+
+	// raise low stock alert once the stock crosses the minimum stock
+	if before > stock.MinStock.Float64 && after <= stock.MinStock.Float64 {
+		n := notification.Notification{}
+		n.Type.Set("low_stock")
+		n.Title.Set("Low stock")
+		n.Message.Set(fmt.Sprintf("The stock of %s at %s is %s %s, at or below the minimum stock of %s.",
+			cns.Name.String, brc.Name.String,
+			strconv.FormatFloat(after, 'f', -1, 64), cns.Unit.String,
+			strconv.FormatFloat(stock.MinStock.Float64, 'f', -1, 64)))
+		n.Endpoint.Set(u.EndPoint())
+		n.DataID.Set(stock.ID.String)
+		n.BranchID = brc.ID
+		err = notification.Notify(tx, n)
+		if err != nil {
+			return stock, err
+		}
+	}
+
+	// invalidate cache
+	app.Cache().Invalidate(u.EndPoint(), stock.ID.String)
+	return stock, nil
+}
@@ -0,0 +1,3 @@
+// depreciation is a package related to the depreciation calculation of the asset data.
+// It has no database access, the asset, the depreciation ledger and the reports feed it with the asset data.
+package depreciation
@@ -0,0 +1,358 @@
+package depreciation
+
+import (
+	"math"
+	"time"
+)
+
+// Supported depreciation methods.
+const (
+	StraightLine      = "straight_line"
+	DecliningBalance  = "declining_balance"
+	DoubleDeclining   = "double_declining"
+	SumOfYearsDigits  = "sum_of_years_digits"
+	UnitsOfProduction = "units_of_production"
+
+	// TaxDecliningBalance is the declining-balance method of the Indonesian tax book, the yearly depreciation is
+	// the book value on the start of the year times the rate, and the remaining book value is depreciated at once
+	// in the last period. It is used by the tax book only.
+	TaxDecliningBalance = "tax_declining_balance"
+)
+
+// Methods is the list of the supported depreciation methods, used for validation and documentation.
+var Methods = []string{StraightLine, DecliningBalance, DoubleDeclining, SumOfYearsDigits, UnitsOfProduction}
+
+// Depreciation books, the commercial book follows the category economic age and the tax book follows the fiscal group.
+const (
+	BookCommercial = "commercial"
+	BookTax        = "tax"
+)
+
+// TaxGroup is an Indonesian fiscal depreciation group of non-building assets, the straight-line rate is 1 / life
+// and the declining-balance rate is 2 / life per year.
+type TaxGroup struct {
+	LifeMonths       int64
+	StraightLineRate float64
+	DecliningRate    float64
+}
+
+// TaxGroups is the list of the Indonesian fiscal depreciation groups by the group number.
+var TaxGroups = map[int64]TaxGroup{
+	1: {LifeMonths: 48, StraightLineRate: 0.25, DecliningRate: 0.50},
+	2: {LifeMonths: 96, StraightLineRate: 0.125, DecliningRate: 0.25},
+	3: {LifeMonths: 192, StraightLineRate: 0.0625, DecliningRate: 0.125},
+	4: {LifeMonths: 240, StraightLineRate: 0.05, DecliningRate: 0.10},
+}
+
+// Depreciation conventions, they decide in which month the depreciation starts and how much of the first month is depreciated.
+// A partial first month leaves the rest of it to an extra period after the end of the life.
+const (
+	FullMonth      = "full_month"       // the month of the start date is depreciated in full
+	MidMonth       = "mid_month"        // the month of the start date is depreciated by half
+	ActualDays     = "actual_days"      // the month of the start date is depreciated by its days from the start date
+	StartNextMonth = "start_next_month" // the depreciation starts in the month after the start date
+)
+
+// Conventions is the list of the supported depreciation conventions, used for validation and documentation.
+var Conventions = []string{FullMonth, MidMonth, ActualDays, StartNextMonth}
+
+// DefaultDecliningFactor is the factor of the declining-balance method when it is not set, i.e. 150% declining balance.
+const DefaultDecliningFactor = 1.5
+
+// Input is the data needed to calculate the depreciation schedule of an asset.
+type Input struct {
+	Method     string
+	Cost       float64   // acquisition cost, the book value of the first period
+	Salvage    float64   // the book value never goes below the salvage amount
+	LifeMonths int64     // economic age in months
+	StartDate  time.Time // the first period starts on this date
+	Convention string    // depreciation convention of the first period, default to the full month
+	Factor     float64   // factor of the declining-balance method, the double-declining method always uses 2
+	TotalUnits float64   // estimated lifetime units of the units-of-production method
+	Units      []float64 // cumulative units used at the end of each period of the units-of-production method, index 0 is the first period
+}
+
+// Entry is a single period of the depreciation schedule.
+type Entry struct {
+	Period       int64
+	Date         time.Time // the depreciation is recognized on this date, i.e. the last day of the month of the period
+	Opening      float64
+	Depreciation float64
+	Accumulated  float64
+	Closing      float64
+	Units        float64 // cumulative units depreciated up to the period, units-of-production only
+}
+
+// Reading is the cumulative units used by an asset on a date, e.g. a meter reading.
+type Reading struct {
+	Date  time.Time
+	Units float64
+}
+
+// Value is the depreciation state of an asset on a specific date.
+type Value struct {
+	Periods      int64   // number of recognized periods
+	Accumulated  float64 // accumulated depreciation of the recognized periods
+	BookValue    float64 // cost minus accumulated depreciation
+	Depreciation float64 // depreciation of the current (next unrecognized) period
+}
+
+// Schedule returns the monthly depreciation schedule of the input.
+func Schedule(in Input) []Entry {
+	return Project(in, Entry{})
+}
+
+// Project returns the depreciation schedule of the periods after the from entry, e.g. the last posted entry,
+// continuing from its closing book value. The remaining depreciable amount is spread over the remaining periods,
+// so for an unchanged asset the result is the tail of Schedule. An entry without a date starts from the cost,
+// a dated entry of period 0 (e.g. a revaluation before the first period) starts from its closing book value.
+func Project(in Input, from Entry) []Entry {
+	res := []Entry{}
+	if in.Cost <= 0 || in.Cost-in.Salvage <= 0 {
+		return res
+	}
+
+	periods := in.LifeMonths
+	first := firstFraction(in)
+	if first < 1 {
+		periods++
+	}
+	if in.Method == UnitsOfProduction {
+		if in.TotalUnits <= 0 {
+			return res
+		}
+		periods = int64(len(in.Units))
+	}
+	if periods <= 0 || (in.LifeMonths <= 0 && in.Method != UnitsOfProduction) {
+		return res
+	}
+
+	factor := in.Factor
+	if in.Method == DoubleDeclining {
+		factor = 2
+	} else if factor <= 0 {
+		factor = DefaultDecliningFactor
+	}
+
+	bookValue := in.Cost
+	accumulated := float64(0)
+	usedUnits := float64(0)
+	yearly := float64(0) // monthly depreciation of the current year of the tax declining-balance method
+	if !from.Date.IsZero() {
+		bookValue = from.Closing
+		accumulated = from.Accumulated
+		usedUnits = from.Units
+		if in.Method == TaxDecliningBalance && from.Period%12 != 0 {
+			yearly = yearStartRate(in, from, first, periods, factor)
+		}
+	}
+
+	for period := from.Period + 1; period <= periods; period++ {
+		// the fraction of the month depreciated in the period and the remaining life in months from the start of the period
+		fraction := periodFraction(first, period, periods)
+		remaining := float64(in.LifeMonths) - consumedLife(first, period)
+		depreciable := bookValue - in.Salvage
+
+		dep := float64(0)
+		switch in.Method {
+		case DecliningBalance, DoubleDeclining:
+			// switch to straight line once it gives a higher depreciation, so the asset reaches the salvage amount at the end of its life
+			dep = math.Max(bookValue*factor/float64(in.LifeMonths), depreciable/remaining) * fraction
+		case TaxDecliningBalance:
+			if (period-1)%12 == 0 || yearly <= 0 {
+				yearly = bookValue * factor / float64(in.LifeMonths)
+			}
+			dep = yearly * fraction
+		case SumOfYearsDigits:
+			// the digits are counted per month, so economic ages which are not a multiple of 12 are supported,
+			// the digit of a partial period is its remaining life times its fraction
+			digits := float64(0)
+			for p := period; p <= periods; p++ {
+				digits += (float64(in.LifeMonths) - consumedLife(first, p)) * periodFraction(first, p, periods)
+			}
+			dep = depreciable * remaining * fraction / digits
+		case UnitsOfProduction:
+			// the units are depreciated once, a period depreciates the units used since the previous period
+			units := math.Max(usedUnits, in.Units[period-1])
+			if in.TotalUnits > usedUnits {
+				dep = depreciable * (units - usedUnits) / (in.TotalUnits - usedUnits)
+			}
+			usedUnits = units
+		default:
+			dep = depreciable * fraction / remaining
+		}
+		if period == periods && in.Method != UnitsOfProduction {
+			dep = depreciable
+		}
+		dep = math.Max(0, math.Min(dep, depreciable))
+
+		accumulated += dep
+		res = append(res, Entry{
+			Period:       period,
+			Date:         PeriodDate(in, period),
+			Opening:      bookValue,
+			Depreciation: dep,
+			Accumulated:  accumulated,
+			Closing:      bookValue - dep,
+			Units:        usedUnits,
+		})
+		bookValue -= dep
+	}
+	return res
+}
+
+// yearStartRate returns the monthly depreciation of the tax declining-balance method in the year of the from entry, i.e. the book value
+// on the start of the year times the rate. The book value on the start of the year is derived from the closing book value of the entry
+// and the fractions of the periods of the year up to the entry, so a rolled-forward entry or a partial first period gives the same rate.
+func yearStartRate(in Input, from Entry, first float64, periods int64, factor float64) float64 {
+	rate := factor / float64(in.LifeMonths)
+	consumed := float64(0)
+	for p := from.Period - (from.Period-1)%12; p <= from.Period; p++ {
+		consumed += periodFraction(first, p, periods)
+	}
+	if rate*consumed >= 1 {
+		return 0
+	}
+	return from.Closing * rate / (1 - rate*consumed)
+}
+
+// RollForward merges the entries dated inside a closed period into the next entry dated outside of it, so a catch-up posting
+// recognizes the depreciation of the closed periods in the first open period instead. The entries after the last open entry
+// are dropped, they are rolled forward by a later posting once an open period is due.
+func RollForward(entries []Entry, isClosed func(time.Time) bool) []Entry {
+	res := []Entry{}
+	pending := []Entry{}
+	for _, e := range entries {
+		if isClosed(e.Date) {
+			pending = append(pending, e)
+			continue
+		}
+		if len(pending) > 0 {
+			e.Opening = pending[0].Opening
+			for _, p := range pending {
+				e.Depreciation += p.Depreciation
+			}
+			pending = pending[:0]
+		}
+		res = append(res, e)
+	}
+	return res
+}
+
+// PeriodDate returns the date the depreciation of the period is recognized on, i.e. the last day of its month.
+// The first period is the month of the start date, or the month after it for the start-next-month convention.
+func PeriodDate(in Input, period int64) time.Time {
+	month := int(in.StartDate.Month()) + int(period) - 1
+	if in.Convention == StartNextMonth {
+		month++
+	}
+	return time.Date(in.StartDate.Year(), time.Month(month+1), 0, 0, 0, 0, 0, in.StartDate.Location())
+}
+
+// ElapsedPeriods returns the number of the periods of the input which are recognized on or before the specified date.
+func ElapsedPeriods(in Input, date time.Time) int64 {
+	res := int64(0)
+	for !PeriodDate(in, res+1).After(date) {
+		res++
+	}
+	return res
+}
+
+// CumulativeUnits returns the cumulative units used at the end of each period of the units-of-production method elapsed on the date.
+// A period has the units of the last reading read in or before it, and the last period has at least the units used on the date,
+// so the usage which is not depreciated yet, including a late reading of a posted period, is depreciated in the next unposted period.
+// The readings must be ordered by the date.
+func CumulativeUnits(in Input, date time.Time, used float64, readings []Reading) []float64 {
+	periods := ElapsedPeriods(in, date)
+	if periods < 1 {
+		periods = 1
+	}
+	res := make([]float64, periods)
+	units, i := float64(0), 0
+	for period := int64(1); period <= periods; period++ {
+		end := PeriodDate(in, period).AddDate(0, 0, 1)
+		for ; i < len(readings) && readings[i].Date.Before(end); i++ {
+			units = math.Max(units, readings[i].Units)
+		}
+		res[period-1] = units
+	}
+	res[periods-1] = math.Max(res[periods-1], used)
+	return res
+}
+
+// firstFraction returns the fraction of the month of the first period which is depreciated by the convention.
+// The units-of-production method depreciates by the usage, so its periods are never partial.
+func firstFraction(in Input) float64 {
+	if in.Method == UnitsOfProduction {
+		return 1
+	}
+	switch in.Convention {
+	case MidMonth:
+		return 0.5
+	case ActualDays:
+		days := time.Date(in.StartDate.Year(), in.StartDate.Month()+1, 0, 0, 0, 0, 0, in.StartDate.Location()).Day()
+		return float64(days-in.StartDate.Day()+1) / float64(days)
+	}
+	return 1
+}
+
+// periodFraction returns the fraction of the month depreciated in the period, a partial first period leaves
+// the rest of the month to the last period.
+func periodFraction(first float64, period, periods int64) float64 {
+	if first < 1 && period == 1 {
+		return first
+	}
+	if first < 1 && period == periods {
+		return 1 - first
+	}
+	return 1
+}
+
+// consumedLife returns the life in months depreciated before the period.
+func consumedLife(first float64, period int64) float64 {
+	if period <= 1 {
+		return 0
+	}
+	return first + float64(period-2)
+}
+
+// ValueAt returns the depreciation state of the input on the specified date,
+// a period is recognized when its date is on or before the specified date.
+func ValueAt(in Input, date time.Time) Value {
+	return ValueOf(Schedule(in), in.Cost, date)
+}
+
+// ValueOf returns the depreciation state of the schedule on the specified date.
+func ValueOf(schedule []Entry, cost float64, date time.Time) Value {
+	res := Value{BookValue: math.Max(cost, 0)}
+	for _, e := range schedule {
+		if e.Date.After(date) {
+			res.Depreciation = e.Depreciation
+			break
+		}
+		res.Periods = e.Period
+		res.Accumulated = e.Accumulated
+		res.BookValue = e.Closing
+	}
+	return res
+}
+
+// IsValidConvention reports whether the convention is one of the supported depreciation conventions.
+func IsValidConvention(convention string) bool {
+	for _, c := range Conventions {
+		if c == convention {
+			return true
+		}
+	}
+	return false
+}
+
+// IsValidMethod reports whether the method is one of the supported depreciation methods.
+func IsValidMethod(method string) bool {
+	for _, m := range Methods {
+		if m == method {
+			return true
+		}
+	}
+	return false
+}
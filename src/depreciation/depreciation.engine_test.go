@@ -0,0 +1,248 @@
+package depreciation
+
+import (
+	"math"
+	"testing"
+	"time"
+)
+
+// date returns the date of the specified year, month and day in UTC.
+func date(year int, month time.Month, day int) time.Time {
+	return time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+}
+
+// near reports whether the amounts are equal within a cent.
+func near(a, b float64) bool {
+	return math.Abs(a-b) < 0.005
+}
+
+func TestScheduleConvention(t *testing.T) {
+	tests := []struct {
+		name       string
+		convention string
+		start      time.Time
+		periods    int
+		first      float64
+		middle     float64
+		last       float64
+		firstDate  time.Time
+		lastDate   time.Time
+	}{
+		{"default", "", date(2025, 1, 15), 12, 100, 100, 100, date(2025, 1, 31), date(2025, 12, 31)},
+		{"full month", FullMonth, date(2025, 1, 15), 12, 100, 100, 100, date(2025, 1, 31), date(2025, 12, 31)},
+		{"start next month", StartNextMonth, date(2025, 1, 15), 12, 100, 100, 100, date(2025, 2, 28), date(2026, 1, 31)},
+		{"mid month", MidMonth, date(2025, 1, 15), 13, 50, 100, 50, date(2025, 1, 31), date(2026, 1, 31)},
+		{"actual days", ActualDays, date(2025, 1, 15), 13, 100 * 17.0 / 31, 100, 100 * 14.0 / 31, date(2025, 1, 31), date(2026, 1, 31)},
+		{"actual days from the first day", ActualDays, date(2025, 1, 1), 12, 100, 100, 100, date(2025, 1, 31), date(2025, 12, 31)},
+		{"actual days from the last day", ActualDays, date(2025, 2, 28), 13, 100.0 / 28, 100, 100 * 27.0 / 28, date(2025, 2, 28), date(2026, 2, 28)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			in := Input{Method: StraightLine, Cost: 1400, Salvage: 200, LifeMonths: 12, StartDate: tt.start, Convention: tt.convention}
+			s := Schedule(in)
+			if len(s) != tt.periods {
+				t.Fatalf("periods: expected %d, got %d", tt.periods, len(s))
+			}
+			if !near(s[0].Depreciation, tt.first) {
+				t.Errorf("first depreciation: expected %.2f, got %.2f", tt.first, s[0].Depreciation)
+			}
+			if !near(s[len(s)/2].Depreciation, tt.middle) {
+				t.Errorf("middle depreciation: expected %.2f, got %.2f", tt.middle, s[len(s)/2].Depreciation)
+			}
+			if !near(s[len(s)-1].Depreciation, tt.last) {
+				t.Errorf("last depreciation: expected %.2f, got %.2f", tt.last, s[len(s)-1].Depreciation)
+			}
+			if !s[0].Date.Equal(tt.firstDate) {
+				t.Errorf("first date: expected %s, got %s", tt.firstDate, s[0].Date)
+			}
+			if !s[len(s)-1].Date.Equal(tt.lastDate) {
+				t.Errorf("last date: expected %s, got %s", tt.lastDate, s[len(s)-1].Date)
+			}
+		})
+	}
+}
+
+func TestScheduleReachesSalvage(t *testing.T) {
+	for _, method := range []string{StraightLine, DecliningBalance, DoubleDeclining, SumOfYearsDigits} {
+		for _, convention := range Conventions {
+			t.Run(method+"/"+convention, func(t *testing.T) {
+				in := Input{Method: method, Cost: 10000, Salvage: 1000, LifeMonths: 36, StartDate: date(2025, 3, 20), Convention: convention}
+				s := Schedule(in)
+				last := s[len(s)-1]
+				if !near(last.Closing, in.Salvage) || !near(last.Accumulated, in.Cost-in.Salvage) {
+					t.Errorf("expected closing %.2f and accumulated %.2f, got %.2f and %.2f", in.Salvage, in.Cost-in.Salvage, last.Closing, last.Accumulated)
+				}
+				for _, e := range s {
+					if e.Depreciation < 0 || e.Closing < in.Salvage-0.005 {
+						t.Fatalf("period %d: invalid depreciation %.2f or closing %.2f", e.Period, e.Depreciation, e.Closing)
+					}
+				}
+			})
+		}
+	}
+}
+
+func TestProjectContinuesSchedule(t *testing.T) {
+	tests := []struct {
+		name string
+		in   Input
+	}{
+		{"straight line mid month", Input{Method: StraightLine, Cost: 5000, Salvage: 500, LifeMonths: 24, StartDate: date(2025, 5, 10), Convention: MidMonth}},
+		{"declining balance actual days", Input{Method: DecliningBalance, Cost: 5000, Salvage: 500, LifeMonths: 24, StartDate: date(2025, 5, 10), Convention: ActualDays}},
+		{"sum of years digits start next month", Input{Method: SumOfYearsDigits, Cost: 5000, LifeMonths: 24, StartDate: date(2025, 5, 10), Convention: StartNextMonth}},
+		{"tax declining balance", Input{Method: TaxDecliningBalance, Cost: 1000, LifeMonths: 48, Factor: 2, StartDate: date(2025, 5, 10)}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := Schedule(tt.in)
+			for _, k := range []int{0, 5, 13, len(s) - 2} {
+				next := Project(tt.in, s[k])
+				if len(next) != len(s)-k-1 {
+					t.Fatalf("from period %d: expected %d periods, got %d", s[k].Period, len(s)-k-1, len(next))
+				}
+				for i, e := range next {
+					if !near(e.Depreciation, s[k+1+i].Depreciation) || !e.Date.Equal(s[k+1+i].Date) {
+						t.Fatalf("from period %d: period %d differs from the schedule", s[k].Period, e.Period)
+					}
+				}
+			}
+		})
+	}
+}
+
+func TestTaxDecliningBalance(t *testing.T) {
+	tg := TaxGroups[1]
+	in := Input{Method: TaxDecliningBalance, Cost: 1000, LifeMonths: tg.LifeMonths, Factor: tg.DecliningRate * float64(tg.LifeMonths) / 12, StartDate: date(2025, 1, 1)}
+	s := Schedule(in)
+	yearly := []float64{500, 250, 125}
+	for year, expected := range yearly {
+		total := float64(0)
+		for _, e := range s[year*12 : (year+1)*12] {
+			total += e.Depreciation
+		}
+		if !near(total, expected) {
+			t.Errorf("year %d: expected %.2f, got %.2f", year+1, expected, total)
+		}
+	}
+	if last := s[len(s)-1]; !near(last.Closing, 0) {
+		t.Errorf("expected the book value to be written off in the last period, got %.2f", last.Closing)
+	}
+}
+
+func TestTaxDecliningBalanceProjectMidYear(t *testing.T) {
+	in := Input{Method: TaxDecliningBalance, Cost: 12000, LifeMonths: 48, Factor: 2, StartDate: date(2025, 1, 1)}
+
+	// january to march are closed and rolled into april, the rest of the year keeps the rate of the year
+	closed := func(d time.Time) bool { return !d.After(date(2025, 3, 31)) }
+	rolled := RollForward(Schedule(in)[:4], closed)
+	if len(rolled) != 1 || !near(rolled[0].Depreciation, 2000) {
+		t.Fatalf("expected a single rolled entry of 2000, got %+v", rolled)
+	}
+	next := Project(in, rolled[0])
+	for _, e := range next[:8] {
+		if !near(e.Depreciation, 500) {
+			t.Fatalf("period %d: expected 500, got %.2f", e.Period, e.Depreciation)
+		}
+	}
+	if !near(next[8].Depreciation, 250) {
+		t.Errorf("expected the second year to start at 250, got %.2f", next[8].Depreciation)
+	}
+
+	// a partial first period keeps the rate of the year too
+	in.Convention = MidMonth
+	s := Schedule(in)
+	next = Project(in, s[0])
+	if !near(s[0].Depreciation, 250) || !near(next[0].Depreciation, 500) {
+		t.Errorf("expected 250 in the first period and 500 after it, got %.2f and %.2f", s[0].Depreciation, next[0].Depreciation)
+	}
+}
+
+func TestElapsedPeriods(t *testing.T) {
+	tests := []struct {
+		name       string
+		convention string
+		date       time.Time
+		expected   int64
+	}{
+		{"before the first period", FullMonth, date(2025, 1, 30), 0},
+		{"on the first period", FullMonth, date(2025, 1, 31), 1},
+		{"after some periods", FullMonth, date(2025, 4, 15), 3},
+		{"start next month", StartNextMonth, date(2025, 2, 27), 0},
+		{"start next month after some periods", StartNextMonth, date(2025, 4, 30), 3},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			in := Input{Method: StraightLine, Cost: 1200, LifeMonths: 12, StartDate: date(2025, 1, 15), Convention: tt.convention}
+			if got := ElapsedPeriods(in, tt.date); got != tt.expected {
+				t.Errorf("expected %d, got %d", tt.expected, got)
+			}
+		})
+	}
+}
+
+func TestUnitsOfProductionLateReading(t *testing.T) {
+	in := Input{Method: UnitsOfProduction, Cost: 10000, TotalUnits: 1000, StartDate: date(2025, 1, 1)}
+
+	// february is posted before its reading is known
+	in.Units = CumulativeUnits(in, date(2025, 2, 28), 100, []Reading{{date(2025, 1, 31), 100}})
+	posted := Schedule(in)
+	if len(posted) != 2 || !near(posted[0].Depreciation, 1000) || !near(posted[1].Depreciation, 0) {
+		t.Fatalf("expected 1000 and 0 in the first periods, got %+v", posted)
+	}
+
+	// the late reading of february is depreciated in march, so every unit is depreciated once
+	readings := []Reading{{date(2025, 1, 31), 100}, {date(2025, 2, 28), 250}, {date(2025, 3, 31), 300}}
+	in.Units = CumulativeUnits(in, date(2025, 3, 31), 300, readings)
+	next := Project(in, posted[1])
+	if len(next) != 1 {
+		t.Fatalf("expected 1 period, got %d", len(next))
+	}
+	if !near(next[0].Depreciation, 2000) || !near(next[0].Accumulated, 3000) || next[0].Units != 300 {
+		t.Errorf("expected depreciation 2000, accumulated 3000 and 300 units, got %.2f, %.2f and %.2f", next[0].Depreciation, next[0].Accumulated, next[0].Units)
+	}
+}
+
+func TestCumulativeUnits(t *testing.T) {
+	in := Input{Method: UnitsOfProduction, Cost: 1000, TotalUnits: 100, StartDate: date(2025, 1, 10)}
+	readings := []Reading{{date(2025, 1, 31).Add(20 * time.Hour), 10}, {date(2025, 3, 5), 30}}
+	got := CumulativeUnits(in, date(2025, 3, 31), 35, readings)
+	expected := []float64{10, 10, 35}
+	if len(got) != len(expected) {
+		t.Fatalf("expected %d periods, got %d", len(expected), len(got))
+	}
+	for i := range expected {
+		if got[i] != expected[i] {
+			t.Errorf("period %d: expected %.0f, got %.0f", i+1, expected[i], got[i])
+		}
+	}
+}
+
+func TestRollForwardClosedPeriods(t *testing.T) {
+	in := Input{Method: StraightLine, Cost: 1200, LifeMonths: 12, StartDate: date(2025, 1, 1)}
+	s := Schedule(in)[:5]
+
+	// february and march are closed, their depreciation is posted in april
+	closed := func(d time.Time) bool { return d.After(date(2025, 1, 31)) && !d.After(date(2025, 3, 31)) }
+	res := RollForward(s, closed)
+	if len(res) != 3 {
+		t.Fatalf("expected 3 entries, got %d", len(res))
+	}
+	april := res[1]
+	if april.Period != 4 || !april.Date.Equal(date(2025, 4, 30)) {
+		t.Errorf("expected the catch-up in period 4 on 2025-04-30, got period %d on %s", april.Period, april.Date)
+	}
+	if !near(april.Opening, 1100) || !near(april.Depreciation, 300) || !near(april.Closing, 800) || !near(april.Accumulated, 400) {
+		t.Errorf("expected opening 1100, depreciation 300, closing 800 and accumulated 400, got %+v", april)
+	}
+
+	// the posting continues from the rolled entry like from the schedule
+	next := Project(in, res[len(res)-1])
+	if len(next) != 7 || !near(next[0].Depreciation, 100) {
+		t.Errorf("expected 7 periods of 100 after the rolled entries, got %d periods", len(next))
+	}
+
+	// nothing is posted while only closed periods are due
+	if res := RollForward(s[1:3], closed); len(res) != 0 {
+		t.Errorf("expected nothing to post, got %d entries", len(res))
+	}
+}
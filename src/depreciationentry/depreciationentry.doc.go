@@ -0,0 +1,2 @@
+// depreciationentry is a package related to depreciationentry data.
+package depreciationentry
@@ -0,0 +1,133 @@
+package depreciationentry
+
+import "github.com/maulanar/go_asset_tracking_management/app"
+
+// DepreciationEntry is the main model of DepreciationEntry data. It provides a convenient interface for app.ModelInterface
+type DepreciationEntry struct {
+	app.Model
+	ID                 app.NullUUID     `json:"id"                  db:"m.id"                  gorm:"column:id;primaryKey"`
+	Period             app.NullInt64    `json:"period"              db:"m.period"              gorm:"column:period"`
+	Date               app.NullDate     `json:"date"                db:"m.date"                gorm:"column:date"`
+	Book               app.NullString   `json:"book"                db:"m.book"                gorm:"column:book;default:commercial"`
+	Type               app.NullString   `json:"type"                db:"m.type"                gorm:"column:type;default:depreciation"`
+	ReferenceID        app.NullUUID     `json:"reference.id"        db:"m.reference_id"        gorm:"column:reference_id"`
+	Method             app.NullString   `json:"method"              db:"m.method"              gorm:"column:method"`
+	OpeningAmount      app.NullFloat64  `json:"opening_amount"      db:"m.opening_amount"      gorm:"column:opening_amount"`
+	DepreciationAmount app.NullFloat64  `json:"depreciation_amount" db:"m.depreciation_amount" gorm:"column:depreciation_amount"`
+	AccumulatedAmount  app.NullFloat64  `json:"accumulated_amount"  db:"m.accumulated_amount"  gorm:"column:accumulated_amount"`
+	ClosingAmount      app.NullFloat64  `json:"closing_amount"      db:"m.closing_amount"      gorm:"column:closing_amount"`
+	UnitsUsed          app.NullFloat64  `json:"units_used"          db:"m.units_used"          gorm:"column:units_used"`
+	PostedAt           app.NullDateTime `json:"posted_at"           db:"m.posted_at"           gorm:"column:posted_at"`
+
+	AssetID   app.NullUUID   `json:"asset.id"            db:"m.asset_id"            gorm:"column:asset_id"`
+	AssetCode app.NullString `json:"asset.code"          db:"ass.code"              gorm:"-"`
+	AssetName app.NullString `json:"asset.name"          db:"ass.name"              gorm:"-"`
+
+	CreatedAt app.NullDateTime `json:"created_at"          db:"m.created_at"          gorm:"column:created_at"`
+	UpdatedAt app.NullDateTime `json:"updated_at"          db:"m.updated_at"          gorm:"column:updated_at"`
+	DeletedAt app.NullDateTime `json:"deleted_at"          db:"m.deleted_at,hide"     gorm:"column:deleted_at"`
+}
+
+// EndPoint returns the DepreciationEntry end point, it used for cache key, etc.
+func (DepreciationEntry) EndPoint() string {
+	return "depreciation_entries"
+}
+
+// TableVersion returns the versions of the DepreciationEntry table in the database.
+// Change this value with date format YY.MM.DDHHii when any table structure changes.
+func (DepreciationEntry) TableVersion() string {
+	return "26.10.192800"
+}
+
+// TableName returns the name of the DepreciationEntry table in the database.
+func (DepreciationEntry) TableName() string {
+	return "depreciation_entries"
+}
+
+// TableAliasName returns the table alias name of the DepreciationEntry table, used for querying.
+func (DepreciationEntry) TableAliasName() string {
+	return "m"
+}
+
+// GetRelations returns the relations of the DepreciationEntry data in the database, used for querying.
+func (m *DepreciationEntry) GetRelations() map[string]map[string]any {
+	m.AddRelation("left", "assets", "ass", []map[string]any{{"column1": "ass.id", "column2": "m.asset_id"}})
+	return m.Relations
+}
+
+// GetFilters returns the filter of the DepreciationEntry data in the database, used for querying.
+func (m *DepreciationEntry) GetFilters() []map[string]any {
+	m.AddFilter(map[string]any{"column1": "m.deleted_at", "operator": "=", "value": nil})
+	return m.Filters
+}
+
+// GetSorts returns the default sort of the DepreciationEntry data in the database, used for querying.
+func (m *DepreciationEntry) GetSorts() []map[string]any {
+	m.AddSort(map[string]any{"column": "m.date", "direction": "desc"})
+	m.AddSort(map[string]any{"column": "ass.code", "direction": "asc"})
+	return m.Sorts
+}
+
+// GetFields returns list of the field of the DepreciationEntry data in the database, used for querying.
+func (m *DepreciationEntry) GetFields() map[string]map[string]any {
+	m.SetFields(m)
+	return m.Fields
+}
+
+// GetSchema returns the DepreciationEntry schema, used for querying.
+func (m *DepreciationEntry) GetSchema() map[string]any {
+	return m.SetSchema(m)
+}
+
+// OpenAPISchemaName returns the name of the DepreciationEntry schema in the open api documentation.
+func (DepreciationEntry) OpenAPISchemaName() string {
+	return "DepreciationEntry"
+}
+
+// GetOpenAPISchema returns the Open API Schema of the DepreciationEntry in the open api documentation.
+func (m *DepreciationEntry) GetOpenAPISchema() map[string]any {
+	return m.SetOpenAPISchema(m)
+}
+
+type DepreciationEntryList struct {
+	app.ListModel
+}
+
+// OpenAPISchemaName returns the name of the DepreciationEntryList schema in the open api documentation.
+func (DepreciationEntryList) OpenAPISchemaName() string {
+	return "DepreciationEntryList"
+}
+
+// GetOpenAPISchema returns the Open API Schema of the DepreciationEntryList in the open api documentation.
+func (p *DepreciationEntryList) GetOpenAPISchema() map[string]any {
+	return p.SetOpenAPISchema(&DepreciationEntry{})
+}
+
+// Types of the DepreciationEntry data, a revaluation, an impairment or a capitalized maintenance entry resets the carrying amount
+// and the depreciation of the next periods continues from it.
+const (
+	TypeDepreciation   = "depreciation"
+	TypeRevaluation    = "revaluation"
+	TypeImpairment     = "impairment"
+	TypeCapitalization = "capitalization"
+)
+
+// ParamCreate is the expected parameters for create a new DepreciationEntry data.
+type ParamCreate struct {
+	UseCaseHandler
+}
+
+// ParamUpdate is the expected parameters for update the DepreciationEntry data.
+type ParamUpdate struct {
+	UseCaseHandler
+}
+
+// ParamPartiallyUpdate is the expected parameters for partially update the DepreciationEntry data.
+type ParamPartiallyUpdate struct {
+	UseCaseHandler
+}
+
+// ParamDelete is the expected parameters for delete the DepreciationEntry data.
+type ParamDelete struct {
+	UseCaseHandler
+}
@@ -8,6 +8,8 @@ type EmployeeAsset struct {
 	ID         app.NullUUID `json:"id"                              db:"m.id"                 gorm:"column:id;primaryKey"`
 	Date       app.NullDate `json:"date"                            db:"m.date"               gorm:"column:date"`
 	AssignDate app.NullDate `json:"assign_date"                     db:"m.assign_date"        gorm:"column:assign_date"`
+	ReturnDate app.NullDate `json:"return_date"                     db:"m.return_date"        gorm:"column:return_date"`
+	ParentID   app.NullUUID `json:"parent.id"                       db:"m.parent_id"          gorm:"column:parent_id"`
 
 	AssetID                  app.NullUUID    `json:"asset.id"                        db:"m.asset_id"           gorm:"column:asset_id"`
 	AssetCode                app.NullString  `json:"asset.code"                      db:"ass.code"             gorm:"-"`
@@ -66,7 +68,7 @@ func (EmployeeAsset) EndPoint() string {
 // TableVersion returns the versions of the EmployeeAsset table in the database.
 // Change this value with date format YY.MM.DDHHii when any table structure changes.
 func (EmployeeAsset) TableVersion() string {
-	return "28.06.291152"
+	return "26.10.191200"
 }
 
 // TableName returns the name of the EmployeeAsset table in the database.
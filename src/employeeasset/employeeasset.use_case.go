@@ -159,6 +159,12 @@ func (u *UseCaseHandler) Create(p *ParamCreate) error {
 		return app.Error().New(http.StatusInternalServerError, err.Error())
 	}
 
+	// assign the child assets together with their parent
+	err = u.cascadeAssign()
+	if err != nil {
+		return err
+	}
+
 	// invalidate cache
 	app.Cache().Invalidate(u.EndPoint())
 
@@ -206,6 +212,14 @@ func (u UseCaseHandler) UpdateByID(id string, p *ParamUpdate) error {
 		return app.Error().New(http.StatusInternalServerError, err.Error())
 	}
 
+	// return the child assets together with their parent
+	if u.ReturnDate.Valid && !old.ReturnDate.Valid {
+		err = u.cascadeReturn()
+		if err != nil {
+			return err
+		}
+	}
+
 	// invalidate cache
 	app.Cache().Invalidate(u.EndPoint(), old.ID.String)
 
@@ -253,6 +267,14 @@ func (u UseCaseHandler) PartiallyUpdateByID(id string, p *ParamPartiallyUpdate)
 		return app.Error().New(http.StatusInternalServerError, err.Error())
 	}
 
+	// return the child assets together with their parent
+	if u.ReturnDate.Valid && !old.ReturnDate.Valid {
+		err = u.cascadeReturn()
+		if err != nil {
+			return err
+		}
+	}
+
 	// invalidate cache
 	app.Cache().Invalidate(u.EndPoint(), old.ID.String)
 
@@ -315,15 +337,22 @@ func (u *UseCaseHandler) setDefaultValue(old EmployeeAsset) error {
 	if !u.AssetID.Valid || u.AssetID.String == "" {
 		key = u.AssetCode.String
 	}
+	if key == "" && u.ReturnDate.Valid && !old.ReturnDate.Valid {
+		key = old.AssetID.String
+	}
 	if key != "" {
 		assUC := asset.UseCase(*u.Ctx, url.Values{})
 		ass, err := assUC.GetByID(key)
 		if err != nil {
 			return err
 		}
+		u.AssetID = ass.ID
 
-		// update status to unavailable
+		// update status to unavailable, the asset is available again once it is returned
 		assUC.Status.Set("unavailable")
+		if u.ReturnDate.Valid || old.ReturnDate.Valid {
+			assUC.Status.Set("available")
+		}
 		err = assUC.UpdateByID(ass.ID.String, &asset.ParamUpdate{})
 		if err != nil {
 			return err
@@ -378,3 +407,83 @@ func (u *UseCaseHandler) setDefaultValue(old EmployeeAsset) error {
 
 	return nil
 }
+
+// cascadeAssign assigns the child assets of the assigned asset to the same employee. A child which is still assigned
+// to the employee is skipped, and a child which is still assigned to another employee must be returned first.
+func (u *UseCaseHandler) cascadeAssign() error {
+	children, err := asset.UseCase(*u.Ctx, url.Values{}).GetChildren(u.AssetID.String)
+	if err != nil {
+		return err
+	}
+
+	// prepare db for current ctx
+	tx, err := u.Ctx.DB()
+	if err != nil {
+		return app.Error().New(http.StatusInternalServerError, err.Error())
+	}
+
+	for _, child := range children {
+		holder := EmployeeAsset{}
+		err = tx.Where("asset_id = ?", child.ID).
+			Where("return_date IS NULL").
+			Where("deleted_at IS NULL").
+			Limit(1).Find(&holder).Error
+		if err != nil {
+			return app.Error().New(http.StatusInternalServerError, err.Error())
+		}
+		if holder.ID.Valid && holder.EmployeeID.String == u.EmployeeID.String {
+			continue
+		}
+		if holder.ID.Valid {
+			return app.Error().New(http.StatusBadRequest, u.Ctx.Trans("employee_asset_child_assigned", map[string]string{
+				"asset": child.Code.String,
+			}))
+		}
+		p := ParamCreate{
+			AssignDate:  u.AssignDate,
+			AssetID:     child.ID,
+			EmployeeID:  u.EmployeeID,
+			ConditionID: u.ConditionID,
+		}
+		childUC := UseCase(*u.Ctx, url.Values{})
+		childUC.AssignDate = u.AssignDate
+		childUC.AssetID = child.ID
+		childUC.EmployeeID = u.EmployeeID
+		childUC.ConditionID = u.ConditionID
+		childUC.ParentID = u.ID
+		err = childUC.Create(&p)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// cascadeReturn returns the child assets which were assigned together with the returned asset.
+func (u *UseCaseHandler) cascadeReturn() error {
+
+	// prepare db for current ctx
+	tx, err := u.Ctx.DB()
+	if err != nil {
+		return app.Error().New(http.StatusInternalServerError, err.Error())
+	}
+
+	ids := []string{}
+	err = tx.Model(&EmployeeAsset{}).
+		Where("parent_id = ?", u.ID).
+		Where("return_date IS NULL").
+		Where("deleted_at IS NULL").
+		Pluck("id", &ids).Error
+	if err != nil {
+		return app.Error().New(http.StatusInternalServerError, err.Error())
+	}
+	for _, id := range ids {
+		childUC := UseCase(*u.Ctx, url.Values{})
+		childUC.ReturnDate = u.ReturnDate
+		err = childUC.PartiallyUpdateByID(id, &ParamPartiallyUpdate{})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,2 @@
+// fiscalperiod is a package related to fiscalperiod data.
+package fiscalperiod
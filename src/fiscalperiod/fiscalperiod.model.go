@@ -0,0 +1,122 @@
+package fiscalperiod
+
+import "github.com/maulanar/go_asset_tracking_management/app"
+
+// FiscalPeriod is the main model of FiscalPeriod data. It provides a convenient interface for app.ModelInterface
+type FiscalPeriod struct {
+	app.Model
+	ID          app.NullUUID     `json:"id"          db:"m.id"              gorm:"column:id;primaryKey"`
+	Code        app.NullString   `json:"code"        db:"m.code"            gorm:"column:code"`
+	Name        app.NullString   `json:"name"        db:"m.name"            gorm:"column:name"`
+	StartDate   app.NullDate     `json:"start_date"  db:"m.start_date"      gorm:"column:start_date"`
+	EndDate     app.NullDate     `json:"end_date"    db:"m.end_date"        gorm:"column:end_date"`
+	Status      app.NullString   `json:"status"      db:"m.status"          gorm:"column:status;default:open"`
+	ClosedAt    app.NullDateTime `json:"closed_at"   db:"m.closed_at"       gorm:"column:closed_at"`
+	Description app.NullText     `json:"description" db:"m.description"     gorm:"column:description"`
+	CreatedAt   app.NullDateTime `json:"created_at"  db:"m.created_at"      gorm:"column:created_at"`
+	UpdatedAt   app.NullDateTime `json:"updated_at"  db:"m.updated_at"      gorm:"column:updated_at"`
+	DeletedAt   app.NullDateTime `json:"deleted_at"  db:"m.deleted_at,hide" gorm:"column:deleted_at"`
+}
+
+// EndPoint returns the FiscalPeriod end point, it used for cache key, etc.
+func (FiscalPeriod) EndPoint() string {
+	return "fiscal_periods"
+}
+
+// TableVersion returns the versions of the FiscalPeriod table in the database.
+// Change this value with date format YY.MM.DDHHii when any table structure changes.
+func (FiscalPeriod) TableVersion() string {
+	return "26.10.191800"
+}
+
+// TableName returns the name of the FiscalPeriod table in the database.
+func (FiscalPeriod) TableName() string {
+	return "fiscal_periods"
+}
+
+// TableAliasName returns the table alias name of the FiscalPeriod table, used for querying.
+func (FiscalPeriod) TableAliasName() string {
+	return "m"
+}
+
+// GetRelations returns the relations of the FiscalPeriod data in the database, used for querying.
+func (m *FiscalPeriod) GetRelations() map[string]map[string]any {
+	// m.AddRelation("left", "users", "cu", []map[string]any{{"column1": "cu.id", "column2": "m.created_by_user_id"}})
+	// m.AddRelation("left", "users", "uu", []map[string]any{{"column1": "uu.id", "column2": "m.updated_by_user_id"}})
+	return m.Relations
+}
+
+// GetFilters returns the filter of the FiscalPeriod data in the database, used for querying.
+func (m *FiscalPeriod) GetFilters() []map[string]any {
+	m.AddFilter(map[string]any{"column1": "m.deleted_at", "operator": "=", "value": nil})
+	return m.Filters
+}
+
+// GetSorts returns the default sort of the FiscalPeriod data in the database, used for querying.
+func (m *FiscalPeriod) GetSorts() []map[string]any {
+	m.AddSort(map[string]any{"column": "m.start_date", "direction": "desc"})
+	return m.Sorts
+}
+
+// GetFields returns list of the field of the FiscalPeriod data in the database, used for querying.
+func (m *FiscalPeriod) GetFields() map[string]map[string]any {
+	m.SetFields(m)
+	return m.Fields
+}
+
+// GetSchema returns the FiscalPeriod schema, used for querying.
+func (m *FiscalPeriod) GetSchema() map[string]any {
+	return m.SetSchema(m)
+}
+
+// OpenAPISchemaName returns the name of the FiscalPeriod schema in the open api documentation.
+func (FiscalPeriod) OpenAPISchemaName() string {
+	return "FiscalPeriod"
+}
+
+// GetOpenAPISchema returns the Open API Schema of the FiscalPeriod in the open api documentation.
+func (m *FiscalPeriod) GetOpenAPISchema() map[string]any {
+	return m.SetOpenAPISchema(m)
+}
+
+type FiscalPeriodList struct {
+	app.ListModel
+}
+
+// OpenAPISchemaName returns the name of the FiscalPeriodList schema in the open api documentation.
+func (FiscalPeriodList) OpenAPISchemaName() string {
+	return "FiscalPeriodList"
+}
+
+// GetOpenAPISchema returns the Open API Schema of the FiscalPeriodList in the open api documentation.
+func (p *FiscalPeriodList) GetOpenAPISchema() map[string]any {
+	return p.SetOpenAPISchema(&FiscalPeriod{})
+}
+
+// Statuses of the FiscalPeriod data.
+const (
+	StatusOpen   = "open"
+	StatusClosed = "closed"
+)
+
+// ParamCreate is the expected parameters for create a new FiscalPeriod data.
+type ParamCreate struct {
+	UseCaseHandler
+	StartDate app.NullDate `json:"start_date" db:"m.start_date" gorm:"column:start_date" validate:"required"`
+	EndDate   app.NullDate `json:"end_date"   db:"m.end_date"   gorm:"column:end_date"   validate:"required"`
+}
+
+// ParamUpdate is the expected parameters for update the FiscalPeriod data.
+type ParamUpdate struct {
+	UseCaseHandler
+}
+
+// ParamPartiallyUpdate is the expected parameters for partially update the FiscalPeriod data.
+type ParamPartiallyUpdate struct {
+	UseCaseHandler
+}
+
+// ParamDelete is the expected parameters for delete the FiscalPeriod data.
+type ParamDelete struct {
+	UseCaseHandler
+}
@@ -0,0 +1,496 @@
+package fiscalperiod
+
+import (
+	"net/http"
+	"net/url"
+	"time"
+
+	"gorm.io/gorm"
+
+	"github.com/maulanar/go_asset_tracking_management/app"
+)
+
+// UseCase returns a UseCaseHandler for expected use case functional.
+func UseCase(ctx app.Ctx, query ...url.Values) UseCaseHandler {
+	u := UseCaseHandler{
+		Ctx:   &ctx,
+		Query: url.Values{},
+	}
+	if len(query) > 0 {
+		u.Query = query[0]
+	}
+	return u
+}
+
+// UseCaseHandler provides a convenient interface for FiscalPeriod use case, use UseCase to access UseCaseHandler.
+type UseCaseHandler struct {
+	FiscalPeriod
+
+	// injectable dependencies
+	Ctx   *app.Ctx   `json:"-" db:"-" gorm:"-"`
+	Query url.Values `json:"-" db:"-" gorm:"-"`
+}
+
+// Async return UseCaseHandler with async process.
+func (u UseCaseHandler) Async(ctx app.Ctx, query ...url.Values) UseCaseHandler {
+	ctx.IsAsync = true
+	return UseCase(ctx, query...)
+}
+
+// GetByID returns the FiscalPeriod data for the specified ID.
+func (u UseCaseHandler) GetByID(id string) (FiscalPeriod, error) {
+	res := FiscalPeriod{}
+
+	// check permission
+	err := u.Ctx.ValidatePermission("fiscal_periods.detail")
+	if err != nil {
+		return res, err
+	}
+
+	// get from cache and return if exists
+	cacheKey := u.EndPoint() + "." + id
+	app.Cache().Get(cacheKey, &res)
+	if res.ID.Valid {
+		return res, err
+	}
+
+	// prepare db for current ctx
+	tx, err := u.Ctx.DB()
+	if err != nil {
+		return res, app.Error().New(http.StatusInternalServerError, err.Error())
+	}
+
+	// get from db
+	key := "id"
+	if !app.Validator().IsValid(id, "uuid") {
+		key = "code"
+	}
+	u.Query.Add(key, id)
+	err = app.Query().First(tx, &res, u.Query)
+	if err != nil {
+		return res, u.Ctx.NotFoundError(err, u.EndPoint(), key, id)
+	}
+
+	// save to cache and return if exists
+	app.Cache().Set(cacheKey, res)
+	return res, err
+}
+
+// Get returns the list of FiscalPeriod data.
+func (u UseCaseHandler) Get() (app.ListModel, error) {
+	res := app.ListModel{}
+
+	// check permission
+	err := u.Ctx.ValidatePermission("fiscal_periods.list")
+	if err != nil {
+		return res, err
+	}
+	// get from cache and return if exists
+	cacheKey := u.EndPoint() + "?" + u.Query.Encode()
+	err = app.Cache().Get(cacheKey, &res)
+	if err == nil {
+		return res, err
+	}
+
+	// prepare db for current ctx
+	tx, err := u.Ctx.DB()
+	if err != nil {
+		return res, app.Error().New(http.StatusInternalServerError, err.Error())
+	}
+
+	// set pagination info
+	res.Results.PageContext.Count,
+		res.Results.PageContext.Page,
+		res.Results.PageContext.PerPage,
+		res.Results.PageContext.PageCount,
+		err = app.Query().PaginationInfo(tx, &FiscalPeriod{}, u.Query)
+	if err != nil {
+		return res, app.Error().New(http.StatusInternalServerError, err.Error())
+	}
+	// return data count if $per_page set to 0
+	if res.Results.PageContext.PerPage == 0 {
+		return res, err
+	}
+
+	// find data
+	data, err := app.Query().Find(tx, &FiscalPeriod{}, u.Query)
+	if err != nil {
+		return res, app.Error().New(http.StatusInternalServerError, err.Error())
+	}
+	res.SetData(data, u.Query)
+
+	// save to cache and return if exists
+	app.Cache().Set(cacheKey, res)
+	return res, err
+}
+
+// Create creates a new data FiscalPeriod with specified parameters.
+func (u *UseCaseHandler) Create(p *ParamCreate) error {
+
+	// check permission
+	err := u.Ctx.ValidatePermission("fiscal_periods.create")
+	if err != nil {
+		return err
+	}
+
+	// validate param
+	err = u.Ctx.ValidateParam(p)
+	if err != nil {
+		return err
+	}
+
+	// set default value for undefined field
+	err = u.setDefaultValue(FiscalPeriod{})
+	if err != nil {
+		return err
+	}
+
+	// prepare db for current ctx
+	tx, err := u.Ctx.DB()
+	if err != nil {
+		return app.Error().New(http.StatusInternalServerError, err.Error())
+	}
+
+	// save data to db
+	err = tx.Model(&u).Create(&u).Error
+	if err != nil {
+		return app.Error().New(http.StatusInternalServerError, err.Error())
+	}
+
+	// invalidate cache
+	app.Cache().Invalidate(u.EndPoint())
+
+	// save history (user activity), send webhook, etc
+	go u.Ctx.Hook("POST", "create", p.ID.String, p)
+	return nil
+}
+
+// UpdateByID updates the FiscalPeriod data for the specified ID with specified parameters.
+func (u UseCaseHandler) UpdateByID(id string, p *ParamUpdate) error {
+
+	// check permission
+	err := u.Ctx.ValidatePermission("fiscal_periods.edit")
+	if err != nil {
+		return err
+	}
+
+	// validate param
+	err = u.Ctx.ValidateParam(p)
+	if err != nil {
+		return err
+	}
+
+	// get previous data
+	old, err := u.GetByID(id)
+	if err != nil {
+		return err
+	}
+
+	// set default value for undefined field
+	err = u.setDefaultValue(old)
+	if err != nil {
+		return err
+	}
+
+	// prepare db for current ctx
+	tx, err := u.Ctx.DB()
+	if err != nil {
+		return app.Error().New(http.StatusInternalServerError, err.Error())
+	}
+
+	// update data on the db
+	err = tx.Model(&u).Where("id = ?", old.ID).Updates(u).Error
+	if err != nil {
+		return app.Error().New(http.StatusInternalServerError, err.Error())
+	}
+
+	// invalidate cache
+	app.Cache().Invalidate(u.EndPoint(), old.ID.String)
+
+	// save history (user activity), send webhook, etc
+	go u.Ctx.Hook("PUT", "Update", old.ID.String, old)
+	return nil
+}
+
+// PartiallyUpdateByID updates the FiscalPeriod data for the specified ID with specified parameters.
+func (u UseCaseHandler) PartiallyUpdateByID(id string, p *ParamPartiallyUpdate) error {
+
+	// check permission
+	err := u.Ctx.ValidatePermission("fiscal_periods.edit")
+	if err != nil {
+		return err
+	}
+
+	// validate param
+	err = u.Ctx.ValidateParam(p)
+	if err != nil {
+		return err
+	}
+
+	// get previous data
+	old, err := u.GetByID(id)
+	if err != nil {
+		return err
+	}
+
+	// set default value for undefined field
+	err = u.setDefaultValue(old)
+	if err != nil {
+		return err
+	}
+
+	// prepare db for current ctx
+	tx, err := u.Ctx.DB()
+	if err != nil {
+		return app.Error().New(http.StatusInternalServerError, err.Error())
+	}
+
+	// update data on the db
+	err = tx.Model(&u).Where("id = ?", old.ID).Updates(u).Error
+	if err != nil {
+		return app.Error().New(http.StatusInternalServerError, err.Error())
+	}
+
+	// invalidate cache
+	app.Cache().Invalidate(u.EndPoint(), old.ID.String)
+
+	// save history (user activity), send webhook, etc
+	go u.Ctx.Hook("PATCH", "Partially Update", old.ID.String, old)
+	return nil
+}
+
+// DeleteByID deletes the FiscalPeriod data for the specified ID.
+func (u UseCaseHandler) DeleteByID(id string, p *ParamDelete) error {
+
+	// check permission
+	err := u.Ctx.ValidatePermission("fiscal_periods.delete")
+	if err != nil {
+		return err
+	}
+
+	// validate param
+	err = u.Ctx.ValidateParam(p)
+	if err != nil {
+		return err
+	}
+
+	// get previous data
+	old, err := u.GetByID(id)
+	if err != nil {
+		return err
+	}
+	if old.Status.String == StatusClosed {
+		return app.Error().New(http.StatusBadRequest, u.Ctx.Trans("fiscal_period_status_invalid", map[string]string{
+			"code":   old.Code.String,
+			"status": old.Status.String,
+		}))
+	}
+
+	// prepare db for current ctx
+	tx, err := u.Ctx.DB()
+	if err != nil {
+		return app.Error().New(http.StatusInternalServerError, err.Error())
+	}
+
+	// update data on the db
+	err = tx.Model(&p).Where("id = ?", old.ID).Update("deleted_at", time.Now().UTC()).Error
+	if err != nil {
+		return app.Error().New(http.StatusInternalServerError, err.Error())
+	}
+
+	// invalidate cache
+	app.Cache().Invalidate(u.EndPoint(), old.ID.String)
+
+	// save history (user activity), send webhook, etc
+	go u.Ctx.Hook("DELETE", "DELETE", old.ID.String, old)
+	return nil
+}
+
+// setDefaultValue set default value of undefined field when create or update FiscalPeriod data.
+func (u *UseCaseHandler) setDefaultValue(old FiscalPeriod) error {
+	if !old.ID.Valid {
+		u.ID = app.NewNullUUID()
+	} else {
+		u.ID = old.ID
+	}
+
+	// a closed period must be reopened before it can be changed
+	if old.Status.String == StatusClosed {
+		return app.Error().New(http.StatusBadRequest, u.Ctx.Trans("fiscal_period_status_invalid", map[string]string{
+			"code":   old.Code.String,
+			"status": old.Status.String,
+		}))
+	}
+	u.Status = old.Status
+	u.ClosedAt = old.ClosedAt
+	if !u.Status.Valid {
+		u.Status.Set(StatusOpen)
+	}
+
+	// validate period
+	if !u.StartDate.Valid {
+		u.StartDate = old.StartDate
+	}
+	if !u.EndDate.Valid {
+		u.EndDate = old.EndDate
+	}
+	if u.EndDate.Time.Before(u.StartDate.Time) {
+		return app.Error().New(http.StatusBadRequest, u.Ctx.Trans("fiscal_period_invalid"))
+	}
+	tx, err := u.Ctx.DB()
+	if err != nil {
+		return app.Error().New(http.StatusInternalServerError, err.Error())
+	}
+	other := FiscalPeriod{}
+	err = tx.Where("id <> ?", u.ID).
+		Where("deleted_at IS NULL").
+		Where("start_date <= ? AND end_date >= ?", u.EndDate, u.StartDate).
+		Limit(1).Find(&other).Error
+	if err != nil {
+		return app.Error().New(http.StatusInternalServerError, err.Error())
+	}
+	if other.ID.Valid {
+		return app.Error().New(http.StatusBadRequest, u.Ctx.Trans("fiscal_period_overlap", map[string]string{"code": other.Code.String}))
+	}
+
+	if !u.Name.Valid || u.Name.String == "" {
+		if old.Name.Valid && old.Name.String != "" {
+			u.Name = old.Name
+		} else {
+			u.Name.Set(u.StartDate.Time.Format("January 2006"))
+		}
+	}
+
+	// Penentuan kode
+	if u.Code.Valid && u.Code.String != "" {
+		// Jika kode dikirim dan berbeda dengan data lama, cek ke DB
+		if !old.Code.Valid || u.Code.String != old.Code.String {
+			err := app.Common().IsFieldValueExists(u.Ctx, u.EndPoint(), "Code", u.TableName(), "code", u.Code.String)
+			if err != nil {
+				return err
+			}
+		}
+	} else {
+		// Jika tidak kirim kode dan data lama ada, gunakan data lama
+		if old.Code.Valid && old.Code.String != "" {
+			u.Code = old.Code
+		} else {
+			// Jika tidak kirim kode dan data lama tidak ada, generate baru
+			newCode, err := app.Common().GenerateCode(u.Ctx, u.TableName(), "code", u.Name.String)
+			if err != nil {
+				return err
+			}
+			u.Code.Set(newCode)
+		}
+	}
+
+	return nil
+}
+
+// CloseByID closes the FiscalPeriod data for the specified ID, the prices and the dates inside a closed period are locked.
+func (u UseCaseHandler) CloseByID(id string) error {
+	return u.setStatusByID(id, "fiscal_periods.close", StatusOpen, StatusClosed)
+}
+
+// ReopenByID reopens the closed FiscalPeriod data for the specified ID.
+func (u UseCaseHandler) ReopenByID(id string) error {
+	return u.setStatusByID(id, "fiscal_periods.reopen", StatusClosed, StatusOpen)
+}
+
+// setStatusByID changes the status of the FiscalPeriod data for the specified ID from the expected status.
+func (u UseCaseHandler) setStatusByID(id, aclKey, from, to string) error {
+
+	// check permission
+	err := u.Ctx.ValidatePermission(aclKey)
+	if err != nil {
+		return err
+	}
+
+	// get previous data
+	old, err := u.GetByID(id)
+	if err != nil {
+		return err
+	}
+	if old.Status.String != from {
+		return app.Error().New(http.StatusBadRequest, u.Ctx.Trans("fiscal_period_status_invalid", map[string]string{
+			"code":   old.Code.String,
+			"status": old.Status.String,
+		}))
+	}
+
+	// prepare db for current ctx
+	tx, err := u.Ctx.DB()
+	if err != nil {
+		return app.Error().New(http.StatusInternalServerError, err.Error())
+	}
+
+	// update data on the db
+	closedAt := app.NullDateTime{}
+	if to == StatusClosed {
+		closedAt.Set(time.Now().UTC())
+	}
+	err = tx.Model(&FiscalPeriod{}).Where("id = ?", old.ID).Updates(map[string]any{
+		"status":     to,
+		"closed_at":  closedAt,
+		"updated_at": time.Now().UTC(),
+	}).Error
+	if err != nil {
+		return app.Error().New(http.StatusInternalServerError, err.Error())
+	}
+
+	// invalidate cache
+	app.Cache().Invalidate(u.EndPoint(), old.ID.String)
+
+	// save history (user activity), send webhook, etc
+	go u.Ctx.Hook("POST", to, old.ID.String, old)
+	return nil
+}
+
+// ValidateOpen returns an error when one of the dates is inside a closed fiscal period.
+func (u UseCaseHandler) ValidateOpen(dates ...app.NullDate) error {
+	tx, err := u.Ctx.DB()
+	if err != nil {
+		return app.Error().New(http.StatusInternalServerError, err.Error())
+	}
+	for _, date := range dates {
+		if !date.Valid {
+			continue
+		}
+		closed := FiscalPeriod{}
+		err = tx.Where("status = ?", StatusClosed).
+			Where("deleted_at IS NULL").
+			Where("start_date <= ? AND end_date >= ?", date, date).
+			Limit(1).Find(&closed).Error
+		if err != nil {
+			return app.Error().New(http.StatusInternalServerError, err.Error())
+		}
+		if closed.ID.Valid {
+			return app.Error().New(http.StatusBadRequest, u.Ctx.Trans("fiscal_period_closed", map[string]string{
+				"code": closed.Code.String,
+				"date": date.Time.Format("2006-01-02"),
+			}))
+		}
+	}
+	return nil
+}
+
+// Closed returns the closed fiscal periods ordered by the start date, it is used to check the dates posted by the system
+// (e.g. the depreciation) without a query per date.
+func Closed(tx *gorm.DB) ([]FiscalPeriod, error) {
+	res := []FiscalPeriod{}
+	err := tx.Where("status = ?", StatusClosed).
+		Where("deleted_at IS NULL").
+		Order("start_date").
+		Find(&res).Error
+	return res, err
+}
+
+// IsClosed reports whether the date is inside one of the closed fiscal periods.
+func IsClosed(closed []FiscalPeriod, date time.Time) bool {
+	for _, fp := range closed {
+		if !date.Before(fp.StartDate.Time) && !date.After(fp.EndDate.Time) {
+			return true
+		}
+	}
+	return false
+}
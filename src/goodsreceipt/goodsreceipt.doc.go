@@ -0,0 +1,2 @@
+// goodsreceipt is a package related to goodsreceipt data.
+package goodsreceipt
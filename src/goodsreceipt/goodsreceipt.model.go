@@ -0,0 +1,208 @@
+package goodsreceipt
+
+import "github.com/maulanar/go_asset_tracking_management/app"
+
+// GoodsReceipt is the main model of GoodsReceipt data. It provides a convenient interface for app.ModelInterface
+type GoodsReceipt struct {
+	app.Model
+	ID          app.NullUUID   `json:"id"                    db:"m.id"                gorm:"column:id;primaryKey"`
+	Code        app.NullString `json:"code"                  db:"m.code"              gorm:"column:code"`
+	Date        app.NullDate   `json:"date"                  db:"m.date"              gorm:"column:date"`
+	Description app.NullText   `json:"description"           db:"m.description"       gorm:"column:description"`
+
+	PurchaseOrderID   app.NullUUID   `json:"purchase_order.id"     db:"m.purchase_order_id" gorm:"column:purchase_order_id"`
+	PurchaseOrderCode app.NullString `json:"purchase_order.code"   db:"po.code"             gorm:"-"`
+	VendorID          app.NullUUID   `json:"vendor.id"             db:"po.vendor_id"        gorm:"-"`
+	VendorCode        app.NullString `json:"vendor.code"           db:"vnd.code"            gorm:"-"`
+	VendorName        app.NullString `json:"vendor.name"           db:"vnd.name"            gorm:"-"`
+
+	AttachmentID   app.NullUUID `json:"attachment.id"         db:"m.attachment_id"     gorm:"column:attachment_id"`
+	AttachmentName app.NullText `json:"attachment.name"       db:"att.name"            gorm:"-"`
+	AttachmentPath app.NullText `json:"attachment.path"       db:"att.path"            gorm:"-"`
+	AttachmentURL  app.NullText `json:"attachment.url"        db:"att.url"             gorm:"-"`
+
+	Lines []GoodsReceiptLine `json:"lines"                 db:"-"                   gorm:"-"`
+
+	CreatedAt app.NullDateTime `json:"created_at"            db:"m.created_at"        gorm:"column:created_at"`
+	UpdatedAt app.NullDateTime `json:"updated_at"            db:"m.updated_at"        gorm:"column:updated_at"`
+	DeletedAt app.NullDateTime `json:"deleted_at"            db:"m.deleted_at,hide"   gorm:"column:deleted_at"`
+}
+
+// EndPoint returns the GoodsReceipt end point, it used for cache key, etc.
+func (GoodsReceipt) EndPoint() string {
+	return "goods_receipts"
+}
+
+// TableVersion returns the versions of the GoodsReceipt table in the database.
+// Change this value with date format YY.MM.DDHHii when any table structure changes.
+func (GoodsReceipt) TableVersion() string {
+	return "26.10.190900"
+}
+
+// TableName returns the name of the GoodsReceipt table in the database.
+func (GoodsReceipt) TableName() string {
+	return "goods_receipts"
+}
+
+// TableAliasName returns the table alias name of the GoodsReceipt table, used for querying.
+func (GoodsReceipt) TableAliasName() string {
+	return "m"
+}
+
+// GetRelations returns the relations of the GoodsReceipt data in the database, used for querying.
+func (m *GoodsReceipt) GetRelations() map[string]map[string]any {
+	m.AddRelation("left", "purchase_orders", "po", []map[string]any{{"column1": "po.id", "column2": "m.purchase_order_id"}})
+	m.AddRelation("left", "vendors", "vnd", []map[string]any{{"column1": "vnd.id", "column2": "po.vendor_id"}})
+	m.AddRelation("left", "attachments", "att", []map[string]any{{"column1": "att.id", "column2": "m.attachment_id"}})
+	return m.Relations
+}
+
+// GetFilters returns the filter of the GoodsReceipt data in the database, used for querying.
+func (m *GoodsReceipt) GetFilters() []map[string]any {
+	m.AddFilter(map[string]any{"column1": "m.deleted_at", "operator": "=", "value": nil})
+	return m.Filters
+}
+
+// GetSorts returns the default sort of the GoodsReceipt data in the database, used for querying.
+func (m *GoodsReceipt) GetSorts() []map[string]any {
+	m.AddSort(map[string]any{"column": "m.updated_at", "direction": "desc"})
+	return m.Sorts
+}
+
+// GetFields returns list of the field of the GoodsReceipt data in the database, used for querying.
+func (m *GoodsReceipt) GetFields() map[string]map[string]any {
+	m.SetFields(m)
+	return m.Fields
+}
+
+// GetSchema returns the GoodsReceipt schema, used for querying.
+func (m *GoodsReceipt) GetSchema() map[string]any {
+	return m.SetSchema(m)
+}
+
+// OpenAPISchemaName returns the name of the GoodsReceipt schema in the open api documentation.
+func (GoodsReceipt) OpenAPISchemaName() string {
+	return "GoodsReceipt"
+}
+
+// GetOpenAPISchema returns the Open API Schema of the GoodsReceipt in the open api documentation.
+func (m *GoodsReceipt) GetOpenAPISchema() map[string]any {
+	return m.SetOpenAPISchema(m)
+}
+
+type GoodsReceiptList struct {
+	app.ListModel
+}
+
+// OpenAPISchemaName returns the name of the GoodsReceiptList schema in the open api documentation.
+func (GoodsReceiptList) OpenAPISchemaName() string {
+	return "GoodsReceiptList"
+}
+
+// GetOpenAPISchema returns the Open API Schema of the GoodsReceiptList in the open api documentation.
+func (p *GoodsReceiptList) GetOpenAPISchema() map[string]any {
+	return p.SetOpenAPISchema(&GoodsReceipt{})
+}
+
+// ParamCreate is the expected parameters for create a new GoodsReceipt data.
+type ParamCreate struct {
+	UseCaseHandler
+	Date            app.NullDate       `json:"date"                  db:"m.date"              gorm:"column:date"              validate:"required"`
+	PurchaseOrderID app.NullUUID       `json:"purchase_order.id"     db:"m.purchase_order_id" gorm:"column:purchase_order_id" validate:"required"`
+	Lines           []GoodsReceiptLine `json:"lines"                 db:"-"                   gorm:"-"                        validate:"required,min=1,dive"`
+}
+
+// ParamUpdate is the expected parameters for update the GoodsReceipt data.
+type ParamUpdate struct {
+	UseCaseHandler
+}
+
+// ParamPartiallyUpdate is the expected parameters for partially update the GoodsReceipt data.
+type ParamPartiallyUpdate struct {
+	UseCaseHandler
+}
+
+// ParamDelete is the expected parameters for delete the GoodsReceipt data.
+type ParamDelete struct {
+	UseCaseHandler
+}
+
+// GoodsReceiptLine is the line item of GoodsReceipt data, one row per purchase order line received.
+type GoodsReceiptLine struct {
+	app.Model
+	ID             app.NullUUID  `json:"id"                            db:"m.id"                     gorm:"column:id;primaryKey"`
+	GoodsReceiptID app.NullUUID  `json:"goods_receipt.id"              db:"m.goods_receipt_id"       gorm:"column:goods_receipt_id"`
+	Qty            app.NullInt64 `json:"qty"                           db:"m.qty"                    gorm:"column:qty"                    validate:"required,gt=0"`
+
+	PurchaseOrderLineID        app.NullUUID    `json:"purchase_order_line.id"          db:"m.purchase_order_line_id" gorm:"column:purchase_order_line_id" validate:"required"`
+	PurchaseOrderLineName      app.NullString  `json:"purchase_order_line.name"        db:"pol.name"                 gorm:"-"`
+	PurchaseOrderLineUnitPrice app.NullFloat64 `json:"purchase_order_line.unit_price"  db:"pol.unit_price"           gorm:"-"`
+	CategoryID                 app.NullUUID    `json:"category.id"                     db:"pol.category_id"          gorm:"-"`
+	CategoryCode               app.NullString  `json:"category.code"                   db:"cat.code"                 gorm:"-"`
+	CategoryName               app.NullString  `json:"category.name"                   db:"cat.name"                 gorm:"-"`
+
+	CreatedAt app.NullDateTime `json:"created_at"                    db:"m.created_at"             gorm:"column:created_at"`
+	UpdatedAt app.NullDateTime `json:"updated_at"                    db:"m.updated_at"             gorm:"column:updated_at"`
+	DeletedAt app.NullDateTime `json:"deleted_at"                    db:"m.deleted_at,hide"        gorm:"column:deleted_at"`
+}
+
+// EndPoint returns the GoodsReceiptLine end point, it used for cache key, etc.
+func (GoodsReceiptLine) EndPoint() string {
+	return "goods_receipt_lines"
+}
+
+// TableVersion returns the versions of the GoodsReceiptLine table in the database.
+// Change this value with date format YY.MM.DDHHii when any table structure changes.
+func (GoodsReceiptLine) TableVersion() string {
+	return "26.10.190900"
+}
+
+// TableName returns the name of the GoodsReceiptLine table in the database.
+func (GoodsReceiptLine) TableName() string {
+	return "goods_receipt_lines"
+}
+
+// TableAliasName returns the table alias name of the GoodsReceiptLine table, used for querying.
+func (GoodsReceiptLine) TableAliasName() string {
+	return "m"
+}
+
+// GetRelations returns the relations of the GoodsReceiptLine data in the database, used for querying.
+func (m *GoodsReceiptLine) GetRelations() map[string]map[string]any {
+	m.AddRelation("left", "purchase_order_lines", "pol", []map[string]any{{"column1": "pol.id", "column2": "m.purchase_order_line_id"}})
+	m.AddRelation("left", "categories", "cat", []map[string]any{{"column1": "cat.id", "column2": "pol.category_id"}})
+	return m.Relations
+}
+
+// GetFilters returns the filter of the GoodsReceiptLine data in the database, used for querying.
+func (m *GoodsReceiptLine) GetFilters() []map[string]any {
+	m.AddFilter(map[string]any{"column1": "m.deleted_at", "operator": "=", "value": nil})
+	return m.Filters
+}
+
+// GetSorts returns the default sort of the GoodsReceiptLine data in the database, used for querying.
+func (m *GoodsReceiptLine) GetSorts() []map[string]any {
+	m.AddSort(map[string]any{"column": "m.created_at", "direction": "asc"})
+	return m.Sorts
+}
+
+// GetFields returns list of the field of the GoodsReceiptLine data in the database, used for querying.
+func (m *GoodsReceiptLine) GetFields() map[string]map[string]any {
+	m.SetFields(m)
+	return m.Fields
+}
+
+// GetSchema returns the GoodsReceiptLine schema, used for querying.
+func (m *GoodsReceiptLine) GetSchema() map[string]any {
+	return m.SetSchema(m)
+}
+
+// OpenAPISchemaName returns the name of the GoodsReceiptLine schema in the open api documentation.
+func (GoodsReceiptLine) OpenAPISchemaName() string {
+	return "GoodsReceiptLine"
+}
+
+// GetOpenAPISchema returns the Open API Schema of the GoodsReceiptLine in the open api documentation.
+func (m *GoodsReceiptLine) GetOpenAPISchema() map[string]any {
+	return m.SetOpenAPISchema(m)
+}
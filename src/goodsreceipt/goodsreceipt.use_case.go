@@ -0,0 +1,628 @@
+package goodsreceipt
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"grest.dev/grest"
+
+	"github.com/maulanar/go_asset_tracking_management/app"
+	"github.com/maulanar/go_asset_tracking_management/src/asset"
+	"github.com/maulanar/go_asset_tracking_management/src/attachment"
+	"github.com/maulanar/go_asset_tracking_management/src/depreciationentry"
+	"github.com/maulanar/go_asset_tracking_management/src/employeeasset"
+	"github.com/maulanar/go_asset_tracking_management/src/fiscalperiod"
+	"github.com/maulanar/go_asset_tracking_management/src/purchaseorder"
+)
+
+// UseCase returns a UseCaseHandler for expected use case functional.
+func UseCase(ctx app.Ctx, query ...url.Values) UseCaseHandler {
+	u := UseCaseHandler{
+		Ctx:   &ctx,
+		Query: url.Values{},
+	}
+	if len(query) > 0 {
+		u.Query = query[0]
+	}
+	return u
+}
+
+// UseCaseHandler provides a convenient interface for GoodsReceipt use case, use UseCase to access UseCaseHandler.
+type UseCaseHandler struct {
+	GoodsReceipt
+
+	// injectable dependencies
+	Ctx   *app.Ctx   `json:"-" db:"-" gorm:"-"`
+	Query url.Values `json:"-" db:"-" gorm:"-"`
+}
+
+// Async return UseCaseHandler with async process.
+func (u UseCaseHandler) Async(ctx app.Ctx, query ...url.Values) UseCaseHandler {
+	ctx.IsAsync = true
+	return UseCase(ctx, query...)
+}
+
+// GetByID returns the GoodsReceipt data for the specified ID.
+func (u UseCaseHandler) GetByID(id string) (GoodsReceipt, error) {
+	res := GoodsReceipt{}
+
+	// check permission
+	err := u.Ctx.ValidatePermission("goods_receipts.detail")
+	if err != nil {
+		return res, err
+	}
+
+	// get from cache and return if exists
+	cacheKey := u.EndPoint() + "." + id
+	app.Cache().Get(cacheKey, &res)
+	if res.ID.Valid {
+		return res, err
+	}
+
+	// prepare db for current ctx
+	tx, err := u.Ctx.DB()
+	if err != nil {
+		return res, app.Error().New(http.StatusInternalServerError, err.Error())
+	}
+
+	// get from db
+	key := "id"
+	if !app.Validator().IsValid(id, "uuid") {
+		key = "code"
+	}
+	u.Query.Add(key, id)
+	err = app.Query().First(tx, &res, u.Query)
+	if err != nil {
+		return res, u.Ctx.NotFoundError(err, u.EndPoint(), key, id)
+	}
+
+	// get line items
+	res.Lines, err = u.GetLines(res.ID.String)
+	if err != nil {
+		return res, err
+	}
+
+	// save to cache and return if exists
+	app.Cache().Set(cacheKey, res)
+	return res, err
+}
+
+// Get returns the list of GoodsReceipt data.
+func (u UseCaseHandler) Get() (app.ListModel, error) {
+	res := app.ListModel{}
+
+	// check permission
+	err := u.Ctx.ValidatePermission("goods_receipts.list")
+	if err != nil {
+		return res, err
+	}
+	// get from cache and return if exists
+	cacheKey := u.EndPoint() + "?" + u.Query.Encode()
+	err = app.Cache().Get(cacheKey, &res)
+	if err == nil {
+		return res, err
+	}
+
+	// prepare db for current ctx
+	tx, err := u.Ctx.DB()
+	if err != nil {
+		return res, app.Error().New(http.StatusInternalServerError, err.Error())
+	}
+
+	// set pagination info
+	res.Results.PageContext.Count,
+		res.Results.PageContext.Page,
+		res.Results.PageContext.PerPage,
+		res.Results.PageContext.PageCount,
+		err = app.Query().PaginationInfo(tx, &GoodsReceipt{}, u.Query)
+	if err != nil {
+		return res, app.Error().New(http.StatusInternalServerError, err.Error())
+	}
+	// return data count if $per_page set to 0
+	if res.Results.PageContext.PerPage == 0 {
+		return res, err
+	}
+
+	// find data
+	data, err := app.Query().Find(tx, &GoodsReceipt{}, u.Query)
+	if err != nil {
+		return res, app.Error().New(http.StatusInternalServerError, err.Error())
+	}
+	res.SetData(data, u.Query)
+
+	// save to cache and return if exists
+	app.Cache().Set(cacheKey, res)
+	return res, err
+}
+
+// Create creates a new data GoodsReceipt with specified parameters.
+func (u *UseCaseHandler) Create(p *ParamCreate) error {
+
+	// check permission
+	err := u.Ctx.ValidatePermission("goods_receipts.create")
+	if err != nil {
+		return err
+	}
+
+	// validate param
+	err = u.Ctx.ValidateParam(p)
+	if err != nil {
+		return err
+	}
+
+	// set default value for undefined field
+	err = u.setDefaultValue(GoodsReceipt{})
+	if err != nil {
+		return err
+	}
+
+	// prepare db for current ctx
+	tx, err := u.Ctx.DB()
+	if err != nil {
+		return app.Error().New(http.StatusInternalServerError, err.Error())
+	}
+
+	// save data to db
+	err = tx.Model(&u).Create(&u).Error
+	if err != nil {
+		return app.Error().New(http.StatusInternalServerError, err.Error())
+	}
+
+	// save line items, update received qty and register the received assets
+	err = u.receiveLines(tx)
+	if err != nil {
+		return err
+	}
+
+	// invalidate cache
+	app.Cache().Invalidate(u.EndPoint())
+
+	// save history (user activity), send webhook, etc
+	go u.Ctx.Hook("POST", "create", p.ID.String, p)
+	return nil
+}
+
+// UpdateByID updates the GoodsReceipt data for the specified ID with specified parameters.
+func (u UseCaseHandler) UpdateByID(id string, p *ParamUpdate) error {
+
+	// check permission
+	err := u.Ctx.ValidatePermission("goods_receipts.edit")
+	if err != nil {
+		return err
+	}
+
+	// validate param
+	err = u.Ctx.ValidateParam(p)
+	if err != nil {
+		return err
+	}
+
+	// get previous data
+	old, err := u.GetByID(id)
+	if err != nil {
+		return err
+	}
+
+	// set default value for undefined field
+	err = u.setDefaultValue(old)
+	if err != nil {
+		return err
+	}
+
+	// prepare db for current ctx
+	tx, err := u.Ctx.DB()
+	if err != nil {
+		return app.Error().New(http.StatusInternalServerError, err.Error())
+	}
+
+	// update data on the db
+	err = tx.Model(&u).Where("id = ?", old.ID).Updates(u).Error
+	if err != nil {
+		return app.Error().New(http.StatusInternalServerError, err.Error())
+	}
+
+	// invalidate cache
+	app.Cache().Invalidate(u.EndPoint(), old.ID.String)
+
+	// save history (user activity), send webhook, etc
+	go u.Ctx.Hook("PUT", "Update", old.ID.String, old)
+	return nil
+}
+
+// PartiallyUpdateByID updates the GoodsReceipt data for the specified ID with specified parameters.
+func (u UseCaseHandler) PartiallyUpdateByID(id string, p *ParamPartiallyUpdate) error {
+
+	// check permission
+	err := u.Ctx.ValidatePermission("goods_receipts.edit")
+	if err != nil {
+		return err
+	}
+
+	// validate param
+	err = u.Ctx.ValidateParam(p)
+	if err != nil {
+		return err
+	}
+
+	// get previous data
+	old, err := u.GetByID(id)
+	if err != nil {
+		return err
+	}
+
+	// set default value for undefined field
+	err = u.setDefaultValue(old)
+	if err != nil {
+		return err
+	}
+
+	// prepare db for current ctx
+	tx, err := u.Ctx.DB()
+	if err != nil {
+		return app.Error().New(http.StatusInternalServerError, err.Error())
+	}
+
+	// update data on the db
+	err = tx.Model(&u).Where("id = ?", old.ID).Updates(u).Error
+	if err != nil {
+		return app.Error().New(http.StatusInternalServerError, err.Error())
+	}
+
+	// invalidate cache
+	app.Cache().Invalidate(u.EndPoint(), old.ID.String)
+
+	// save history (user activity), send webhook, etc
+	go u.Ctx.Hook("PATCH", "Partially Update", old.ID.String, old)
+	return nil
+}
+
+// DeleteByID deletes the GoodsReceipt data for the specified ID.
+// The receipt is reversed, the received qty is taken back from the purchase order lines and the assets registered
+// by the receipt are deleted with their depreciation, so it can only be deleted while none of its assets has been used.
+func (u UseCaseHandler) DeleteByID(id string, p *ParamDelete) error {
+
+	// check permission
+	err := u.Ctx.ValidatePermission("goods_receipts.delete")
+	if err != nil {
+		return err
+	}
+
+	// validate param
+	err = u.Ctx.ValidateParam(p)
+	if err != nil {
+		return err
+	}
+
+	// get previous data
+	old, err := u.GetByID(id)
+	if err != nil {
+		return err
+	}
+
+	// prepare db for current ctx
+	tx, err := u.Ctx.DB()
+	if err != nil {
+		return app.Error().New(http.StatusInternalServerError, err.Error())
+	}
+
+	// reverse the received units
+	err = u.reverseLines(tx, old)
+	if err != nil {
+		return err
+	}
+
+	// update data on the db
+	now := time.Now().UTC()
+	err = tx.Model(&GoodsReceiptLine{}).Where("goods_receipt_id = ?", old.ID).Where("deleted_at IS NULL").Update("deleted_at", now).Error
+	if err != nil {
+		return app.Error().New(http.StatusInternalServerError, err.Error())
+	}
+	err = tx.Model(&p).Where("id = ?", old.ID).Update("deleted_at", now).Error
+	if err != nil {
+		return app.Error().New(http.StatusInternalServerError, err.Error())
+	}
+
+	// update purchase order status based on the remaining qty
+	err = purchaseorder.UseCase(*u.Ctx, url.Values{}).UpdateReceivedStatus(old.PurchaseOrderID.String)
+	if err != nil {
+		return err
+	}
+
+	// invalidate cache
+	app.Cache().Invalidate(u.EndPoint(), old.ID.String)
+
+	// save history (user activity), send webhook, etc
+	go u.Ctx.Hook("DELETE", "DELETE", old.ID.String, old)
+	return nil
+}
+
+// setDefaultValue set default value of undefined field when create or update GoodsReceipt data.
+func (u *UseCaseHandler) setDefaultValue(old GoodsReceipt) error {
+	if !old.ID.Valid {
+		u.ID = app.NewNullUUID()
+	} else {
+		u.ID = old.ID
+	}
+
+	// receipt lines are immutable, only validate them when the goods receipt is created
+	if !old.ID.Valid {
+		po, err := purchaseorder.UseCase(*u.Ctx, url.Values{}).GetByID(u.PurchaseOrderID.String)
+		if err != nil {
+			return err
+		}
+		if po.Status.String != purchaseorder.StatusOpen && po.Status.String != purchaseorder.StatusPartiallyReceived {
+			return app.Error().New(http.StatusBadRequest, u.Ctx.Trans("purchase_order_not_receivable", map[string]string{
+				"code":   po.Code.String,
+				"status": po.Status.String,
+			}))
+		}
+		u.PurchaseOrderID = po.ID
+
+		// the purchase order lines are read with a lock, so concurrent receipts can not receive the same remaining qty
+		tx, err := u.Ctx.DB()
+		if err != nil {
+			return app.Error().New(http.StatusInternalServerError, err.Error())
+		}
+		lines := []purchaseorder.PurchaseOrderLine{}
+		err = tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where("purchase_order_id = ?", po.ID).
+			Where("deleted_at IS NULL").
+			Find(&lines).Error
+		if err != nil {
+			return app.Error().New(http.StatusInternalServerError, err.Error())
+		}
+		poLines := map[string]purchaseorder.PurchaseOrderLine{}
+		for _, pol := range lines {
+			poLines[pol.ID.String] = pol
+		}
+		receivedQty := map[string]int64{}
+		for i := range u.Lines {
+			line := &u.Lines[i]
+			err := u.Ctx.ValidateParam(line)
+			if err != nil {
+				return err
+			}
+			pol, ok := poLines[line.PurchaseOrderLineID.String]
+			if !ok {
+				return u.Ctx.NotFoundError(gorm.ErrRecordNotFound, pol.EndPoint(), "id", line.PurchaseOrderLineID.String)
+			}
+			receivedQty[pol.ID.String] += line.Qty.Int64
+			remaining := pol.Qty.Int64 - pol.ReceivedQty.Int64
+			if receivedQty[pol.ID.String] > remaining {
+				return app.Error().New(http.StatusBadRequest, u.Ctx.Trans("receive_qty_exceeds_remaining", map[string]string{
+					"qty":       strconv.FormatInt(receivedQty[pol.ID.String], 10),
+					"item":      pol.Name.String,
+					"remaining": strconv.FormatInt(remaining, 10),
+				}))
+			}
+			line.ID = app.NewNullUUID()
+			line.GoodsReceiptID = u.ID
+			line.PurchaseOrderLineName = pol.Name
+			line.PurchaseOrderLineUnitPrice = pol.UnitPrice
+			line.CategoryID = pol.CategoryID
+		}
+
+		if !u.Date.Valid {
+			u.Date.Set(time.Now())
+		}
+	} else {
+		u.Lines = nil
+	}
+
+	// validate attachment
+	if u.AttachmentID.Valid && u.AttachmentID.String != "" {
+		attUC := attachment.UseCase(*u.Ctx, url.Values{})
+		att, err := attUC.GetByID(u.AttachmentID.String)
+		if err != nil {
+			return err
+		}
+
+		// Update data attachment
+		upAtt := attachment.ParamUpdate{}
+		upAtt.Endpoint.Set(u.EndPoint())
+		upAtt.DataId.Set(u.ID.String)
+		err = attUC.UpdateByID(att.ID.String, &upAtt)
+		if err != nil {
+			return err
+		}
+	}
+
+	// Penentuan kode
+	if u.Code.Valid && u.Code.String != "" {
+		if !old.Code.Valid || u.Code.String != old.Code.String {
+			err := app.Common().IsFieldValueExists(u.Ctx, u.EndPoint(), "Code", u.TableName(), "code", u.Code.String)
+			if err != nil {
+				return err
+			}
+		}
+	} else if old.Code.Valid && old.Code.String != "" {
+		u.Code = old.Code
+	} else {
+		newCode, err := app.Common().GenerateCode(u.Ctx, u.TableName(), "code", "Goods Receipt")
+		if err != nil {
+			return err
+		}
+		u.Code.Set(newCode)
+	}
+
+	return nil
+}
+
+// receiveLines saves the line items of the GoodsReceipt data, adds the received qty to the purchase order lines,
+// creates one asset for each received unit and updates the purchase order status.
+func (u *UseCaseHandler) receiveLines(tx *gorm.DB) error {
+	if len(u.Lines) == 0 {
+		return nil
+	}
+
+	for _, line := range u.Lines {
+		err := tx.Create(&line).Error
+		if err != nil {
+			return app.Error().New(http.StatusInternalServerError, err.Error())
+		}
+		err = tx.Model(&purchaseorder.PurchaseOrderLine{}).
+			Where("id = ?", line.PurchaseOrderLineID).
+			Update("received_qty", gorm.Expr("COALESCE(received_qty, 0) + ?", line.Qty.Int64)).Error
+		if err != nil {
+			return app.Error().New(http.StatusInternalServerError, err.Error())
+		}
+
+		// register every received unit as an asset with the purchase price and receipt date
+		for i := int64(0); i < line.Qty.Int64; i++ {
+			assUC := asset.UseCase(*u.Ctx, url.Values{})
+			assUC.Name = line.PurchaseOrderLineName
+			assUC.CategoryID = line.CategoryID
+			assUC.Price = line.PurchaseOrderLineUnitPrice
+			assUC.InputDate = u.Date
+			assUC.PurchaseOrderLineID = line.PurchaseOrderLineID
+			assUC.GoodsReceiptID = u.ID
+			assUC.Status.Set("available")
+			err = assUC.Create(&asset.ParamCreate{})
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	// update purchase order status based on the remaining qty
+	return purchaseorder.UseCase(*u.Ctx, url.Values{}).UpdateReceivedStatus(u.PurchaseOrderID.String)
+}
+
+// reverseLines takes the received qty of the GoodsReceipt data back from the purchase order lines and deletes the assets
+// registered by the receipt with their depreciation entries. An asset which is no longer available or has been assigned
+// is in use and the receipt can not be reversed, neither can a receipt or a depreciation inside a closed fiscal period.
+func (u *UseCaseHandler) reverseLines(tx *gorm.DB, old GoodsReceipt) error {
+	assets := []asset.Asset{}
+	err := tx.Select("id", "code", "status").
+		Where("goods_receipt_id = ?", old.ID).
+		Where("deleted_at IS NULL").
+		Find(&assets).Error
+	if err != nil {
+		return app.Error().New(http.StatusInternalServerError, err.Error())
+	}
+	assetIDs := []string{}
+	for _, a := range assets {
+		assetIDs = append(assetIDs, a.ID.String)
+	}
+
+	// the assets must be unused
+	assigned := map[string]bool{}
+	if len(assetIDs) > 0 {
+		ids := []string{}
+		err = tx.Model(&employeeasset.EmployeeAsset{}).
+			Where("asset_id IN ?", assetIDs).
+			Where("deleted_at IS NULL").
+			Distinct().Pluck("asset_id", &ids).Error
+		if err != nil {
+			return app.Error().New(http.StatusInternalServerError, err.Error())
+		}
+		for _, id := range ids {
+			assigned[id] = true
+		}
+	}
+	for _, a := range assets {
+		if a.Status.String != asset.StatusAvailable || assigned[a.ID.String] {
+			return app.Error().New(http.StatusBadRequest, u.Ctx.Trans("goods_receipt_assets_in_use", map[string]string{
+				"code":   old.Code.String,
+				"asset":  a.Code.String,
+				"status": a.Status.String,
+			}))
+		}
+	}
+
+	// the receipt and the depreciation of its assets must be in an open fiscal period
+	dates := []app.NullDate{old.Date}
+	if len(assetIDs) > 0 {
+		entryDates := []time.Time{}
+		err = tx.Model(&depreciationentry.DepreciationEntry{}).
+			Where("asset_id IN ?", assetIDs).
+			Where("deleted_at IS NULL").
+			Distinct().Pluck("date", &entryDates).Error
+		if err != nil {
+			return app.Error().New(http.StatusInternalServerError, err.Error())
+		}
+		for _, date := range entryDates {
+			dates = append(dates, app.NewNullDate(date))
+		}
+	}
+	err = fiscalperiod.UseCase(*u.Ctx, url.Values{}).ValidateOpen(dates...)
+	if err != nil {
+		return err
+	}
+
+	// delete the assets with their depreciation
+	now := time.Now().UTC()
+	if len(assetIDs) > 0 {
+		err = tx.Model(&depreciationentry.DepreciationEntry{}).
+			Where("asset_id IN ?", assetIDs).
+			Where("deleted_at IS NULL").
+			Update("deleted_at", now).Error
+		if err != nil {
+			return app.Error().New(http.StatusInternalServerError, err.Error())
+		}
+		err = tx.Model(&asset.Asset{}).Where("id IN ?", assetIDs).Update("deleted_at", now).Error
+		if err != nil {
+			return app.Error().New(http.StatusInternalServerError, err.Error())
+		}
+		for _, id := range assetIDs {
+			app.Cache().Invalidate(asset.Asset{}.EndPoint(), id)
+		}
+	}
+
+	// take the received qty back from the purchase order lines, the lines are locked like a receipt does
+	lines := []GoodsReceiptLine{}
+	err = tx.Where("goods_receipt_id = ?", old.ID).Where("deleted_at IS NULL").Find(&lines).Error
+	if err != nil {
+		return app.Error().New(http.StatusInternalServerError, err.Error())
+	}
+	err = tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where("purchase_order_id = ?", old.PurchaseOrderID).
+		Where("deleted_at IS NULL").
+		Find(&[]purchaseorder.PurchaseOrderLine{}).Error
+	if err != nil {
+		return app.Error().New(http.StatusInternalServerError, err.Error())
+	}
+	for _, line := range lines {
+		err = tx.Model(&purchaseorder.PurchaseOrderLine{}).
+			Where("id = ?", line.PurchaseOrderLineID).
+			Update("received_qty", gorm.Expr("GREATEST(COALESCE(received_qty, 0) - ?, 0)", line.Qty.Int64)).Error
+		if err != nil {
+			return app.Error().New(http.StatusInternalServerError, err.Error())
+		}
+	}
+	return nil
+}
+
+// GetLines returns the line items of the GoodsReceipt data for the specified goods receipt ID.
+func (u UseCaseHandler) GetLines(goodsReceiptID string) ([]GoodsReceiptLine, error) {
+	res := []GoodsReceiptLine{}
+
+	// prepare db for current ctx
+	tx, err := u.Ctx.DB()
+	if err != nil {
+		return res, app.Error().New(http.StatusInternalServerError, err.Error())
+	}
+
+	query := url.Values{}
+	query.Add("goods_receipt.id", goodsReceiptID)
+	query.Add(grest.QueryDisablePagination, "true")
+	data, err := app.Query().Find(tx, &GoodsReceiptLine{}, query)
+	if err != nil {
+		return res, app.Error().New(http.StatusInternalServerError, err.Error())
+	}
+
+	jByte, err := json.Marshal(data)
+	if err != nil {
+		return res, app.Error().New(http.StatusInternalServerError, err.Error())
+	}
+	err = app.BindJSON(jByte, &res)
+	if err != nil {
+		return res, app.Error().New(http.StatusInternalServerError, err.Error())
+	}
+	return res, nil
+}
@@ -0,0 +1,2 @@
+// incident is a package related to incident data.
+package incident
@@ -0,0 +1,188 @@
+package incident
+
+import (
+	"github.com/maulanar/go_asset_tracking_management/app"
+	"github.com/maulanar/go_asset_tracking_management/src/attachment"
+)
+
+// Incident is the main model of Incident data. It provides a convenient interface for app.ModelInterface
+type Incident struct {
+	app.Model
+	ID          app.NullUUID     `json:"id"                db:"m.id"              gorm:"column:id;primaryKey"`
+	Code        app.NullString   `json:"code"              db:"m.code"            gorm:"column:code"`
+	Type        app.NullString   `json:"type"              db:"m.type"            gorm:"column:type"                    validate:"omitempty,oneof=damage loss malfunction"`
+	Status      app.NullString   `json:"status"            db:"m.status"          gorm:"column:status;default:new"      validate:"omitempty,oneof=new triaged converted resolved rejected"`
+	Priority    app.NullString   `json:"priority"          db:"m.priority"        gorm:"column:priority;default:medium" validate:"omitempty,oneof=low medium high critical"`
+	Description app.NullText     `json:"description"       db:"m.description"     gorm:"column:description"`
+	ReportedAt  app.NullDateTime `json:"reported_at"       db:"m.reported_at"     gorm:"column:reported_at"`
+	TriageNote  app.NullText     `json:"triage_note"       db:"m.triage_note"     gorm:"column:triage_note"`
+	TriagedAt   app.NullDateTime `json:"triaged_at"        db:"m.triaged_at"      gorm:"column:triaged_at"`
+	ClosedAt    app.NullDateTime `json:"closed_at"         db:"m.closed_at"       gorm:"column:closed_at"`
+
+	// photos of the incident, the attachments are uploaded first and linked to the incident by their id
+	Photos []attachment.Attachment `json:"photos"            db:"-"                 gorm:"-"`
+
+	AssetID     app.NullUUID   `json:"asset.id"          db:"m.asset_id"        gorm:"column:asset_id"`
+	AssetCode   app.NullString `json:"asset.code"        db:"ass.code"          gorm:"-"`
+	AssetName   app.NullString `json:"asset.name"        db:"ass.name"          gorm:"-"`
+	AssetStatus app.NullString `json:"asset.status"      db:"ass.status"        gorm:"-"`
+
+	EmployeeID   app.NullUUID   `json:"employee.id"       db:"m.employee_id"     gorm:"column:employee_id"`
+	EmployeeCode app.NullString `json:"employee.code"     db:"emp.code"          gorm:"-"`
+	EmployeeName app.NullString `json:"employee.name"     db:"emp.name"          gorm:"-"`
+
+	BranchID   app.NullUUID   `json:"branch.id"         db:"m.branch_id"       gorm:"column:branch_id"`
+	BranchCode app.NullString `json:"branch.code"       db:"brc.code"          gorm:"-"`
+	BranchName app.NullString `json:"branch.name"       db:"brc.name"          gorm:"-"`
+
+	WorkOrderID     app.NullUUID   `json:"work_order.id"     db:"m.work_order_id"   gorm:"column:work_order_id"`
+	WorkOrderCode   app.NullString `json:"work_order.code"   db:"wo.code"           gorm:"-"`
+	WorkOrderStatus app.NullString `json:"work_order.status" db:"wo.status"         gorm:"-"`
+
+	CreatedAt app.NullDateTime `json:"created_at"        db:"m.created_at"      gorm:"column:created_at"`
+	UpdatedAt app.NullDateTime `json:"updated_at"        db:"m.updated_at"      gorm:"column:updated_at"`
+	DeletedAt app.NullDateTime `json:"deleted_at"        db:"m.deleted_at,hide" gorm:"column:deleted_at"`
+}
+
+// EndPoint returns the Incident end point, it used for cache key, etc.
+func (Incident) EndPoint() string {
+	return "incidents"
+}
+
+// TableVersion returns the versions of the Incident table in the database.
+// Change this value with date format YY.MM.DDHHii when any table structure changes.
+func (Incident) TableVersion() string {
+	return "26.10.192600"
+}
+
+// TableName returns the name of the Incident table in the database.
+func (Incident) TableName() string {
+	return "incidents"
+}
+
+// TableAliasName returns the table alias name of the Incident table, used for querying.
+func (Incident) TableAliasName() string {
+	return "m"
+}
+
+// GetRelations returns the relations of the Incident data in the database, used for querying.
+func (m *Incident) GetRelations() map[string]map[string]any {
+	m.AddRelation("left", "assets", "ass", []map[string]any{{"column1": "ass.id", "column2": "m.asset_id"}})
+	m.AddRelation("left", "employees", "emp", []map[string]any{{"column1": "emp.id", "column2": "m.employee_id"}})
+	m.AddRelation("left", "branches", "brc", []map[string]any{{"column1": "brc.id", "column2": "m.branch_id"}})
+	m.AddRelation("left", "work_orders", "wo", []map[string]any{{"column1": "wo.id", "column2": "m.work_order_id"}})
+	return m.Relations
+}
+
+// GetFilters returns the filter of the Incident data in the database, used for querying.
+func (m *Incident) GetFilters() []map[string]any {
+	m.AddFilter(map[string]any{"column1": "m.deleted_at", "operator": "=", "value": nil})
+	return m.Filters
+}
+
+// GetSorts returns the default sort of the Incident data in the database, used for querying.
+func (m *Incident) GetSorts() []map[string]any {
+	m.AddSort(map[string]any{"column": "m.reported_at", "direction": "desc"})
+	return m.Sorts
+}
+
+// GetFields returns list of the field of the Incident data in the database, used for querying.
+func (m *Incident) GetFields() map[string]map[string]any {
+	m.SetFields(m)
+	return m.Fields
+}
+
+// GetSchema returns the Incident schema, used for querying.
+func (m *Incident) GetSchema() map[string]any {
+	return m.SetSchema(m)
+}
+
+// OpenAPISchemaName returns the name of the Incident schema in the open api documentation.
+func (Incident) OpenAPISchemaName() string {
+	return "Incident"
+}
+
+// GetOpenAPISchema returns the Open API Schema of the Incident in the open api documentation.
+func (m *Incident) GetOpenAPISchema() map[string]any {
+	return m.SetOpenAPISchema(m)
+}
+
+type IncidentList struct {
+	app.ListModel
+}
+
+// OpenAPISchemaName returns the name of the IncidentList schema in the open api documentation.
+func (IncidentList) OpenAPISchemaName() string {
+	return "IncidentList"
+}
+
+// GetOpenAPISchema returns the Open API Schema of the IncidentList in the open api documentation.
+func (p *IncidentList) GetOpenAPISchema() map[string]any {
+	return p.SetOpenAPISchema(&Incident{})
+}
+
+// Types of the Incident data.
+const (
+	TypeDamage      = "damage"
+	TypeLoss        = "loss"
+	TypeMalfunction = "malfunction"
+)
+
+// Statuses of the Incident data, a converted, resolved or rejected incident is closed and can not be changed.
+const (
+	StatusNew       = "new"
+	StatusTriaged   = "triaged"
+	StatusConverted = "converted"
+	StatusResolved  = "resolved"
+	StatusRejected  = "rejected"
+)
+
+// Transitions are the allowed status changes of the Incident data.
+var Transitions = map[string][]string{
+	StatusNew:     {StatusTriaged, StatusConverted, StatusResolved, StatusRejected},
+	StatusTriaged: {StatusConverted, StatusResolved, StatusRejected},
+}
+
+// IsClosed returns true when the Incident data with the status can not be changed anymore.
+func IsClosed(status string) bool {
+	return status == StatusConverted || status == StatusResolved || status == StatusRejected
+}
+
+// ParamTriage is the expected parameters for triage the Incident data.
+type ParamTriage struct {
+	Status   app.NullString `json:"status"   validate:"required,oneof=triaged resolved rejected"`
+	Priority app.NullString `json:"priority" validate:"omitempty,oneof=low medium high critical"`
+	Note     app.NullText   `json:"note"`
+}
+
+// ParamConvert is the expected parameters for convert the Incident data into a WorkOrder data.
+type ParamConvert struct {
+	MaintenanceTypeID app.NullUUID `json:"maintenance_type.id" validate:"required"`
+	DueDate           app.NullDate `json:"due_date"            validate:"required"`
+	TechnicianID      app.NullUUID `json:"technician.id"`
+	VendorID          app.NullUUID `json:"vendor.id"`
+}
+
+// ParamCreate is the expected parameters for create a new Incident data.
+type ParamCreate struct {
+	UseCaseHandler
+	Type        app.NullString `json:"type"        db:"m.type"        gorm:"column:type"        validate:"required,oneof=damage loss malfunction"`
+	Description app.NullText   `json:"description" db:"m.description" gorm:"column:description" validate:"required"`
+	AssetID     app.NullUUID   `json:"asset.id"    db:"m.asset_id"    gorm:"column:asset_id"    validate:"required"`
+	EmployeeID  app.NullUUID   `json:"employee.id" db:"m.employee_id" gorm:"column:employee_id" validate:"required"`
+}
+
+// ParamUpdate is the expected parameters for update the Incident data.
+type ParamUpdate struct {
+	UseCaseHandler
+}
+
+// ParamPartiallyUpdate is the expected parameters for partially update the Incident data.
+type ParamPartiallyUpdate struct {
+	UseCaseHandler
+}
+
+// ParamDelete is the expected parameters for delete the Incident data.
+type ParamDelete struct {
+	UseCaseHandler
+}
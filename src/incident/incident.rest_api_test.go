@@ -0,0 +1,214 @@
+package incident
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gofiber/fiber/v2/utils"
+	"gorm.io/gorm"
+
+	"github.com/maulanar/go_asset_tracking_management/app"
+	"github.com/maulanar/go_asset_tracking_management/src/asset"
+	"github.com/maulanar/go_asset_tracking_management/src/attachment"
+	"github.com/maulanar/go_asset_tracking_management/src/branch"
+	"github.com/maulanar/go_asset_tracking_management/src/category"
+	"github.com/maulanar/go_asset_tracking_management/src/condition"
+	"github.com/maulanar/go_asset_tracking_management/src/department"
+	"github.com/maulanar/go_asset_tracking_management/src/employee"
+	"github.com/maulanar/go_asset_tracking_management/src/employeeasset"
+	"github.com/maulanar/go_asset_tracking_management/src/goodsreceipt"
+	"github.com/maulanar/go_asset_tracking_management/src/jobposition"
+	"github.com/maulanar/go_asset_tracking_management/src/location"
+	"github.com/maulanar/go_asset_tracking_management/src/notification"
+	"github.com/maulanar/go_asset_tracking_management/src/purchaseorder"
+	"github.com/maulanar/go_asset_tracking_management/src/tag"
+	"github.com/maulanar/go_asset_tracking_management/src/warranty"
+	"github.com/maulanar/go_asset_tracking_management/src/workorder"
+)
+
+// prepareTest prepares the test.
+func prepareTest(tb testing.TB) {
+	app.Test()
+	tx := app.Test().Tx
+	app.DB().RegisterTable("main", Incident{})
+	app.DB().MigrateTable(tx, "main", app.Setting{})
+	tx.Session(&gorm.Session{AllowGlobalUpdate: true}).Delete(&Incident{})
+
+	app.Server().AddMiddleware(app.Test().NewCtx([]string{
+		"incidents.detail",
+		"incidents.list",
+		"incidents.create",
+		"incidents.edit",
+		"incidents.delete",
+	}))
+	app.Server().AddRoute("/incidents", "POST", REST().Create, nil)
+	app.Server().AddRoute("/incidents", "GET", REST().Get, nil)
+	app.Server().AddRoute("/incidents/:id", "GET", REST().GetByID, nil)
+	app.Server().AddRoute("/incidents/:id", "PUT", REST().UpdateByID, nil)
+	app.Server().AddRoute("/incidents/:id", "PATCH", REST().PartiallyUpdateByID, nil)
+	app.Server().AddRoute("/incidents/:id", "DELETE", REST().DeleteByID, nil)
+}
+
+// getTestIncidentID returns an available Incident ID.
+func getTestIncidentID() string {
+	return "todo"
+}
+
+// tests is test scenario.
+var tests = []struct {
+	description  string // description of the test case
+	method       string // method to test
+	path         string // route path to test
+	token        string // token to test
+	bodyRequest  string // body to test
+	expectedCode int    // expected HTTP status code
+	expectedBody string // expected body response
+}{
+	{
+		description:  "Get empty list of Incident",
+		method:       "GET",
+		path:         "/incidents",
+		token:        app.TestFullAccessToken,
+		expectedCode: http.StatusOK,
+		expectedBody: `{"count":0,"results":[]}`,
+	},
+	{
+		description:  "Create Incident with minimum payload",
+		method:       "POST",
+		path:         "/incidents",
+		token:        app.TestFullAccessToken,
+		bodyRequest:  `{"name":"Kilogram"}`,
+		expectedCode: http.StatusCreated,
+		expectedBody: `{"name":"Kilogram"}`,
+	},
+	{
+		description:  "Get Incident by ID",
+		method:       "GET",
+		path:         "/incidents/" + getTestIncidentID(),
+		token:        app.TestFullAccessToken,
+		expectedCode: http.StatusOK,
+		expectedBody: `{"name":"Kilogram"}`,
+	},
+	{
+		description:  "Update Incident by ID",
+		method:       "PUT",
+		path:         "/incidents/" + getTestIncidentID(),
+		token:        app.TestFullAccessToken,
+		bodyRequest:  `{"reason":"Update Incident by ID","name":"KG"}`,
+		expectedCode: http.StatusOK,
+		expectedBody: `{"name":"KG"}`,
+	},
+	{
+		description:  "Partially update Incident by ID",
+		method:       "PATCH",
+		path:         "/incidents/" + getTestIncidentID(),
+		token:        app.TestFullAccessToken,
+		bodyRequest:  `{"reason":"Partially Update Incident by ID","name":"Kilo Gram"}`,
+		expectedCode: http.StatusOK,
+		expectedBody: `{"name":"Kilo Gram"}`,
+	},
+	{
+		description:  "Delete Incident by ID",
+		method:       "DELETE",
+		path:         "/incidents/" + getTestIncidentID(),
+		token:        app.TestFullAccessToken,
+		bodyRequest:  `{"reason":"Delete Incident by ID"}`,
+		expectedCode: http.StatusOK,
+		expectedBody: `{"code":200}`,
+	},
+}
+
+// TestIncidentREST tests the REST API of Incident data with specified scenario.
+func TestIncidentREST(t *testing.T) {
+	prepareTest(t)
+
+	// Iterate through test single test cases
+	for _, test := range tests {
+
+		// Create a new http request with the route from the test case
+		req := httptest.NewRequest(test.method, test.path, strings.NewReader(test.bodyRequest))
+		req.Header.Add("Authorization", "Bearer "+test.token)
+		req.Header.Add("Content-Type", "application/json")
+
+		// Perform the request plain with the app, the second argument is a request latency (set to -1 for no latency)
+		res, err := app.Server().Test(req)
+
+		// Verify if the status code is as expected
+		utils.AssertEqual(t, nil, err, "app.Server().Test(req)")
+		utils.AssertEqual(t, test.expectedCode, res.StatusCode, test.description)
+
+		// Verify if the body response is as expected
+		body, err := io.ReadAll(res.Body)
+		utils.AssertEqual(t, nil, err, "io.ReadAll(res.Body)")
+		app.Test().AssertMatchJSONElement(t, []byte(test.expectedBody), body, test.description)
+		res.Body.Close()
+	}
+}
+
+// TestIncidentCreateHeldAsset tests that an employee reports an incident of the asset they currently hold.
+func TestIncidentCreateHeldAsset(t *testing.T) {
+	prepareTest(t)
+	tx := app.Test().Tx
+	app.DB().RegisterTable("main", asset.Asset{})
+	app.DB().RegisterTable("main", attachment.Attachment{})
+	app.DB().RegisterTable("main", branch.Branch{})
+	app.DB().RegisterTable("main", category.Category{})
+	app.DB().RegisterTable("main", condition.Condition{})
+	app.DB().RegisterTable("main", department.Department{})
+	app.DB().RegisterTable("main", employee.Employee{})
+	app.DB().RegisterTable("main", employeeasset.EmployeeAsset{})
+	app.DB().RegisterTable("main", goodsreceipt.GoodsReceipt{})
+	app.DB().RegisterTable("main", jobposition.JobPosition{})
+	app.DB().RegisterTable("main", location.Location{})
+	app.DB().RegisterTable("main", notification.Notification{})
+	app.DB().RegisterTable("main", purchaseorder.PurchaseOrder{})
+	app.DB().RegisterTable("main", purchaseorder.PurchaseOrderLine{})
+	app.DB().RegisterTable("main", tag.Tag{})
+	app.DB().RegisterTable("main", tag.AssetTag{})
+	app.DB().RegisterTable("main", warranty.Warranty{})
+	app.DB().RegisterTable("main", workorder.WorkOrder{})
+	app.DB().MigrateTable(tx, "main", app.Setting{})
+
+	brc := branch.Branch{ID: app.NewNullUUID(), Code: app.NewNullString("BRC-INC"), Name: app.NewNullString("Incident Branch")}
+	emp := employee.Employee{ID: app.NewNullUUID(), Code: app.NewNullString("EMP-INC"), Name: app.NewNullString("Incident Reporter"), BranchID: brc.ID}
+	ass := asset.Asset{ID: app.NewNullUUID(), Code: app.NewNullString("AST-INC"), Name: app.NewNullString("Incident Laptop")}
+	ea := employeeasset.EmployeeAsset{ID: app.NewNullUUID(), AssetID: ass.ID, EmployeeID: emp.ID, AssignDate: app.NewNullDate(time.Now())}
+	for _, data := range []any{&brc, &emp, &ass, &ea} {
+		err := tx.Create(data).Error
+		utils.AssertEqual(t, nil, err, "tx.Create(data)")
+	}
+
+	body := `{"type":"damage","description":"Broken screen","asset.id":"` + ass.ID.String + `","employee.id":"` + emp.ID.String + `"}`
+	req := httptest.NewRequest("POST", "/incidents", strings.NewReader(body))
+	req.Header.Add("Authorization", "Bearer "+app.TestFullAccessToken)
+	req.Header.Add("Content-Type", "application/json")
+	res, err := app.Server().Test(req)
+	utils.AssertEqual(t, nil, err, "app.Server().Test(req)")
+	utils.AssertEqual(t, http.StatusCreated, res.StatusCode, "Create Incident of the held asset")
+	res.Body.Close()
+
+	inc := Incident{}
+	err = tx.Where("asset_id = ?", ass.ID).First(&inc).Error
+	utils.AssertEqual(t, nil, err, "tx.First(&inc)")
+	utils.AssertEqual(t, emp.ID.String, inc.EmployeeID.String, "employee of the incident")
+	utils.AssertEqual(t, brc.ID.String, inc.BranchID.String, "branch of the incident")
+	utils.AssertEqual(t, StatusNew, inc.Status.String, "status of the incident")
+}
+
+// BenchmarkIncidentREST tests the REST API of Incident data with specified scenario.
+func BenchmarkIncidentREST(b *testing.B) {
+	b.ReportAllocs()
+	prepareTest(b)
+	for i := 0; i < b.N; i++ {
+		for _, test := range tests {
+			req := httptest.NewRequest(test.method, test.path, strings.NewReader(test.bodyRequest))
+			req.Header.Add("Authorization", "Bearer "+test.token)
+			req.Header.Add("Content-Type", "application/json")
+			app.Server().Test(req)
+		}
+	}
+}
@@ -0,0 +1,601 @@
+package incident
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"slices"
+	"time"
+
+	"grest.dev/grest"
+
+	"github.com/maulanar/go_asset_tracking_management/app"
+	"github.com/maulanar/go_asset_tracking_management/src/asset"
+	"github.com/maulanar/go_asset_tracking_management/src/attachment"
+	"github.com/maulanar/go_asset_tracking_management/src/employee"
+	"github.com/maulanar/go_asset_tracking_management/src/employeeasset"
+	"github.com/maulanar/go_asset_tracking_management/src/notification"
+	"github.com/maulanar/go_asset_tracking_management/src/workorder"
+)
+
+// UseCase returns a UseCaseHandler for expected use case functional.
+func UseCase(ctx app.Ctx, query ...url.Values) UseCaseHandler {
+	u := UseCaseHandler{
+		Ctx:   &ctx,
+		Query: url.Values{},
+	}
+	if len(query) > 0 {
+		u.Query = query[0]
+	}
+	return u
+}
+
+// UseCaseHandler provides a convenient interface for Incident use case, use UseCase to access UseCaseHandler.
+type UseCaseHandler struct {
+	Incident
+
+	// injectable dependencies
+	Ctx   *app.Ctx   `json:"-" db:"-" gorm:"-"`
+	Query url.Values `json:"-" db:"-" gorm:"-"`
+}
+
+// Async return UseCaseHandler with async process.
+func (u UseCaseHandler) Async(ctx app.Ctx, query ...url.Values) UseCaseHandler {
+	ctx.IsAsync = true
+	return UseCase(ctx, query...)
+}
+
+// GetByID returns the Incident data for the specified ID.
+func (u UseCaseHandler) GetByID(id string) (Incident, error) {
+	res := Incident{}
+
+	// check permission
+	err := u.Ctx.ValidatePermission("incidents.detail")
+	if err != nil {
+		return res, err
+	}
+
+	// get from cache and return if exists
+	cacheKey := u.EndPoint() + "." + id
+	app.Cache().Get(cacheKey, &res)
+	if res.ID.Valid {
+		return res, err
+	}
+
+	// prepare db for current ctx
+	tx, err := u.Ctx.DB()
+	if err != nil {
+		return res, app.Error().New(http.StatusInternalServerError, err.Error())
+	}
+
+	// get from db
+	key := "id"
+	if !app.Validator().IsValid(id, "uuid") {
+		key = "code"
+	}
+	u.Query.Add(key, id)
+	err = app.Query().First(tx, &res, u.Query)
+	if err != nil {
+		return res, u.Ctx.NotFoundError(err, u.EndPoint(), key, id)
+	}
+
+	// get photos
+	res.Photos, err = u.GetPhotos(res.ID.String)
+	if err != nil {
+		return res, err
+	}
+
+	// save to cache and return if exists
+	app.Cache().Set(cacheKey, res)
+	return res, err
+}
+
+// Get returns the list of Incident data.
+func (u UseCaseHandler) Get() (app.ListModel, error) {
+	res := app.ListModel{}
+
+	// check permission
+	err := u.Ctx.ValidatePermission("incidents.list")
+	if err != nil {
+		return res, err
+	}
+	// get from cache and return if exists
+	cacheKey := u.EndPoint() + "?" + u.Query.Encode()
+	err = app.Cache().Get(cacheKey, &res)
+	if err == nil {
+		return res, err
+	}
+
+	// prepare db for current ctx
+	tx, err := u.Ctx.DB()
+	if err != nil {
+		return res, app.Error().New(http.StatusInternalServerError, err.Error())
+	}
+
+	// set pagination info
+	res.Results.PageContext.Count,
+		res.Results.PageContext.Page,
+		res.Results.PageContext.PerPage,
+		res.Results.PageContext.PageCount,
+		err = app.Query().PaginationInfo(tx, &Incident{}, u.Query)
+	if err != nil {
+		return res, app.Error().New(http.StatusInternalServerError, err.Error())
+	}
+	// return data count if $per_page set to 0
+	if res.Results.PageContext.PerPage == 0 {
+		return res, err
+	}
+
+	// find data
+	data, err := app.Query().Find(tx, &Incident{}, u.Query)
+	if err != nil {
+		return res, app.Error().New(http.StatusInternalServerError, err.Error())
+	}
+	res.SetData(data, u.Query)
+
+	// save to cache and return if exists
+	app.Cache().Set(cacheKey, res)
+	return res, err
+}
+
+// Create creates a new data Incident with specified parameters.
+func (u *UseCaseHandler) Create(p *ParamCreate) error {
+
+	// check permission
+	err := u.Ctx.ValidatePermission("incidents.create")
+	if err != nil {
+		return err
+	}
+
+	// validate param
+	err = u.Ctx.ValidateParam(p)
+	if err != nil {
+		return err
+	}
+
+	// set default value for undefined field
+	err = u.setDefaultValue(Incident{})
+	if err != nil {
+		return err
+	}
+
+	// prepare db for current ctx
+	tx, err := u.Ctx.DB()
+	if err != nil {
+		return app.Error().New(http.StatusInternalServerError, err.Error())
+	}
+
+	// save data to db
+	err = tx.Model(&u).Create(&u).Error
+	if err != nil {
+		return app.Error().New(http.StatusInternalServerError, err.Error())
+	}
+
+	// notify the admin of the branch of the asset
+	n := notification.Notification{}
+	n.Type.Set("incident_reported")
+	n.Title.Set("Asset incident reported")
+	n.Message.Set(fmt.Sprintf("%s reported %s of asset %s (%s): %s", u.EmployeeName.String, u.Type.String, u.AssetName.String, u.AssetCode.String, u.Description.String))
+	n.Endpoint.Set(u.EndPoint())
+	n.DataID.Set(u.ID.String)
+	n.BranchID = u.BranchID
+	err = notification.Notify(tx, n)
+	if err != nil {
+		return err
+	}
+
+	// invalidate cache
+	app.Cache().Invalidate(u.EndPoint())
+
+	// save history (user activity), send webhook, etc
+	go u.Ctx.Hook("POST", "create", p.ID.String, p)
+	return nil
+}
+
+// UpdateByID updates the Incident data for the specified ID with specified parameters.
+func (u UseCaseHandler) UpdateByID(id string, p *ParamUpdate) error {
+
+	// check permission
+	err := u.Ctx.ValidatePermission("incidents.edit")
+	if err != nil {
+		return err
+	}
+
+	// validate param
+	err = u.Ctx.ValidateParam(p)
+	if err != nil {
+		return err
+	}
+
+	// get previous data
+	old, err := u.GetByID(id)
+	if err != nil {
+		return err
+	}
+
+	// set default value for undefined field
+	err = u.setDefaultValue(old)
+	if err != nil {
+		return err
+	}
+
+	// prepare db for current ctx
+	tx, err := u.Ctx.DB()
+	if err != nil {
+		return app.Error().New(http.StatusInternalServerError, err.Error())
+	}
+
+	// update data on the db
+	err = tx.Model(&u).Where("id = ?", old.ID).Updates(u).Error
+	if err != nil {
+		return app.Error().New(http.StatusInternalServerError, err.Error())
+	}
+
+	// invalidate cache
+	app.Cache().Invalidate(u.EndPoint(), old.ID.String)
+
+	// save history (user activity), send webhook, etc
+	go u.Ctx.Hook("PUT", "Update", old.ID.String, old)
+	return nil
+}
+
+// PartiallyUpdateByID updates the Incident data for the specified ID with specified parameters.
+func (u UseCaseHandler) PartiallyUpdateByID(id string, p *ParamPartiallyUpdate) error {
+
+	// check permission
+	err := u.Ctx.ValidatePermission("incidents.edit")
+	if err != nil {
+		return err
+	}
+
+	// validate param
+	err = u.Ctx.ValidateParam(p)
+	if err != nil {
+		return err
+	}
+
+	// get previous data
+	old, err := u.GetByID(id)
+	if err != nil {
+		return err
+	}
+
+	// set default value for undefined field
+	err = u.setDefaultValue(old)
+	if err != nil {
+		return err
+	}
+
+	// prepare db for current ctx
+	tx, err := u.Ctx.DB()
+	if err != nil {
+		return app.Error().New(http.StatusInternalServerError, err.Error())
+	}
+
+	// update data on the db
+	err = tx.Model(&u).Where("id = ?", old.ID).Updates(u).Error
+	if err != nil {
+		return app.Error().New(http.StatusInternalServerError, err.Error())
+	}
+
+	// invalidate cache
+	app.Cache().Invalidate(u.EndPoint(), old.ID.String)
+
+	// save history (user activity), send webhook, etc
+	go u.Ctx.Hook("PATCH", "Partially Update", old.ID.String, old)
+	return nil
+}
+
+// DeleteByID deletes the Incident data for the specified ID.
+func (u UseCaseHandler) DeleteByID(id string, p *ParamDelete) error {
+
+	// check permission
+	err := u.Ctx.ValidatePermission("incidents.delete")
+	if err != nil {
+		return err
+	}
+
+	// validate param
+	err = u.Ctx.ValidateParam(p)
+	if err != nil {
+		return err
+	}
+
+	// get previous data
+	old, err := u.GetByID(id)
+	if err != nil {
+		return err
+	}
+	if old.Status.String != StatusNew {
+		return app.Error().New(http.StatusBadRequest, u.Ctx.Trans("incident_not_deletable", map[string]string{
+			"code":   old.Code.String,
+			"status": old.Status.String,
+		}))
+	}
+
+	// prepare db for current ctx
+	tx, err := u.Ctx.DB()
+	if err != nil {
+		return app.Error().New(http.StatusInternalServerError, err.Error())
+	}
+
+	// update data on the db
+	err = tx.Model(&p).Where("id = ?", old.ID).Update("deleted_at", time.Now().UTC()).Error
+	if err != nil {
+		return app.Error().New(http.StatusInternalServerError, err.Error())
+	}
+
+	// invalidate cache
+	app.Cache().Invalidate(u.EndPoint(), old.ID.String)
+
+	// save history (user activity), send webhook, etc
+	go u.Ctx.Hook("DELETE", "DELETE", old.ID.String, old)
+	return nil
+}
+
+// TriageByID triages the Incident data for the specified ID, the incident is triaged, resolved or rejected with a note.
+func (u UseCaseHandler) TriageByID(id string, p *ParamTriage) error {
+	err := u.Ctx.ValidateParam(p)
+	if err != nil {
+		return err
+	}
+	return u.setStatusByID(id, "incidents.triage", p.Status.String, func(old Incident, fields map[string]any) error {
+		if p.Priority.Valid {
+			fields["priority"] = p.Priority
+		}
+		if p.Note.Valid {
+			fields["triage_note"] = p.Note
+		}
+		return nil
+	})
+}
+
+// ConvertByID converts the Incident data for the specified ID into a new WorkOrder data of the asset in one step,
+// the description of the incident becomes the problem of the work order. A lost asset can not be repaired.
+func (u UseCaseHandler) ConvertByID(id string, p *ParamConvert) error {
+	err := u.Ctx.ValidateParam(p)
+	if err != nil {
+		return err
+	}
+	return u.setStatusByID(id, "incidents.convert", StatusConverted, func(old Incident, fields map[string]any) error {
+		if old.Type.String == TypeLoss {
+			return app.Error().New(http.StatusBadRequest, u.Ctx.Trans("incident_not_convertible", map[string]string{"code": old.Code.String}))
+		}
+
+		woUC := workorder.UseCase(*u.Ctx, url.Values{})
+		woUC.AssetID = old.AssetID
+		woUC.MaintenanceTypeID = p.MaintenanceTypeID
+		woUC.DueDate = p.DueDate
+		woUC.Priority = old.Priority
+		woUC.Problem = old.Description
+		woUC.Description.Set("Incident " + old.Code.String)
+		woUC.TechnicianID = p.TechnicianID
+		woUC.VendorID = p.VendorID
+		err := woUC.Create(&workorder.ParamCreate{
+			UseCaseHandler:    woUC,
+			AssetID:           woUC.AssetID,
+			MaintenanceTypeID: woUC.MaintenanceTypeID,
+			DueDate:           woUC.DueDate,
+		})
+		if err != nil {
+			return err
+		}
+		fields["work_order_id"] = woUC.ID
+		return nil
+	})
+}
+
+// setStatusByID changes the status of the Incident data for the specified ID when the transition is allowed,
+// the time of the transition is recorded. The prepare func adds the fields of the transition.
+func (u UseCaseHandler) setStatusByID(id, aclKey, to string, prepare func(old Incident, fields map[string]any) error) error {
+
+	// check permission
+	err := u.Ctx.ValidatePermission(aclKey)
+	if err != nil {
+		return err
+	}
+
+	// get previous data
+	old, err := u.GetByID(id)
+	if err != nil {
+		return err
+	}
+	if !slices.Contains(Transitions[old.Status.String], to) {
+		return app.Error().New(http.StatusBadRequest, u.Ctx.Trans("incident_transition_invalid", map[string]string{
+			"code": old.Code.String,
+			"from": old.Status.String,
+			"to":   to,
+		}))
+	}
+
+	now := time.Now().UTC()
+	fields := map[string]any{
+		"status":     to,
+		"updated_at": now,
+	}
+	if !old.TriagedAt.Valid {
+		fields["triaged_at"] = now
+	}
+	if IsClosed(to) {
+		fields["closed_at"] = now
+	}
+	err = prepare(old, fields)
+	if err != nil {
+		return err
+	}
+
+	// prepare db for current ctx
+	tx, err := u.Ctx.DB()
+	if err != nil {
+		return app.Error().New(http.StatusInternalServerError, err.Error())
+	}
+
+	// update data on the db
+	err = tx.Model(&Incident{}).Where("id = ?", old.ID).Updates(fields).Error
+	if err != nil {
+		return app.Error().New(http.StatusInternalServerError, err.Error())
+	}
+
+	// invalidate cache
+	app.Cache().Invalidate(u.EndPoint(), old.ID.String)
+
+	// save history (user activity), send webhook, etc
+	go u.Ctx.Hook("POST", to, old.ID.String, old)
+	return nil
+}
+
+// GetPhotos returns the photos of the Incident data for the specified incident ID.
+func (u UseCaseHandler) GetPhotos(incidentID string) ([]attachment.Attachment, error) {
+	res := []attachment.Attachment{}
+
+	// prepare db for current ctx
+	tx, err := u.Ctx.DB()
+	if err != nil {
+		return res, app.Error().New(http.StatusInternalServerError, err.Error())
+	}
+
+	query := url.Values{}
+	query.Add("endpoint", u.EndPoint())
+	query.Add("data_id", incidentID)
+	query.Add(grest.QueryDisablePagination, "true")
+	data, err := app.Query().Find(tx, &attachment.Attachment{}, query)
+	if err != nil {
+		return res, app.Error().New(http.StatusInternalServerError, err.Error())
+	}
+
+	jByte, err := json.Marshal(data)
+	if err != nil {
+		return res, app.Error().New(http.StatusInternalServerError, err.Error())
+	}
+	err = app.BindJSON(jByte, &res)
+	if err != nil {
+		return res, app.Error().New(http.StatusInternalServerError, err.Error())
+	}
+	return res, nil
+}
+
+// setDefaultValue set default value of undefined field when create or update Incident data.
+func (u *UseCaseHandler) setDefaultValue(old Incident) error {
+	if !old.ID.Valid {
+		u.ID = app.NewNullUUID()
+	} else {
+		u.ID = old.ID
+	}
+
+	// a closed incident can not be changed, and the status is only changed by its triage or conversion
+	if old.ID.Valid && IsClosed(old.Status.String) {
+		return app.Error().New(http.StatusBadRequest, u.Ctx.Trans("incident_closed", map[string]string{
+			"code":   old.Code.String,
+			"status": old.Status.String,
+		}))
+	}
+	if u.Status.Valid && u.Status.String != old.Status.String && (old.ID.Valid || u.Status.String != StatusNew) {
+		return app.Error().New(http.StatusBadRequest, u.Ctx.Trans("incident_status_invalid", map[string]string{
+			"code":   old.Code.String,
+			"status": u.Status.String,
+		}))
+	}
+	if old.ID.Valid && ((u.AssetID.Valid && u.AssetID.String != old.AssetID.String) ||
+		(u.EmployeeID.Valid && u.EmployeeID.String != old.EmployeeID.String)) {
+		return app.Error().New(http.StatusBadRequest, u.Ctx.Trans("incident_asset_immutable", map[string]string{"code": old.Code.String}))
+	}
+	if old.ID.Valid {
+		u.Status = old.Status
+		u.AssetID, u.EmployeeID, u.BranchID = old.AssetID, old.EmployeeID, old.BranchID
+	}
+
+	// Penentuan kode
+	if u.Code.Valid && u.Code.String != "" {
+		// Jika kode dikirim dan berbeda dengan data lama, cek ke DB
+		if !old.Code.Valid || u.Code.String != old.Code.String {
+			err := app.Common().IsFieldValueExists(u.Ctx, u.EndPoint(), "Code", u.TableName(), "code", u.Code.String)
+			if err != nil {
+				return err
+			}
+		}
+	} else {
+		// Jika tidak kirim kode dan data lama ada, gunakan data lama
+		if old.Code.Valid && old.Code.String != "" {
+			u.Code = old.Code
+		} else {
+			// Jika tidak kirim kode dan data lama tidak ada, generate baru
+			newCode, err := app.Common().GenerateCode(u.Ctx, u.TableName(), "code", "Incident")
+			if err != nil {
+				return err
+			}
+			u.Code.Set(newCode)
+		}
+	}
+
+	// a new incident is reported by the employee who holds the asset, it is raised to the branch of the employee
+	if !old.ID.Valid {
+		err := u.validateHolder()
+		if err != nil {
+			return err
+		}
+		u.Status.Set(StatusNew)
+		if !u.Priority.Valid || u.Priority.String == "" {
+			u.Priority.Set(workorder.PriorityMedium)
+		}
+		if !u.ReportedAt.Valid {
+			u.ReportedAt.Set(time.Now().UTC())
+		}
+	}
+
+	// link the photos to the incident
+	for _, photo := range u.Photos {
+		attUC := attachment.UseCase(*u.Ctx, url.Values{})
+		att, err := attUC.GetByID(photo.ID.String)
+		if err != nil {
+			return err
+		}
+		attUC.Endpoint.Set(u.EndPoint())
+		attUC.DataId.Set(u.ID.String)
+		err = attUC.UpdateByID(att.ID.String, &attachment.ParamUpdate{})
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// validateHolder validates that the asset of the new Incident data is currently assigned to the reporting employee.
+func (u *UseCaseHandler) validateHolder() error {
+	ass, err := asset.UseCase(*u.Ctx, url.Values{}).GetByID(u.AssetID.String)
+	if err != nil {
+		return err
+	}
+	emp, err := employee.UseCase(*u.Ctx, url.Values{}).GetByID(u.EmployeeID.String)
+	if err != nil {
+		return err
+	}
+
+	// prepare db for current ctx
+	tx, err := u.Ctx.DB()
+	if err != nil {
+		return app.Error().New(http.StatusInternalServerError, err.Error())
+	}
+
+	var count int64
+	err = tx.Model(&employeeasset.EmployeeAsset{}).
+		Where("asset_id = ?", ass.ID).
+		Where("employee_id = ?", emp.ID).
+		Where("return_date IS NULL").
+		Where("deleted_at IS NULL").
+		Count(&count).Error
+	if err != nil {
+		return app.Error().New(http.StatusInternalServerError, err.Error())
+	}
+	if count == 0 {
+		return app.Error().New(http.StatusBadRequest, u.Ctx.Trans("incident_asset_not_held", map[string]string{
+			"asset":    ass.Code.String,
+			"employee": emp.Name.String,
+		}))
+	}
+	u.AssetID, u.AssetCode, u.AssetName = ass.ID, ass.Code, ass.Name
+	u.EmployeeID, u.EmployeeName = emp.ID, emp.Name
+	u.BranchID = emp.BranchID
+	return nil
+}
@@ -0,0 +1,2 @@
+// jobrun is a package related to jobrun data.
+package jobrun
@@ -0,0 +1,123 @@
+package jobrun
+
+import "github.com/maulanar/go_asset_tracking_management/app"
+
+// JobRun is the main model of JobRun data. It provides a convenient interface for app.ModelInterface
+type JobRun struct {
+	app.Model
+	ID             app.NullUUID     `json:"id"              db:"m.id"              gorm:"column:id;primaryKey"`
+	Name           app.NullString   `json:"name"            db:"m.name"            gorm:"column:name"`
+	Status         app.NullString   `json:"status"          db:"m.status"          gorm:"column:status;default:running"`
+	StartedAt      app.NullDateTime `json:"started_at"      db:"m.started_at"      gorm:"column:started_at"`
+	FinishedAt     app.NullDateTime `json:"finished_at"     db:"m.finished_at"     gorm:"column:finished_at"`
+	DurationMs     app.NullInt64    `json:"duration_ms"     db:"m.duration_ms"     gorm:"column:duration_ms"`
+	ProcessedCount app.NullInt64    `json:"processed_count" db:"m.processed_count" gorm:"column:processed_count"`
+	UpdatedCount   app.NullInt64    `json:"updated_count"   db:"m.updated_count"   gorm:"column:updated_count"`
+	SkippedCount   app.NullInt64    `json:"skipped_count"   db:"m.skipped_count"   gorm:"column:skipped_count"`
+	FailedCount    app.NullInt64    `json:"failed_count"    db:"m.failed_count"    gorm:"column:failed_count"`
+	Message        app.NullText     `json:"message"         db:"m.message"         gorm:"column:message"`
+	CreatedAt      app.NullDateTime `json:"created_at"      db:"m.created_at"      gorm:"column:created_at"`
+	UpdatedAt      app.NullDateTime `json:"updated_at"      db:"m.updated_at"      gorm:"column:updated_at"`
+	DeletedAt      app.NullDateTime `json:"deleted_at"      db:"m.deleted_at,hide" gorm:"column:deleted_at"`
+}
+
+// EndPoint returns the JobRun end point, it used for cache key, etc.
+func (JobRun) EndPoint() string {
+	return "job_runs"
+}
+
+// TableVersion returns the versions of the JobRun table in the database.
+// Change this value with date format YY.MM.DDHHii when any table structure changes.
+func (JobRun) TableVersion() string {
+	return "26.10.192100"
+}
+
+// TableName returns the name of the JobRun table in the database.
+func (JobRun) TableName() string {
+	return "job_runs"
+}
+
+// TableAliasName returns the table alias name of the JobRun table, used for querying.
+func (JobRun) TableAliasName() string {
+	return "m"
+}
+
+// GetRelations returns the relations of the JobRun data in the database, used for querying.
+func (m *JobRun) GetRelations() map[string]map[string]any {
+	return m.Relations
+}
+
+// GetFilters returns the filter of the JobRun data in the database, used for querying.
+func (m *JobRun) GetFilters() []map[string]any {
+	m.AddFilter(map[string]any{"column1": "m.deleted_at", "operator": "=", "value": nil})
+	return m.Filters
+}
+
+// GetSorts returns the default sort of the JobRun data in the database, used for querying.
+func (m *JobRun) GetSorts() []map[string]any {
+	m.AddSort(map[string]any{"column": "m.started_at", "direction": "desc"})
+	return m.Sorts
+}
+
+// GetFields returns list of the field of the JobRun data in the database, used for querying.
+func (m *JobRun) GetFields() map[string]map[string]any {
+	m.SetFields(m)
+	return m.Fields
+}
+
+// GetSchema returns the JobRun schema, used for querying.
+func (m *JobRun) GetSchema() map[string]any {
+	return m.SetSchema(m)
+}
+
+// OpenAPISchemaName returns the name of the JobRun schema in the open api documentation.
+func (JobRun) OpenAPISchemaName() string {
+	return "JobRun"
+}
+
+// GetOpenAPISchema returns the Open API Schema of the JobRun in the open api documentation.
+func (m *JobRun) GetOpenAPISchema() map[string]any {
+	return m.SetOpenAPISchema(m)
+}
+
+type JobRunList struct {
+	app.ListModel
+}
+
+// OpenAPISchemaName returns the name of the JobRunList schema in the open api documentation.
+func (JobRunList) OpenAPISchemaName() string {
+	return "JobRunList"
+}
+
+// GetOpenAPISchema returns the Open API Schema of the JobRunList in the open api documentation.
+func (p *JobRunList) GetOpenAPISchema() map[string]any {
+	return p.SetOpenAPISchema(&JobRun{})
+}
+
+// Statuses of the JobRun data, a run is partial when some of the rows failed and the rest were processed.
+const (
+	StatusRunning = "running"
+	StatusSuccess = "success"
+	StatusPartial = "partial"
+	StatusFailed  = "failed"
+)
+
+// ParamCreate is the expected parameters for create a new JobRun data.
+type ParamCreate struct {
+	UseCaseHandler
+}
+
+// ParamUpdate is the expected parameters for update the JobRun data.
+type ParamUpdate struct {
+	UseCaseHandler
+}
+
+// ParamPartiallyUpdate is the expected parameters for partially update the JobRun data.
+type ParamPartiallyUpdate struct {
+	UseCaseHandler
+}
+
+// ParamDelete is the expected parameters for delete the JobRun data.
+type ParamDelete struct {
+	UseCaseHandler
+}
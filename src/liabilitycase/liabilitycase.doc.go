@@ -0,0 +1,2 @@
+// liabilitycase is a package related to liabilitycase data.
+package liabilitycase
@@ -0,0 +1,258 @@
+package liabilitycase
+
+import "github.com/maulanar/go_asset_tracking_management/app"
+
+// LiabilityCase is the main model of LiabilityCase data. It provides a convenient interface for app.ModelInterface
+type LiabilityCase struct {
+	app.Model
+	ID           app.NullUUID   `json:"id"                         db:"m.id"                   gorm:"column:id;primaryKey"`
+	Code         app.NullString `json:"code"                       db:"m.code"                 gorm:"column:code"`
+	Type         app.NullString `json:"type"                       db:"m.type"                 gorm:"column:type"                             validate:"omitempty,oneof=loss theft damage"`
+	IncidentDate app.NullDate   `json:"incident_date"              db:"m.incident_date"        gorm:"column:incident_date"`
+	Description  app.NullText   `json:"description"                db:"m.description"          gorm:"column:description"`
+	Status       app.NullString `json:"status"                     db:"m.status"               gorm:"column:status;default:draft"             validate:"omitempty,oneof=draft approved rejected settled"`
+
+	// the book value of the asset on the incident date, the charge is the liable part of it unless it is decided otherwise
+	BookValue           app.NullFloat64 `json:"book_value"                 db:"m.book_value"           gorm:"column:book_value"`
+	LiabilityPercentage app.NullFloat64 `json:"liability_percentage"       db:"m.liability_percentage" gorm:"column:liability_percentage;default:100" validate:"omitempty,gte=0,lte=100"`
+	ChargeAmount        app.NullFloat64 `json:"charge_amount"              db:"m.charge_amount"        gorm:"column:charge_amount"                    validate:"omitempty,gte=0"`
+	Decision            app.NullText    `json:"decision"                   db:"m.decision"             gorm:"column:decision"`
+	PaidAmount          app.NullFloat64 `json:"paid_amount"                db:"m.paid_amount"          gorm:"column:paid_amount"`
+	OutstandingAmount   app.NullFloat64 `json:"outstanding_amount"         db:"m.outstanding_amount"   gorm:"column:outstanding_amount"`
+
+	// the charge is repaid in monthly installments starting on the first due date, the schedule is generated on the approval
+	Installments app.NullInt64          `json:"installments"               db:"m.installments"         gorm:"column:installments;default:1"           validate:"omitempty,gt=0"`
+	FirstDueDate app.NullDate           `json:"first_due_date"             db:"m.first_due_date"       gorm:"column:first_due_date"`
+	Schedule     []LiabilityInstallment `json:"schedule"                   db:"-"                      gorm:"-"`
+
+	ApprovalNote app.NullText     `json:"approval_note"              db:"m.approval_note"        gorm:"column:approval_note"`
+	ApprovedAt   app.NullDateTime `json:"approved_at"                db:"m.approved_at"          gorm:"column:approved_at"`
+	RejectedAt   app.NullDateTime `json:"rejected_at"                db:"m.rejected_at"          gorm:"column:rejected_at"`
+	SettledAt    app.NullDateTime `json:"settled_at"                 db:"m.settled_at"           gorm:"column:settled_at"`
+
+	EmployeeAssetID         app.NullUUID `json:"employee_asset.id"          db:"m.employee_asset_id"    gorm:"column:employee_asset_id"`
+	EmployeeAssetAssignDate app.NullDate `json:"employee_asset.assign_date" db:"ea.assign_date"         gorm:"-"`
+	EmployeeAssetReturnDate app.NullDate `json:"employee_asset.return_date" db:"ea.return_date"         gorm:"-"`
+
+	AssetID    app.NullUUID    `json:"asset.id"                   db:"m.asset_id"             gorm:"column:asset_id"`
+	AssetCode  app.NullString  `json:"asset.code"                 db:"ass.code"               gorm:"-"`
+	AssetName  app.NullString  `json:"asset.name"                 db:"ass.name"               gorm:"-"`
+	AssetPrice app.NullFloat64 `json:"asset.price"                db:"ass.price"              gorm:"-"`
+
+	EmployeeID             app.NullUUID   `json:"employee.id"                db:"m.employee_id"          gorm:"column:employee_id"`
+	EmployeeCode           app.NullString `json:"employee.code"              db:"emp.code"               gorm:"-"`
+	EmployeeName           app.NullString `json:"employee.name"              db:"emp.name"               gorm:"-"`
+	EmployeeDepartmentName app.NullString `json:"employee.department.name"   db:"emp_dept.name"          gorm:"-"`
+	EmployeeBranchName     app.NullString `json:"employee.branch.name"       db:"emp_brc.name"           gorm:"-"`
+
+	CreatedAt app.NullDateTime `json:"created_at"                 db:"m.created_at"           gorm:"column:created_at"`
+	UpdatedAt app.NullDateTime `json:"updated_at"                 db:"m.updated_at"           gorm:"column:updated_at"`
+	DeletedAt app.NullDateTime `json:"deleted_at"                 db:"m.deleted_at,hide"      gorm:"column:deleted_at"`
+}
+
+// EndPoint returns the LiabilityCase end point, it used for cache key, etc.
+func (LiabilityCase) EndPoint() string {
+	return "liability_cases"
+}
+
+// TableVersion returns the versions of the LiabilityCase table in the database.
+// Change this value with date format YY.MM.DDHHii when any table structure changes.
+func (LiabilityCase) TableVersion() string {
+	return "26.10.192700"
+}
+
+// TableName returns the name of the LiabilityCase table in the database.
+func (LiabilityCase) TableName() string {
+	return "liability_cases"
+}
+
+// TableAliasName returns the table alias name of the LiabilityCase table, used for querying.
+func (LiabilityCase) TableAliasName() string {
+	return "m"
+}
+
+// GetRelations returns the relations of the LiabilityCase data in the database, used for querying.
+func (m *LiabilityCase) GetRelations() map[string]map[string]any {
+	m.AddRelation("left", "employee_assets", "ea", []map[string]any{{"column1": "ea.id", "column2": "m.employee_asset_id"}})
+	m.AddRelation("left", "assets", "ass", []map[string]any{{"column1": "ass.id", "column2": "m.asset_id"}})
+	m.AddRelation("left", "employees", "emp", []map[string]any{{"column1": "emp.id", "column2": "m.employee_id"}})
+	m.AddRelation("left", "departments", "emp_dept", []map[string]any{{"column1": "emp_dept.id", "column2": "emp.department_id"}})
+	m.AddRelation("left", "branches", "emp_brc", []map[string]any{{"column1": "emp_brc.id", "column2": "emp.branch_id"}})
+	return m.Relations
+}
+
+// GetFilters returns the filter of the LiabilityCase data in the database, used for querying.
+func (m *LiabilityCase) GetFilters() []map[string]any {
+	m.AddFilter(map[string]any{"column1": "m.deleted_at", "operator": "=", "value": nil})
+	return m.Filters
+}
+
+// GetSorts returns the default sort of the LiabilityCase data in the database, used for querying.
+func (m *LiabilityCase) GetSorts() []map[string]any {
+	m.AddSort(map[string]any{"column": "m.incident_date", "direction": "desc"})
+	m.AddSort(map[string]any{"column": "m.code", "direction": "desc"})
+	return m.Sorts
+}
+
+// GetFields returns list of the field of the LiabilityCase data in the database, used for querying.
+func (m *LiabilityCase) GetFields() map[string]map[string]any {
+	m.SetFields(m)
+	return m.Fields
+}
+
+// GetSchema returns the LiabilityCase schema, used for querying.
+func (m *LiabilityCase) GetSchema() map[string]any {
+	return m.SetSchema(m)
+}
+
+// OpenAPISchemaName returns the name of the LiabilityCase schema in the open api documentation.
+func (LiabilityCase) OpenAPISchemaName() string {
+	return "LiabilityCase"
+}
+
+// GetOpenAPISchema returns the Open API Schema of the LiabilityCase in the open api documentation.
+func (m *LiabilityCase) GetOpenAPISchema() map[string]any {
+	return m.SetOpenAPISchema(m)
+}
+
+type LiabilityCaseList struct {
+	app.ListModel
+}
+
+// OpenAPISchemaName returns the name of the LiabilityCaseList schema in the open api documentation.
+func (LiabilityCaseList) OpenAPISchemaName() string {
+	return "LiabilityCaseList"
+}
+
+// GetOpenAPISchema returns the Open API Schema of the LiabilityCaseList in the open api documentation.
+func (p *LiabilityCaseList) GetOpenAPISchema() map[string]any {
+	return p.SetOpenAPISchema(&LiabilityCase{})
+}
+
+// Statuses of the LiabilityCase data, only a draft case can be changed and an approved case is settled once it is repaid.
+const (
+	StatusDraft    = "draft"
+	StatusApproved = "approved"
+	StatusRejected = "rejected"
+	StatusSettled  = "settled"
+)
+
+// ParamApprove is the expected parameters for approve the LiabilityCase data.
+type ParamApprove struct {
+	Note app.NullText `json:"note"`
+}
+
+// ParamReject is the expected parameters for reject the LiabilityCase data.
+type ParamReject struct {
+	Note app.NullText `json:"note" validate:"required"`
+}
+
+// ParamPay is the expected parameters for record a repayment of the LiabilityCase data,
+// the repayment is applied to the unpaid installments by their due date.
+type ParamPay struct {
+	Date   app.NullDate    `json:"date"   validate:"required"`
+	Amount app.NullFloat64 `json:"amount" validate:"required,gt=0"`
+}
+
+// Statement is the printable statement of charges of the LiabilityCase data.
+type Statement struct {
+	CreatedAt string
+	Case      LiabilityCase
+}
+
+// ParamCreate is the expected parameters for create a new LiabilityCase data.
+type ParamCreate struct {
+	UseCaseHandler
+	Type            app.NullString `json:"type"              db:"m.type"              gorm:"column:type"              validate:"required,oneof=loss theft damage"`
+	IncidentDate    app.NullDate   `json:"incident_date"     db:"m.incident_date"     gorm:"column:incident_date"     validate:"required"`
+	EmployeeAssetID app.NullUUID   `json:"employee_asset.id" db:"m.employee_asset_id" gorm:"column:employee_asset_id" validate:"required"`
+}
+
+// ParamUpdate is the expected parameters for update the LiabilityCase data.
+type ParamUpdate struct {
+	UseCaseHandler
+}
+
+// ParamPartiallyUpdate is the expected parameters for partially update the LiabilityCase data.
+type ParamPartiallyUpdate struct {
+	UseCaseHandler
+}
+
+// ParamDelete is the expected parameters for delete the LiabilityCase data.
+type ParamDelete struct {
+	UseCaseHandler
+}
+
+// LiabilityInstallment is the repayment schedule line of LiabilityCase data.
+type LiabilityInstallment struct {
+	app.Model
+	ID              app.NullUUID     `json:"id"                db:"m.id"                gorm:"column:id;primaryKey"`
+	LiabilityCaseID app.NullUUID     `json:"liability_case.id" db:"m.liability_case_id" gorm:"column:liability_case_id"`
+	No              app.NullInt64    `json:"no"                db:"m.no"                gorm:"column:no"`
+	DueDate         app.NullDate     `json:"due_date"          db:"m.due_date"          gorm:"column:due_date"`
+	Amount          app.NullFloat64  `json:"amount"            db:"m.amount"            gorm:"column:amount"`
+	PaidAmount      app.NullFloat64  `json:"paid_amount"       db:"m.paid_amount"       gorm:"column:paid_amount"`
+	PaidDate        app.NullDate     `json:"paid_date"         db:"m.paid_date"         gorm:"column:paid_date"`
+	CreatedAt       app.NullDateTime `json:"created_at"        db:"m.created_at"        gorm:"column:created_at"`
+	UpdatedAt       app.NullDateTime `json:"updated_at"        db:"m.updated_at"        gorm:"column:updated_at"`
+	DeletedAt       app.NullDateTime `json:"deleted_at"        db:"m.deleted_at,hide"   gorm:"column:deleted_at"`
+}
+
+// EndPoint returns the LiabilityInstallment end point, it used for cache key, etc.
+func (LiabilityInstallment) EndPoint() string {
+	return "liability_installments"
+}
+
+// TableVersion returns the versions of the LiabilityInstallment table in the database.
+// Change this value with date format YY.MM.DDHHii when any table structure changes.
+func (LiabilityInstallment) TableVersion() string {
+	return "26.10.192700"
+}
+
+// TableName returns the name of the LiabilityInstallment table in the database.
+func (LiabilityInstallment) TableName() string {
+	return "liability_installments"
+}
+
+// TableAliasName returns the table alias name of the LiabilityInstallment table, used for querying.
+func (LiabilityInstallment) TableAliasName() string {
+	return "m"
+}
+
+// GetRelations returns the relations of the LiabilityInstallment data in the database, used for querying.
+func (m *LiabilityInstallment) GetRelations() map[string]map[string]any {
+	return m.Relations
+}
+
+// GetFilters returns the filter of the LiabilityInstallment data in the database, used for querying.
+func (m *LiabilityInstallment) GetFilters() []map[string]any {
+	m.AddFilter(map[string]any{"column1": "m.deleted_at", "operator": "=", "value": nil})
+	return m.Filters
+}
+
+// GetSorts returns the default sort of the LiabilityInstallment data in the database, used for querying.
+func (m *LiabilityInstallment) GetSorts() []map[string]any {
+	m.AddSort(map[string]any{"column": "m.no", "direction": "asc"})
+	return m.Sorts
+}
+
+// GetFields returns list of the field of the LiabilityInstallment data in the database, used for querying.
+func (m *LiabilityInstallment) GetFields() map[string]map[string]any {
+	m.SetFields(m)
+	return m.Fields
+}
+
+// GetSchema returns the LiabilityInstallment schema, used for querying.
+func (m *LiabilityInstallment) GetSchema() map[string]any {
+	return m.SetSchema(m)
+}
+
+// OpenAPISchemaName returns the name of the LiabilityInstallment schema in the open api documentation.
+func (LiabilityInstallment) OpenAPISchemaName() string {
+	return "LiabilityInstallment"
+}
+
+// GetOpenAPISchema returns the Open API Schema of the LiabilityInstallment in the open api documentation.
+func (m *LiabilityInstallment) GetOpenAPISchema() map[string]any {
+	return m.SetOpenAPISchema(m)
+}
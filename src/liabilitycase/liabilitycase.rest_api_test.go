@@ -0,0 +1,147 @@
+package liabilitycase
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gofiber/fiber/v2/utils"
+	"gorm.io/gorm"
+
+	"github.com/maulanar/go_asset_tracking_management/app"
+)
+
+// prepareTest prepares the test.
+func prepareTest(tb testing.TB) {
+	app.Test()
+	tx := app.Test().Tx
+	app.DB().RegisterTable("main", LiabilityCase{})
+	app.DB().MigrateTable(tx, "main", app.Setting{})
+	tx.Session(&gorm.Session{AllowGlobalUpdate: true}).Delete(&LiabilityCase{})
+
+	app.Server().AddMiddleware(app.Test().NewCtx([]string{
+		"liability_cases.detail",
+		"liability_cases.list",
+		"liability_cases.create",
+		"liability_cases.edit",
+		"liability_cases.delete",
+	}))
+	app.Server().AddRoute("/liability_cases", "POST", REST().Create, nil)
+	app.Server().AddRoute("/liability_cases", "GET", REST().Get, nil)
+	app.Server().AddRoute("/liability_cases/:id", "GET", REST().GetByID, nil)
+	app.Server().AddRoute("/liability_cases/:id", "PUT", REST().UpdateByID, nil)
+	app.Server().AddRoute("/liability_cases/:id", "PATCH", REST().PartiallyUpdateByID, nil)
+	app.Server().AddRoute("/liability_cases/:id", "DELETE", REST().DeleteByID, nil)
+}
+
+// getTestLiabilityCaseID returns an available LiabilityCase ID.
+func getTestLiabilityCaseID() string {
+	return "todo"
+}
+
+// tests is test scenario.
+var tests = []struct {
+	description  string // description of the test case
+	method       string // method to test
+	path         string // route path to test
+	token        string // token to test
+	bodyRequest  string // body to test
+	expectedCode int    // expected HTTP status code
+	expectedBody string // expected body response
+}{
+	{
+		description:  "Get empty list of LiabilityCase",
+		method:       "GET",
+		path:         "/liability_cases",
+		token:        app.TestFullAccessToken,
+		expectedCode: http.StatusOK,
+		expectedBody: `{"count":0,"results":[]}`,
+	},
+	{
+		description:  "Create LiabilityCase with minimum payload",
+		method:       "POST",
+		path:         "/liability_cases",
+		token:        app.TestFullAccessToken,
+		bodyRequest:  `{"name":"Kilogram"}`,
+		expectedCode: http.StatusCreated,
+		expectedBody: `{"name":"Kilogram"}`,
+	},
+	{
+		description:  "Get LiabilityCase by ID",
+		method:       "GET",
+		path:         "/liability_cases/" + getTestLiabilityCaseID(),
+		token:        app.TestFullAccessToken,
+		expectedCode: http.StatusOK,
+		expectedBody: `{"name":"Kilogram"}`,
+	},
+	{
+		description:  "Update LiabilityCase by ID",
+		method:       "PUT",
+		path:         "/liability_cases/" + getTestLiabilityCaseID(),
+		token:        app.TestFullAccessToken,
+		bodyRequest:  `{"reason":"Update LiabilityCase by ID","name":"KG"}`,
+		expectedCode: http.StatusOK,
+		expectedBody: `{"name":"KG"}`,
+	},
+	{
+		description:  "Partially update LiabilityCase by ID",
+		method:       "PATCH",
+		path:         "/liability_cases/" + getTestLiabilityCaseID(),
+		token:        app.TestFullAccessToken,
+		bodyRequest:  `{"reason":"Partially Update LiabilityCase by ID","name":"Kilo Gram"}`,
+		expectedCode: http.StatusOK,
+		expectedBody: `{"name":"Kilo Gram"}`,
+	},
+	{
+		description:  "Delete LiabilityCase by ID",
+		method:       "DELETE",
+		path:         "/liability_cases/" + getTestLiabilityCaseID(),
+		token:        app.TestFullAccessToken,
+		bodyRequest:  `{"reason":"Delete LiabilityCase by ID"}`,
+		expectedCode: http.StatusOK,
+		expectedBody: `{"code":200}`,
+	},
+}
+
+// TestLiabilityCaseREST tests the REST API of LiabilityCase data with specified scenario.
+func TestLiabilityCaseREST(t *testing.T) {
+	prepareTest(t)
+
+	// Iterate through test single test cases
+	for _, test := range tests {
+
+		// Create a new http request with the route from the test case
+		req := httptest.NewRequest(test.method, test.path, strings.NewReader(test.bodyRequest))
+		req.Header.Add("Authorization", "Bearer "+test.token)
+		req.Header.Add("Content-Type", "application/json")
+
+		// Perform the request plain with the app, the second argument is a request latency (set to -1 for no latency)
+		res, err := app.Server().Test(req)
+
+		// Verify if the status code is as expected
+		utils.AssertEqual(t, nil, err, "app.Server().Test(req)")
+		utils.AssertEqual(t, test.expectedCode, res.StatusCode, test.description)
+
+		// Verify if the body response is as expected
+		body, err := io.ReadAll(res.Body)
+		utils.AssertEqual(t, nil, err, "io.ReadAll(res.Body)")
+		app.Test().AssertMatchJSONElement(t, []byte(test.expectedBody), body, test.description)
+		res.Body.Close()
+	}
+}
+
+// BenchmarkLiabilityCaseREST tests the REST API of LiabilityCase data with specified scenario.
+func BenchmarkLiabilityCaseREST(b *testing.B) {
+	b.ReportAllocs()
+	prepareTest(b)
+	for i := 0; i < b.N; i++ {
+		for _, test := range tests {
+			req := httptest.NewRequest(test.method, test.path, strings.NewReader(test.bodyRequest))
+			req.Header.Add("Authorization", "Bearer "+test.token)
+			req.Header.Add("Content-Type", "application/json")
+			app.Server().Test(req)
+		}
+	}
+}
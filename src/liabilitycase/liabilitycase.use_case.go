@@ -0,0 +1,723 @@
+package liabilitycase
+
+import (
+	"encoding/json"
+	"math"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"grest.dev/grest"
+
+	"github.com/maulanar/go_asset_tracking_management/app"
+	"github.com/maulanar/go_asset_tracking_management/src/asset"
+	"github.com/maulanar/go_asset_tracking_management/src/employeeasset"
+)
+
+// UseCase returns a UseCaseHandler for expected use case functional.
+func UseCase(ctx app.Ctx, query ...url.Values) UseCaseHandler {
+	u := UseCaseHandler{
+		Ctx:   &ctx,
+		Query: url.Values{},
+	}
+	if len(query) > 0 {
+		u.Query = query[0]
+	}
+	return u
+}
+
+// UseCaseHandler provides a convenient interface for LiabilityCase use case, use UseCase to access UseCaseHandler.
+type UseCaseHandler struct {
+	LiabilityCase
+
+	// injectable dependencies
+	Ctx   *app.Ctx   `json:"-" db:"-" gorm:"-"`
+	Query url.Values `json:"-" db:"-" gorm:"-"`
+}
+
+// Async return UseCaseHandler with async process.
+func (u UseCaseHandler) Async(ctx app.Ctx, query ...url.Values) UseCaseHandler {
+	ctx.IsAsync = true
+	return UseCase(ctx, query...)
+}
+
+// GetByID returns the LiabilityCase data for the specified ID.
+func (u UseCaseHandler) GetByID(id string) (LiabilityCase, error) {
+	res := LiabilityCase{}
+
+	// check permission
+	err := u.Ctx.ValidatePermission("liability_cases.detail")
+	if err != nil {
+		return res, err
+	}
+
+	// get from cache and return if exists
+	cacheKey := u.EndPoint() + "." + id
+	app.Cache().Get(cacheKey, &res)
+	if res.ID.Valid {
+		return res, err
+	}
+
+	// prepare db for current ctx
+	tx, err := u.Ctx.DB()
+	if err != nil {
+		return res, app.Error().New(http.StatusInternalServerError, err.Error())
+	}
+
+	// get from db
+	key := "id"
+	if !app.Validator().IsValid(id, "uuid") {
+		key = "code"
+	}
+	u.Query.Add(key, id)
+	err = app.Query().First(tx, &res, u.Query)
+	if err != nil {
+		return res, u.Ctx.NotFoundError(err, u.EndPoint(), key, id)
+	}
+
+	// get repayment schedule
+	res.Schedule, err = u.GetSchedule(res.ID.String)
+	if err != nil {
+		return res, err
+	}
+
+	// save to cache and return if exists
+	app.Cache().Set(cacheKey, res)
+	return res, err
+}
+
+// Get returns the list of LiabilityCase data.
+func (u UseCaseHandler) Get() (app.ListModel, error) {
+	res := app.ListModel{}
+
+	// check permission
+	err := u.Ctx.ValidatePermission("liability_cases.list")
+	if err != nil {
+		return res, err
+	}
+	// get from cache and return if exists
+	cacheKey := u.EndPoint() + "?" + u.Query.Encode()
+	err = app.Cache().Get(cacheKey, &res)
+	if err == nil {
+		return res, err
+	}
+
+	// prepare db for current ctx
+	tx, err := u.Ctx.DB()
+	if err != nil {
+		return res, app.Error().New(http.StatusInternalServerError, err.Error())
+	}
+
+	// set pagination info
+	res.Results.PageContext.Count,
+		res.Results.PageContext.Page,
+		res.Results.PageContext.PerPage,
+		res.Results.PageContext.PageCount,
+		err = app.Query().PaginationInfo(tx, &LiabilityCase{}, u.Query)
+	if err != nil {
+		return res, app.Error().New(http.StatusInternalServerError, err.Error())
+	}
+	// return data count if $per_page set to 0
+	if res.Results.PageContext.PerPage == 0 {
+		return res, err
+	}
+
+	// find data
+	data, err := app.Query().Find(tx, &LiabilityCase{}, u.Query)
+	if err != nil {
+		return res, app.Error().New(http.StatusInternalServerError, err.Error())
+	}
+	res.SetData(data, u.Query)
+
+	// save to cache and return if exists
+	app.Cache().Set(cacheKey, res)
+	return res, err
+}
+
+// Create creates a new data LiabilityCase with specified parameters.
+func (u *UseCaseHandler) Create(p *ParamCreate) error {
+
+	// check permission
+	err := u.Ctx.ValidatePermission("liability_cases.create")
+	if err != nil {
+		return err
+	}
+
+	// validate param
+	err = u.Ctx.ValidateParam(p)
+	if err != nil {
+		return err
+	}
+
+	// set default value for undefined field
+	err = u.setDefaultValue(LiabilityCase{})
+	if err != nil {
+		return err
+	}
+
+	// prepare db for current ctx
+	tx, err := u.Ctx.DB()
+	if err != nil {
+		return app.Error().New(http.StatusInternalServerError, err.Error())
+	}
+
+	// save data to db
+	err = tx.Model(&u).Create(&u).Error
+	if err != nil {
+		return app.Error().New(http.StatusInternalServerError, err.Error())
+	}
+
+	// invalidate cache
+	app.Cache().Invalidate(u.EndPoint())
+
+	// save history (user activity), send webhook, etc
+	go u.Ctx.Hook("POST", "create", p.ID.String, p)
+	return nil
+}
+
+// UpdateByID updates the LiabilityCase data for the specified ID with specified parameters.
+func (u UseCaseHandler) UpdateByID(id string, p *ParamUpdate) error {
+
+	// check permission
+	err := u.Ctx.ValidatePermission("liability_cases.edit")
+	if err != nil {
+		return err
+	}
+
+	// validate param
+	err = u.Ctx.ValidateParam(p)
+	if err != nil {
+		return err
+	}
+
+	// get previous data
+	old, err := u.GetByID(id)
+	if err != nil {
+		return err
+	}
+
+	// set default value for undefined field
+	err = u.setDefaultValue(old)
+	if err != nil {
+		return err
+	}
+
+	// prepare db for current ctx
+	tx, err := u.Ctx.DB()
+	if err != nil {
+		return app.Error().New(http.StatusInternalServerError, err.Error())
+	}
+
+	// update data on the db
+	err = tx.Model(&u).Where("id = ?", old.ID).Updates(u).Error
+	if err != nil {
+		return app.Error().New(http.StatusInternalServerError, err.Error())
+	}
+
+	// invalidate cache
+	app.Cache().Invalidate(u.EndPoint(), old.ID.String)
+
+	// save history (user activity), send webhook, etc
+	go u.Ctx.Hook("PUT", "Update", old.ID.String, old)
+	return nil
+}
+
+// PartiallyUpdateByID updates the LiabilityCase data for the specified ID with specified parameters.
+func (u UseCaseHandler) PartiallyUpdateByID(id string, p *ParamPartiallyUpdate) error {
+
+	// check permission
+	err := u.Ctx.ValidatePermission("liability_cases.edit")
+	if err != nil {
+		return err
+	}
+
+	// validate param
+	err = u.Ctx.ValidateParam(p)
+	if err != nil {
+		return err
+	}
+
+	// get previous data
+	old, err := u.GetByID(id)
+	if err != nil {
+		return err
+	}
+
+	// set default value for undefined field
+	err = u.setDefaultValue(old)
+	if err != nil {
+		return err
+	}
+
+	// prepare db for current ctx
+	tx, err := u.Ctx.DB()
+	if err != nil {
+		return app.Error().New(http.StatusInternalServerError, err.Error())
+	}
+
+	// update data on the db
+	err = tx.Model(&u).Where("id = ?", old.ID).Updates(u).Error
+	if err != nil {
+		return app.Error().New(http.StatusInternalServerError, err.Error())
+	}
+
+	// invalidate cache
+	app.Cache().Invalidate(u.EndPoint(), old.ID.String)
+
+	// save history (user activity), send webhook, etc
+	go u.Ctx.Hook("PATCH", "Partially Update", old.ID.String, old)
+	return nil
+}
+
+// DeleteByID deletes the LiabilityCase data for the specified ID.
+func (u UseCaseHandler) DeleteByID(id string, p *ParamDelete) error {
+
+	// check permission
+	err := u.Ctx.ValidatePermission("liability_cases.delete")
+	if err != nil {
+		return err
+	}
+
+	// validate param
+	err = u.Ctx.ValidateParam(p)
+	if err != nil {
+		return err
+	}
+
+	// get previous data
+	old, err := u.GetByID(id)
+	if err != nil {
+		return err
+	}
+	if old.Status.String != StatusDraft {
+		return app.Error().New(http.StatusBadRequest, u.Ctx.Trans("liability_case_not_deletable", map[string]string{
+			"code":   old.Code.String,
+			"status": old.Status.String,
+		}))
+	}
+
+	// prepare db for current ctx
+	tx, err := u.Ctx.DB()
+	if err != nil {
+		return app.Error().New(http.StatusInternalServerError, err.Error())
+	}
+
+	// update data on the db
+	err = tx.Model(&p).Where("id = ?", old.ID).Update("deleted_at", time.Now().UTC()).Error
+	if err != nil {
+		return app.Error().New(http.StatusInternalServerError, err.Error())
+	}
+
+	// invalidate cache
+	app.Cache().Invalidate(u.EndPoint(), old.ID.String)
+
+	// save history (user activity), send webhook, etc
+	go u.Ctx.Hook("DELETE", "DELETE", old.ID.String, old)
+	return nil
+}
+
+// ApproveByID approves the draft LiabilityCase data for the specified ID, the charge becomes the receivable of the employee
+// and its repayment schedule is generated. A case without charge is settled right away.
+func (u UseCaseHandler) ApproveByID(id string, p *ParamApprove) error {
+
+	// check permission
+	err := u.Ctx.ValidatePermission("liability_cases.approve")
+	if err != nil {
+		return err
+	}
+
+	// validate param
+	err = u.Ctx.ValidateParam(p)
+	if err != nil {
+		return err
+	}
+
+	// get previous data
+	old, err := u.getDraft(id, StatusApproved)
+	if err != nil {
+		return err
+	}
+
+	// prepare db for current ctx
+	tx, err := u.Ctx.DB()
+	if err != nil {
+		return app.Error().New(http.StatusInternalServerError, err.Error())
+	}
+
+	now := time.Now().UTC()
+	fields := map[string]any{
+		"status":             StatusApproved,
+		"approval_note":      p.Note,
+		"approved_at":        now,
+		"paid_amount":        0,
+		"outstanding_amount": old.ChargeAmount.Float64,
+		"updated_at":         now,
+	}
+	if old.ChargeAmount.Float64 <= 0 {
+		fields["status"] = StatusSettled
+		fields["settled_at"] = now
+	} else {
+		firstDueDate, err := saveSchedule(tx, old)
+		if err != nil {
+			return app.Error().New(http.StatusInternalServerError, err.Error())
+		}
+		fields["first_due_date"] = firstDueDate
+	}
+	return u.saveFields(tx, old, fields, "approve")
+}
+
+// RejectByID rejects the draft LiabilityCase data for the specified ID, the employee is not charged.
+func (u UseCaseHandler) RejectByID(id string, p *ParamReject) error {
+
+	// check permission
+	err := u.Ctx.ValidatePermission("liability_cases.approve")
+	if err != nil {
+		return err
+	}
+
+	// validate param
+	err = u.Ctx.ValidateParam(p)
+	if err != nil {
+		return err
+	}
+
+	// get previous data
+	old, err := u.getDraft(id, StatusRejected)
+	if err != nil {
+		return err
+	}
+
+	// prepare db for current ctx
+	tx, err := u.Ctx.DB()
+	if err != nil {
+		return app.Error().New(http.StatusInternalServerError, err.Error())
+	}
+
+	now := time.Now().UTC()
+	return u.saveFields(tx, old, map[string]any{
+		"status":        StatusRejected,
+		"approval_note": p.Note,
+		"rejected_at":   now,
+		"updated_at":    now,
+	}, "reject")
+}
+
+// PayByID records a repayment of the approved LiabilityCase data for the specified ID, the repayment is applied to
+// the unpaid installments by their due date and the case is settled once the charge is fully repaid.
+func (u UseCaseHandler) PayByID(id string, p *ParamPay) error {
+
+	// check permission
+	err := u.Ctx.ValidatePermission("liability_cases.pay")
+	if err != nil {
+		return err
+	}
+
+	// validate param
+	err = u.Ctx.ValidateParam(p)
+	if err != nil {
+		return err
+	}
+
+	// get previous data
+	old, err := u.GetByID(id)
+	if err != nil {
+		return err
+	}
+
+	// prepare db for current ctx
+	tx, err := u.Ctx.DB()
+	if err != nil {
+		return app.Error().New(http.StatusInternalServerError, err.Error())
+	}
+
+	// the case is locked and its amounts and schedule are read again from the db, so concurrent repayments are applied one after another
+	locked := LiabilityCase{}
+	err = tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+		Select("id", "status", "paid_amount", "outstanding_amount").
+		Where("id = ?", old.ID).
+		Limit(1).Find(&locked).Error
+	if err != nil {
+		return app.Error().New(http.StatusInternalServerError, err.Error())
+	}
+	old.Status, old.PaidAmount, old.OutstandingAmount = locked.Status, locked.PaidAmount, locked.OutstandingAmount
+	schedule := []LiabilityInstallment{}
+	err = tx.Where("liability_case_id = ?", old.ID).
+		Where("deleted_at IS NULL").
+		Order("no ASC").
+		Find(&schedule).Error
+	if err != nil {
+		return app.Error().New(http.StatusInternalServerError, err.Error())
+	}
+
+	if old.Status.String != StatusApproved {
+		return app.Error().New(http.StatusBadRequest, u.Ctx.Trans("liability_case_transition_invalid", map[string]string{
+			"code": old.Code.String,
+			"from": old.Status.String,
+			"to":   StatusSettled,
+		}))
+	}
+	amount := round(p.Amount.Float64)
+	if amount > old.OutstandingAmount.Float64 {
+		return app.Error().New(http.StatusBadRequest, u.Ctx.Trans("liability_payment_invalid", map[string]string{
+			"amount": strconv.FormatFloat(old.OutstandingAmount.Float64, 'f', 2, 64),
+		}))
+	}
+
+	remaining := amount
+	for _, line := range schedule {
+		unpaid := round(line.Amount.Float64 - line.PaidAmount.Float64)
+		if remaining <= 0 {
+			break
+		}
+		if unpaid <= 0 {
+			continue
+		}
+		paid := math.Min(unpaid, remaining)
+		remaining = round(remaining - paid)
+		err = tx.Model(&LiabilityInstallment{}).Where("id = ?", line.ID).Updates(map[string]any{
+			"paid_amount": round(line.PaidAmount.Float64 + paid),
+			"paid_date":   p.Date,
+			"updated_at":  time.Now().UTC(),
+		}).Error
+		if err != nil {
+			return app.Error().New(http.StatusInternalServerError, err.Error())
+		}
+	}
+
+	now := time.Now().UTC()
+	outstanding := round(old.OutstandingAmount.Float64 - amount)
+	fields := map[string]any{
+		"paid_amount":        round(old.PaidAmount.Float64 + amount),
+		"outstanding_amount": outstanding,
+		"updated_at":         now,
+	}
+	if outstanding <= 0 {
+		fields["status"] = StatusSettled
+		fields["settled_at"] = now
+	}
+	return u.saveFields(tx, old, fields, "pay")
+}
+
+// StatementByID returns the printable statement of charges of the LiabilityCase data for the specified ID.
+func (u UseCaseHandler) StatementByID(id string) (Statement, error) {
+	res := Statement{CreatedAt: time.Now().Format("2006-01-02 15:04:05")}
+	data, err := u.GetByID(id)
+	if err != nil {
+		return res, err
+	}
+	res.Case = data
+	return res, nil
+}
+
+// GetSchedule returns the repayment schedule of the LiabilityCase data for the specified case ID.
+func (u UseCaseHandler) GetSchedule(liabilityCaseID string) ([]LiabilityInstallment, error) {
+	res := []LiabilityInstallment{}
+
+	// prepare db for current ctx
+	tx, err := u.Ctx.DB()
+	if err != nil {
+		return res, app.Error().New(http.StatusInternalServerError, err.Error())
+	}
+
+	query := url.Values{}
+	query.Add("liability_case.id", liabilityCaseID)
+	query.Add("$sort", "no")
+	query.Add(grest.QueryDisablePagination, "true")
+	data, err := app.Query().Find(tx, &LiabilityInstallment{}, query)
+	if err != nil {
+		return res, app.Error().New(http.StatusInternalServerError, err.Error())
+	}
+
+	jByte, err := json.Marshal(data)
+	if err != nil {
+		return res, app.Error().New(http.StatusInternalServerError, err.Error())
+	}
+	err = app.BindJSON(jByte, &res)
+	if err != nil {
+		return res, app.Error().New(http.StatusInternalServerError, err.Error())
+	}
+	return res, nil
+}
+
+// getDraft returns the LiabilityCase data for the specified ID when it is still a draft which can be decided.
+func (u UseCaseHandler) getDraft(id, to string) (LiabilityCase, error) {
+	old, err := u.GetByID(id)
+	if err != nil {
+		return old, err
+	}
+	if old.Status.String != StatusDraft {
+		return old, app.Error().New(http.StatusBadRequest, u.Ctx.Trans("liability_case_transition_invalid", map[string]string{
+			"code": old.Code.String,
+			"from": old.Status.String,
+			"to":   to,
+		}))
+	}
+	return old, nil
+}
+
+// saveFields updates the fields of the LiabilityCase data on the db after one of its transitions.
+func (u UseCaseHandler) saveFields(tx *gorm.DB, old LiabilityCase, fields map[string]any, action string) error {
+	err := tx.Model(&LiabilityCase{}).Where("id = ?", old.ID).Updates(fields).Error
+	if err != nil {
+		return app.Error().New(http.StatusInternalServerError, err.Error())
+	}
+
+	// invalidate cache
+	app.Cache().Invalidate(u.EndPoint(), old.ID.String)
+
+	// save history (user activity), send webhook, etc
+	go u.Ctx.Hook("POST", action, old.ID.String, old)
+	return nil
+}
+
+// saveSchedule generates the monthly repayment schedule of the charge of the LiabilityCase data and returns its first due date,
+// the first due date defaults to the first day of the next month and the rounding difference is put on the last installment.
+func saveSchedule(tx *gorm.DB, c LiabilityCase) (time.Time, error) {
+	now := time.Now()
+	due := time.Date(now.Year(), now.Month()+1, 1, 0, 0, 0, 0, time.UTC)
+	if c.FirstDueDate.Valid {
+		due = c.FirstDueDate.Time
+	}
+	n := c.Installments.Int64
+	if n < 1 {
+		n = 1
+	}
+	each, total := round(c.ChargeAmount.Float64/float64(n)), float64(0)
+	for i := int64(1); i <= n; i++ {
+		amount := each
+		if i == n {
+			amount = round(c.ChargeAmount.Float64 - total)
+		}
+		total += amount
+		line := LiabilityInstallment{
+			ID:              app.NewNullUUID(),
+			LiabilityCaseID: c.ID,
+			No:              app.NewNullInt64(i),
+			DueDate:         app.NewNullDate(due.AddDate(0, int(i-1), 0)),
+			Amount:          app.NewNullFloat64(amount),
+			PaidAmount:      app.NewNullFloat64(0),
+			CreatedAt:       app.NewNullDateTime(time.Now().UTC()),
+			UpdatedAt:       app.NewNullDateTime(time.Now().UTC()),
+		}
+		err := tx.Create(&line).Error
+		if err != nil {
+			return due, err
+		}
+	}
+	return due, nil
+}
+
+// round rounds the amount to the cent.
+func round(amount float64) float64 {
+	return math.Round(amount*100) / 100
+}
+
+// setDefaultValue set default value of undefined field when create or update LiabilityCase data.
+func (u *UseCaseHandler) setDefaultValue(old LiabilityCase) error {
+	if !old.ID.Valid {
+		u.ID = app.NewNullUUID()
+	} else {
+		u.ID = old.ID
+	}
+
+	// only a draft case can be changed, and the status is only changed by its approval and repayment
+	if old.ID.Valid && old.Status.String != StatusDraft {
+		return app.Error().New(http.StatusBadRequest, u.Ctx.Trans("liability_case_closed", map[string]string{
+			"code":   old.Code.String,
+			"status": old.Status.String,
+		}))
+	}
+	if u.Status.Valid && u.Status.String != old.Status.String && (old.ID.Valid || u.Status.String != StatusDraft) {
+		return app.Error().New(http.StatusBadRequest, u.Ctx.Trans("liability_case_status_invalid", map[string]string{
+			"code":   old.Code.String,
+			"status": u.Status.String,
+		}))
+	}
+	u.Status.Set(StatusDraft)
+
+	// Penentuan kode
+	if u.Code.Valid && u.Code.String != "" {
+		// Jika kode dikirim dan berbeda dengan data lama, cek ke DB
+		if !old.Code.Valid || u.Code.String != old.Code.String {
+			err := app.Common().IsFieldValueExists(u.Ctx, u.EndPoint(), "Code", u.TableName(), "code", u.Code.String)
+			if err != nil {
+				return err
+			}
+		}
+	} else {
+		// Jika tidak kirim kode dan data lama ada, gunakan data lama
+		if old.Code.Valid && old.Code.String != "" {
+			u.Code = old.Code
+		} else {
+			// Jika tidak kirim kode dan data lama tidak ada, generate baru
+			newCode, err := app.Common().GenerateCode(u.Ctx, u.TableName(), "code", "Liability")
+			if err != nil {
+				return err
+			}
+			u.Code.Set(newCode)
+		}
+	}
+
+	// the asset and the employee come from the assignment, the incident must happen while the asset is assigned
+	employeeAssetID, incidentDate := old.EmployeeAssetID, old.IncidentDate
+	if u.EmployeeAssetID.Valid && u.EmployeeAssetID.String != "" {
+		employeeAssetID = u.EmployeeAssetID
+	}
+	if u.IncidentDate.Valid {
+		incidentDate = u.IncidentDate
+	}
+	bookValue := old.BookValue
+	if employeeAssetID.String != old.EmployeeAssetID.String || !incidentDate.Time.Equal(old.IncidentDate.Time) {
+		ea, err := employeeasset.UseCase(*u.Ctx, url.Values{}).GetByID(employeeAssetID.String)
+		if err != nil {
+			return err
+		}
+		if incidentDate.Time.After(time.Now()) || incidentDate.Time.Before(ea.AssignDate.Time) ||
+			(ea.ReturnDate.Valid && incidentDate.Time.After(ea.ReturnDate.Time)) {
+			return app.Error().New(http.StatusBadRequest, u.Ctx.Trans("liability_case_date_invalid", map[string]string{
+				"asset":    ea.AssetCode.String,
+				"employee": ea.EmployeeName.String,
+			}))
+		}
+		u.EmployeeAssetID, u.AssetID, u.EmployeeID = ea.ID, ea.AssetID, ea.EmployeeID
+
+		// the book value of the asset on the incident date
+		value, err := asset.UseCase(*u.Ctx, url.Values{}).BookValueAt(ea.AssetID.String, incidentDate.Time)
+		if err != nil {
+			return err
+		}
+		bookValue.Set(round(value))
+		u.BookValue = bookValue
+	}
+
+	// the charge is the liable part of the book value unless it is decided otherwise
+	percentage := old.LiabilityPercentage
+	if u.LiabilityPercentage.Valid {
+		percentage = u.LiabilityPercentage
+	}
+	if !percentage.Valid {
+		percentage.Set(100)
+		u.LiabilityPercentage = percentage
+	}
+	if !u.ChargeAmount.Valid {
+		u.ChargeAmount = old.ChargeAmount
+		if !old.ID.Valid || bookValue.Float64 != old.BookValue.Float64 || percentage.Float64 != old.LiabilityPercentage.Float64 {
+			u.ChargeAmount.Set(round(bookValue.Float64 * percentage.Float64 / 100))
+		}
+	}
+	if u.ChargeAmount.Float64 > bookValue.Float64 {
+		return app.Error().New(http.StatusBadRequest, u.Ctx.Trans("liability_charge_invalid", map[string]string{
+			"amount": strconv.FormatFloat(bookValue.Float64, 'f', 2, 64),
+		}))
+	}
+	u.PaidAmount.Set(0)
+	u.OutstandingAmount = u.ChargeAmount
+	if !old.ID.Valid && !u.Installments.Valid {
+		u.Installments.Set(1)
+	}
+
+	return nil
+}
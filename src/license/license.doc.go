@@ -0,0 +1,2 @@
+// license is a package related to license data.
+package license
@@ -0,0 +1,157 @@
+package license
+
+import "github.com/maulanar/go_asset_tracking_management/app"
+
+// License is the main model of License data. It provides a convenient interface for app.ModelInterface
+type License struct {
+	app.Model
+	ID               app.NullUUID     `json:"id"                 db:"m.id"                 gorm:"column:id;primaryKey"`
+	Code             app.NullString   `json:"code"               db:"m.code"               gorm:"column:code"`
+	Product          app.NullString   `json:"product"            db:"m.product"            gorm:"column:product"`
+	Version          app.NullString   `json:"version"            db:"m.version"            gorm:"column:version"`
+	LicenseType      app.NullString   `json:"license_type"       db:"m.license_type"       gorm:"column:license_type"       validate:"omitempty,oneof=perpetual subscription"`
+	LicenseKey       app.NullText     `json:"license_key"        db:"m.license_key,hide"   gorm:"column:license_key"`
+	Seats            app.NullInt64    `json:"seats"              db:"m.seats"              gorm:"column:seats"              validate:"omitempty,gte=1"`
+	PurchaseCost     app.NullFloat64  `json:"purchase_cost"      db:"m.purchase_cost"      gorm:"column:purchase_cost"      validate:"omitempty,gte=0"`
+	PurchaseDate     app.NullDate     `json:"purchase_date"      db:"m.purchase_date"      gorm:"column:purchase_date"`
+	ExpiryDate       app.NullDate     `json:"expiry_date"        db:"m.expiry_date"        gorm:"column:expiry_date"`
+	RenewalTerms     app.NullText     `json:"renewal_terms"      db:"m.renewal_terms"      gorm:"column:renewal_terms"`
+	AutoRenew        app.NullBool     `json:"auto_renew"         db:"m.auto_renew"         gorm:"column:auto_renew"`
+	NotifyDaysBefore app.NullInt64    `json:"notify_days_before" db:"m.notify_days_before" gorm:"column:notify_days_before" validate:"omitempty,gte=0"`
+	NotifiedAt       app.NullDateTime `json:"notified_at"        db:"m.notified_at"        gorm:"column:notified_at"`
+	Description      app.NullText     `json:"description"        db:"m.description"        gorm:"column:description"`
+	UsedSeats        app.NullInt64    `json:"used_seats"         db:"lst.used_seats"       gorm:"-"`
+	AvailableSeats   app.NullInt64    `json:"available_seats"    db:"lst.available_seats"  gorm:"-"`
+	Status           app.NullString   `json:"status"             db:"lst.status"           gorm:"-"`
+
+	VendorID   app.NullUUID   `json:"vendor.id"          db:"m.vendor_id"          gorm:"column:vendor_id"`
+	VendorCode app.NullString `json:"vendor.code"        db:"vnd.code"             gorm:"-"`
+	VendorName app.NullString `json:"vendor.name"        db:"vnd.name"             gorm:"-"`
+
+	CreatedAt app.NullDateTime `json:"created_at"         db:"m.created_at"         gorm:"column:created_at"`
+	UpdatedAt app.NullDateTime `json:"updated_at"         db:"m.updated_at"         gorm:"column:updated_at"`
+	DeletedAt app.NullDateTime `json:"deleted_at"         db:"m.deleted_at,hide"    gorm:"column:deleted_at"`
+}
+
+// EndPoint returns the License end point, it used for cache key, etc.
+func (License) EndPoint() string {
+	return "licenses"
+}
+
+// TableVersion returns the versions of the License table in the database.
+// Change this value with date format YY.MM.DDHHii when any table structure changes.
+func (License) TableVersion() string {
+	return "26.10.191400"
+}
+
+// TableName returns the name of the License table in the database.
+func (License) TableName() string {
+	return "licenses"
+}
+
+// TableAliasName returns the table alias name of the License table, used for querying.
+func (License) TableAliasName() string {
+	return "m"
+}
+
+// GetRelations returns the relations of the License data in the database, used for querying.
+func (m *License) GetRelations() map[string]map[string]any {
+	m.AddRelation("left", "vendors", "vnd", []map[string]any{{"column1": "vnd.id", "column2": "m.vendor_id"}})
+
+	// seat usage and license status based on the current date
+	m.AddRelation("left", `(
+  SELECT l.id,
+         COALESCE(s.used_seats, 0) AS used_seats,
+         COALESCE(l.seats, 0) - COALESCE(s.used_seats, 0) AS available_seats,
+         CASE
+           WHEN l.expiry_date IS NULL THEN 'perpetual'
+           WHEN l.expiry_date < CURRENT_DATE THEN 'expired'
+           WHEN l.expiry_date - COALESCE(l.notify_days_before, 0) <= CURRENT_DATE THEN 'expiring'
+           ELSE 'active'
+         END AS status
+  FROM licenses l
+  LEFT JOIN (
+    SELECT ls.license_id, COUNT(*) AS used_seats
+    FROM license_seats ls
+    WHERE ls.deleted_at IS NULL AND ls.released_date IS NULL
+    GROUP BY ls.license_id
+  ) s ON s.license_id = l.id
+)`, "lst", []map[string]any{{"column1": "lst.id", "column2": "m.id"}})
+	return m.Relations
+}
+
+// GetFilters returns the filter of the License data in the database, used for querying.
+func (m *License) GetFilters() []map[string]any {
+	m.AddFilter(map[string]any{"column1": "m.deleted_at", "operator": "=", "value": nil})
+	return m.Filters
+}
+
+// GetSorts returns the default sort of the License data in the database, used for querying.
+func (m *License) GetSorts() []map[string]any {
+	m.AddSort(map[string]any{"column": "m.product", "direction": "asc"})
+	return m.Sorts
+}
+
+// GetFields returns list of the field of the License data in the database, used for querying.
+func (m *License) GetFields() map[string]map[string]any {
+	m.SetFields(m)
+	return m.Fields
+}
+
+// GetSchema returns the License schema, used for querying.
+func (m *License) GetSchema() map[string]any {
+	return m.SetSchema(m)
+}
+
+// OpenAPISchemaName returns the name of the License schema in the open api documentation.
+func (License) OpenAPISchemaName() string {
+	return "License"
+}
+
+// GetOpenAPISchema returns the Open API Schema of the License in the open api documentation.
+func (m *License) GetOpenAPISchema() map[string]any {
+	return m.SetOpenAPISchema(m)
+}
+
+type LicenseList struct {
+	app.ListModel
+}
+
+// OpenAPISchemaName returns the name of the LicenseList schema in the open api documentation.
+func (LicenseList) OpenAPISchemaName() string {
+	return "LicenseList"
+}
+
+// GetOpenAPISchema returns the Open API Schema of the LicenseList in the open api documentation.
+func (p *LicenseList) GetOpenAPISchema() map[string]any {
+	return p.SetOpenAPISchema(&License{})
+}
+
+// ParamCreate is the expected parameters for create a new License data.
+type ParamCreate struct {
+	UseCaseHandler
+	Product app.NullString `json:"product"            db:"m.product"            gorm:"column:product"            validate:"required"`
+	Seats   app.NullInt64  `json:"seats"              db:"m.seats"              gorm:"column:seats"              validate:"required,gte=1"`
+}
+
+// ParamUpdate is the expected parameters for update the License data.
+type ParamUpdate struct {
+	UseCaseHandler
+}
+
+// ParamPartiallyUpdate is the expected parameters for partially update the License data.
+type ParamPartiallyUpdate struct {
+	UseCaseHandler
+}
+
+// LicenseKey is the decrypted license key of the License data.
+type LicenseKey struct {
+	ID         app.NullUUID   `json:"id"`
+	Product    app.NullString `json:"product"`
+	LicenseKey app.NullText   `json:"license_key"`
+}
+
+// ParamDelete is the expected parameters for delete the License data.
+type ParamDelete struct {
+	UseCaseHandler
+}
@@ -0,0 +1,493 @@
+package license
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/maulanar/go_asset_tracking_management/app"
+	"github.com/maulanar/go_asset_tracking_management/src/notification"
+	"github.com/maulanar/go_asset_tracking_management/src/vendor"
+)
+
+// UseCase returns a UseCaseHandler for expected use case functional.
+func UseCase(ctx app.Ctx, query ...url.Values) UseCaseHandler {
+	u := UseCaseHandler{
+		Ctx:   &ctx,
+		Query: url.Values{},
+	}
+	if len(query) > 0 {
+		u.Query = query[0]
+	}
+	return u
+}
+
+// UseCaseHandler provides a convenient interface for License use case, use UseCase to access UseCaseHandler.
+type UseCaseHandler struct {
+	License
+
+	// injectable dependencies
+	Ctx   *app.Ctx   `json:"-" db:"-" gorm:"-"`
+	Query url.Values `json:"-" db:"-" gorm:"-"`
+}
+
+// Async return UseCaseHandler with async process.
+func (u UseCaseHandler) Async(ctx app.Ctx, query ...url.Values) UseCaseHandler {
+	ctx.IsAsync = true
+	return UseCase(ctx, query...)
+}
+
+// GetByID returns the License data for the specified ID.
+func (u UseCaseHandler) GetByID(id string) (License, error) {
+	res := License{}
+
+	// check permission
+	err := u.Ctx.ValidatePermission("licenses.detail")
+	if err != nil {
+		return res, err
+	}
+
+	// get from cache and return if exists
+	cacheKey := u.EndPoint() + "." + id
+	app.Cache().Get(cacheKey, &res)
+	if res.ID.Valid {
+		return res, err
+	}
+
+	// prepare db for current ctx
+	tx, err := u.Ctx.DB()
+	if err != nil {
+		return res, app.Error().New(http.StatusInternalServerError, err.Error())
+	}
+
+	// get from db
+	key := "id"
+	if !app.Validator().IsValid(id, "uuid") {
+		key = "code"
+	}
+	u.Query.Add(key, id)
+	err = app.Query().First(tx, &res, u.Query)
+	if err != nil {
+		return res, u.Ctx.NotFoundError(err, u.EndPoint(), key, id)
+	}
+
+	// save to cache and return if exists
+	app.Cache().Set(cacheKey, res)
+	return res, err
+}
+
+// Get returns the list of License data.
+func (u UseCaseHandler) Get() (app.ListModel, error) {
+	res := app.ListModel{}
+
+	// check permission
+	err := u.Ctx.ValidatePermission("licenses.list")
+	if err != nil {
+		return res, err
+	}
+	// get from cache and return if exists
+	cacheKey := u.EndPoint() + "?" + u.Query.Encode()
+	err = app.Cache().Get(cacheKey, &res)
+	if err == nil {
+		return res, err
+	}
+
+	// prepare db for current ctx
+	tx, err := u.Ctx.DB()
+	if err != nil {
+		return res, app.Error().New(http.StatusInternalServerError, err.Error())
+	}
+
+	// set pagination info
+	res.Results.PageContext.Count,
+		res.Results.PageContext.Page,
+		res.Results.PageContext.PerPage,
+		res.Results.PageContext.PageCount,
+		err = app.Query().PaginationInfo(tx, &License{}, u.Query)
+	if err != nil {
+		return res, app.Error().New(http.StatusInternalServerError, err.Error())
+	}
+	// return data count if $per_page set to 0
+	if res.Results.PageContext.PerPage == 0 {
+		return res, err
+	}
+
+	// find data
+	data, err := app.Query().Find(tx, &License{}, u.Query)
+	if err != nil {
+		return res, app.Error().New(http.StatusInternalServerError, err.Error())
+	}
+	res.SetData(data, u.Query)
+
+	// save to cache and return if exists
+	app.Cache().Set(cacheKey, res)
+	return res, err
+}
+
+// Create creates a new data License with specified parameters.
+func (u *UseCaseHandler) Create(p *ParamCreate) error {
+
+	// check permission
+	err := u.Ctx.ValidatePermission("licenses.create")
+	if err != nil {
+		return err
+	}
+
+	// validate param
+	err = u.Ctx.ValidateParam(p)
+	if err != nil {
+		return err
+	}
+
+	// set default value for undefined field
+	err = u.setDefaultValue(License{})
+	if err != nil {
+		return err
+	}
+
+	// prepare db for current ctx
+	tx, err := u.Ctx.DB()
+	if err != nil {
+		return app.Error().New(http.StatusInternalServerError, err.Error())
+	}
+
+	// save data to db
+	err = tx.Model(&u).Create(&u).Error
+	if err != nil {
+		return app.Error().New(http.StatusInternalServerError, err.Error())
+	}
+
+	// invalidate cache
+	app.Cache().Invalidate(u.EndPoint())
+
+	// save history (user activity), send webhook, etc
+	p.LicenseKey = u.LicenseKey
+	go u.Ctx.Hook("POST", "create", p.ID.String, p)
+	return nil
+}
+
+// UpdateByID updates the License data for the specified ID with specified parameters.
+func (u UseCaseHandler) UpdateByID(id string, p *ParamUpdate) error {
+
+	// check permission
+	err := u.Ctx.ValidatePermission("licenses.edit")
+	if err != nil {
+		return err
+	}
+
+	// validate param
+	err = u.Ctx.ValidateParam(p)
+	if err != nil {
+		return err
+	}
+
+	// get previous data
+	old, err := u.GetByID(id)
+	if err != nil {
+		return err
+	}
+
+	// set default value for undefined field
+	err = u.setDefaultValue(old)
+	if err != nil {
+		return err
+	}
+
+	// prepare db for current ctx
+	tx, err := u.Ctx.DB()
+	if err != nil {
+		return app.Error().New(http.StatusInternalServerError, err.Error())
+	}
+
+	// update data on the db
+	err = tx.Model(&u).Where("id = ?", old.ID).Updates(u).Error
+	if err != nil {
+		return app.Error().New(http.StatusInternalServerError, err.Error())
+	}
+
+	// invalidate cache
+	app.Cache().Invalidate(u.EndPoint(), old.ID.String)
+
+	// save history (user activity), send webhook, etc
+	go u.Ctx.Hook("PUT", "Update", old.ID.String, old)
+	return nil
+}
+
+// PartiallyUpdateByID updates the License data for the specified ID with specified parameters.
+func (u UseCaseHandler) PartiallyUpdateByID(id string, p *ParamPartiallyUpdate) error {
+
+	// check permission
+	err := u.Ctx.ValidatePermission("licenses.edit")
+	if err != nil {
+		return err
+	}
+
+	// validate param
+	err = u.Ctx.ValidateParam(p)
+	if err != nil {
+		return err
+	}
+
+	// get previous data
+	old, err := u.GetByID(id)
+	if err != nil {
+		return err
+	}
+
+	// set default value for undefined field
+	err = u.setDefaultValue(old)
+	if err != nil {
+		return err
+	}
+
+	// prepare db for current ctx
+	tx, err := u.Ctx.DB()
+	if err != nil {
+		return app.Error().New(http.StatusInternalServerError, err.Error())
+	}
+
+	// update data on the db
+	err = tx.Model(&u).Where("id = ?", old.ID).Updates(u).Error
+	if err != nil {
+		return app.Error().New(http.StatusInternalServerError, err.Error())
+	}
+
+	// invalidate cache
+	app.Cache().Invalidate(u.EndPoint(), old.ID.String)
+
+	// save history (user activity), send webhook, etc
+	go u.Ctx.Hook("PATCH", "Partially Update", old.ID.String, old)
+	return nil
+}
+
+// DeleteByID deletes the License data for the specified ID.
+func (u UseCaseHandler) DeleteByID(id string, p *ParamDelete) error {
+
+	// check permission
+	err := u.Ctx.ValidatePermission("licenses.delete")
+	if err != nil {
+		return err
+	}
+
+	// validate param
+	err = u.Ctx.ValidateParam(p)
+	if err != nil {
+		return err
+	}
+
+	// get previous data
+	old, err := u.GetByID(id)
+	if err != nil {
+		return err
+	}
+
+	// license with assigned seats must be released first
+	if old.UsedSeats.Int64 > 0 {
+		return app.Error().New(http.StatusBadRequest, u.Ctx.Trans("license_in_use", map[string]string{
+			"product": old.Product.String,
+			"used":    strconv.FormatInt(old.UsedSeats.Int64, 10),
+		}))
+	}
+
+	// prepare db for current ctx
+	tx, err := u.Ctx.DB()
+	if err != nil {
+		return app.Error().New(http.StatusInternalServerError, err.Error())
+	}
+
+	// update data on the db
+	err = tx.Model(&p).Where("id = ?", old.ID).Update("deleted_at", time.Now().UTC()).Error
+	if err != nil {
+		return app.Error().New(http.StatusInternalServerError, err.Error())
+	}
+
+	// invalidate cache
+	app.Cache().Invalidate(u.EndPoint(), old.ID.String)
+
+	// save history (user activity), send webhook, etc
+	go u.Ctx.Hook("DELETE", "DELETE", old.ID.String, old)
+	return nil
+}
+
+// setDefaultValue set default value of undefined field when create or update License data.
+func (u *UseCaseHandler) setDefaultValue(old License) error {
+	if !old.ID.Valid {
+		u.ID = app.NewNullUUID()
+	} else {
+		u.ID = old.ID
+	}
+
+	// Penentuan kode
+	if u.Code.Valid && u.Code.String != "" {
+		// Jika kode dikirim dan berbeda dengan data lama, cek ke DB
+		if !old.Code.Valid || u.Code.String != old.Code.String {
+			err := app.Common().IsFieldValueExists(u.Ctx, u.EndPoint(), "Code", u.TableName(), "code", u.Code.String)
+			if err != nil {
+				return err
+			}
+		}
+	} else {
+		// Jika tidak kirim kode dan data lama ada, gunakan data lama
+		if old.Code.Valid && old.Code.String != "" {
+			u.Code = old.Code
+		} else {
+			// Jika tidak kirim kode dan data lama tidak ada, generate baru
+			newCode, err := app.Common().GenerateCode(u.Ctx, u.TableName(), "code", u.Product.String)
+			if err != nil {
+				return err
+			}
+			u.Code.Set(newCode)
+		}
+	}
+
+	// validate vendor
+	if u.VendorID.Valid && u.VendorID.String != "" {
+		vnd, err := vendor.UseCase(*u.Ctx, url.Values{}).GetByID(u.VendorID.String)
+		if err != nil {
+			return err
+		}
+		u.VendorID = vnd.ID
+	}
+
+	// license key is never stored as plain text
+	if u.LicenseKey.Valid && u.LicenseKey.String != "" {
+		encrypted, err := app.Crypto().Encrypt(u.LicenseKey.String)
+		if err != nil {
+			return app.Error().New(http.StatusInternalServerError, err.Error())
+		}
+		u.LicenseKey.Set(encrypted)
+	}
+
+	// seats can not be reduced below the assigned seats
+	if u.Seats.Valid && old.ID.Valid && u.Seats.Int64 < old.UsedSeats.Int64 {
+		return app.Error().New(http.StatusBadRequest, u.Ctx.Trans("license_seats_below_used", map[string]string{
+			"seats": strconv.FormatInt(u.Seats.Int64, 10),
+			"used":  strconv.FormatInt(old.UsedSeats.Int64, 10),
+		}))
+	}
+
+	// validate license period
+	purchaseDate, expiryDate := old.PurchaseDate, old.ExpiryDate
+	if u.PurchaseDate.Valid {
+		purchaseDate = u.PurchaseDate
+	}
+	if u.ExpiryDate.Valid {
+		expiryDate = u.ExpiryDate
+	}
+	if purchaseDate.Valid && expiryDate.Valid && expiryDate.Time.Before(purchaseDate.Time) {
+		return app.Error().New(http.StatusBadRequest, u.Ctx.Trans("license_invalid_period"))
+	}
+
+	if u.Ctx.Action.Method == "POST" {
+		if !u.LicenseType.Valid {
+			if u.ExpiryDate.Valid {
+				u.LicenseType.Set("subscription")
+			} else {
+				u.LicenseType.Set("perpetual")
+			}
+		}
+		if !u.AutoRenew.Valid {
+			u.AutoRenew.Set(false)
+		}
+	}
+
+	if !u.NotifyDaysBefore.Valid && !old.NotifyDaysBefore.Valid {
+		u.NotifyDaysBefore.Set(int64(app.LICENSE_EXPIRY_NOTIFY_DAYS))
+	}
+
+	return nil
+}
+
+// GetKeyByID returns the decrypted license key of the License data for the specified ID.
+func (u UseCaseHandler) GetKeyByID(id string) (LicenseKey, error) {
+	res := LicenseKey{}
+
+	// check permission
+	err := u.Ctx.ValidatePermission("licenses.key")
+	if err != nil {
+		return res, err
+	}
+
+	lic, err := u.GetByID(id)
+	if err != nil {
+		return res, err
+	}
+	res.ID = lic.ID
+	res.Product = lic.Product
+
+	// prepare db for current ctx
+	tx, err := u.Ctx.DB()
+	if err != nil {
+		return res, app.Error().New(http.StatusInternalServerError, err.Error())
+	}
+
+	encrypted := ""
+	err = tx.Model(&License{}).Where("id = ?", lic.ID).Select("COALESCE(license_key, '')").Scan(&encrypted).Error
+	if err != nil {
+		return res, app.Error().New(http.StatusInternalServerError, err.Error())
+	}
+	if encrypted != "" {
+		key, err := app.Crypto().Decrypt(encrypted)
+		if err != nil {
+			return res, app.Error().New(http.StatusInternalServerError, err.Error())
+		}
+		res.LicenseKey.Set(key)
+	}
+	return res, nil
+}
+
+// JobNotifyExpiringLicense raises a notification for every license that enters its notification window,
+// i.e. notify_days_before days before the expiry date. Each license is notified once per expiry date.
+func JobNotifyExpiringLicense() {
+	tx, err := app.DB().Conn("main")
+	if err != nil {
+		return
+	}
+
+	type expiringLicense struct {
+		ID         string
+		Code       string
+		Product    string
+		ExpiryDate time.Time
+		AutoRenew  bool
+	}
+	licenses := []expiringLicense{}
+	err = tx.Raw(`
+SELECT l.id, l.code, l.product, l.expiry_date, COALESCE(l.auto_renew, false) AS auto_renew
+FROM licenses l
+WHERE l.deleted_at IS NULL
+  AND l.expiry_date >= CURRENT_DATE
+  AND l.expiry_date - COALESCE(l.notify_days_before, 0) <= CURRENT_DATE
+  AND (l.notified_at IS NULL OR l.notified_at < l.expiry_date - COALESCE(l.notify_days_before, 0))
+`).Scan(&licenses).Error
+	if err != nil {
+		app.Logger().Error().Err(err).Msg("failed to get expiring licenses")
+		return
+	}
+
+	for _, l := range licenses {
+		msg := fmt.Sprintf("The license %s (%s) will expire on %s.", l.Product, l.Code, l.ExpiryDate.Format("2006-01-02"))
+		if l.AutoRenew {
+			msg += " It is set to renew automatically."
+		}
+		n := notification.Notification{}
+		n.Type.Set("license_expiry")
+		n.Title.Set("License expiring")
+		n.Message.Set(msg)
+		n.Endpoint.Set(License{}.EndPoint())
+		n.DataID.Set(l.ID)
+		err = notification.Notify(tx, n)
+		if err != nil {
+			app.Logger().Error().Err(err).Str("license_id", l.ID).Msg("failed to notify expiring license")
+			continue
+		}
+		err = tx.Model(&License{}).Where("id = ?", l.ID).Update("notified_at", time.Now().UTC()).Error
+		if err != nil {
+			app.Logger().Error().Err(err).Str("license_id", l.ID).Msg("failed to update license notified_at")
+		}
+	}
+
+	app.Cache().DeleteWithPrefix(License{}.EndPoint())
+}
@@ -0,0 +1,2 @@
+// licenseseat is a package related to licenseseat data.
+package licenseseat
@@ -0,0 +1,125 @@
+package licenseseat
+
+import "github.com/maulanar/go_asset_tracking_management/app"
+
+// LicenseSeat is the main model of LicenseSeat data. It provides a convenient interface for app.ModelInterface
+type LicenseSeat struct {
+	app.Model
+	ID           app.NullUUID `json:"id"               db:"m.id"               gorm:"column:id;primaryKey"`
+	AssignedDate app.NullDate `json:"assigned_date"    db:"m.assigned_date"    gorm:"column:assigned_date"`
+	ReleasedDate app.NullDate `json:"released_date"    db:"m.released_date"    gorm:"column:released_date"`
+	Description  app.NullText `json:"description"      db:"m.description"      gorm:"column:description"`
+
+	LicenseID      app.NullUUID   `json:"license.id"       db:"m.license_id"       gorm:"column:license_id"`
+	LicenseCode    app.NullString `json:"license.code"     db:"lic.code"           gorm:"-"`
+	LicenseProduct app.NullString `json:"license.product"  db:"lic.product"        gorm:"-"`
+
+	EmployeeID   app.NullUUID   `json:"employee.id"      db:"m.employee_id"      gorm:"column:employee_id"`
+	EmployeeCode app.NullString `json:"employee.code"    db:"emp.code"           gorm:"-"`
+	EmployeeName app.NullString `json:"employee.name"    db:"emp.name"           gorm:"-"`
+
+	AssetID   app.NullUUID   `json:"asset.id"         db:"m.asset_id"         gorm:"column:asset_id"`
+	AssetCode app.NullString `json:"asset.code"       db:"ass.code"           gorm:"-"`
+	AssetName app.NullString `json:"asset.name"       db:"ass.name"           gorm:"-"`
+
+	CreatedAt app.NullDateTime `json:"created_at"       db:"m.created_at"       gorm:"column:created_at"`
+	UpdatedAt app.NullDateTime `json:"updated_at"       db:"m.updated_at"       gorm:"column:updated_at"`
+	DeletedAt app.NullDateTime `json:"deleted_at"       db:"m.deleted_at,hide"  gorm:"column:deleted_at"`
+}
+
+// EndPoint returns the LicenseSeat end point, it used for cache key, etc.
+func (LicenseSeat) EndPoint() string {
+	return "license_seats"
+}
+
+// TableVersion returns the versions of the LicenseSeat table in the database.
+// Change this value with date format YY.MM.DDHHii when any table structure changes.
+func (LicenseSeat) TableVersion() string {
+	return "26.10.191400"
+}
+
+// TableName returns the name of the LicenseSeat table in the database.
+func (LicenseSeat) TableName() string {
+	return "license_seats"
+}
+
+// TableAliasName returns the table alias name of the LicenseSeat table, used for querying.
+func (LicenseSeat) TableAliasName() string {
+	return "m"
+}
+
+// GetRelations returns the relations of the LicenseSeat data in the database, used for querying.
+func (m *LicenseSeat) GetRelations() map[string]map[string]any {
+	m.AddRelation("left", "licenses", "lic", []map[string]any{{"column1": "lic.id", "column2": "m.license_id"}})
+	m.AddRelation("left", "employees", "emp", []map[string]any{{"column1": "emp.id", "column2": "m.employee_id"}})
+	m.AddRelation("left", "assets", "ass", []map[string]any{{"column1": "ass.id", "column2": "m.asset_id"}})
+	return m.Relations
+}
+
+// GetFilters returns the filter of the LicenseSeat data in the database, used for querying.
+func (m *LicenseSeat) GetFilters() []map[string]any {
+	m.AddFilter(map[string]any{"column1": "m.deleted_at", "operator": "=", "value": nil})
+	return m.Filters
+}
+
+// GetSorts returns the default sort of the LicenseSeat data in the database, used for querying.
+func (m *LicenseSeat) GetSorts() []map[string]any {
+	m.AddSort(map[string]any{"column": "m.assigned_date", "direction": "desc"})
+	return m.Sorts
+}
+
+// GetFields returns list of the field of the LicenseSeat data in the database, used for querying.
+func (m *LicenseSeat) GetFields() map[string]map[string]any {
+	m.SetFields(m)
+	return m.Fields
+}
+
+// GetSchema returns the LicenseSeat schema, used for querying.
+func (m *LicenseSeat) GetSchema() map[string]any {
+	return m.SetSchema(m)
+}
+
+// OpenAPISchemaName returns the name of the LicenseSeat schema in the open api documentation.
+func (LicenseSeat) OpenAPISchemaName() string {
+	return "LicenseSeat"
+}
+
+// GetOpenAPISchema returns the Open API Schema of the LicenseSeat in the open api documentation.
+func (m *LicenseSeat) GetOpenAPISchema() map[string]any {
+	return m.SetOpenAPISchema(m)
+}
+
+type LicenseSeatList struct {
+	app.ListModel
+}
+
+// OpenAPISchemaName returns the name of the LicenseSeatList schema in the open api documentation.
+func (LicenseSeatList) OpenAPISchemaName() string {
+	return "LicenseSeatList"
+}
+
+// GetOpenAPISchema returns the Open API Schema of the LicenseSeatList in the open api documentation.
+func (p *LicenseSeatList) GetOpenAPISchema() map[string]any {
+	return p.SetOpenAPISchema(&LicenseSeat{})
+}
+
+// ParamCreate is the expected parameters for create a new LicenseSeat data.
+type ParamCreate struct {
+	UseCaseHandler
+	LicenseID app.NullUUID `json:"license.id"       db:"m.license_id"       gorm:"column:license_id"         validate:"required"`
+}
+
+// ParamUpdate is the expected parameters for update the LicenseSeat data.
+type ParamUpdate struct {
+	UseCaseHandler
+}
+
+// ParamPartiallyUpdate is the expected parameters for partially update the LicenseSeat data.
+type ParamPartiallyUpdate struct {
+	UseCaseHandler
+}
+
+// ParamDelete is the expected parameters for delete the LicenseSeat data.
+type ParamDelete struct {
+	UseCaseHandler
+}
@@ -0,0 +1,147 @@
+package licenseseat
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gofiber/fiber/v2/utils"
+	"gorm.io/gorm"
+
+	"github.com/maulanar/go_asset_tracking_management/app"
+)
+
+// prepareTest prepares the test.
+func prepareTest(tb testing.TB) {
+	app.Test()
+	tx := app.Test().Tx
+	app.DB().RegisterTable("main", LicenseSeat{})
+	app.DB().MigrateTable(tx, "main", app.Setting{})
+	tx.Session(&gorm.Session{AllowGlobalUpdate: true}).Delete(&LicenseSeat{})
+
+	app.Server().AddMiddleware(app.Test().NewCtx([]string{
+		"license_seats.detail",
+		"license_seats.list",
+		"license_seats.create",
+		"license_seats.edit",
+		"license_seats.delete",
+	}))
+	app.Server().AddRoute("/license_seats", "POST", REST().Create, nil)
+	app.Server().AddRoute("/license_seats", "GET", REST().Get, nil)
+	app.Server().AddRoute("/license_seats/:id", "GET", REST().GetByID, nil)
+	app.Server().AddRoute("/license_seats/:id", "PUT", REST().UpdateByID, nil)
+	app.Server().AddRoute("/license_seats/:id", "PATCH", REST().PartiallyUpdateByID, nil)
+	app.Server().AddRoute("/license_seats/:id", "DELETE", REST().DeleteByID, nil)
+}
+
+// getTestLicenseSeatID returns an available LicenseSeat ID.
+func getTestLicenseSeatID() string {
+	return "todo"
+}
+
+// tests is test scenario.
+var tests = []struct {
+	description  string // description of the test case
+	method       string // method to test
+	path         string // route path to test
+	token        string // token to test
+	bodyRequest  string // body to test
+	expectedCode int    // expected HTTP status code
+	expectedBody string // expected body response
+}{
+	{
+		description:  "Get empty list of LicenseSeat",
+		method:       "GET",
+		path:         "/license_seats",
+		token:        app.TestFullAccessToken,
+		expectedCode: http.StatusOK,
+		expectedBody: `{"count":0,"results":[]}`,
+	},
+	{
+		description:  "Create LicenseSeat with minimum payload",
+		method:       "POST",
+		path:         "/license_seats",
+		token:        app.TestFullAccessToken,
+		bodyRequest:  `{"name":"Kilogram"}`,
+		expectedCode: http.StatusCreated,
+		expectedBody: `{"name":"Kilogram"}`,
+	},
+	{
+		description:  "Get LicenseSeat by ID",
+		method:       "GET",
+		path:         "/license_seats/" + getTestLicenseSeatID(),
+		token:        app.TestFullAccessToken,
+		expectedCode: http.StatusOK,
+		expectedBody: `{"name":"Kilogram"}`,
+	},
+	{
+		description:  "Update LicenseSeat by ID",
+		method:       "PUT",
+		path:         "/license_seats/" + getTestLicenseSeatID(),
+		token:        app.TestFullAccessToken,
+		bodyRequest:  `{"reason":"Update LicenseSeat by ID","name":"KG"}`,
+		expectedCode: http.StatusOK,
+		expectedBody: `{"name":"KG"}`,
+	},
+	{
+		description:  "Partially update LicenseSeat by ID",
+		method:       "PATCH",
+		path:         "/license_seats/" + getTestLicenseSeatID(),
+		token:        app.TestFullAccessToken,
+		bodyRequest:  `{"reason":"Partially Update LicenseSeat by ID","name":"Kilo Gram"}`,
+		expectedCode: http.StatusOK,
+		expectedBody: `{"name":"Kilo Gram"}`,
+	},
+	{
+		description:  "Delete LicenseSeat by ID",
+		method:       "DELETE",
+		path:         "/license_seats/" + getTestLicenseSeatID(),
+		token:        app.TestFullAccessToken,
+		bodyRequest:  `{"reason":"Delete LicenseSeat by ID"}`,
+		expectedCode: http.StatusOK,
+		expectedBody: `{"code":200}`,
+	},
+}
+
+// TestLicenseSeatREST tests the REST API of LicenseSeat data with specified scenario.
+func TestLicenseSeatREST(t *testing.T) {
+	prepareTest(t)
+
+	// Iterate through test single test cases
+	for _, test := range tests {
+
+		// Create a new http request with the route from the test case
+		req := httptest.NewRequest(test.method, test.path, strings.NewReader(test.bodyRequest))
+		req.Header.Add("Authorization", "Bearer "+test.token)
+		req.Header.Add("Content-Type", "application/json")
+
+		// Perform the request plain with the app, the second argument is a request latency (set to -1 for no latency)
+		res, err := app.Server().Test(req)
+
+		// Verify if the status code is as expected
+		utils.AssertEqual(t, nil, err, "app.Server().Test(req)")
+		utils.AssertEqual(t, test.expectedCode, res.StatusCode, test.description)
+
+		// Verify if the body response is as expected
+		body, err := io.ReadAll(res.Body)
+		utils.AssertEqual(t, nil, err, "io.ReadAll(res.Body)")
+		app.Test().AssertMatchJSONElement(t, []byte(test.expectedBody), body, test.description)
+		res.Body.Close()
+	}
+}
+
+// BenchmarkLicenseSeatREST tests the REST API of LicenseSeat data with specified scenario.
+func BenchmarkLicenseSeatREST(b *testing.B) {
+	b.ReportAllocs()
+	prepareTest(b)
+	for i := 0; i < b.N; i++ {
+		for _, test := range tests {
+			req := httptest.NewRequest(test.method, test.path, strings.NewReader(test.bodyRequest))
+			req.Header.Add("Authorization", "Bearer "+test.token)
+			req.Header.Add("Content-Type", "application/json")
+			app.Server().Test(req)
+		}
+	}
+}
@@ -0,0 +1,424 @@
+package licenseseat
+
+import (
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"github.com/maulanar/go_asset_tracking_management/app"
+	"github.com/maulanar/go_asset_tracking_management/src/asset"
+	"github.com/maulanar/go_asset_tracking_management/src/employee"
+	"github.com/maulanar/go_asset_tracking_management/src/license"
+)
+
+// UseCase returns a UseCaseHandler for expected use case functional.
+func UseCase(ctx app.Ctx, query ...url.Values) UseCaseHandler {
+	u := UseCaseHandler{
+		Ctx:   &ctx,
+		Query: url.Values{},
+	}
+	if len(query) > 0 {
+		u.Query = query[0]
+	}
+	return u
+}
+
+// UseCaseHandler provides a convenient interface for LicenseSeat use case, use UseCase to access UseCaseHandler.
+type UseCaseHandler struct {
+	LicenseSeat
+
+	// injectable dependencies
+	Ctx   *app.Ctx   `json:"-" db:"-" gorm:"-"`
+	Query url.Values `json:"-" db:"-" gorm:"-"`
+}
+
+// Async return UseCaseHandler with async process.
+func (u UseCaseHandler) Async(ctx app.Ctx, query ...url.Values) UseCaseHandler {
+	ctx.IsAsync = true
+	return UseCase(ctx, query...)
+}
+
+// GetByID returns the LicenseSeat data for the specified ID.
+func (u UseCaseHandler) GetByID(id string) (LicenseSeat, error) {
+	res := LicenseSeat{}
+
+	// check permission
+	err := u.Ctx.ValidatePermission("license_seats.detail")
+	if err != nil {
+		return res, err
+	}
+
+	// get from cache and return if exists
+	cacheKey := u.EndPoint() + "." + id
+	app.Cache().Get(cacheKey, &res)
+	if res.ID.Valid {
+		return res, err
+	}
+
+	// prepare db for current ctx
+	tx, err := u.Ctx.DB()
+	if err != nil {
+		return res, app.Error().New(http.StatusInternalServerError, err.Error())
+	}
+
+	// get from db
+	key := "id"
+	if !app.Validator().IsValid(id, "uuid") {
+		key = "code"
+	}
+	u.Query.Add(key, id)
+	err = app.Query().First(tx, &res, u.Query)
+	if err != nil {
+		return res, u.Ctx.NotFoundError(err, u.EndPoint(), key, id)
+	}
+
+	// save to cache and return if exists
+	app.Cache().Set(cacheKey, res)
+	return res, err
+}
+
+// Get returns the list of LicenseSeat data.
+func (u UseCaseHandler) Get() (app.ListModel, error) {
+	res := app.ListModel{}
+
+	// check permission
+	err := u.Ctx.ValidatePermission("license_seats.list")
+	if err != nil {
+		return res, err
+	}
+	// get from cache and return if exists
+	cacheKey := u.EndPoint() + "?" + u.Query.Encode()
+	err = app.Cache().Get(cacheKey, &res)
+	if err == nil {
+		return res, err
+	}
+
+	// prepare db for current ctx
+	tx, err := u.Ctx.DB()
+	if err != nil {
+		return res, app.Error().New(http.StatusInternalServerError, err.Error())
+	}
+
+	// set pagination info
+	res.Results.PageContext.Count,
+		res.Results.PageContext.Page,
+		res.Results.PageContext.PerPage,
+		res.Results.PageContext.PageCount,
+		err = app.Query().PaginationInfo(tx, &LicenseSeat{}, u.Query)
+	if err != nil {
+		return res, app.Error().New(http.StatusInternalServerError, err.Error())
+	}
+	// return data count if $per_page set to 0
+	if res.Results.PageContext.PerPage == 0 {
+		return res, err
+	}
+
+	// find data
+	data, err := app.Query().Find(tx, &LicenseSeat{}, u.Query)
+	if err != nil {
+		return res, app.Error().New(http.StatusInternalServerError, err.Error())
+	}
+	res.SetData(data, u.Query)
+
+	// save to cache and return if exists
+	app.Cache().Set(cacheKey, res)
+	return res, err
+}
+
+// Create creates a new data LicenseSeat with specified parameters.
+func (u *UseCaseHandler) Create(p *ParamCreate) error {
+
+	// check permission
+	err := u.Ctx.ValidatePermission("license_seats.create")
+	if err != nil {
+		return err
+	}
+
+	// validate param
+	err = u.Ctx.ValidateParam(p)
+	if err != nil {
+		return err
+	}
+
+	// set default value for undefined field
+	err = u.setDefaultValue(LicenseSeat{})
+	if err != nil {
+		return err
+	}
+
+	// prepare db for current ctx
+	tx, err := u.Ctx.DB()
+	if err != nil {
+		return app.Error().New(http.StatusInternalServerError, err.Error())
+	}
+
+	// save data to db
+	err = tx.Model(&u).Create(&u).Error
+	if err != nil {
+		return app.Error().New(http.StatusInternalServerError, err.Error())
+	}
+
+	// invalidate cache
+	app.Cache().Invalidate(u.EndPoint())
+	app.Cache().Invalidate(license.License{}.EndPoint(), u.LicenseID.String)
+
+	// save history (user activity), send webhook, etc
+	go u.Ctx.Hook("POST", "create", p.ID.String, p)
+	return nil
+}
+
+// UpdateByID updates the LicenseSeat data for the specified ID with specified parameters.
+func (u UseCaseHandler) UpdateByID(id string, p *ParamUpdate) error {
+
+	// check permission
+	err := u.Ctx.ValidatePermission("license_seats.edit")
+	if err != nil {
+		return err
+	}
+
+	// validate param
+	err = u.Ctx.ValidateParam(p)
+	if err != nil {
+		return err
+	}
+
+	// get previous data
+	old, err := u.GetByID(id)
+	if err != nil {
+		return err
+	}
+
+	// set default value for undefined field
+	err = u.setDefaultValue(old)
+	if err != nil {
+		return err
+	}
+
+	// prepare db for current ctx
+	tx, err := u.Ctx.DB()
+	if err != nil {
+		return app.Error().New(http.StatusInternalServerError, err.Error())
+	}
+
+	// update data on the db
+	err = tx.Model(&u).Where("id = ?", old.ID).Updates(u).Error
+	if err != nil {
+		return app.Error().New(http.StatusInternalServerError, err.Error())
+	}
+
+	// invalidate cache
+	app.Cache().Invalidate(u.EndPoint(), old.ID.String)
+	app.Cache().Invalidate(license.License{}.EndPoint(), old.LicenseID.String)
+
+	// save history (user activity), send webhook, etc
+	go u.Ctx.Hook("PUT", "Update", old.ID.String, old)
+	return nil
+}
+
+// PartiallyUpdateByID updates the LicenseSeat data for the specified ID with specified parameters.
+func (u UseCaseHandler) PartiallyUpdateByID(id string, p *ParamPartiallyUpdate) error {
+
+	// check permission
+	err := u.Ctx.ValidatePermission("license_seats.edit")
+	if err != nil {
+		return err
+	}
+
+	// validate param
+	err = u.Ctx.ValidateParam(p)
+	if err != nil {
+		return err
+	}
+
+	// get previous data
+	old, err := u.GetByID(id)
+	if err != nil {
+		return err
+	}
+
+	// set default value for undefined field
+	err = u.setDefaultValue(old)
+	if err != nil {
+		return err
+	}
+
+	// prepare db for current ctx
+	tx, err := u.Ctx.DB()
+	if err != nil {
+		return app.Error().New(http.StatusInternalServerError, err.Error())
+	}
+
+	// update data on the db
+	err = tx.Model(&u).Where("id = ?", old.ID).Updates(u).Error
+	if err != nil {
+		return app.Error().New(http.StatusInternalServerError, err.Error())
+	}
+
+	// invalidate cache
+	app.Cache().Invalidate(u.EndPoint(), old.ID.String)
+	app.Cache().Invalidate(license.License{}.EndPoint(), old.LicenseID.String)
+
+	// save history (user activity), send webhook, etc
+	go u.Ctx.Hook("PATCH", "Partially Update", old.ID.String, old)
+	return nil
+}
+
+// DeleteByID deletes the LicenseSeat data for the specified ID.
+func (u UseCaseHandler) DeleteByID(id string, p *ParamDelete) error {
+
+	// check permission
+	err := u.Ctx.ValidatePermission("license_seats.delete")
+	if err != nil {
+		return err
+	}
+
+	// validate param
+	err = u.Ctx.ValidateParam(p)
+	if err != nil {
+		return err
+	}
+
+	// get previous data
+	old, err := u.GetByID(id)
+	if err != nil {
+		return err
+	}
+
+	// prepare db for current ctx
+	tx, err := u.Ctx.DB()
+	if err != nil {
+		return app.Error().New(http.StatusInternalServerError, err.Error())
+	}
+
+	// update data on the db
+	err = tx.Model(&p).Where("id = ?", old.ID).Update("deleted_at", time.Now().UTC()).Error
+	if err != nil {
+		return app.Error().New(http.StatusInternalServerError, err.Error())
+	}
+
+	// invalidate cache
+	app.Cache().Invalidate(u.EndPoint(), old.ID.String)
+	app.Cache().Invalidate(license.License{}.EndPoint(), old.LicenseID.String)
+
+	// save history (user activity), send webhook, etc
+	go u.Ctx.Hook("DELETE", "DELETE", old.ID.String, old)
+	return nil
+}
+
+// setDefaultValue set default value of undefined field when create or update LicenseSeat data.
+func (u *UseCaseHandler) setDefaultValue(old LicenseSeat) error {
+	if !old.ID.Valid {
+		u.ID = app.NewNullUUID()
+	} else {
+		u.ID = old.ID
+	}
+
+	if old.ID.Valid {
+		// license and assignee can not be changed, release the seat and assign a new one instead
+		u.LicenseID = old.LicenseID
+		u.EmployeeID = old.EmployeeID
+		u.AssetID = old.AssetID
+		if !u.AssignedDate.Valid {
+			u.AssignedDate = old.AssignedDate
+		}
+	} else {
+		err := u.assign()
+		if err != nil {
+			return err
+		}
+	}
+
+	if !u.AssignedDate.Valid {
+		u.AssignedDate.Set(time.Now())
+	}
+	if u.ReleasedDate.Valid && u.ReleasedDate.Time.Before(u.AssignedDate.Time) {
+		return app.Error().New(http.StatusBadRequest, u.Ctx.Trans("license_seat_invalid_period"))
+	}
+
+	return nil
+}
+
+// assign validates the assignee of a new seat and refuses the seat when the license is fully allocated.
+func (u *UseCaseHandler) assign() error {
+	hasEmployee := u.EmployeeID.Valid && u.EmployeeID.String != ""
+	hasAsset := u.AssetID.Valid && u.AssetID.String != ""
+	if hasEmployee == hasAsset {
+		return app.Error().New(http.StatusBadRequest, u.Ctx.Trans("license_seat_assignee_required"))
+	}
+
+	lic, err := license.UseCase(*u.Ctx, url.Values{}).GetByID(u.LicenseID.String)
+	if err != nil {
+		return err
+	}
+	u.LicenseID = lic.ID
+
+	assignee := ""
+	if hasEmployee {
+		emp, err := employee.UseCase(*u.Ctx, url.Values{}).GetByID(u.EmployeeID.String)
+		if err != nil {
+			return err
+		}
+		u.EmployeeID = emp.ID
+		assignee = emp.Name.String
+	} else {
+		ass, err := asset.UseCase(*u.Ctx, url.Values{}).GetByID(u.AssetID.String)
+		if err != nil {
+			return err
+		}
+		u.AssetID = ass.ID
+		assignee = ass.Name.String
+	}
+
+	// prepare db for current ctx
+	tx, err := u.Ctx.DB()
+	if err != nil {
+		return app.Error().New(http.StatusInternalServerError, err.Error())
+	}
+
+	// lock the license row so concurrent assignments can not exceed the seats
+	seats := int64(0)
+	err = tx.Model(&license.License{}).Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where("id = ?", lic.ID).Select("COALESCE(seats, 0)").Scan(&seats).Error
+	if err != nil {
+		return app.Error().New(http.StatusInternalServerError, err.Error())
+	}
+
+	active := tx.Model(&LicenseSeat{}).
+		Where("license_id = ?", lic.ID).
+		Where("released_date IS NULL").
+		Where("deleted_at IS NULL")
+
+	duplicate := int64(0)
+	if hasEmployee {
+		err = active.Session(&gorm.Session{}).Where("employee_id = ?", u.EmployeeID).Count(&duplicate).Error
+	} else {
+		err = active.Session(&gorm.Session{}).Where("asset_id = ?", u.AssetID).Count(&duplicate).Error
+	}
+	if err != nil {
+		return app.Error().New(http.StatusInternalServerError, err.Error())
+	}
+	if duplicate > 0 {
+		return app.Error().New(http.StatusBadRequest, u.Ctx.Trans("license_seat_already_assigned", map[string]string{
+			"product":  lic.Product.String,
+			"assignee": assignee,
+		}))
+	}
+
+	used := int64(0)
+	err = active.Session(&gorm.Session{}).Count(&used).Error
+	if err != nil {
+		return app.Error().New(http.StatusInternalServerError, err.Error())
+	}
+	if used >= seats {
+		return app.Error().New(http.StatusBadRequest, u.Ctx.Trans("license_seat_exceeded", map[string]string{
+			"product": lic.Product.String,
+			"seats":   strconv.FormatInt(seats, 10),
+		}))
+	}
+
+	return nil
+}
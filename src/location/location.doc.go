@@ -0,0 +1,2 @@
+// location is a package related to location data.
+package location
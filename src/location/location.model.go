@@ -0,0 +1,132 @@
+package location
+
+import "github.com/maulanar/go_asset_tracking_management/app"
+
+// Location is the main model of Location data. It provides a convenient interface for app.ModelInterface
+type Location struct {
+	app.Model
+	ID          app.NullUUID   `json:"id"          db:"m.id"              gorm:"column:id;primaryKey"`
+	Code        app.NullString `json:"code"        db:"m.code"            gorm:"column:code"`
+	Name        app.NullString `json:"name"        db:"m.name"            gorm:"column:name"`
+	Type        app.NullString `json:"type"        db:"m.type"            gorm:"column:type"        validate:"omitempty,oneof=building floor room"`
+	Path        app.NullText   `json:"path"        db:"m.path"            gorm:"column:path"`
+	Description app.NullText   `json:"description" db:"m.description"     gorm:"column:description"`
+	IsActive    app.NullBool   `json:"is_active"   db:"m.is_active"       gorm:"column:is_active;default:true"`
+
+	ParentID   app.NullUUID   `json:"parent.id"   db:"m.parent_id"       gorm:"column:parent_id"`
+	ParentCode app.NullString `json:"parent.code" db:"par.code"          gorm:"-"`
+	ParentName app.NullString `json:"parent.name" db:"par.name"          gorm:"-"`
+	ParentType app.NullString `json:"parent.type" db:"par.type"          gorm:"-"`
+
+	BranchID   app.NullUUID   `json:"branch.id"   db:"m.branch_id"       gorm:"column:branch_id"`
+	BranchCode app.NullString `json:"branch.code" db:"brc.code"          gorm:"-"`
+	BranchName app.NullString `json:"branch.name" db:"brc.name"          gorm:"-"`
+
+	CreatedAt app.NullDateTime `json:"created_at"  db:"m.created_at"      gorm:"column:created_at"`
+	UpdatedAt app.NullDateTime `json:"updated_at"  db:"m.updated_at"      gorm:"column:updated_at"`
+	DeletedAt app.NullDateTime `json:"deleted_at"  db:"m.deleted_at,hide" gorm:"column:deleted_at"`
+}
+
+// ParentTypes maps each location type to the type of its parent location, a building is placed directly under a branch.
+var ParentTypes = map[string]string{
+	"building": "",
+	"floor":    "building",
+	"room":     "floor",
+}
+
+// EndPoint returns the Location end point, it used for cache key, etc.
+func (Location) EndPoint() string {
+	return "locations"
+}
+
+// TableVersion returns the versions of the Location table in the database.
+// Change this value with date format YY.MM.DDHHii when any table structure changes.
+func (Location) TableVersion() string {
+	return "26.10.191500"
+}
+
+// TableName returns the name of the Location table in the database.
+func (Location) TableName() string {
+	return "locations"
+}
+
+// TableAliasName returns the table alias name of the Location table, used for querying.
+func (Location) TableAliasName() string {
+	return "m"
+}
+
+// GetRelations returns the relations of the Location data in the database, used for querying.
+func (m *Location) GetRelations() map[string]map[string]any {
+	m.AddRelation("left", "locations", "par", []map[string]any{{"column1": "par.id", "column2": "m.parent_id"}})
+	m.AddRelation("left", "branches", "brc", []map[string]any{{"column1": "brc.id", "column2": "m.branch_id"}})
+	return m.Relations
+}
+
+// GetFilters returns the filter of the Location data in the database, used for querying.
+func (m *Location) GetFilters() []map[string]any {
+	m.AddFilter(map[string]any{"column1": "m.deleted_at", "operator": "=", "value": nil})
+	return m.Filters
+}
+
+// GetSorts returns the default sort of the Location data in the database, used for querying.
+func (m *Location) GetSorts() []map[string]any {
+	m.AddSort(map[string]any{"column": "m.path", "direction": "asc"})
+	return m.Sorts
+}
+
+// GetFields returns list of the field of the Location data in the database, used for querying.
+func (m *Location) GetFields() map[string]map[string]any {
+	m.SetFields(m)
+	return m.Fields
+}
+
+// GetSchema returns the Location schema, used for querying.
+func (m *Location) GetSchema() map[string]any {
+	return m.SetSchema(m)
+}
+
+// OpenAPISchemaName returns the name of the Location schema in the open api documentation.
+func (Location) OpenAPISchemaName() string {
+	return "Location"
+}
+
+// GetOpenAPISchema returns the Open API Schema of the Location in the open api documentation.
+func (m *Location) GetOpenAPISchema() map[string]any {
+	return m.SetOpenAPISchema(m)
+}
+
+type LocationList struct {
+	app.ListModel
+}
+
+// OpenAPISchemaName returns the name of the LocationList schema in the open api documentation.
+func (LocationList) OpenAPISchemaName() string {
+	return "LocationList"
+}
+
+// GetOpenAPISchema returns the Open API Schema of the LocationList in the open api documentation.
+func (p *LocationList) GetOpenAPISchema() map[string]any {
+	return p.SetOpenAPISchema(&Location{})
+}
+
+// ParamCreate is the expected parameters for create a new Location data.
+type ParamCreate struct {
+	UseCaseHandler
+	Name app.NullString `json:"name"        db:"m.name"            gorm:"column:name"        validate:"required"`
+	Type app.NullString `json:"type"        db:"m.type"            gorm:"column:type"        validate:"required,oneof=building floor room"`
+}
+
+// ParamUpdate is the expected parameters for update the Location data.
+type ParamUpdate struct {
+	UseCaseHandler
+}
+
+// ParamPartiallyUpdate is the expected parameters for partially update the Location data.
+type ParamPartiallyUpdate struct {
+	UseCaseHandler
+}
+
+// ParamDelete is the expected parameters for delete the Location data.
+type ParamDelete struct {
+	UseCaseHandler
+}
@@ -0,0 +1,147 @@
+package location
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gofiber/fiber/v2/utils"
+	"gorm.io/gorm"
+
+	"github.com/maulanar/go_asset_tracking_management/app"
+)
+
+// prepareTest prepares the test.
+func prepareTest(tb testing.TB) {
+	app.Test()
+	tx := app.Test().Tx
+	app.DB().RegisterTable("main", Location{})
+	app.DB().MigrateTable(tx, "main", app.Setting{})
+	tx.Session(&gorm.Session{AllowGlobalUpdate: true}).Delete(&Location{})
+
+	app.Server().AddMiddleware(app.Test().NewCtx([]string{
+		"locations.detail",
+		"locations.list",
+		"locations.create",
+		"locations.edit",
+		"locations.delete",
+	}))
+	app.Server().AddRoute("/locations", "POST", REST().Create, nil)
+	app.Server().AddRoute("/locations", "GET", REST().Get, nil)
+	app.Server().AddRoute("/locations/:id", "GET", REST().GetByID, nil)
+	app.Server().AddRoute("/locations/:id", "PUT", REST().UpdateByID, nil)
+	app.Server().AddRoute("/locations/:id", "PATCH", REST().PartiallyUpdateByID, nil)
+	app.Server().AddRoute("/locations/:id", "DELETE", REST().DeleteByID, nil)
+}
+
+// getTestLocationID returns an available Location ID.
+func getTestLocationID() string {
+	return "todo"
+}
+
+// tests is test scenario.
+var tests = []struct {
+	description  string // description of the test case
+	method       string // method to test
+	path         string // route path to test
+	token        string // token to test
+	bodyRequest  string // body to test
+	expectedCode int    // expected HTTP status code
+	expectedBody string // expected body response
+}{
+	{
+		description:  "Get empty list of Location",
+		method:       "GET",
+		path:         "/locations",
+		token:        app.TestFullAccessToken,
+		expectedCode: http.StatusOK,
+		expectedBody: `{"count":0,"results":[]}`,
+	},
+	{
+		description:  "Create Location with minimum payload",
+		method:       "POST",
+		path:         "/locations",
+		token:        app.TestFullAccessToken,
+		bodyRequest:  `{"name":"Kilogram"}`,
+		expectedCode: http.StatusCreated,
+		expectedBody: `{"name":"Kilogram"}`,
+	},
+	{
+		description:  "Get Location by ID",
+		method:       "GET",
+		path:         "/locations/" + getTestLocationID(),
+		token:        app.TestFullAccessToken,
+		expectedCode: http.StatusOK,
+		expectedBody: `{"name":"Kilogram"}`,
+	},
+	{
+		description:  "Update Location by ID",
+		method:       "PUT",
+		path:         "/locations/" + getTestLocationID(),
+		token:        app.TestFullAccessToken,
+		bodyRequest:  `{"reason":"Update Location by ID","name":"KG"}`,
+		expectedCode: http.StatusOK,
+		expectedBody: `{"name":"KG"}`,
+	},
+	{
+		description:  "Partially update Location by ID",
+		method:       "PATCH",
+		path:         "/locations/" + getTestLocationID(),
+		token:        app.TestFullAccessToken,
+		bodyRequest:  `{"reason":"Partially Update Location by ID","name":"Kilo Gram"}`,
+		expectedCode: http.StatusOK,
+		expectedBody: `{"name":"Kilo Gram"}`,
+	},
+	{
+		description:  "Delete Location by ID",
+		method:       "DELETE",
+		path:         "/locations/" + getTestLocationID(),
+		token:        app.TestFullAccessToken,
+		bodyRequest:  `{"reason":"Delete Location by ID"}`,
+		expectedCode: http.StatusOK,
+		expectedBody: `{"code":200}`,
+	},
+}
+
+// TestLocationREST tests the REST API of Location data with specified scenario.
+func TestLocationREST(t *testing.T) {
+	prepareTest(t)
+
+	// Iterate through test single test cases
+	for _, test := range tests {
+
+		// Create a new http request with the route from the test case
+		req := httptest.NewRequest(test.method, test.path, strings.NewReader(test.bodyRequest))
+		req.Header.Add("Authorization", "Bearer "+test.token)
+		req.Header.Add("Content-Type", "application/json")
+
+		// Perform the request plain with the app, the second argument is a request latency (set to -1 for no latency)
+		res, err := app.Server().Test(req)
+
+		// Verify if the status code is as expected
+		utils.AssertEqual(t, nil, err, "app.Server().Test(req)")
+		utils.AssertEqual(t, test.expectedCode, res.StatusCode, test.description)
+
+		// Verify if the body response is as expected
+		body, err := io.ReadAll(res.Body)
+		utils.AssertEqual(t, nil, err, "io.ReadAll(res.Body)")
+		app.Test().AssertMatchJSONElement(t, []byte(test.expectedBody), body, test.description)
+		res.Body.Close()
+	}
+}
+
+// BenchmarkLocationREST tests the REST API of Location data with specified scenario.
+func BenchmarkLocationREST(b *testing.B) {
+	b.ReportAllocs()
+	prepareTest(b)
+	for i := 0; i < b.N; i++ {
+		for _, test := range tests {
+			req := httptest.NewRequest(test.method, test.path, strings.NewReader(test.bodyRequest))
+			req.Header.Add("Authorization", "Bearer "+test.token)
+			req.Header.Add("Content-Type", "application/json")
+			app.Server().Test(req)
+		}
+	}
+}
@@ -0,0 +1,473 @@
+package location
+
+import (
+	"net/http"
+	"net/url"
+	"time"
+
+	"gorm.io/gorm"
+
+	"github.com/maulanar/go_asset_tracking_management/app"
+	"github.com/maulanar/go_asset_tracking_management/src/branch"
+)
+
+// UseCase returns a UseCaseHandler for expected use case functional.
+func UseCase(ctx app.Ctx, query ...url.Values) UseCaseHandler {
+	u := UseCaseHandler{
+		Ctx:   &ctx,
+		Query: url.Values{},
+	}
+	if len(query) > 0 {
+		u.Query = query[0]
+	}
+	return u
+}
+
+// UseCaseHandler provides a convenient interface for Location use case, use UseCase to access UseCaseHandler.
+type UseCaseHandler struct {
+	Location
+
+	// injectable dependencies
+	Ctx   *app.Ctx   `json:"-" db:"-" gorm:"-"`
+	Query url.Values `json:"-" db:"-" gorm:"-"`
+}
+
+// Async return UseCaseHandler with async process.
+func (u UseCaseHandler) Async(ctx app.Ctx, query ...url.Values) UseCaseHandler {
+	ctx.IsAsync = true
+	return UseCase(ctx, query...)
+}
+
+// GetByID returns the Location data for the specified ID.
+func (u UseCaseHandler) GetByID(id string) (Location, error) {
+	res := Location{}
+
+	// check permission
+	err := u.Ctx.ValidatePermission("locations.detail")
+	if err != nil {
+		return res, err
+	}
+
+	// get from cache and return if exists
+	cacheKey := u.EndPoint() + "." + id
+	app.Cache().Get(cacheKey, &res)
+	if res.ID.Valid {
+		return res, err
+	}
+
+	// prepare db for current ctx
+	tx, err := u.Ctx.DB()
+	if err != nil {
+		return res, app.Error().New(http.StatusInternalServerError, err.Error())
+	}
+
+	// get from db
+	key := "id"
+	if !app.Validator().IsValid(id, "uuid") {
+		key = "code"
+	}
+	u.Query.Add(key, id)
+	err = app.Query().First(tx, &res, u.Query)
+	if err != nil {
+		return res, u.Ctx.NotFoundError(err, u.EndPoint(), key, id)
+	}
+
+	// save to cache and return if exists
+	app.Cache().Set(cacheKey, res)
+	return res, err
+}
+
+// Get returns the list of Location data.
+func (u UseCaseHandler) Get() (app.ListModel, error) {
+	res := app.ListModel{}
+
+	// check permission
+	err := u.Ctx.ValidatePermission("locations.list")
+	if err != nil {
+		return res, err
+	}
+	// get from cache and return if exists
+	cacheKey := u.EndPoint() + "?" + u.Query.Encode()
+	err = app.Cache().Get(cacheKey, &res)
+	if err == nil {
+		return res, err
+	}
+
+	// prepare db for current ctx
+	tx, err := u.Ctx.DB()
+	if err != nil {
+		return res, app.Error().New(http.StatusInternalServerError, err.Error())
+	}
+
+	// set pagination info
+	res.Results.PageContext.Count,
+		res.Results.PageContext.Page,
+		res.Results.PageContext.PerPage,
+		res.Results.PageContext.PageCount,
+		err = app.Query().PaginationInfo(tx, &Location{}, u.Query)
+	if err != nil {
+		return res, app.Error().New(http.StatusInternalServerError, err.Error())
+	}
+	// return data count if $per_page set to 0
+	if res.Results.PageContext.PerPage == 0 {
+		return res, err
+	}
+
+	// find data
+	data, err := app.Query().Find(tx, &Location{}, u.Query)
+	if err != nil {
+		return res, app.Error().New(http.StatusInternalServerError, err.Error())
+	}
+	res.SetData(data, u.Query)
+
+	// save to cache and return if exists
+	app.Cache().Set(cacheKey, res)
+	return res, err
+}
+
+// Create creates a new data Location with specified parameters.
+func (u *UseCaseHandler) Create(p *ParamCreate) error {
+
+	// check permission
+	err := u.Ctx.ValidatePermission("locations.create")
+	if err != nil {
+		return err
+	}
+
+	// validate param
+	err = u.Ctx.ValidateParam(p)
+	if err != nil {
+		return err
+	}
+
+	// set default value for undefined field
+	err = u.setDefaultValue(Location{})
+	if err != nil {
+		return err
+	}
+
+	// prepare db for current ctx
+	tx, err := u.Ctx.DB()
+	if err != nil {
+		return app.Error().New(http.StatusInternalServerError, err.Error())
+	}
+
+	// save data to db
+	err = tx.Model(&u).Create(&u).Error
+	if err != nil {
+		return app.Error().New(http.StatusInternalServerError, err.Error())
+	}
+
+	// invalidate cache
+	app.Cache().Invalidate(u.EndPoint())
+
+	// save history (user activity), send webhook, etc
+	go u.Ctx.Hook("POST", "create", p.ID.String, p)
+	return nil
+}
+
+// UpdateByID updates the Location data for the specified ID with specified parameters.
+func (u UseCaseHandler) UpdateByID(id string, p *ParamUpdate) error {
+
+	// check permission
+	err := u.Ctx.ValidatePermission("locations.edit")
+	if err != nil {
+		return err
+	}
+
+	// validate param
+	err = u.Ctx.ValidateParam(p)
+	if err != nil {
+		return err
+	}
+
+	// get previous data
+	old, err := u.GetByID(id)
+	if err != nil {
+		return err
+	}
+
+	// set default value for undefined field
+	err = u.setDefaultValue(old)
+	if err != nil {
+		return err
+	}
+
+	// prepare db for current ctx
+	tx, err := u.Ctx.DB()
+	if err != nil {
+		return app.Error().New(http.StatusInternalServerError, err.Error())
+	}
+
+	// update data on the db
+	err = tx.Model(&u).Where("id = ?", old.ID).Updates(u).Error
+	if err != nil {
+		return app.Error().New(http.StatusInternalServerError, err.Error())
+	}
+
+	// keep the path and branch of the descendants in sync
+	if u.Path.String != old.Path.String || u.BranchID.String != old.BranchID.String {
+		err = u.updateDescendants(tx)
+		if err != nil {
+			return err
+		}
+	}
+
+	// invalidate cache
+	app.Cache().Invalidate(u.EndPoint(), old.ID.String)
+
+	// save history (user activity), send webhook, etc
+	go u.Ctx.Hook("PUT", "Update", old.ID.String, old)
+	return nil
+}
+
+// PartiallyUpdateByID updates the Location data for the specified ID with specified parameters.
+func (u UseCaseHandler) PartiallyUpdateByID(id string, p *ParamPartiallyUpdate) error {
+
+	// check permission
+	err := u.Ctx.ValidatePermission("locations.edit")
+	if err != nil {
+		return err
+	}
+
+	// validate param
+	err = u.Ctx.ValidateParam(p)
+	if err != nil {
+		return err
+	}
+
+	// get previous data
+	old, err := u.GetByID(id)
+	if err != nil {
+		return err
+	}
+
+	// set default value for undefined field
+	err = u.setDefaultValue(old)
+	if err != nil {
+		return err
+	}
+
+	// prepare db for current ctx
+	tx, err := u.Ctx.DB()
+	if err != nil {
+		return app.Error().New(http.StatusInternalServerError, err.Error())
+	}
+
+	// update data on the db
+	err = tx.Model(&u).Where("id = ?", old.ID).Updates(u).Error
+	if err != nil {
+		return app.Error().New(http.StatusInternalServerError, err.Error())
+	}
+
+	// keep the path and branch of the descendants in sync
+	if u.Path.String != old.Path.String || u.BranchID.String != old.BranchID.String {
+		err = u.updateDescendants(tx)
+		if err != nil {
+			return err
+		}
+	}
+
+	// invalidate cache
+	app.Cache().Invalidate(u.EndPoint(), old.ID.String)
+
+	// save history (user activity), send webhook, etc
+	go u.Ctx.Hook("PATCH", "Partially Update", old.ID.String, old)
+	return nil
+}
+
+// DeleteByID deletes the Location data for the specified ID.
+func (u UseCaseHandler) DeleteByID(id string, p *ParamDelete) error {
+
+	// check permission
+	err := u.Ctx.ValidatePermission("locations.delete")
+	if err != nil {
+		return err
+	}
+
+	// validate param
+	err = u.Ctx.ValidateParam(p)
+	if err != nil {
+		return err
+	}
+
+	// get previous data
+	old, err := u.GetByID(id)
+	if err != nil {
+		return err
+	}
+
+	// prepare db for current ctx
+	tx, err := u.Ctx.DB()
+	if err != nil {
+		return app.Error().New(http.StatusInternalServerError, err.Error())
+	}
+
+	// location with child locations or assets can not be deleted
+	count := int64(0)
+	err = tx.Raw(`
+SELECT (SELECT COUNT(*) FROM locations WHERE parent_id = @id AND deleted_at IS NULL)
+     + (SELECT COUNT(*) FROM assets WHERE location_id = @id AND deleted_at IS NULL)`,
+		map[string]any{"id": old.ID.String}).Scan(&count).Error
+	if err != nil {
+		return app.Error().New(http.StatusInternalServerError, err.Error())
+	}
+	if count > 0 {
+		return app.Error().New(http.StatusBadRequest, u.Ctx.Trans("location_in_use", map[string]string{
+			"name": old.Path.String,
+		}))
+	}
+
+	// update data on the db
+	err = tx.Model(&p).Where("id = ?", old.ID).Update("deleted_at", time.Now().UTC()).Error
+	if err != nil {
+		return app.Error().New(http.StatusInternalServerError, err.Error())
+	}
+
+	// invalidate cache
+	app.Cache().Invalidate(u.EndPoint(), old.ID.String)
+
+	// save history (user activity), send webhook, etc
+	go u.Ctx.Hook("DELETE", "DELETE", old.ID.String, old)
+	return nil
+}
+
+// setDefaultValue set default value of undefined field when create or update Location data.
+func (u *UseCaseHandler) setDefaultValue(old Location) error {
+	if !old.ID.Valid {
+		u.ID = app.NewNullUUID()
+	} else {
+		u.ID = old.ID
+		u.Type = old.Type
+	}
+
+	if !u.Name.Valid || u.Name.String == "" {
+		u.Name = old.Name
+	}
+
+	// Penentuan kode
+	if u.Code.Valid && u.Code.String != "" {
+		// Jika kode dikirim dan berbeda dengan data lama, cek ke DB
+		if !old.Code.Valid || u.Code.String != old.Code.String {
+			err := app.Common().IsFieldValueExists(u.Ctx, u.EndPoint(), "Code", u.TableName(), "code", u.Code.String)
+			if err != nil {
+				return err
+			}
+		}
+	} else {
+		// Jika tidak kirim kode dan data lama ada, gunakan data lama
+		if old.Code.Valid && old.Code.String != "" {
+			u.Code = old.Code
+		} else {
+			// Jika tidak kirim kode dan data lama tidak ada, generate baru
+			newCode, err := app.Common().GenerateCode(u.Ctx, u.TableName(), "code", u.Name.String)
+			if err != nil {
+				return err
+			}
+			u.Code.Set(newCode)
+		}
+	}
+
+	// validate parent, the level follows branch → building → floor → room
+	if !u.ParentID.Valid || u.ParentID.String == "" {
+		u.ParentID = old.ParentID
+	}
+	parentType := ParentTypes[u.Type.String]
+	if parentType == "" {
+		if u.ParentID.Valid && u.ParentID.String != "" {
+			return app.Error().New(http.StatusBadRequest, u.Ctx.Trans("location_parent_invalid", map[string]string{
+				"type":        u.Type.String,
+				"parent_type": "branch",
+			}))
+		}
+		if !u.BranchID.Valid || u.BranchID.String == "" {
+			u.BranchID = old.BranchID
+		}
+		if !u.BranchID.Valid || u.BranchID.String == "" {
+			return app.Error().New(http.StatusBadRequest, u.Ctx.Trans("required_key", map[string]string{"key": "branch.id"}))
+		}
+		brc, err := branch.UseCase(*u.Ctx, url.Values{}).GetByID(u.BranchID.String)
+		if err != nil {
+			return err
+		}
+		u.BranchID = brc.ID
+		u.Path.Set(u.Name.String)
+	} else {
+		if !u.ParentID.Valid || u.ParentID.String == "" {
+			return app.Error().New(http.StatusBadRequest, u.Ctx.Trans("required_key", map[string]string{"key": "parent.id"}))
+		}
+		parent, err := UseCase(*u.Ctx, url.Values{}).GetByID(u.ParentID.String)
+		if err != nil {
+			return err
+		}
+		if parent.Type.String != parentType {
+			return app.Error().New(http.StatusBadRequest, u.Ctx.Trans("location_parent_invalid", map[string]string{
+				"type":        u.Type.String,
+				"parent_type": parentType,
+			}))
+		}
+		u.ParentID = parent.ID
+		u.BranchID = parent.BranchID
+		u.Path.Set(parent.Path.String + " / " + u.Name.String)
+	}
+
+	if u.Ctx.Action.Method == "POST" {
+		if !u.IsActive.Valid {
+			u.IsActive.Set(true)
+		}
+	}
+
+	return nil
+}
+
+// updateDescendants recomputes the path and branch of all descendants of the location.
+func (u *UseCaseHandler) updateDescendants(tx *gorm.DB) error {
+	err := tx.Exec(`
+WITH RECURSIVE tree AS (
+  SELECT l.id, l.path, l.branch_id
+  FROM locations l
+  WHERE l.id = @id
+  UNION ALL
+  SELECT c.id, tree.path || ' / ' || c.name, tree.branch_id
+  FROM locations c
+  JOIN tree ON c.parent_id = tree.id
+  WHERE c.deleted_at IS NULL
+)
+UPDATE locations l
+SET path = tree.path, branch_id = tree.branch_id
+FROM tree
+WHERE l.id = tree.id AND l.id <> @id`, map[string]any{"id": u.ID.String}).Error
+	if err != nil {
+		return app.Error().New(http.StatusInternalServerError, err.Error())
+	}
+	app.Cache().DeleteWithPrefix(u.EndPoint())
+	return nil
+}
+
+// GetSubtreeIDs returns the ids of the specified location and all of its descendants.
+func (u UseCaseHandler) GetSubtreeIDs(id string) ([]string, error) {
+	ids := []string{}
+
+	loc, err := u.GetByID(id)
+	if err != nil {
+		return ids, err
+	}
+
+	// prepare db for current ctx
+	tx, err := u.Ctx.DB()
+	if err != nil {
+		return ids, app.Error().New(http.StatusInternalServerError, err.Error())
+	}
+
+	err = tx.Raw(`
+WITH RECURSIVE tree AS (
+  SELECT l.id FROM locations l WHERE l.id = ?
+  UNION ALL
+  SELECT c.id FROM locations c JOIN tree ON c.parent_id = tree.id WHERE c.deleted_at IS NULL
+)
+SELECT id FROM tree`, loc.ID.String).Scan(&ids).Error
+	if err != nil {
+		return ids, app.Error().New(http.StatusInternalServerError, err.Error())
+	}
+	return ids, nil
+}
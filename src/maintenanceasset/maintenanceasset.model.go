@@ -5,26 +5,34 @@ import "github.com/maulanar/go_asset_tracking_management/app"
 // MaintenanceAsset is the main model of MaintenanceAsset data. It provides a convenient interface for app.ModelInterface
 type MaintenanceAsset struct {
 	app.Model
-	ID          app.NullUUID    `json:"id"                                db:"m.id"                              gorm:"column:id;primaryKey"`
-	Code        app.NullString  `json:"code"                              db:"m.code"                            gorm:"column:code"`
-	Date        app.NullDate    `json:"date"                              db:"m.date"                            gorm:"column:date"`
-	Description app.NullText    `json:"description"                       db:"m.description"                     gorm:"column:description"`
-	Amount      app.NullFloat64 `json:"amount"                            db:"m.amount"                          gorm:"column:amount"`
+	ID          app.NullUUID           `json:"id"                                db:"m.id"                              gorm:"column:id;primaryKey"`
+	Code        app.NullString         `json:"code"                              db:"m.code"                            gorm:"column:code"`
+	Date        app.NullDate           `json:"date"                              db:"m.date"                            gorm:"column:date"`
+	Description app.NullText           `json:"description"                       db:"m.description"                     gorm:"column:description"`
+	Amount      app.NullFloat64        `json:"amount"                            db:"m.amount"                          gorm:"column:amount"`
+	PartsAmount app.NullFloat64        `json:"parts_amount"                      db:"m.parts_amount"                    gorm:"column:parts_amount"`
+	TotalAmount app.NullFloat64        `json:"total_amount"                      db:"m.total_amount"                    gorm:"column:total_amount"`
+	Parts       []MaintenanceAssetPart `json:"parts"                             db:"-"                                 gorm:"-"                            validate:"omitempty,dive"`
 
-	AssetID                         app.NullUUID    `json:"asset.id"                          db:"m.asset_id"                      gorm:"column:asset_id"`
+	// a capitalized maintenance cost is added to the carrying amount of the asset on the maintenance date
+	// and the economic age of the asset is extended by the life extension months
+	IsCapitalized       app.NullBool  `json:"is_capitalized"                    db:"m.is_capitalized"                  gorm:"column:is_capitalized;default:false"`
+	LifeExtensionMonths app.NullInt64 `json:"life_extension_months"             db:"m.life_extension_months"           gorm:"column:life_extension_months" validate:"omitempty,gte=0"`
+
+	AssetID                         app.NullUUID    `json:"asset.id"                          db:"m.asset_id"                        gorm:"column:asset_id"`
 	AssetCode                       app.NullString  `json:"asset.code"                        db:"ass.code"                          gorm:"-"`
 	AssetName                       app.NullString  `json:"asset.name"                        db:"ass.name"                          gorm:"-"`
 	AssetInputDate                  app.NullDate    `json:"asset.input_date"                  db:"ass.input_date"                    gorm:"-"`
 	AssetPrice                      app.NullFloat64 `json:"asset.price"                       db:"ass.price"                         gorm:"-"`
 	AssetAttachmentID               app.NullUUID    `json:"asset.attachment.id"               db:"ass.attachment_id"                 gorm:"-"`
-	AssetAttachmentName             app.NullText    `json:"asset.attachment.name"             db:"assatt.name"                          gorm:"-"`
-	AssetAttachmentPath             app.NullText    `json:"asset.attachment.path"             db:"assatt.path"                          gorm:"-"`
-	AssetAttachmentURL              app.NullText    `json:"asset.attachment.url"              db:"assatt.url"                           gorm:"-"`
+	AssetAttachmentName             app.NullText    `json:"asset.attachment.name"             db:"assatt.name"                       gorm:"-"`
+	AssetAttachmentPath             app.NullText    `json:"asset.attachment.path"             db:"assatt.path"                       gorm:"-"`
+	AssetAttachmentURL              app.NullText    `json:"asset.attachment.url"              db:"assatt.url"                        gorm:"-"`
 	AssetCategoryID                 app.NullUUID    `json:"asset.category.id"                 db:"ass.category_id"                   gorm:"-"`
-	AssetCategoryCode               app.NullString  `json:"asset.category.code"               db:"asscat.code"                          gorm:"-"`
-	AssetCategoryName               app.NullString  `json:"asset.category.name"               db:"asscat.name"                          gorm:"-"`
-	AssetCategoryEconomicAges       app.NullInt64   `json:"asset.category.economic_age"       db:"asscat.economic_age"                  gorm:"-"`
-	AssetCategoryDescription        app.NullText    `json:"asset.category.description"        db:"asscat.description"                   gorm:"-"`
+	AssetCategoryCode               app.NullString  `json:"asset.category.code"               db:"asscat.code"                       gorm:"-"`
+	AssetCategoryName               app.NullString  `json:"asset.category.name"               db:"asscat.name"                       gorm:"-"`
+	AssetCategoryEconomicAges       app.NullInt64   `json:"asset.category.economic_age"       db:"asscat.economic_age"               gorm:"-"`
+	AssetCategoryDescription        app.NullText    `json:"asset.category.description"        db:"asscat.description"                gorm:"-"`
 	AssetAssignDate                 app.NullDate    `json:"asset.assign_date"                 db:"emp_ass.assign_date"               gorm:"-"`
 	AssetConditionID                app.NullUUID    `json:"asset.condition.id"                db:"emp_ass.condition_id"              gorm:"-"`
 	AssetConditionCode              app.NullString  `json:"asset.condition.code"              db:"emp_ass_cond.code"                 gorm:"-"`
@@ -34,13 +42,13 @@ type MaintenanceAsset struct {
 	AssetDepartmentCode             app.NullString  `json:"asset.department.code"             db:"emp_ass_dpt.code"                  gorm:"-"`
 	AssetDepartmentName             app.NullString  `json:"asset.department.name"             db:"emp_ass_dpt.name"                  gorm:"-"`
 	AssetDepartmentDescription      app.NullText    `json:"asset.department.description"      db:"emp_ass_dpt.description"           gorm:"-"`
-	AssetEmployeeID                 app.NullUUID    `json:"asset.employee.id"                 db:"emp_ass_emp.id"                            gorm:"-"`
-	AssetEmployeeCode               app.NullString  `json:"asset.employee.code"               db:"emp_ass_emp.code"                          gorm:"-"`
-	AssetEmployeeName               app.NullString  `json:"asset.employee.name"               db:"emp_ass_emp.name"                          gorm:"-"`
-	AssetEmployeeAddress            app.NullText    `json:"asset.employee.address"            db:"emp_ass_emp.address"                       gorm:"-"`
-	AssetEmployeePhone              app.NullString  `json:"asset.employee.phone"              db:"emp_ass_emp.phone"                         gorm:"-"`
-	AssetEmployeeEmail              app.NullString  `json:"asset.employee.email"              db:"emp_ass_emp.email"                         gorm:"-"`
-	AssetEmployeeIsActive           app.NullBool    `json:"asset.employee.is_active"          db:"emp_ass_emp.is_active"                     gorm:"-"`
+	AssetEmployeeID                 app.NullUUID    `json:"asset.employee.id"                 db:"emp_ass_emp.id"                    gorm:"-"`
+	AssetEmployeeCode               app.NullString  `json:"asset.employee.code"               db:"emp_ass_emp.code"                  gorm:"-"`
+	AssetEmployeeName               app.NullString  `json:"asset.employee.name"               db:"emp_ass_emp.name"                  gorm:"-"`
+	AssetEmployeeAddress            app.NullText    `json:"asset.employee.address"            db:"emp_ass_emp.address"               gorm:"-"`
+	AssetEmployeePhone              app.NullString  `json:"asset.employee.phone"              db:"emp_ass_emp.phone"                 gorm:"-"`
+	AssetEmployeeEmail              app.NullString  `json:"asset.employee.email"              db:"emp_ass_emp.email"                 gorm:"-"`
+	AssetEmployeeIsActive           app.NullBool    `json:"asset.employee.is_active"          db:"emp_ass_emp.is_active"             gorm:"-"`
 	AssetBranchID                   app.NullUUID    `json:"asset.branch.id"                   db:"emp_ass_brc.id"                    gorm:"-"`
 	AssetBranchCode                 app.NullString  `json:"asset.branch.code"                 db:"emp_ass_brc.code"                  gorm:"-"`
 	AssetBranchName                 app.NullString  `json:"asset.branch.name"                 db:"emp_ass_brc.name"                  gorm:"-"`
@@ -51,11 +59,12 @@ type MaintenanceAsset struct {
 	AssetSalvageAmount              app.NullFloat64 `json:"asset.salvage.amount"              db:"ass.salvage_amount"                gorm:"-"`
 	AssetCurrentValue               app.NullFloat64 `json:"asset.current.amount"              db:"ass.current_amount"                gorm:"-"`
 
-	MaintenanceTypeID          app.NullUUID   `json:"maintenance_type.id"               db:"m.maintenance_type_id"             gorm:"column:maintenance_type_id"`
-	MaintenanceTypeCode        app.NullString `json:"maintenance_type.code"             db:"mt.code"                           gorm:"-"`
-	MaintenanceTypeName        app.NullString `json:"maintenance_type.name"             db:"mt.name"                           gorm:"-"`
-	MaintenanceTypeDescription app.NullText   `json:"maintenance_type.description"      db:"mt.description"                    gorm:"-"`
-	MaintenanceTypeIsActive    app.NullBool   `json:"maintenance_type.is_active"        db:"mt.is_active"                      gorm:"-"`
+	MaintenanceTypeID              app.NullUUID   `json:"maintenance_type.id"               db:"m.maintenance_type_id"             gorm:"column:maintenance_type_id"`
+	MaintenanceTypeCode            app.NullString `json:"maintenance_type.code"             db:"mt.code"                           gorm:"-"`
+	MaintenanceTypeName            app.NullString `json:"maintenance_type.name"             db:"mt.name"                           gorm:"-"`
+	MaintenanceTypeDescription     app.NullText   `json:"maintenance_type.description"      db:"mt.description"                    gorm:"-"`
+	MaintenanceTypeIsActive        app.NullBool   `json:"maintenance_type.is_active"        db:"mt.is_active"                      gorm:"-"`
+	MaintenanceTypeIsCapitalizable app.NullBool   `json:"maintenance_type.is_capitalizable" db:"mt.is_capitalizable"               gorm:"-"`
 
 	EmployeeId                     app.NullUUID   `json:"employee.id"                       db:"m.employee_id"                     gorm:"column:employee_id"`
 	EmployeeCode                   app.NullString `json:"employee.code"                     db:"emp.code"                          gorm:"-"`
@@ -82,6 +91,11 @@ type MaintenanceAsset struct {
 	EmployeeJobPositionDescription app.NullText   `json:"employee.job_position.description" db:"empjp.description"                 gorm:"-"`
 	EmployeeIsActive               app.NullBool   `json:"employee.is_active"                db:"emp.is_active"                     gorm:"-"`
 
+	IsClaimable      app.NullBool   `json:"is_claimable"                      db:"m.is_claimable"                    gorm:"column:is_claimable"`
+	WarrantyID       app.NullUUID   `json:"warranty.id"                       db:"m.warranty_id"                     gorm:"column:warranty_id"`
+	WarrantyProvider app.NullString `json:"warranty.provider"                 db:"war.provider"                      gorm:"-"`
+	WarrantyEndDate  app.NullDate   `json:"warranty.end_date"                 db:"war.end_date"                      gorm:"-"`
+
 	AttachmentId   app.NullUUID `json:"attachment.id"                     db:"m.attachment_id"                   gorm:"column:attachment_id"`
 	AttachmentName app.NullText `json:"attachment.name"                   db:"att.name"                          gorm:"-"`
 	AttachmentPath app.NullText `json:"attachment.path"                   db:"att.path"                          gorm:"-"`
@@ -100,7 +114,7 @@ func (MaintenanceAsset) EndPoint() string {
 // TableVersion returns the versions of the MaintenanceAsset table in the database.
 // Change this value with date format YY.MM.DDHHii when any table structure changes.
 func (MaintenanceAsset) TableVersion() string {
-	return "28.06.291152"
+	return "26.10.192500"
 }
 
 // TableName returns the name of the MaintenanceAsset table in the database.
@@ -128,8 +142,9 @@ func (m *MaintenanceAsset) GetRelations() map[string]map[string]any {
          ea.employee_id
   FROM employee_assets ea
   WHERE ea.deleted_at IS NULL
+    AND ea.return_date IS NULL
   ORDER BY ea.asset_id, ea.assign_date DESC, ea.id DESC
-)`, "emp_ass", []map[string]any{{"column1": "emp_ass.asset_id", "column2": "m.id"}})
+)`, "emp_ass", []map[string]any{{"column1": "emp_ass.asset_id", "column2": "m.asset_id"}})
 
 	m.AddRelation("left", "conditions", "emp_ass_cond", []map[string]any{{"column1": "emp_ass_cond.id", "column2": "emp_ass.condition_id"}})
 	m.AddRelation("left", "employees", "emp_ass_emp", []map[string]any{{"column1": "emp_ass_emp.id", "column2": "emp_ass.employee_id"}})
@@ -144,6 +159,7 @@ func (m *MaintenanceAsset) GetRelations() map[string]map[string]any {
 	m.AddRelation("left", "job_positions", "empjp", []map[string]any{{"column1": "empjp.id", "column2": "emp.job_position_id"}})
 
 	m.AddRelation("left", "attachments", "att", []map[string]any{{"column1": "att.id", "column2": "m.attachment_id"}})
+	m.AddRelation("left", "warranties", "war", []map[string]any{{"column1": "war.id", "column2": "m.warranty_id"}})
 	// m.AddRelation("left", "users", "cu", []map[string]any{{"column1": "cu.id", "column2": "m.created_by_user_id"}})
 	// m.AddRelation("left", "users", "cu", []map[string]any{{"column1": "cu.id", "column2": "m.created_by_user_id"}})
 	// m.AddRelation("left", "users", "cu", []map[string]any{{"column1": "cu.id", "column2": "m.created_by_user_id"}})
@@ -220,3 +236,88 @@ type ParamPartiallyUpdate struct {
 type ParamDelete struct {
 	UseCaseHandler
 }
+
+// MaintenanceAssetPart is the spare part line of MaintenanceAsset data, the part is issued from the stock of the branch.
+type MaintenanceAssetPart struct {
+	app.Model
+	ID                 app.NullUUID    `json:"id"                   db:"m.id"                   gorm:"column:id;primaryKey"`
+	MaintenanceAssetID app.NullUUID    `json:"maintenance_asset.id" db:"m.maintenance_asset_id" gorm:"column:maintenance_asset_id"`
+	Qty                app.NullFloat64 `json:"qty"                  db:"m.qty"                  gorm:"column:qty"                  validate:"required,gt=0"`
+	UnitCost           app.NullFloat64 `json:"unit_cost"            db:"m.unit_cost"            gorm:"column:unit_cost"            validate:"omitempty,gte=0"`
+	Amount             app.NullFloat64 `json:"amount"               db:"m.amount"               gorm:"column:amount"`
+	StockMovementID    app.NullUUID    `json:"stock_movement.id"    db:"m.stock_movement_id"    gorm:"column:stock_movement_id"`
+
+	ConsumableID   app.NullUUID   `json:"consumable.id"        db:"m.consumable_id"        gorm:"column:consumable_id"        validate:"required"`
+	ConsumableCode app.NullString `json:"consumable.code"      db:"cns.code"               gorm:"-"`
+	ConsumableName app.NullString `json:"consumable.name"      db:"cns.name"               gorm:"-"`
+	ConsumableUnit app.NullString `json:"consumable.unit"      db:"cns.unit"               gorm:"-"`
+
+	BranchID   app.NullUUID   `json:"branch.id"            db:"m.branch_id"            gorm:"column:branch_id"`
+	BranchCode app.NullString `json:"branch.code"          db:"brc.code"               gorm:"-"`
+	BranchName app.NullString `json:"branch.name"          db:"brc.name"               gorm:"-"`
+
+	CreatedAt app.NullDateTime `json:"created_at"           db:"m.created_at"           gorm:"column:created_at"`
+	UpdatedAt app.NullDateTime `json:"updated_at"           db:"m.updated_at"           gorm:"column:updated_at"`
+	DeletedAt app.NullDateTime `json:"deleted_at"           db:"m.deleted_at,hide"      gorm:"column:deleted_at"`
+}
+
+// EndPoint returns the MaintenanceAssetPart end point, it used for cache key, etc.
+func (MaintenanceAssetPart) EndPoint() string {
+	return "maintenance_asset_parts"
+}
+
+// TableVersion returns the versions of the MaintenanceAssetPart table in the database.
+// Change this value with date format YY.MM.DDHHii when any table structure changes.
+func (MaintenanceAssetPart) TableVersion() string {
+	return "26.10.192500"
+}
+
+// TableName returns the name of the MaintenanceAssetPart table in the database.
+func (MaintenanceAssetPart) TableName() string {
+	return "maintenance_asset_parts"
+}
+
+// TableAliasName returns the table alias name of the MaintenanceAssetPart table, used for querying.
+func (MaintenanceAssetPart) TableAliasName() string {
+	return "m"
+}
+
+// GetRelations returns the relations of the MaintenanceAssetPart data in the database, used for querying.
+func (m *MaintenanceAssetPart) GetRelations() map[string]map[string]any {
+	m.AddRelation("left", "consumables", "cns", []map[string]any{{"column1": "cns.id", "column2": "m.consumable_id"}})
+	m.AddRelation("left", "branches", "brc", []map[string]any{{"column1": "brc.id", "column2": "m.branch_id"}})
+	return m.Relations
+}
+
+// GetFilters returns the filter of the MaintenanceAssetPart data in the database, used for querying.
+func (m *MaintenanceAssetPart) GetFilters() []map[string]any {
+	m.AddFilter(map[string]any{"column1": "m.deleted_at", "operator": "=", "value": nil})
+	return m.Filters
+}
+
+// GetSorts returns the default sort of the MaintenanceAssetPart data in the database, used for querying.
+func (m *MaintenanceAssetPart) GetSorts() []map[string]any {
+	m.AddSort(map[string]any{"column": "m.created_at", "direction": "asc"})
+	return m.Sorts
+}
+
+// GetFields returns list of the field of the MaintenanceAssetPart data in the database, used for querying.
+func (m *MaintenanceAssetPart) GetFields() map[string]map[string]any {
+	m.SetFields(m)
+	return m.Fields
+}
+
+// GetSchema returns the MaintenanceAssetPart schema, used for querying.
+func (m *MaintenanceAssetPart) GetSchema() map[string]any {
+	return m.SetSchema(m)
+}
+
+// OpenAPISchemaName returns the name of the MaintenanceAssetPart schema in the open api documentation.
+func (MaintenanceAssetPart) OpenAPISchemaName() string {
+	return "MaintenanceAssetPart"
+}
+
+// GetOpenAPISchema returns the Open API Schema of the MaintenanceAssetPart in the open api documentation.
+func (m *MaintenanceAssetPart) GetOpenAPISchema() map[string]any {
+	return m.SetOpenAPISchema(m)
+}
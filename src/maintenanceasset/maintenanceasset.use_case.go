@@ -1,11 +1,23 @@
 package maintenanceasset
 
 import (
+	"encoding/json"
 	"net/http"
 	"net/url"
 	"time"
 
+	"gorm.io/gorm"
+	"grest.dev/grest"
+
 	"github.com/maulanar/go_asset_tracking_management/app"
+	"github.com/maulanar/go_asset_tracking_management/src/asset"
+	"github.com/maulanar/go_asset_tracking_management/src/attachment"
+	"github.com/maulanar/go_asset_tracking_management/src/consumable"
+	"github.com/maulanar/go_asset_tracking_management/src/employee"
+	"github.com/maulanar/go_asset_tracking_management/src/fiscalperiod"
+	"github.com/maulanar/go_asset_tracking_management/src/maintenancetype"
+	"github.com/maulanar/go_asset_tracking_management/src/stockmovement"
+	"github.com/maulanar/go_asset_tracking_management/src/warranty"
 )
 
 // UseCase returns a UseCaseHandler for expected use case functional.
@@ -69,6 +81,12 @@ func (u UseCaseHandler) GetByID(id string) (MaintenanceAsset, error) {
 		return res, u.Ctx.NotFoundError(err, u.EndPoint(), key, id)
 	}
 
+	// get spare parts
+	res.Parts, err = u.GetParts(res.ID.String)
+	if err != nil {
+		return res, err
+	}
+
 	// save to cache and return if exists
 	app.Cache().Set(cacheKey, res)
 	return res, err
@@ -155,6 +173,12 @@ func (u *UseCaseHandler) Create(p *ParamCreate) error {
 		return app.Error().New(http.StatusInternalServerError, err.Error())
 	}
 
+	// issue the spare parts from the stock
+	err = u.saveParts(tx, MaintenanceAsset{})
+	if err != nil {
+		return err
+	}
+
 	// invalidate cache
 	app.Cache().Invalidate(u.EndPoint())
 
@@ -202,6 +226,12 @@ func (u UseCaseHandler) UpdateByID(id string, p *ParamUpdate) error {
 		return app.Error().New(http.StatusInternalServerError, err.Error())
 	}
 
+	// issue the spare parts from the stock
+	err = u.saveParts(tx, old)
+	if err != nil {
+		return err
+	}
+
 	// invalidate cache
 	app.Cache().Invalidate(u.EndPoint(), old.ID.String)
 
@@ -249,6 +279,12 @@ func (u UseCaseHandler) PartiallyUpdateByID(id string, p *ParamPartiallyUpdate)
 		return app.Error().New(http.StatusInternalServerError, err.Error())
 	}
 
+	// issue the spare parts from the stock
+	err = u.saveParts(tx, old)
+	if err != nil {
+		return err
+	}
+
 	// invalidate cache
 	app.Cache().Invalidate(u.EndPoint(), old.ID.String)
 
@@ -290,6 +326,20 @@ func (u UseCaseHandler) DeleteByID(id string, p *ParamDelete) error {
 		return app.Error().New(http.StatusInternalServerError, err.Error())
 	}
 
+	// return the spare parts to the stock
+	err = u.reverseParts(tx, old)
+	if err != nil {
+		return err
+	}
+
+	// remove the capitalized cost from the carrying amount of the asset
+	if old.IsCapitalized.Bool && old.TotalAmount.Float64 > 0 {
+		err = asset.UseCase(*u.Ctx, url.Values{}).Uncapitalize(old.AssetID.String, old.ID.String, old.LifeExtensionMonths.Int64)
+		if err != nil {
+			return err
+		}
+	}
+
 	// invalidate cache
 	app.Cache().Invalidate(u.EndPoint(), old.ID.String)
 
@@ -306,5 +356,342 @@ func (u *UseCaseHandler) setDefaultValue(old MaintenanceAsset) error {
 		u.ID = old.ID
 	}
 
+	// Penentuan kode
+	if u.Code.Valid && u.Code.String != "" {
+		// Jika kode dikirim dan berbeda dengan data lama, cek ke DB
+		if !old.Code.Valid || u.Code.String != old.Code.String {
+			err := app.Common().IsFieldValueExists(u.Ctx, u.EndPoint(), "Code", u.TableName(), "code", u.Code.String)
+			if err != nil {
+				return err
+			}
+		}
+	} else {
+		// Jika tidak kirim kode dan data lama ada, gunakan data lama
+		if old.Code.Valid && old.Code.String != "" {
+			u.Code = old.Code
+		} else {
+			// Jika tidak kirim kode dan data lama tidak ada, generate baru
+			newCode, err := app.Common().GenerateCode(u.Ctx, u.TableName(), "code", "Maintenance")
+			if err != nil {
+				return err
+			}
+			u.Code.Set(newCode)
+		}
+	}
+
+	// validate asset, the maintenance of a disposed or deleted asset is rejected
+	assetID := old.AssetID
+	if u.AssetID.Valid && u.AssetID.String != "" {
+		assetID = u.AssetID
+	}
+	if assetID.Valid && assetID.String != old.AssetID.String {
+		err := u.validateAsset(assetID.String)
+		if err != nil {
+			return err
+		}
+	}
+
+	// validate maintenance type, the capitalizable flag of a new maintenance defaults to its type
+	if u.MaintenanceTypeID.Valid && u.MaintenanceTypeID.String != "" && u.MaintenanceTypeID.String != old.MaintenanceTypeID.String {
+		mt, err := maintenancetype.UseCase(*u.Ctx, url.Values{}).GetByID(u.MaintenanceTypeID.String)
+		if err != nil {
+			return err
+		}
+		if !old.ID.Valid && !u.IsCapitalized.Valid {
+			u.IsCapitalized = mt.IsCapitalizable
+		}
+	}
+	if !old.ID.Valid && !u.IsCapitalized.Valid {
+		u.IsCapitalized.Set(false)
+	}
+
+	// validate employee
+	if u.EmployeeId.Valid && u.EmployeeId.String != "" && u.EmployeeId.String != old.EmployeeId.String {
+		_, err := employee.UseCase(*u.Ctx, url.Values{}).GetByID(u.EmployeeId.String)
+		if err != nil {
+			return err
+		}
+	}
+
+	// validate attachment (maintenance invoice or report)
+	if u.AttachmentId.Valid && u.AttachmentId.String != "" {
+		attUC := attachment.UseCase(*u.Ctx, url.Values{})
+		att, err := attUC.GetByID(u.AttachmentId.String)
+		if err != nil {
+			return err
+		}
+
+		// Update data attachment
+		upAtt := attachment.ParamUpdate{}
+		upAtt.Endpoint.Set(u.EndPoint())
+		upAtt.DataId.Set(u.ID.String)
+		err = attUC.UpdateByID(att.ID.String, &upAtt)
+		if err != nil {
+			return err
+		}
+	}
+
+	date := old.Date
+	if u.Date.Valid {
+		date = u.Date
+	}
+	if !date.Valid {
+		date.Set(time.Now())
+	}
+	if u.Amount.Valid && u.Amount.Float64 < 0 {
+		return app.Error().New(http.StatusBadRequest, u.Ctx.Trans("maintenance_amount_invalid"))
+	}
+
+	// the cost of the spare parts is added to the amount of the maintenance
+	err := u.setParts(old)
+	if err != nil {
+		return err
+	}
+
+	// the dates and the amounts inside a closed fiscal period are locked
+	if !old.ID.Valid || !date.Time.Equal(old.Date.Time) || u.TotalAmount.Float64 != old.TotalAmount.Float64 {
+		err := fiscalperiod.UseCase(*u.Ctx, url.Values{}).ValidateOpen(old.Date, date)
+		if err != nil {
+			return err
+		}
+	}
+
+	// flag as claimable when the asset is under warranty on the maintenance date
+	if assetID.Valid && assetID.String != "" {
+		war, err := warranty.UseCase(*u.Ctx, url.Values{}).GetActiveByAssetID(assetID.String, date.Time)
+		if err != nil && app.Error().StatusCode(err) != http.StatusNotFound {
+			return err
+		}
+		u.IsClaimable.Set(err == nil)
+		u.WarrantyID = war.ID
+	}
+
+	return u.setCapitalization(old, assetID, date)
+}
+
+// setCapitalization posts the capitalized cost of the maintenance to the depreciation ledger of the asset,
+// a changed capitalization removes the previous entry first, so it is only allowed while that entry is the last posted entry.
+func (u *UseCaseHandler) setCapitalization(old MaintenanceAsset, assetID app.NullUUID, date app.NullDate) error {
+	isCapitalized, amount, lifeExtension := old.IsCapitalized, u.TotalAmount, old.LifeExtensionMonths
+	if u.IsCapitalized.Valid {
+		isCapitalized = u.IsCapitalized
+	}
+	if u.LifeExtensionMonths.Valid {
+		lifeExtension = u.LifeExtensionMonths
+	}
+	if !isCapitalized.Bool && lifeExtension.Int64 > 0 {
+		return app.Error().New(http.StatusBadRequest, u.Ctx.Trans("maintenance_life_extension_invalid"))
+	}
+	if old.ID.Valid &&
+		isCapitalized.Bool == old.IsCapitalized.Bool &&
+		amount.Float64 == old.TotalAmount.Float64 &&
+		lifeExtension.Int64 == old.LifeExtensionMonths.Int64 &&
+		assetID.String == old.AssetID.String &&
+		date.Time.Equal(old.Date.Time) {
+		return nil
+	}
+
+	assetUC := asset.UseCase(*u.Ctx, url.Values{})
+	if old.ID.Valid && old.IsCapitalized.Bool && old.TotalAmount.Float64 > 0 {
+		err := assetUC.Uncapitalize(old.AssetID.String, old.ID.String, old.LifeExtensionMonths.Int64)
+		if err != nil {
+			return err
+		}
+	}
+	if isCapitalized.Bool && amount.Float64 > 0 {
+		if date.Time.After(time.Now()) {
+			return app.Error().New(http.StatusBadRequest, u.Ctx.Trans("maintenance_capitalization_date_future"))
+		}
+		_, err := assetUC.Capitalize(assetID.String, u.ID.String, date.Time, amount.Float64, lifeExtension.Int64)
+		if err != nil {
+			return err
+		}
+	}
 	return nil
 }
+
+// validateAsset validates that the asset of the maintenance exists and has not been disposed or deleted.
+func (u *UseCaseHandler) validateAsset(assetID string) error {
+	ass, err := asset.UseCase(*u.Ctx, url.Values{}).GetByID(assetID)
+	if err != nil {
+
+		// the soft deleted asset is not found by GetByID, tell it apart from the unknown one
+		tx, txErr := u.Ctx.DB()
+		if txErr != nil {
+			return err
+		}
+		deleted := asset.Asset{}
+		txErr = tx.Select("id", "code").Where("id = ?", assetID).Where("deleted_at IS NOT NULL").Limit(1).Find(&deleted).Error
+		if txErr == nil && deleted.ID.Valid {
+			return app.Error().New(http.StatusBadRequest, u.Ctx.Trans("asset_deleted", map[string]string{"code": deleted.Code.String}))
+		}
+		return err
+	}
+	if ass.Status.String == asset.StatusDisposed {
+		return app.Error().New(http.StatusBadRequest, u.Ctx.Trans("asset_disposed", map[string]string{"code": ass.Code.String}))
+	}
+	return nil
+}
+
+// setParts prepares the spare parts of the MaintenanceAsset data and sets the parts amount and the total amount,
+// the branch of a part defaults to the branch of the employee and its unit cost defaults to the unit cost of the spare part.
+func (u *UseCaseHandler) setParts(old MaintenanceAsset) error {
+	amount, partsAmount := old.Amount, old.PartsAmount
+	if u.Amount.Valid {
+		amount = u.Amount
+	}
+	if len(u.Parts) > 0 {
+		employeeID := old.EmployeeId
+		if u.EmployeeId.Valid && u.EmployeeId.String != "" {
+			employeeID = u.EmployeeId
+		}
+		emp := employee.Employee{}
+		total := float64(0)
+		for i := range u.Parts {
+			part := &u.Parts[i]
+			err := u.Ctx.ValidateParam(part)
+			if err != nil {
+				return err
+			}
+			cns, err := consumable.UseCase(*u.Ctx, url.Values{}).GetByID(part.ConsumableID.String)
+			if err != nil {
+				return err
+			}
+			if cns.Type.String != consumable.TypeSparePart || !cns.IsActive.Bool {
+				return app.Error().New(http.StatusBadRequest, u.Ctx.Trans("maintenance_part_invalid", map[string]string{"code": cns.Code.String}))
+			}
+			if !part.BranchID.Valid || part.BranchID.String == "" {
+				if !emp.ID.Valid && employeeID.Valid {
+					emp, err = employee.UseCase(*u.Ctx, url.Values{}).GetByID(employeeID.String)
+					if err != nil {
+						return err
+					}
+				}
+				part.BranchID = emp.BranchID
+			}
+			if !part.BranchID.Valid || part.BranchID.String == "" {
+				return app.Error().New(http.StatusBadRequest, u.Ctx.Trans("required_key", map[string]string{"key": "parts.branch.id"}))
+			}
+			if !part.UnitCost.Valid {
+				part.UnitCost = cns.UnitCost
+			}
+			part.ID = app.NewNullUUID()
+			part.MaintenanceAssetID = u.ID
+			part.Amount.Set(part.Qty.Float64 * part.UnitCost.Float64)
+			total += part.Amount.Float64
+		}
+		partsAmount.Set(total)
+		u.PartsAmount = partsAmount
+	}
+	u.TotalAmount.Set(amount.Float64 + partsAmount.Float64)
+	return nil
+}
+
+// saveParts replaces the spare parts of the MaintenanceAsset data with the prepared u.Parts,
+// the previous parts are returned to the stock and the new parts are issued from the stock of their branch.
+func (u *UseCaseHandler) saveParts(tx *gorm.DB, old MaintenanceAsset) error {
+	if len(u.Parts) == 0 {
+		return nil
+	}
+	err := u.reverseParts(tx, old)
+	if err != nil {
+		return err
+	}
+
+	date, employeeID := old.Date, old.EmployeeId
+	if u.Date.Valid {
+		date = u.Date
+	}
+	if u.EmployeeId.Valid {
+		employeeID = u.EmployeeId
+	}
+	for _, part := range u.Parts {
+		smUC := stockmovement.UseCase(*u.Ctx, url.Values{})
+		smUC.Type.Set("issue")
+		smUC.Qty = part.Qty
+		smUC.ConsumableID = part.ConsumableID
+		smUC.BranchID = part.BranchID
+		smUC.Date = date
+		smUC.EmployeeID = employeeID
+		smUC.Description.Set("Maintenance " + u.Code.String)
+		err = smUC.Create(&stockmovement.ParamCreate{
+			UseCaseHandler: smUC,
+			Type:           smUC.Type,
+			Qty:            smUC.Qty,
+			ConsumableID:   smUC.ConsumableID,
+			BranchID:       smUC.BranchID,
+		})
+		if err != nil {
+			return err
+		}
+		part.StockMovementID = smUC.ID
+		err = tx.Create(&part).Error
+		if err != nil {
+			return app.Error().New(http.StatusInternalServerError, err.Error())
+		}
+	}
+	return nil
+}
+
+// reverseParts returns the spare parts of the MaintenanceAsset data to the stock with an adjustment and deletes them.
+func (u *UseCaseHandler) reverseParts(tx *gorm.DB, old MaintenanceAsset) error {
+	if !old.ID.Valid {
+		return nil
+	}
+	for _, part := range old.Parts {
+		if !part.StockMovementID.Valid {
+			continue
+		}
+		smUC := stockmovement.UseCase(*u.Ctx, url.Values{})
+		smUC.Type.Set("adjustment")
+		smUC.Qty = part.Qty
+		smUC.ConsumableID = part.ConsumableID
+		smUC.BranchID = part.BranchID
+		smUC.Date.Set(time.Now())
+		smUC.Description.Set("Reversal of maintenance " + old.Code.String)
+		err := smUC.Create(&stockmovement.ParamCreate{
+			UseCaseHandler: smUC,
+			Type:           smUC.Type,
+			Qty:            smUC.Qty,
+			ConsumableID:   smUC.ConsumableID,
+			BranchID:       smUC.BranchID,
+		})
+		if err != nil {
+			return err
+		}
+	}
+	err := tx.Model(&MaintenanceAssetPart{}).Where("maintenance_asset_id = ?", old.ID).Where("deleted_at IS NULL").Update("deleted_at", time.Now().UTC()).Error
+	if err != nil {
+		return app.Error().New(http.StatusInternalServerError, err.Error())
+	}
+	return nil
+}
+
+// GetParts returns the spare parts of the MaintenanceAsset data for the specified maintenance ID.
+func (u UseCaseHandler) GetParts(maintenanceAssetID string) ([]MaintenanceAssetPart, error) {
+	res := []MaintenanceAssetPart{}
+
+	// prepare db for current ctx
+	tx, err := u.Ctx.DB()
+	if err != nil {
+		return res, app.Error().New(http.StatusInternalServerError, err.Error())
+	}
+
+	query := url.Values{}
+	query.Add("maintenance_asset.id", maintenanceAssetID)
+	query.Add(grest.QueryDisablePagination, "true")
+	data, err := app.Query().Find(tx, &MaintenanceAssetPart{}, query)
+	if err != nil {
+		return res, app.Error().New(http.StatusInternalServerError, err.Error())
+	}
+
+	jByte, err := json.Marshal(data)
+	if err != nil {
+		return res, app.Error().New(http.StatusInternalServerError, err.Error())
+	}
+	err = app.BindJSON(jByte, &res)
+	if err != nil {
+		return res, app.Error().New(http.StatusInternalServerError, err.Error())
+	}
+	return res, nil
+}
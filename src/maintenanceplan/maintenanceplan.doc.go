@@ -0,0 +1,2 @@
+// maintenanceplan is a package related to maintenanceplan data.
+package maintenanceplan
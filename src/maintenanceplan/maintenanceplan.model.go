@@ -0,0 +1,146 @@
+package maintenanceplan
+
+import "github.com/maulanar/go_asset_tracking_management/app"
+
+// MaintenancePlan is the main model of MaintenancePlan data. It provides a convenient interface for app.ModelInterface
+type MaintenancePlan struct {
+	app.Model
+	ID            app.NullUUID   `json:"id"                    db:"m.id"                  gorm:"column:id;primaryKey"`
+	Code          app.NullString `json:"code"                  db:"m.code"                gorm:"column:code"`
+	Name          app.NullString `json:"name"                  db:"m.name"                gorm:"column:name"`
+	Description   app.NullText   `json:"description"           db:"m.description"         gorm:"column:description"`
+	IntervalUnit  app.NullString `json:"interval.unit"         db:"m.interval_unit"       gorm:"column:interval_unit"       validate:"omitempty,oneof=days months meter"`
+	IntervalValue app.NullInt64  `json:"interval.value"        db:"m.interval_value"      gorm:"column:interval_value"      validate:"omitempty,gt=0"`
+	LeadDays      app.NullInt64  `json:"lead_days"             db:"m.lead_days"           gorm:"column:lead_days;default:7" validate:"omitempty,gte=0"`
+	StartDate     app.NullDate   `json:"start_date"            db:"m.start_date"          gorm:"column:start_date"`
+	IsActive      app.NullBool   `json:"is_active"             db:"m.is_active"           gorm:"column:is_active;default:true"`
+
+	MaintenanceTypeID   app.NullUUID   `json:"maintenance_type.id"   db:"m.maintenance_type_id" gorm:"column:maintenance_type_id"`
+	MaintenanceTypeCode app.NullString `json:"maintenance_type.code" db:"mt.code"               gorm:"-"`
+	MaintenanceTypeName app.NullString `json:"maintenance_type.name" db:"mt.name"               gorm:"-"`
+
+	AssetID   app.NullUUID   `json:"asset.id"              db:"m.asset_id"            gorm:"column:asset_id"`
+	AssetCode app.NullString `json:"asset.code"            db:"ass.code"              gorm:"-"`
+	AssetName app.NullString `json:"asset.name"            db:"ass.name"              gorm:"-"`
+
+	MeterID   app.NullUUID   `json:"meter.id"              db:"m.meter_id"            gorm:"column:meter_id"`
+	MeterCode app.NullString `json:"meter.code"            db:"mtr.code"              gorm:"-"`
+	MeterName app.NullString `json:"meter.name"            db:"mtr.name"              gorm:"-"`
+	MeterUnit app.NullString `json:"meter.unit"            db:"mtr.unit"              gorm:"-"`
+
+	CategoryID   app.NullUUID   `json:"category.id"           db:"m.category_id"         gorm:"column:category_id"`
+	CategoryCode app.NullString `json:"category.code"         db:"cat.code"              gorm:"-"`
+	CategoryName app.NullString `json:"category.name"         db:"cat.name"              gorm:"-"`
+
+	CreatedAt app.NullDateTime `json:"created_at"            db:"m.created_at"          gorm:"column:created_at"`
+	UpdatedAt app.NullDateTime `json:"updated_at"            db:"m.updated_at"          gorm:"column:updated_at"`
+	DeletedAt app.NullDateTime `json:"deleted_at"            db:"m.deleted_at,hide"     gorm:"column:deleted_at"`
+}
+
+// EndPoint returns the MaintenancePlan end point, it used for cache key, etc.
+func (MaintenancePlan) EndPoint() string {
+	return "maintenance_plans"
+}
+
+// TableVersion returns the versions of the MaintenancePlan table in the database.
+// Change this value with date format YY.MM.DDHHii when any table structure changes.
+func (MaintenancePlan) TableVersion() string {
+	return "26.10.192400"
+}
+
+// TableName returns the name of the MaintenancePlan table in the database.
+func (MaintenancePlan) TableName() string {
+	return "maintenance_plans"
+}
+
+// TableAliasName returns the table alias name of the MaintenancePlan table, used for querying.
+func (MaintenancePlan) TableAliasName() string {
+	return "m"
+}
+
+// GetRelations returns the relations of the MaintenancePlan data in the database, used for querying.
+func (m *MaintenancePlan) GetRelations() map[string]map[string]any {
+	m.AddRelation("left", "maintenance_types", "mt", []map[string]any{{"column1": "mt.id", "column2": "m.maintenance_type_id"}})
+	m.AddRelation("left", "assets", "ass", []map[string]any{{"column1": "ass.id", "column2": "m.asset_id"}})
+	m.AddRelation("left", "categories", "cat", []map[string]any{{"column1": "cat.id", "column2": "m.category_id"}})
+	m.AddRelation("left", "meters", "mtr", []map[string]any{{"column1": "mtr.id", "column2": "m.meter_id"}})
+	return m.Relations
+}
+
+// GetFilters returns the filter of the MaintenancePlan data in the database, used for querying.
+func (m *MaintenancePlan) GetFilters() []map[string]any {
+	m.AddFilter(map[string]any{"column1": "m.deleted_at", "operator": "=", "value": nil})
+	return m.Filters
+}
+
+// GetSorts returns the default sort of the MaintenancePlan data in the database, used for querying.
+func (m *MaintenancePlan) GetSorts() []map[string]any {
+	m.AddSort(map[string]any{"column": "m.code", "direction": "asc"})
+	return m.Sorts
+}
+
+// GetFields returns list of the field of the MaintenancePlan data in the database, used for querying.
+func (m *MaintenancePlan) GetFields() map[string]map[string]any {
+	m.SetFields(m)
+	return m.Fields
+}
+
+// GetSchema returns the MaintenancePlan schema, used for querying.
+func (m *MaintenancePlan) GetSchema() map[string]any {
+	return m.SetSchema(m)
+}
+
+// OpenAPISchemaName returns the name of the MaintenancePlan schema in the open api documentation.
+func (MaintenancePlan) OpenAPISchemaName() string {
+	return "MaintenancePlan"
+}
+
+// GetOpenAPISchema returns the Open API Schema of the MaintenancePlan in the open api documentation.
+func (m *MaintenancePlan) GetOpenAPISchema() map[string]any {
+	return m.SetOpenAPISchema(m)
+}
+
+type MaintenancePlanList struct {
+	app.ListModel
+}
+
+// OpenAPISchemaName returns the name of the MaintenancePlanList schema in the open api documentation.
+func (MaintenancePlanList) OpenAPISchemaName() string {
+	return "MaintenancePlanList"
+}
+
+// GetOpenAPISchema returns the Open API Schema of the MaintenancePlanList in the open api documentation.
+func (p *MaintenancePlanList) GetOpenAPISchema() map[string]any {
+	return p.SetOpenAPISchema(&MaintenancePlan{})
+}
+
+// Interval units of the MaintenancePlan data, a meter plan is due every interval value of the usage read from its meter.
+const (
+	IntervalDays   = "days"
+	IntervalMonths = "months"
+	IntervalMeter  = "meter"
+)
+
+// ParamCreate is the expected parameters for create a new MaintenancePlan data.
+type ParamCreate struct {
+	UseCaseHandler
+	Name              app.NullString `json:"name"                  db:"m.name"                gorm:"column:name"                validate:"required"`
+	IntervalUnit      app.NullString `json:"interval.unit"         db:"m.interval_unit"       gorm:"column:interval_unit"       validate:"required,oneof=days months meter"`
+	IntervalValue     app.NullInt64  `json:"interval.value"        db:"m.interval_value"      gorm:"column:interval_value"      validate:"required,gt=0"`
+	MaintenanceTypeID app.NullUUID   `json:"maintenance_type.id"   db:"m.maintenance_type_id" gorm:"column:maintenance_type_id" validate:"required"`
+}
+
+// ParamUpdate is the expected parameters for update the MaintenancePlan data.
+type ParamUpdate struct {
+	UseCaseHandler
+}
+
+// ParamPartiallyUpdate is the expected parameters for partially update the MaintenancePlan data.
+type ParamPartiallyUpdate struct {
+	UseCaseHandler
+}
+
+// ParamDelete is the expected parameters for delete the MaintenancePlan data.
+type ParamDelete struct {
+	UseCaseHandler
+}
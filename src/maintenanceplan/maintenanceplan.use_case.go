@@ -0,0 +1,564 @@
+package maintenanceplan
+
+import (
+	"math"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/maulanar/go_asset_tracking_management/app"
+	"github.com/maulanar/go_asset_tracking_management/src/asset"
+	"github.com/maulanar/go_asset_tracking_management/src/category"
+	"github.com/maulanar/go_asset_tracking_management/src/jobrun"
+	"github.com/maulanar/go_asset_tracking_management/src/maintenancetype"
+	"github.com/maulanar/go_asset_tracking_management/src/meter"
+	"github.com/maulanar/go_asset_tracking_management/src/workorder"
+)
+
+// UseCase returns a UseCaseHandler for expected use case functional.
+func UseCase(ctx app.Ctx, query ...url.Values) UseCaseHandler {
+	u := UseCaseHandler{
+		Ctx:   &ctx,
+		Query: url.Values{},
+	}
+	if len(query) > 0 {
+		u.Query = query[0]
+	}
+	return u
+}
+
+// UseCaseHandler provides a convenient interface for MaintenancePlan use case, use UseCase to access UseCaseHandler.
+type UseCaseHandler struct {
+	MaintenancePlan
+
+	// injectable dependencies
+	Ctx   *app.Ctx   `json:"-" db:"-" gorm:"-"`
+	Query url.Values `json:"-" db:"-" gorm:"-"`
+}
+
+// Async return UseCaseHandler with async process.
+func (u UseCaseHandler) Async(ctx app.Ctx, query ...url.Values) UseCaseHandler {
+	ctx.IsAsync = true
+	return UseCase(ctx, query...)
+}
+
+// GetByID returns the MaintenancePlan data for the specified ID.
+func (u UseCaseHandler) GetByID(id string) (MaintenancePlan, error) {
+	res := MaintenancePlan{}
+
+	// check permission
+	err := u.Ctx.ValidatePermission("maintenance_plans.detail")
+	if err != nil {
+		return res, err
+	}
+
+	// get from cache and return if exists
+	cacheKey := u.EndPoint() + "." + id
+	app.Cache().Get(cacheKey, &res)
+	if res.ID.Valid {
+		return res, err
+	}
+
+	// prepare db for current ctx
+	tx, err := u.Ctx.DB()
+	if err != nil {
+		return res, app.Error().New(http.StatusInternalServerError, err.Error())
+	}
+
+	// get from db
+	key := "id"
+	if !app.Validator().IsValid(id, "uuid") {
+		key = "code"
+	}
+	u.Query.Add(key, id)
+	err = app.Query().First(tx, &res, u.Query)
+	if err != nil {
+		return res, u.Ctx.NotFoundError(err, u.EndPoint(), key, id)
+	}
+
+	// save to cache and return if exists
+	app.Cache().Set(cacheKey, res)
+	return res, err
+}
+
+// Get returns the list of MaintenancePlan data.
+func (u UseCaseHandler) Get() (app.ListModel, error) {
+	res := app.ListModel{}
+
+	// check permission
+	err := u.Ctx.ValidatePermission("maintenance_plans.list")
+	if err != nil {
+		return res, err
+	}
+	// get from cache and return if exists
+	cacheKey := u.EndPoint() + "?" + u.Query.Encode()
+	err = app.Cache().Get(cacheKey, &res)
+	if err == nil {
+		return res, err
+	}
+
+	// prepare db for current ctx
+	tx, err := u.Ctx.DB()
+	if err != nil {
+		return res, app.Error().New(http.StatusInternalServerError, err.Error())
+	}
+
+	// set pagination info
+	res.Results.PageContext.Count,
+		res.Results.PageContext.Page,
+		res.Results.PageContext.PerPage,
+		res.Results.PageContext.PageCount,
+		err = app.Query().PaginationInfo(tx, &MaintenancePlan{}, u.Query)
+	if err != nil {
+		return res, app.Error().New(http.StatusInternalServerError, err.Error())
+	}
+	// return data count if $per_page set to 0
+	if res.Results.PageContext.PerPage == 0 {
+		return res, err
+	}
+
+	// find data
+	data, err := app.Query().Find(tx, &MaintenancePlan{}, u.Query)
+	if err != nil {
+		return res, app.Error().New(http.StatusInternalServerError, err.Error())
+	}
+	res.SetData(data, u.Query)
+
+	// save to cache and return if exists
+	app.Cache().Set(cacheKey, res)
+	return res, err
+}
+
+// Create creates a new data MaintenancePlan with specified parameters.
+func (u *UseCaseHandler) Create(p *ParamCreate) error {
+
+	// check permission
+	err := u.Ctx.ValidatePermission("maintenance_plans.create")
+	if err != nil {
+		return err
+	}
+
+	// validate param
+	err = u.Ctx.ValidateParam(p)
+	if err != nil {
+		return err
+	}
+
+	// set default value for undefined field
+	err = u.setDefaultValue(MaintenancePlan{})
+	if err != nil {
+		return err
+	}
+
+	// prepare db for current ctx
+	tx, err := u.Ctx.DB()
+	if err != nil {
+		return app.Error().New(http.StatusInternalServerError, err.Error())
+	}
+
+	// save data to db
+	err = tx.Model(&u).Create(&u).Error
+	if err != nil {
+		return app.Error().New(http.StatusInternalServerError, err.Error())
+	}
+
+	// invalidate cache
+	app.Cache().Invalidate(u.EndPoint())
+
+	// save history (user activity), send webhook, etc
+	go u.Ctx.Hook("POST", "create", p.ID.String, p)
+	return nil
+}
+
+// UpdateByID updates the MaintenancePlan data for the specified ID with specified parameters.
+func (u UseCaseHandler) UpdateByID(id string, p *ParamUpdate) error {
+
+	// check permission
+	err := u.Ctx.ValidatePermission("maintenance_plans.edit")
+	if err != nil {
+		return err
+	}
+
+	// validate param
+	err = u.Ctx.ValidateParam(p)
+	if err != nil {
+		return err
+	}
+
+	// get previous data
+	old, err := u.GetByID(id)
+	if err != nil {
+		return err
+	}
+
+	// set default value for undefined field
+	err = u.setDefaultValue(old)
+	if err != nil {
+		return err
+	}
+
+	// prepare db for current ctx
+	tx, err := u.Ctx.DB()
+	if err != nil {
+		return app.Error().New(http.StatusInternalServerError, err.Error())
+	}
+
+	// update data on the db
+	err = tx.Model(&u).Where("id = ?", old.ID).Updates(u).Error
+	if err != nil {
+		return app.Error().New(http.StatusInternalServerError, err.Error())
+	}
+
+	// invalidate cache
+	app.Cache().Invalidate(u.EndPoint(), old.ID.String)
+
+	// save history (user activity), send webhook, etc
+	go u.Ctx.Hook("PUT", "Update", old.ID.String, old)
+	return nil
+}
+
+// PartiallyUpdateByID updates the MaintenancePlan data for the specified ID with specified parameters.
+func (u UseCaseHandler) PartiallyUpdateByID(id string, p *ParamPartiallyUpdate) error {
+
+	// check permission
+	err := u.Ctx.ValidatePermission("maintenance_plans.edit")
+	if err != nil {
+		return err
+	}
+
+	// validate param
+	err = u.Ctx.ValidateParam(p)
+	if err != nil {
+		return err
+	}
+
+	// get previous data
+	old, err := u.GetByID(id)
+	if err != nil {
+		return err
+	}
+
+	// set default value for undefined field
+	err = u.setDefaultValue(old)
+	if err != nil {
+		return err
+	}
+
+	// prepare db for current ctx
+	tx, err := u.Ctx.DB()
+	if err != nil {
+		return app.Error().New(http.StatusInternalServerError, err.Error())
+	}
+
+	// update data on the db
+	err = tx.Model(&u).Where("id = ?", old.ID).Updates(u).Error
+	if err != nil {
+		return app.Error().New(http.StatusInternalServerError, err.Error())
+	}
+
+	// invalidate cache
+	app.Cache().Invalidate(u.EndPoint(), old.ID.String)
+
+	// save history (user activity), send webhook, etc
+	go u.Ctx.Hook("PATCH", "Partially Update", old.ID.String, old)
+	return nil
+}
+
+// DeleteByID deletes the MaintenancePlan data for the specified ID.
+func (u UseCaseHandler) DeleteByID(id string, p *ParamDelete) error {
+
+	// check permission
+	err := u.Ctx.ValidatePermission("maintenance_plans.delete")
+	if err != nil {
+		return err
+	}
+
+	// validate param
+	err = u.Ctx.ValidateParam(p)
+	if err != nil {
+		return err
+	}
+
+	// get previous data
+	old, err := u.GetByID(id)
+	if err != nil {
+		return err
+	}
+
+	// prepare db for current ctx
+	tx, err := u.Ctx.DB()
+	if err != nil {
+		return app.Error().New(http.StatusInternalServerError, err.Error())
+	}
+
+	// update data on the db
+	err = tx.Model(&p).Where("id = ?", old.ID).Update("deleted_at", time.Now().UTC()).Error
+	if err != nil {
+		return app.Error().New(http.StatusInternalServerError, err.Error())
+	}
+
+	// invalidate cache
+	app.Cache().Invalidate(u.EndPoint(), old.ID.String)
+
+	// save history (user activity), send webhook, etc
+	go u.Ctx.Hook("DELETE", "DELETE", old.ID.String, old)
+	return nil
+}
+
+// setDefaultValue set default value of undefined field when create or update MaintenancePlan data.
+func (u *UseCaseHandler) setDefaultValue(old MaintenancePlan) error {
+	if !old.ID.Valid {
+		u.ID = app.NewNullUUID()
+	} else {
+		u.ID = old.ID
+	}
+
+	// Penentuan kode
+	if u.Code.Valid && u.Code.String != "" {
+		// Jika kode dikirim dan berbeda dengan data lama, cek ke DB
+		if !old.Code.Valid || u.Code.String != old.Code.String {
+			err := app.Common().IsFieldValueExists(u.Ctx, u.EndPoint(), "Code", u.TableName(), "code", u.Code.String)
+			if err != nil {
+				return err
+			}
+		}
+	} else {
+		// Jika tidak kirim kode dan data lama ada, gunakan data lama
+		if old.Code.Valid && old.Code.String != "" {
+			u.Code = old.Code
+		} else {
+			// Jika tidak kirim kode dan data lama tidak ada, generate baru
+			newCode, err := app.Common().GenerateCode(u.Ctx, u.TableName(), "code", "Maintenance Plan")
+			if err != nil {
+				return err
+			}
+			u.Code.Set(newCode)
+		}
+	}
+
+	// the plan applies to a single asset or to all assets of a category
+	assetID, categoryID := old.AssetID, old.CategoryID
+	if u.AssetID.Valid {
+		assetID = u.AssetID
+	}
+	if u.CategoryID.Valid {
+		categoryID = u.CategoryID
+	}
+	if (assetID.String == "") == (categoryID.String == "") {
+		return app.Error().New(http.StatusBadRequest, u.Ctx.Trans("maintenance_plan_target_invalid"))
+	}
+	if assetID.String != "" && assetID.String != old.AssetID.String {
+		ass, err := asset.UseCase(*u.Ctx, url.Values{}).GetByID(assetID.String)
+		if err != nil {
+			return err
+		}
+		if ass.Status.String == asset.StatusDisposed {
+			return app.Error().New(http.StatusBadRequest, u.Ctx.Trans("asset_disposed", map[string]string{"code": ass.Code.String}))
+		}
+	}
+	if categoryID.String != "" && categoryID.String != old.CategoryID.String {
+		_, err := category.UseCase(*u.Ctx, url.Values{}).GetByID(categoryID.String)
+		if err != nil {
+			return err
+		}
+	}
+	if u.MaintenanceTypeID.Valid && u.MaintenanceTypeID.String != old.MaintenanceTypeID.String {
+		_, err := maintenancetype.UseCase(*u.Ctx, url.Values{}).GetByID(u.MaintenanceTypeID.String)
+		if err != nil {
+			return err
+		}
+	}
+
+	// a meter plan follows a meter of the category of the plan or of its asset
+	intervalUnit, meterID := old.IntervalUnit, old.MeterID
+	if u.IntervalUnit.Valid {
+		intervalUnit = u.IntervalUnit
+	}
+	if u.MeterID.Valid {
+		meterID = u.MeterID
+	}
+	if intervalUnit.String != IntervalMeter {
+		// the meter is ignored by a time based plan
+	} else if meterID.String == "" {
+		return app.Error().New(http.StatusBadRequest, u.Ctx.Trans("required_key", map[string]string{"key": "meter.id"}))
+	} else if meterID.String != old.MeterID.String || assetID.String != old.AssetID.String || categoryID.String != old.CategoryID.String {
+		mtr, err := meter.UseCase(*u.Ctx, url.Values{}).GetByID(meterID.String)
+		if err != nil {
+			return err
+		}
+		if assetID.String != "" {
+			ass, err := asset.UseCase(*u.Ctx, url.Values{}).GetByID(assetID.String)
+			if err != nil {
+				return err
+			}
+			categoryID = ass.CategoryID
+		}
+		if mtr.CategoryID.String != categoryID.String {
+			return app.Error().New(http.StatusBadRequest, u.Ctx.Trans("maintenance_plan_meter_invalid", map[string]string{"meter": mtr.Code.String}))
+		}
+		u.MeterID = meterID
+	}
+
+	if !old.ID.Valid {
+		if !u.StartDate.Valid {
+			u.StartDate.Set(time.Now())
+		}
+		if !u.LeadDays.Valid {
+			u.LeadDays.Set(7)
+		}
+		if !u.IsActive.Valid {
+			u.IsActive.Set(true)
+		}
+	}
+
+	return nil
+}
+
+// duePlan is an asset which a MaintenancePlan applies to, with the last work order generated for it.
+type duePlan struct {
+	PlanID            string
+	MaintenanceTypeID string
+	Description       string
+	IntervalUnit      string
+	IntervalValue     int64
+	LeadDays          int64
+	StartDate         time.Time
+	AssetID           string
+	MeterUsage        float64
+	ExpectedRate      float64
+	LastDueDate       app.NullDate
+	LastDueMeter      app.NullFloat64
+	HasOpen           bool
+}
+
+// next returns the due date and the due meter of the next work order of the plan, and whether it is generated now.
+// A time based plan is generated the lead days ahead of its due date, a missed occurrence is skipped to the last one,
+// and a meter plan is generated once the usage read from its meter reaches the due meter, or the lead days ahead
+// of the date when the usage is expected to reach it at the expected rate of the meter, a missed due meter is skipped
+// to the last one the usage has reached.
+func (d duePlan) next(today time.Time) (time.Time, app.NullFloat64, bool) {
+	dueMeter := app.NullFloat64{}
+	if d.IntervalUnit == IntervalMeter {
+		dueMeter.Set(d.LastDueMeter.Float64 + float64(d.IntervalValue))
+		for dueMeter.Float64+float64(d.IntervalValue) <= d.MeterUsage {
+			dueMeter.Float64 += float64(d.IntervalValue)
+		}
+		remaining := dueMeter.Float64 - d.MeterUsage
+		if remaining <= 0 {
+			return today, dueMeter, true
+		}
+		if d.ExpectedRate <= 0 {
+			return today, dueMeter, false
+		}
+		due := today.AddDate(0, 0, int(math.Ceil(remaining/d.ExpectedRate)))
+		return due, dueMeter, !due.AddDate(0, 0, -int(d.LeadDays)).After(today)
+	}
+	step := func(date time.Time) time.Time {
+		if d.IntervalUnit == IntervalMonths {
+			return date.AddDate(0, int(d.IntervalValue), 0)
+		}
+		return date.AddDate(0, 0, int(d.IntervalValue))
+	}
+	due := d.StartDate
+	if d.LastDueDate.Valid {
+		due = step(d.LastDueDate.Time)
+	}
+	for !step(due).After(today) {
+		due = step(due)
+	}
+	return due, dueMeter, !due.AddDate(0, 0, -int(d.LeadDays)).After(today)
+}
+
+// JobGenerateWorkOrders generates the next work order of the active maintenance plans for each of their assets
+// once it is due, a new work order is only generated after the previous one of the plan and the asset is closed.
+// The asset stays in service until the generated work order is scheduled or started.
+func JobGenerateWorkOrders() {
+	tx, err := app.DB().Conn("main")
+	if err != nil {
+		app.Logger().Error().Err(err).Msg("Failed to connect to the database.")
+		return
+	}
+	run := jobrun.Start(tx, "maintenance_plan.generate_work_orders")
+
+	plans := []duePlan{}
+	err = tx.Raw(`
+SELECT mp.id AS plan_id, mp.maintenance_type_id, COALESCE(mp.name, '') AS description,
+       mp.interval_unit, mp.interval_value, COALESCE(mp.lead_days, 0) AS lead_days, mp.start_date,
+       ass.id AS asset_id, COALESCE(mr.cumulative_usage, 0) AS meter_usage, COALESCE(mtr.expected_rate, 0) AS expected_rate,
+       wo.due_date AS last_due_date, wo.due_meter AS last_due_meter,
+       COALESCE(wo.status NOT IN @closed, false) AS has_open
+FROM maintenance_plans mp
+JOIN assets ass ON (ass.id = mp.asset_id OR ass.category_id = mp.category_id)
+  AND ass.deleted_at IS NULL
+  AND COALESCE(ass.status, '') <> @disposed
+LEFT JOIN LATERAL (
+  SELECT w.due_date, w.due_meter, w.status
+  FROM work_orders w
+  WHERE w.maintenance_plan_id = mp.id AND w.asset_id = ass.id AND w.deleted_at IS NULL
+  ORDER BY w.due_date DESC, w.created_at DESC
+  LIMIT 1
+) wo ON true
+LEFT JOIN meters mtr ON mtr.id = mp.meter_id
+LEFT JOIN LATERAL (
+  SELECT r.cumulative_usage
+  FROM meter_readings r
+  WHERE r.meter_id = mp.meter_id AND r.asset_id = ass.id AND r.deleted_at IS NULL
+  ORDER BY r.read_at DESC, r.created_at DESC
+  LIMIT 1
+) mr ON true
+WHERE mp.deleted_at IS NULL
+  AND mp.is_active = true
+  AND mp.interval_value > 0`, map[string]any{
+		"closed":   []string{workorder.StatusDone, workorder.StatusCancelled},
+		"disposed": asset.StatusDisposed,
+	}).Scan(&plans).Error
+	if err != nil {
+		app.Logger().Error().Err(err).Msg("Failed to get the maintenance plans.")
+		run.Finish(tx, err)
+		return
+	}
+
+	now := time.Now()
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+	for _, d := range plans {
+		run.ProcessedCount.Set(run.ProcessedCount.Int64 + 1)
+		dueDate, dueMeter, isDue := d.next(today)
+		if d.HasOpen || !isDue {
+			run.SkippedCount.Set(run.SkippedCount.Int64 + 1)
+			continue
+		}
+		code, err := app.Common().GenerateCode(&app.Ctx{}, workorder.WorkOrder{}.TableName(), "code", "Work Order")
+		if err == nil {
+			wo := workorder.WorkOrder{
+				ID:                app.NewNullUUID(),
+				Code:              app.NewNullString(code),
+				Status:            app.NewNullString(workorder.StatusOpen),
+				Priority:          app.NewNullString(workorder.PriorityMedium),
+				DueDate:           app.NewNullDate(dueDate),
+				DueMeter:          dueMeter,
+				IsOverdue:         app.NewNullBool(dueDate.Before(today)),
+				Description:       app.NewNullText(d.Description),
+				AssetID:           app.NewNullUUID(d.AssetID),
+				MaintenanceTypeID: app.NewNullUUID(d.MaintenanceTypeID),
+				MaintenancePlanID: app.NewNullUUID(d.PlanID),
+				CreatedAt:         app.NewNullDateTime(time.Now().UTC()),
+				UpdatedAt:         app.NewNullDateTime(time.Now().UTC()),
+			}
+			wo.LabourAmount.Set(0)
+			wo.PartsAmount.Set(0)
+			wo.TotalAmount.Set(0)
+			err = tx.Create(&wo).Error
+		}
+		if err != nil {
+			app.Logger().Error().Err(err).Str("maintenance_plan_id", d.PlanID).Str("asset_id", d.AssetID).Msg("Failed to generate the work order.")
+			run.FailedCount.Set(run.FailedCount.Int64 + 1)
+			continue
+		}
+		run.UpdatedCount.Set(run.UpdatedCount.Int64 + 1)
+	}
+	run.Finish(tx, nil)
+
+	if run.UpdatedCount.Int64 > 0 {
+		app.Cache().DeleteWithPrefix(workorder.WorkOrder{}.EndPoint())
+	}
+}
@@ -0,0 +1,25 @@
+package maintenanceplan
+
+import (
+	"testing"
+	"time"
+)
+
+func TestNextMeterSkipsMissedOccurrences(t *testing.T) {
+	today := time.Date(2026, 10, 19, 0, 0, 0, 0, time.UTC)
+	d := duePlan{IntervalUnit: IntervalMeter, IntervalValue: 10000, LeadDays: 7, MeterUsage: 50000}
+
+	// a new plan of an asset which has already run past several intervals is due at the last one it reached
+	due, dueMeter, isDue := d.next(today)
+	if !isDue || !due.Equal(today) || dueMeter.Float64 != 50000 {
+		t.Fatalf("expected to be due today at 50000, got %v on %s at %.0f", isDue, due, dueMeter.Float64)
+	}
+
+	// once it is generated, the next one is due a full interval later
+	d.LastDueMeter.Set(dueMeter.Float64)
+	d.MeterUsage = 52000
+	_, dueMeter, isDue = d.next(today)
+	if isDue || dueMeter.Float64 != 60000 {
+		t.Errorf("expected to be due later at 60000, got %v at %.0f", isDue, dueMeter.Float64)
+	}
+}
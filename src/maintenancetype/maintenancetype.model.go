@@ -5,15 +5,19 @@ import "github.com/maulanar/go_asset_tracking_management/app"
 // MaintenanceType is the main model of MaintenanceType data. It provides a convenient interface for app.ModelInterface
 type MaintenanceType struct {
 	app.Model
-	ID          app.NullUUID   `json:"id"          db:"m.id"              gorm:"column:id;primaryKey"`
-	Code        app.NullString `json:"code"        db:"m.code"            gorm:"column:code"`
-	Name        app.NullString `json:"name"        db:"m.name"            gorm:"column:name"`
-	Description app.NullText   `json:"description" db:"m.description"     gorm:"column:description"`
-	IsActive    app.NullBool   `json:"is_active"   db:"m.is_active"       gorm:"column:is_active;default:true"`
+	ID          app.NullUUID   `json:"id"               db:"m.id"               gorm:"column:id;primaryKey"`
+	Code        app.NullString `json:"code"             db:"m.code"             gorm:"column:code"`
+	Name        app.NullString `json:"name"             db:"m.name"             gorm:"column:name"`
+	Description app.NullText   `json:"description"      db:"m.description"      gorm:"column:description"`
+	IsActive    app.NullBool   `json:"is_active"        db:"m.is_active"        gorm:"column:is_active;default:true"`
 
-	CreatedAt app.NullDateTime `json:"created_at"  db:"m.created_at"      gorm:"column:created_at"`
-	UpdatedAt app.NullDateTime `json:"updated_at"  db:"m.updated_at"      gorm:"column:updated_at"`
-	DeletedAt app.NullDateTime `json:"deleted_at"  db:"m.deleted_at,hide" gorm:"column:deleted_at"`
+	// IsCapitalizable is the default of the maintenance records of the type, e.g. a major overhaul which extends
+	// the life of the asset is capitalized into its carrying amount while a routine service is an expense.
+	IsCapitalizable app.NullBool `json:"is_capitalizable" db:"m.is_capitalizable" gorm:"column:is_capitalizable;default:false"`
+
+	CreatedAt app.NullDateTime `json:"created_at"       db:"m.created_at"       gorm:"column:created_at"`
+	UpdatedAt app.NullDateTime `json:"updated_at"       db:"m.updated_at"       gorm:"column:updated_at"`
+	DeletedAt app.NullDateTime `json:"deleted_at"       db:"m.deleted_at,hide"  gorm:"column:deleted_at"`
 }
 
 // EndPoint returns the MaintenanceType end point, it used for cache key, etc.
@@ -24,7 +28,7 @@ func (MaintenanceType) EndPoint() string {
 // TableVersion returns the versions of the MaintenanceType table in the database.
 // Change this value with date format YY.MM.DDHHii when any table structure changes.
 func (MaintenanceType) TableVersion() string {
-	return "28.06.291152"
+	return "26.10.192300"
 }
 
 // TableName returns the name of the MaintenanceType table in the database.
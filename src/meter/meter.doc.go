@@ -0,0 +1,2 @@
+// meter is a package related to meter data.
+package meter
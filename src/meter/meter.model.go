@@ -0,0 +1,121 @@
+package meter
+
+import "github.com/maulanar/go_asset_tracking_management/app"
+
+// Meter is the main model of Meter data. It provides a convenient interface for app.ModelInterface
+type Meter struct {
+	app.Model
+	ID                  app.NullUUID    `json:"id"                    db:"m.id"                    gorm:"column:id;primaryKey"`
+	Code                app.NullString  `json:"code"                  db:"m.code"                  gorm:"column:code"`
+	Name                app.NullString  `json:"name"                  db:"m.name"                  gorm:"column:name"`
+	Unit                app.NullString  `json:"unit"                  db:"m.unit"                  gorm:"column:unit"`
+	ExpectedRate        app.NullFloat64 `json:"expected_rate"         db:"m.expected_rate"         gorm:"column:expected_rate" validate:"omitempty,gte=0"`
+	IsDepreciationMeter app.NullBool    `json:"is_depreciation_meter" db:"m.is_depreciation_meter" gorm:"column:is_depreciation_meter;default:false"`
+	Description         app.NullText    `json:"description"           db:"m.description"           gorm:"column:description"`
+	IsActive            app.NullBool    `json:"is_active"             db:"m.is_active"             gorm:"column:is_active;default:true"`
+
+	CategoryID   app.NullUUID   `json:"category.id"           db:"m.category_id"           gorm:"column:category_id"`
+	CategoryCode app.NullString `json:"category.code"         db:"cat.code"                gorm:"-"`
+	CategoryName app.NullString `json:"category.name"         db:"cat.name"                gorm:"-"`
+
+	CreatedAt app.NullDateTime `json:"created_at"            db:"m.created_at"            gorm:"column:created_at"`
+	UpdatedAt app.NullDateTime `json:"updated_at"            db:"m.updated_at"            gorm:"column:updated_at"`
+	DeletedAt app.NullDateTime `json:"deleted_at"            db:"m.deleted_at,hide"       gorm:"column:deleted_at"`
+}
+
+// EndPoint returns the Meter end point, it used for cache key, etc.
+func (Meter) EndPoint() string {
+	return "meters"
+}
+
+// TableVersion returns the versions of the Meter table in the database.
+// Change this value with date format YY.MM.DDHHii when any table structure changes.
+func (Meter) TableVersion() string {
+	return "26.10.192400"
+}
+
+// TableName returns the name of the Meter table in the database.
+func (Meter) TableName() string {
+	return "meters"
+}
+
+// TableAliasName returns the table alias name of the Meter table, used for querying.
+func (Meter) TableAliasName() string {
+	return "m"
+}
+
+// GetRelations returns the relations of the Meter data in the database, used for querying.
+func (m *Meter) GetRelations() map[string]map[string]any {
+	m.AddRelation("left", "categories", "cat", []map[string]any{{"column1": "cat.id", "column2": "m.category_id"}})
+	return m.Relations
+}
+
+// GetFilters returns the filter of the Meter data in the database, used for querying.
+func (m *Meter) GetFilters() []map[string]any {
+	m.AddFilter(map[string]any{"column1": "m.deleted_at", "operator": "=", "value": nil})
+	return m.Filters
+}
+
+// GetSorts returns the default sort of the Meter data in the database, used for querying.
+func (m *Meter) GetSorts() []map[string]any {
+	m.AddSort(map[string]any{"column": "m.code", "direction": "asc"})
+	return m.Sorts
+}
+
+// GetFields returns list of the field of the Meter data in the database, used for querying.
+func (m *Meter) GetFields() map[string]map[string]any {
+	m.SetFields(m)
+	return m.Fields
+}
+
+// GetSchema returns the Meter schema, used for querying.
+func (m *Meter) GetSchema() map[string]any {
+	return m.SetSchema(m)
+}
+
+// OpenAPISchemaName returns the name of the Meter schema in the open api documentation.
+func (Meter) OpenAPISchemaName() string {
+	return "Meter"
+}
+
+// GetOpenAPISchema returns the Open API Schema of the Meter in the open api documentation.
+func (m *Meter) GetOpenAPISchema() map[string]any {
+	return m.SetOpenAPISchema(m)
+}
+
+type MeterList struct {
+	app.ListModel
+}
+
+// OpenAPISchemaName returns the name of the MeterList schema in the open api documentation.
+func (MeterList) OpenAPISchemaName() string {
+	return "MeterList"
+}
+
+// GetOpenAPISchema returns the Open API Schema of the MeterList in the open api documentation.
+func (p *MeterList) GetOpenAPISchema() map[string]any {
+	return p.SetOpenAPISchema(&Meter{})
+}
+
+// ParamCreate is the expected parameters for create a new Meter data.
+type ParamCreate struct {
+	UseCaseHandler
+	Name       app.NullString `json:"name"                  db:"m.name"                  gorm:"column:name"                    validate:"required"`
+	Unit       app.NullString `json:"unit"                  db:"m.unit"                  gorm:"column:unit"                    validate:"required"`
+	CategoryID app.NullUUID   `json:"category.id"           db:"m.category_id"           gorm:"column:category_id"             validate:"required"`
+}
+
+// ParamUpdate is the expected parameters for update the Meter data.
+type ParamUpdate struct {
+	UseCaseHandler
+}
+
+// ParamPartiallyUpdate is the expected parameters for partially update the Meter data.
+type ParamPartiallyUpdate struct {
+	UseCaseHandler
+}
+
+// ParamDelete is the expected parameters for delete the Meter data.
+type ParamDelete struct {
+	UseCaseHandler
+}
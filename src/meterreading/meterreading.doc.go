@@ -0,0 +1,2 @@
+// meterreading is a package related to meterreading data.
+package meterreading
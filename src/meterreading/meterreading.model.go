@@ -0,0 +1,132 @@
+package meterreading
+
+import "github.com/maulanar/go_asset_tracking_management/app"
+
+// MeterReading is the main model of MeterReading data. It provides a convenient interface for app.ModelInterface
+type MeterReading struct {
+	app.Model
+	ID              app.NullUUID     `json:"id"               db:"m.id"               gorm:"column:id;primaryKey"`
+	ReadAt          app.NullDateTime `json:"read_at"          db:"m.read_at"          gorm:"column:read_at"`
+	Value           app.NullFloat64  `json:"value"            db:"m.value"            gorm:"column:value" validate:"omitempty,gte=0"`
+	IsReplacement   app.NullBool     `json:"is_replacement"   db:"m.is_replacement"   gorm:"column:is_replacement;default:false"`
+	PreviousValue   app.NullFloat64  `json:"previous_value"   db:"m.previous_value"   gorm:"column:previous_value"`
+	Usage           app.NullFloat64  `json:"usage"            db:"m.usage"            gorm:"column:usage"`
+	CumulativeUsage app.NullFloat64  `json:"cumulative_usage" db:"m.cumulative_usage" gorm:"column:cumulative_usage"`
+	Note            app.NullText     `json:"note"             db:"m.note"             gorm:"column:note"`
+
+	AssetID   app.NullUUID   `json:"asset.id"         db:"m.asset_id"         gorm:"column:asset_id"`
+	AssetCode app.NullString `json:"asset.code"       db:"ass.code"           gorm:"-"`
+	AssetName app.NullString `json:"asset.name"       db:"ass.name"           gorm:"-"`
+
+	MeterID   app.NullUUID   `json:"meter.id"         db:"m.meter_id"         gorm:"column:meter_id"`
+	MeterCode app.NullString `json:"meter.code"       db:"mtr.code"           gorm:"-"`
+	MeterName app.NullString `json:"meter.name"       db:"mtr.name"           gorm:"-"`
+	MeterUnit app.NullString `json:"meter.unit"       db:"mtr.unit"           gorm:"-"`
+
+	EmployeeID   app.NullUUID   `json:"employee.id"      db:"m.employee_id"      gorm:"column:employee_id"`
+	EmployeeCode app.NullString `json:"employee.code"    db:"emp.code"           gorm:"-"`
+	EmployeeName app.NullString `json:"employee.name"    db:"emp.name"           gorm:"-"`
+
+	CreatedAt app.NullDateTime `json:"created_at"       db:"m.created_at"       gorm:"column:created_at"`
+	UpdatedAt app.NullDateTime `json:"updated_at"       db:"m.updated_at"       gorm:"column:updated_at"`
+	DeletedAt app.NullDateTime `json:"deleted_at"       db:"m.deleted_at,hide"  gorm:"column:deleted_at"`
+}
+
+// EndPoint returns the MeterReading end point, it used for cache key, etc.
+func (MeterReading) EndPoint() string {
+	return "meter_readings"
+}
+
+// TableVersion returns the versions of the MeterReading table in the database.
+// Change this value with date format YY.MM.DDHHii when any table structure changes.
+func (MeterReading) TableVersion() string {
+	return "26.10.192400"
+}
+
+// TableName returns the name of the MeterReading table in the database.
+func (MeterReading) TableName() string {
+	return "meter_readings"
+}
+
+// TableAliasName returns the table alias name of the MeterReading table, used for querying.
+func (MeterReading) TableAliasName() string {
+	return "m"
+}
+
+// GetRelations returns the relations of the MeterReading data in the database, used for querying.
+func (m *MeterReading) GetRelations() map[string]map[string]any {
+	m.AddRelation("left", "assets", "ass", []map[string]any{{"column1": "ass.id", "column2": "m.asset_id"}})
+	m.AddRelation("left", "meters", "mtr", []map[string]any{{"column1": "mtr.id", "column2": "m.meter_id"}})
+	m.AddRelation("left", "employees", "emp", []map[string]any{{"column1": "emp.id", "column2": "m.employee_id"}})
+	return m.Relations
+}
+
+// GetFilters returns the filter of the MeterReading data in the database, used for querying.
+func (m *MeterReading) GetFilters() []map[string]any {
+	m.AddFilter(map[string]any{"column1": "m.deleted_at", "operator": "=", "value": nil})
+	return m.Filters
+}
+
+// GetSorts returns the default sort of the MeterReading data in the database, used for querying.
+func (m *MeterReading) GetSorts() []map[string]any {
+	m.AddSort(map[string]any{"column": "m.read_at", "direction": "desc"})
+	return m.Sorts
+}
+
+// GetFields returns list of the field of the MeterReading data in the database, used for querying.
+func (m *MeterReading) GetFields() map[string]map[string]any {
+	m.SetFields(m)
+	return m.Fields
+}
+
+// GetSchema returns the MeterReading schema, used for querying.
+func (m *MeterReading) GetSchema() map[string]any {
+	return m.SetSchema(m)
+}
+
+// OpenAPISchemaName returns the name of the MeterReading schema in the open api documentation.
+func (MeterReading) OpenAPISchemaName() string {
+	return "MeterReading"
+}
+
+// GetOpenAPISchema returns the Open API Schema of the MeterReading in the open api documentation.
+func (m *MeterReading) GetOpenAPISchema() map[string]any {
+	return m.SetOpenAPISchema(m)
+}
+
+type MeterReadingList struct {
+	app.ListModel
+}
+
+// OpenAPISchemaName returns the name of the MeterReadingList schema in the open api documentation.
+func (MeterReadingList) OpenAPISchemaName() string {
+	return "MeterReadingList"
+}
+
+// GetOpenAPISchema returns the Open API Schema of the MeterReadingList in the open api documentation.
+func (p *MeterReadingList) GetOpenAPISchema() map[string]any {
+	return p.SetOpenAPISchema(&MeterReading{})
+}
+
+// ParamCreate is the expected parameters for create a new MeterReading data.
+type ParamCreate struct {
+	UseCaseHandler
+	Value   app.NullFloat64 `json:"value"            db:"m.value"            gorm:"column:value"                    validate:"required,gte=0"`
+	AssetID app.NullUUID    `json:"asset.id"         db:"m.asset_id"         gorm:"column:asset_id"                 validate:"required"`
+	MeterID app.NullUUID    `json:"meter.id"         db:"m.meter_id"         gorm:"column:meter_id"                 validate:"required"`
+}
+
+// ParamUpdate is the expected parameters for update the MeterReading data.
+type ParamUpdate struct {
+	UseCaseHandler
+}
+
+// ParamPartiallyUpdate is the expected parameters for partially update the MeterReading data.
+type ParamPartiallyUpdate struct {
+	UseCaseHandler
+}
+
+// ParamDelete is the expected parameters for delete the MeterReading data.
+type ParamDelete struct {
+	UseCaseHandler
+}
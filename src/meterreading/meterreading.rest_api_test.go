@@ -0,0 +1,147 @@
+package meterreading
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gofiber/fiber/v2/utils"
+	"gorm.io/gorm"
+
+	"github.com/maulanar/go_asset_tracking_management/app"
+)
+
+// prepareTest prepares the test.
+func prepareTest(tb testing.TB) {
+	app.Test()
+	tx := app.Test().Tx
+	app.DB().RegisterTable("main", MeterReading{})
+	app.DB().MigrateTable(tx, "main", app.Setting{})
+	tx.Session(&gorm.Session{AllowGlobalUpdate: true}).Delete(&MeterReading{})
+
+	app.Server().AddMiddleware(app.Test().NewCtx([]string{
+		"meter_readings.detail",
+		"meter_readings.list",
+		"meter_readings.create",
+		"meter_readings.edit",
+		"meter_readings.delete",
+	}))
+	app.Server().AddRoute("/meter_readings", "POST", REST().Create, nil)
+	app.Server().AddRoute("/meter_readings", "GET", REST().Get, nil)
+	app.Server().AddRoute("/meter_readings/:id", "GET", REST().GetByID, nil)
+	app.Server().AddRoute("/meter_readings/:id", "PUT", REST().UpdateByID, nil)
+	app.Server().AddRoute("/meter_readings/:id", "PATCH", REST().PartiallyUpdateByID, nil)
+	app.Server().AddRoute("/meter_readings/:id", "DELETE", REST().DeleteByID, nil)
+}
+
+// getTestMeterReadingID returns an available MeterReading ID.
+func getTestMeterReadingID() string {
+	return "todo"
+}
+
+// tests is test scenario.
+var tests = []struct {
+	description  string // description of the test case
+	method       string // method to test
+	path         string // route path to test
+	token        string // token to test
+	bodyRequest  string // body to test
+	expectedCode int    // expected HTTP status code
+	expectedBody string // expected body response
+}{
+	{
+		description:  "Get empty list of MeterReading",
+		method:       "GET",
+		path:         "/meter_readings",
+		token:        app.TestFullAccessToken,
+		expectedCode: http.StatusOK,
+		expectedBody: `{"count":0,"results":[]}`,
+	},
+	{
+		description:  "Create MeterReading with minimum payload",
+		method:       "POST",
+		path:         "/meter_readings",
+		token:        app.TestFullAccessToken,
+		bodyRequest:  `{"name":"Kilogram"}`,
+		expectedCode: http.StatusCreated,
+		expectedBody: `{"name":"Kilogram"}`,
+	},
+	{
+		description:  "Get MeterReading by ID",
+		method:       "GET",
+		path:         "/meter_readings/" + getTestMeterReadingID(),
+		token:        app.TestFullAccessToken,
+		expectedCode: http.StatusOK,
+		expectedBody: `{"name":"Kilogram"}`,
+	},
+	{
+		description:  "Update MeterReading by ID",
+		method:       "PUT",
+		path:         "/meter_readings/" + getTestMeterReadingID(),
+		token:        app.TestFullAccessToken,
+		bodyRequest:  `{"reason":"Update MeterReading by ID","name":"KG"}`,
+		expectedCode: http.StatusOK,
+		expectedBody: `{"name":"KG"}`,
+	},
+	{
+		description:  "Partially update MeterReading by ID",
+		method:       "PATCH",
+		path:         "/meter_readings/" + getTestMeterReadingID(),
+		token:        app.TestFullAccessToken,
+		bodyRequest:  `{"reason":"Partially Update MeterReading by ID","name":"Kilo Gram"}`,
+		expectedCode: http.StatusOK,
+		expectedBody: `{"name":"Kilo Gram"}`,
+	},
+	{
+		description:  "Delete MeterReading by ID",
+		method:       "DELETE",
+		path:         "/meter_readings/" + getTestMeterReadingID(),
+		token:        app.TestFullAccessToken,
+		bodyRequest:  `{"reason":"Delete MeterReading by ID"}`,
+		expectedCode: http.StatusOK,
+		expectedBody: `{"code":200}`,
+	},
+}
+
+// TestMeterReadingREST tests the REST API of MeterReading data with specified scenario.
+func TestMeterReadingREST(t *testing.T) {
+	prepareTest(t)
+
+	// Iterate through test single test cases
+	for _, test := range tests {
+
+		// Create a new http request with the route from the test case
+		req := httptest.NewRequest(test.method, test.path, strings.NewReader(test.bodyRequest))
+		req.Header.Add("Authorization", "Bearer "+test.token)
+		req.Header.Add("Content-Type", "application/json")
+
+		// Perform the request plain with the app, the second argument is a request latency (set to -1 for no latency)
+		res, err := app.Server().Test(req)
+
+		// Verify if the status code is as expected
+		utils.AssertEqual(t, nil, err, "app.Server().Test(req)")
+		utils.AssertEqual(t, test.expectedCode, res.StatusCode, test.description)
+
+		// Verify if the body response is as expected
+		body, err := io.ReadAll(res.Body)
+		utils.AssertEqual(t, nil, err, "io.ReadAll(res.Body)")
+		app.Test().AssertMatchJSONElement(t, []byte(test.expectedBody), body, test.description)
+		res.Body.Close()
+	}
+}
+
+// BenchmarkMeterReadingREST tests the REST API of MeterReading data with specified scenario.
+func BenchmarkMeterReadingREST(b *testing.B) {
+	b.ReportAllocs()
+	prepareTest(b)
+	for i := 0; i < b.N; i++ {
+		for _, test := range tests {
+			req := httptest.NewRequest(test.method, test.path, strings.NewReader(test.bodyRequest))
+			req.Header.Add("Authorization", "Bearer "+test.token)
+			req.Header.Add("Content-Type", "application/json")
+			app.Server().Test(req)
+		}
+	}
+}
@@ -0,0 +1,476 @@
+package meterreading
+
+import (
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+
+	"gorm.io/gorm"
+
+	"github.com/maulanar/go_asset_tracking_management/app"
+	"github.com/maulanar/go_asset_tracking_management/src/asset"
+	"github.com/maulanar/go_asset_tracking_management/src/employee"
+	"github.com/maulanar/go_asset_tracking_management/src/meter"
+)
+
+// UseCase returns a UseCaseHandler for expected use case functional.
+func UseCase(ctx app.Ctx, query ...url.Values) UseCaseHandler {
+	u := UseCaseHandler{
+		Ctx:   &ctx,
+		Query: url.Values{},
+	}
+	if len(query) > 0 {
+		u.Query = query[0]
+	}
+	return u
+}
+
+// UseCaseHandler provides a convenient interface for MeterReading use case, use UseCase to access UseCaseHandler.
+type UseCaseHandler struct {
+	MeterReading
+
+	// injectable dependencies
+	Ctx   *app.Ctx   `json:"-" db:"-" gorm:"-"`
+	Query url.Values `json:"-" db:"-" gorm:"-"`
+}
+
+// Async return UseCaseHandler with async process.
+func (u UseCaseHandler) Async(ctx app.Ctx, query ...url.Values) UseCaseHandler {
+	ctx.IsAsync = true
+	return UseCase(ctx, query...)
+}
+
+// GetByID returns the MeterReading data for the specified ID.
+func (u UseCaseHandler) GetByID(id string) (MeterReading, error) {
+	res := MeterReading{}
+
+	// check permission
+	err := u.Ctx.ValidatePermission("meter_readings.detail")
+	if err != nil {
+		return res, err
+	}
+
+	// get from cache and return if exists
+	cacheKey := u.EndPoint() + "." + id
+	app.Cache().Get(cacheKey, &res)
+	if res.ID.Valid {
+		return res, err
+	}
+
+	// prepare db for current ctx
+	tx, err := u.Ctx.DB()
+	if err != nil {
+		return res, app.Error().New(http.StatusInternalServerError, err.Error())
+	}
+
+	// get from db
+	key := "id"
+	if !app.Validator().IsValid(id, "uuid") {
+		key = "code"
+	}
+	u.Query.Add(key, id)
+	err = app.Query().First(tx, &res, u.Query)
+	if err != nil {
+		return res, u.Ctx.NotFoundError(err, u.EndPoint(), key, id)
+	}
+
+	// save to cache and return if exists
+	app.Cache().Set(cacheKey, res)
+	return res, err
+}
+
+// Get returns the list of MeterReading data.
+func (u UseCaseHandler) Get() (app.ListModel, error) {
+	res := app.ListModel{}
+
+	// check permission
+	err := u.Ctx.ValidatePermission("meter_readings.list")
+	if err != nil {
+		return res, err
+	}
+	// get from cache and return if exists
+	cacheKey := u.EndPoint() + "?" + u.Query.Encode()
+	err = app.Cache().Get(cacheKey, &res)
+	if err == nil {
+		return res, err
+	}
+
+	// prepare db for current ctx
+	tx, err := u.Ctx.DB()
+	if err != nil {
+		return res, app.Error().New(http.StatusInternalServerError, err.Error())
+	}
+
+	// set pagination info
+	res.Results.PageContext.Count,
+		res.Results.PageContext.Page,
+		res.Results.PageContext.PerPage,
+		res.Results.PageContext.PageCount,
+		err = app.Query().PaginationInfo(tx, &MeterReading{}, u.Query)
+	if err != nil {
+		return res, app.Error().New(http.StatusInternalServerError, err.Error())
+	}
+	// return data count if $per_page set to 0
+	if res.Results.PageContext.PerPage == 0 {
+		return res, err
+	}
+
+	// find data
+	data, err := app.Query().Find(tx, &MeterReading{}, u.Query)
+	if err != nil {
+		return res, app.Error().New(http.StatusInternalServerError, err.Error())
+	}
+	res.SetData(data, u.Query)
+
+	// save to cache and return if exists
+	app.Cache().Set(cacheKey, res)
+	return res, err
+}
+
+// Create creates a new data MeterReading with specified parameters.
+func (u *UseCaseHandler) Create(p *ParamCreate) error {
+
+	// check permission
+	err := u.Ctx.ValidatePermission("meter_readings.create")
+	if err != nil {
+		return err
+	}
+
+	// validate param
+	err = u.Ctx.ValidateParam(p)
+	if err != nil {
+		return err
+	}
+
+	// set default value for undefined field
+	err = u.setDefaultValue(MeterReading{})
+	if err != nil {
+		return err
+	}
+
+	// prepare db for current ctx
+	tx, err := u.Ctx.DB()
+	if err != nil {
+		return app.Error().New(http.StatusInternalServerError, err.Error())
+	}
+
+	// save data to db
+	err = tx.Model(&u).Create(&u).Error
+	if err != nil {
+		return app.Error().New(http.StatusInternalServerError, err.Error())
+	}
+
+	// the depreciation meter drives the units used by the asset
+	err = u.syncAsset(tx, u.AssetID.String, u.MeterID.String)
+	if err != nil {
+		return err
+	}
+
+	// invalidate cache
+	app.Cache().Invalidate(u.EndPoint())
+
+	// save history (user activity), send webhook, etc
+	go u.Ctx.Hook("POST", "create", p.ID.String, p)
+	return nil
+}
+
+// UpdateByID updates the MeterReading data for the specified ID with specified parameters.
+func (u UseCaseHandler) UpdateByID(id string, p *ParamUpdate) error {
+
+	// check permission
+	err := u.Ctx.ValidatePermission("meter_readings.edit")
+	if err != nil {
+		return err
+	}
+
+	// validate param
+	err = u.Ctx.ValidateParam(p)
+	if err != nil {
+		return err
+	}
+
+	// get previous data
+	old, err := u.GetByID(id)
+	if err != nil {
+		return err
+	}
+
+	// set default value for undefined field
+	err = u.setDefaultValue(old)
+	if err != nil {
+		return err
+	}
+
+	// prepare db for current ctx
+	tx, err := u.Ctx.DB()
+	if err != nil {
+		return app.Error().New(http.StatusInternalServerError, err.Error())
+	}
+
+	// update data on the db
+	err = tx.Model(&u).Where("id = ?", old.ID).Updates(u).Error
+	if err != nil {
+		return app.Error().New(http.StatusInternalServerError, err.Error())
+	}
+	err = u.syncAsset(tx, old.AssetID.String, old.MeterID.String)
+	if err != nil {
+		return err
+	}
+
+	// invalidate cache
+	app.Cache().Invalidate(u.EndPoint(), old.ID.String)
+
+	// save history (user activity), send webhook, etc
+	go u.Ctx.Hook("PUT", "Update", old.ID.String, old)
+	return nil
+}
+
+// PartiallyUpdateByID updates the MeterReading data for the specified ID with specified parameters.
+func (u UseCaseHandler) PartiallyUpdateByID(id string, p *ParamPartiallyUpdate) error {
+
+	// check permission
+	err := u.Ctx.ValidatePermission("meter_readings.edit")
+	if err != nil {
+		return err
+	}
+
+	// validate param
+	err = u.Ctx.ValidateParam(p)
+	if err != nil {
+		return err
+	}
+
+	// get previous data
+	old, err := u.GetByID(id)
+	if err != nil {
+		return err
+	}
+
+	// set default value for undefined field
+	err = u.setDefaultValue(old)
+	if err != nil {
+		return err
+	}
+
+	// prepare db for current ctx
+	tx, err := u.Ctx.DB()
+	if err != nil {
+		return app.Error().New(http.StatusInternalServerError, err.Error())
+	}
+
+	// update data on the db
+	err = tx.Model(&u).Where("id = ?", old.ID).Updates(u).Error
+	if err != nil {
+		return app.Error().New(http.StatusInternalServerError, err.Error())
+	}
+	err = u.syncAsset(tx, old.AssetID.String, old.MeterID.String)
+	if err != nil {
+		return err
+	}
+
+	// invalidate cache
+	app.Cache().Invalidate(u.EndPoint(), old.ID.String)
+
+	// save history (user activity), send webhook, etc
+	go u.Ctx.Hook("PATCH", "Partially Update", old.ID.String, old)
+	return nil
+}
+
+// DeleteByID deletes the MeterReading data for the specified ID.
+func (u UseCaseHandler) DeleteByID(id string, p *ParamDelete) error {
+
+	// check permission
+	err := u.Ctx.ValidatePermission("meter_readings.delete")
+	if err != nil {
+		return err
+	}
+
+	// validate param
+	err = u.Ctx.ValidateParam(p)
+	if err != nil {
+		return err
+	}
+
+	// get previous data
+	old, err := u.GetByID(id)
+	if err != nil {
+		return err
+	}
+	err = u.validateLast(old)
+	if err != nil {
+		return err
+	}
+
+	// prepare db for current ctx
+	tx, err := u.Ctx.DB()
+	if err != nil {
+		return app.Error().New(http.StatusInternalServerError, err.Error())
+	}
+
+	// update data on the db
+	err = tx.Model(&p).Where("id = ?", old.ID).Update("deleted_at", time.Now().UTC()).Error
+	if err != nil {
+		return app.Error().New(http.StatusInternalServerError, err.Error())
+	}
+	err = u.syncAsset(tx, old.AssetID.String, old.MeterID.String)
+	if err != nil {
+		return err
+	}
+
+	// invalidate cache
+	app.Cache().Invalidate(u.EndPoint(), old.ID.String)
+
+	// save history (user activity), send webhook, etc
+	go u.Ctx.Hook("DELETE", "DELETE", old.ID.String, old)
+	return nil
+}
+
+// setDefaultValue set default value of undefined field when create or update MeterReading data.
+// A reading continues from the last reading of the meter of the asset, it must not decrease unless the meter is replaced,
+// and only the last reading can be changed or deleted, so the usage of the log is never rewritten.
+func (u *UseCaseHandler) setDefaultValue(old MeterReading) error {
+	if !old.ID.Valid {
+		u.ID = app.NewNullUUID()
+	} else {
+		u.ID = old.ID
+		if (u.AssetID.Valid && u.AssetID.String != old.AssetID.String) || (u.MeterID.Valid && u.MeterID.String != old.MeterID.String) {
+			return app.Error().New(http.StatusBadRequest, u.Ctx.Trans("meter_reading_immutable"))
+		}
+		u.AssetID, u.MeterID = old.AssetID, old.MeterID
+		err := u.validateLast(old)
+		if err != nil {
+			return err
+		}
+	}
+
+	// the meter must be defined for the category of the asset
+	if !old.ID.Valid {
+		ass, err := asset.UseCase(*u.Ctx, url.Values{}).GetByID(u.AssetID.String)
+		if err != nil {
+			return err
+		}
+		if ass.Status.String == asset.StatusDisposed {
+			return app.Error().New(http.StatusBadRequest, u.Ctx.Trans("asset_disposed", map[string]string{"code": ass.Code.String}))
+		}
+		mtr, err := meter.UseCase(*u.Ctx, url.Values{}).GetByID(u.MeterID.String)
+		if err != nil {
+			return err
+		}
+		if mtr.CategoryID.String != ass.CategoryID.String || !mtr.IsActive.Bool {
+			return app.Error().New(http.StatusBadRequest, u.Ctx.Trans("meter_category_invalid", map[string]string{
+				"meter": mtr.Code.String,
+				"asset": ass.Code.String,
+			}))
+		}
+	}
+	if u.EmployeeID.Valid && u.EmployeeID.String != "" && u.EmployeeID.String != old.EmployeeID.String {
+		_, err := employee.UseCase(*u.Ctx, url.Values{}).GetByID(u.EmployeeID.String)
+		if err != nil {
+			return err
+		}
+	}
+
+	readAt, value, isReplacement := old.ReadAt, old.Value, old.IsReplacement
+	if u.ReadAt.Valid {
+		readAt = u.ReadAt
+	}
+	if !readAt.Valid {
+		readAt.Set(time.Now().UTC())
+	}
+	if u.Value.Valid {
+		value = u.Value
+	}
+	if u.IsReplacement.Valid {
+		isReplacement = u.IsReplacement
+	}
+	if readAt.Time.After(time.Now()) {
+		return app.Error().New(http.StatusBadRequest, u.Ctx.Trans("meter_reading_date_future"))
+	}
+
+	// continue from the previous reading
+	prev, err := u.lastReading(u.AssetID.String, u.MeterID.String, u.ID.String)
+	if err != nil {
+		return err
+	}
+	u.ReadAt, u.IsReplacement = readAt, isReplacement
+	u.Usage.Set(0)
+	u.PreviousValue = prev.Value
+	if prev.ID.Valid {
+		if readAt.Time.Before(prev.ReadAt.Time) {
+			return app.Error().New(http.StatusBadRequest, u.Ctx.Trans("meter_reading_date_invalid", map[string]string{
+				"date": prev.ReadAt.Time.Format("2006-01-02 15:04"),
+			}))
+		}
+		if !isReplacement.Bool {
+			if value.Float64 < prev.Value.Float64 {
+				return app.Error().New(http.StatusBadRequest, u.Ctx.Trans("meter_reading_decreased", map[string]string{
+					"value": strconv.FormatFloat(prev.Value.Float64, 'f', -1, 64),
+				}))
+			}
+			u.Usage.Set(value.Float64 - prev.Value.Float64)
+		}
+	}
+
+	// a replaced meter starts again from its new value, the usage of the old meter ends at the previous reading
+	u.CumulativeUsage.Set(prev.CumulativeUsage.Float64 + u.Usage.Float64)
+
+	return nil
+}
+
+// lastReading returns the last MeterReading data of the meter of the asset, excluding the specified reading,
+// or an empty reading when nothing is read yet.
+func (u *UseCaseHandler) lastReading(assetID, meterID, excludeID string) (MeterReading, error) {
+	res := MeterReading{}
+
+	// prepare db for current ctx
+	tx, err := u.Ctx.DB()
+	if err != nil {
+		return res, app.Error().New(http.StatusInternalServerError, err.Error())
+	}
+
+	err = tx.Where("asset_id = ?", assetID).
+		Where("meter_id = ?", meterID).
+		Where("id <> ?", excludeID).
+		Where("deleted_at IS NULL").
+		Order("read_at DESC, created_at DESC").
+		Limit(1).Find(&res).Error
+	if err != nil {
+		return res, app.Error().New(http.StatusInternalServerError, err.Error())
+	}
+	return res, nil
+}
+
+// validateLast validates that the MeterReading data is the last reading of the meter of the asset.
+func (u *UseCaseHandler) validateLast(old MeterReading) error {
+	last, err := u.lastReading(old.AssetID.String, old.MeterID.String, "")
+	if err != nil {
+		return err
+	}
+	if last.ID.String != old.ID.String {
+		return app.Error().New(http.StatusBadRequest, u.Ctx.Trans("meter_reading_locked"))
+	}
+	return nil
+}
+
+// syncAsset sets the units used by the asset to the cumulative usage of its last reading
+// when the meter is the depreciation meter of the category.
+func (u *UseCaseHandler) syncAsset(tx *gorm.DB, assetID, meterID string) error {
+	mtr, err := meter.UseCase(*u.Ctx, url.Values{}).GetByID(meterID)
+	if err != nil {
+		return err
+	}
+	if !mtr.IsDepreciationMeter.Bool {
+		return nil
+	}
+	last, err := u.lastReading(assetID, meterID, "")
+	if err != nil {
+		return err
+	}
+	err = tx.Model(&asset.Asset{}).Where("id = ?", assetID).Update("units_used", last.CumulativeUsage.Float64).Error
+	if err != nil {
+		return app.Error().New(http.StatusInternalServerError, err.Error())
+	}
+	app.Cache().Invalidate(asset.Asset{}.EndPoint(), assetID)
+	return nil
+}
@@ -3,19 +3,42 @@ package src
 import (
 	"github.com/maulanar/go_asset_tracking_management/app"
 	"github.com/maulanar/go_asset_tracking_management/src/asset"
+	"github.com/maulanar/go_asset_tracking_management/src/assetrevaluation"
 	"github.com/maulanar/go_asset_tracking_management/src/attachment"
 	"github.com/maulanar/go_asset_tracking_management/src/branch"
 	"github.com/maulanar/go_asset_tracking_management/src/category"
 	"github.com/maulanar/go_asset_tracking_management/src/condition"
+	"github.com/maulanar/go_asset_tracking_management/src/consumable"
+	"github.com/maulanar/go_asset_tracking_management/src/consumablestock"
 	"github.com/maulanar/go_asset_tracking_management/src/department"
+	"github.com/maulanar/go_asset_tracking_management/src/depreciationentry"
 	"github.com/maulanar/go_asset_tracking_management/src/employee"
 	"github.com/maulanar/go_asset_tracking_management/src/employeeasset"
+	"github.com/maulanar/go_asset_tracking_management/src/fiscalperiod"
+	"github.com/maulanar/go_asset_tracking_management/src/goodsreceipt"
+	"github.com/maulanar/go_asset_tracking_management/src/incident"
 	"github.com/maulanar/go_asset_tracking_management/src/jobposition"
+	"github.com/maulanar/go_asset_tracking_management/src/jobrun"
+	"github.com/maulanar/go_asset_tracking_management/src/liabilitycase"
+	"github.com/maulanar/go_asset_tracking_management/src/license"
+	"github.com/maulanar/go_asset_tracking_management/src/licenseseat"
+	"github.com/maulanar/go_asset_tracking_management/src/location"
 	"github.com/maulanar/go_asset_tracking_management/src/maintenanceasset"
+	"github.com/maulanar/go_asset_tracking_management/src/maintenanceplan"
 	"github.com/maulanar/go_asset_tracking_management/src/maintenancetype"
+	"github.com/maulanar/go_asset_tracking_management/src/meter"
+	"github.com/maulanar/go_asset_tracking_management/src/meterreading"
+	"github.com/maulanar/go_asset_tracking_management/src/notification"
+	"github.com/maulanar/go_asset_tracking_management/src/purchaseorder"
+	"github.com/maulanar/go_asset_tracking_management/src/purchaserequest"
 	"github.com/maulanar/go_asset_tracking_management/src/reports/assetcondition"
 	"github.com/maulanar/go_asset_tracking_management/src/role"
+	"github.com/maulanar/go_asset_tracking_management/src/stockmovement"
+	"github.com/maulanar/go_asset_tracking_management/src/tag"
 	"github.com/maulanar/go_asset_tracking_management/src/user"
+	"github.com/maulanar/go_asset_tracking_management/src/vendor"
+	"github.com/maulanar/go_asset_tracking_management/src/warranty"
+	"github.com/maulanar/go_asset_tracking_management/src/workorder"
 	// import : DONT REMOVE THIS COMMENT
 )
 
@@ -53,6 +76,35 @@ func (*migratorUtil) Configure() {
 	app.DB().RegisterTable("main", role.Role{})
 	app.DB().RegisterTable("main", maintenancetype.MaintenanceType{})
 	app.DB().RegisterTable("main", maintenanceasset.MaintenanceAsset{})
+	app.DB().RegisterTable("main", maintenanceasset.MaintenanceAssetPart{})
+	app.DB().RegisterTable("main", vendor.Vendor{})
+	app.DB().RegisterTable("main", purchaserequest.PurchaseRequest{})
+	app.DB().RegisterTable("main", purchaseorder.PurchaseOrder{})
+	app.DB().RegisterTable("main", purchaseorder.PurchaseOrderLine{})
+	app.DB().RegisterTable("main", goodsreceipt.GoodsReceipt{})
+	app.DB().RegisterTable("main", goodsreceipt.GoodsReceiptLine{})
+	app.DB().RegisterTable("main", notification.Notification{})
+	app.DB().RegisterTable("main", warranty.Warranty{})
+	app.DB().RegisterTable("main", consumable.Consumable{})
+	app.DB().RegisterTable("main", consumablestock.ConsumableStock{})
+	app.DB().RegisterTable("main", stockmovement.StockMovement{})
+	app.DB().RegisterTable("main", license.License{})
+	app.DB().RegisterTable("main", licenseseat.LicenseSeat{})
+	app.DB().RegisterTable("main", location.Location{})
+	app.DB().RegisterTable("main", tag.Tag{})
+	app.DB().RegisterTable("main", tag.AssetTag{})
+	app.DB().RegisterTable("main", depreciationentry.DepreciationEntry{})
+	app.DB().RegisterTable("main", fiscalperiod.FiscalPeriod{})
+	app.DB().RegisterTable("main", assetrevaluation.AssetRevaluation{})
+	app.DB().RegisterTable("main", jobrun.JobRun{})
+	app.DB().RegisterTable("main", maintenanceplan.MaintenancePlan{})
+	app.DB().RegisterTable("main", workorder.WorkOrder{})
+	app.DB().RegisterTable("main", workorder.WorkOrderLine{})
+	app.DB().RegisterTable("main", meter.Meter{})
+	app.DB().RegisterTable("main", meterreading.MeterReading{})
+	app.DB().RegisterTable("main", incident.Incident{})
+	app.DB().RegisterTable("main", liabilitycase.LiabilityCase{})
+	app.DB().RegisterTable("main", liabilitycase.LiabilityInstallment{})
 	// RegisterTable : DONT REMOVE THIS COMMENT
 }
 
@@ -0,0 +1,2 @@
+// notification is a package related to notification data.
+package notification
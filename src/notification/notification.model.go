@@ -0,0 +1,122 @@
+package notification
+
+import "github.com/maulanar/go_asset_tracking_management/app"
+
+// Notification is the main model of Notification data. It provides a convenient interface for app.ModelInterface
+type Notification struct {
+	app.Model
+	ID       app.NullUUID     `json:"id"          db:"m.id"              gorm:"column:id;primaryKey"`
+	Type     app.NullString   `json:"type"        db:"m.type"            gorm:"column:type"`
+	Title    app.NullString   `json:"title"       db:"m.title"           gorm:"column:title"`
+	Message  app.NullText     `json:"message"     db:"m.message"         gorm:"column:message"`
+	Endpoint app.NullString   `json:"endpoint"    db:"m.endpoint"        gorm:"column:endpoint"`
+	DataID   app.NullString   `json:"data_id"     db:"m.data_id"         gorm:"column:data_id"`
+	IsRead   app.NullBool     `json:"is_read"     db:"m.is_read"         gorm:"column:is_read"`
+	ReadAt   app.NullDateTime `json:"read_at"     db:"m.read_at"         gorm:"column:read_at"`
+
+	BranchID   app.NullUUID   `json:"branch.id"   db:"m.branch_id"       gorm:"column:branch_id"`
+	BranchCode app.NullString `json:"branch.code" db:"brc.code"          gorm:"-"`
+	BranchName app.NullString `json:"branch.name" db:"brc.name"          gorm:"-"`
+
+	UserID app.NullUUID `json:"user.id"     db:"m.user_id"         gorm:"column:user_id"`
+
+	CreatedAt app.NullDateTime `json:"created_at"  db:"m.created_at"      gorm:"column:created_at"`
+	UpdatedAt app.NullDateTime `json:"updated_at"  db:"m.updated_at"      gorm:"column:updated_at"`
+	DeletedAt app.NullDateTime `json:"deleted_at"  db:"m.deleted_at,hide" gorm:"column:deleted_at"`
+}
+
+// EndPoint returns the Notification end point, it used for cache key, etc.
+func (Notification) EndPoint() string {
+	return "notifications"
+}
+
+// TableVersion returns the versions of the Notification table in the database.
+// Change this value with date format YY.MM.DDHHii when any table structure changes.
+func (Notification) TableVersion() string {
+	return "26.10.191000"
+}
+
+// TableName returns the name of the Notification table in the database.
+func (Notification) TableName() string {
+	return "notifications"
+}
+
+// TableAliasName returns the table alias name of the Notification table, used for querying.
+func (Notification) TableAliasName() string {
+	return "m"
+}
+
+// GetRelations returns the relations of the Notification data in the database, used for querying.
+func (m *Notification) GetRelations() map[string]map[string]any {
+	m.AddRelation("left", "branches", "brc", []map[string]any{{"column1": "brc.id", "column2": "m.branch_id"}})
+	return m.Relations
+}
+
+// GetFilters returns the filter of the Notification data in the database, used for querying.
+func (m *Notification) GetFilters() []map[string]any {
+	m.AddFilter(map[string]any{"column1": "m.deleted_at", "operator": "=", "value": nil})
+	return m.Filters
+}
+
+// GetSorts returns the default sort of the Notification data in the database, used for querying.
+func (m *Notification) GetSorts() []map[string]any {
+	m.AddSort(map[string]any{"column": "m.created_at", "direction": "desc"})
+	return m.Sorts
+}
+
+// GetFields returns list of the field of the Notification data in the database, used for querying.
+func (m *Notification) GetFields() map[string]map[string]any {
+	m.SetFields(m)
+	return m.Fields
+}
+
+// GetSchema returns the Notification schema, used for querying.
+func (m *Notification) GetSchema() map[string]any {
+	return m.SetSchema(m)
+}
+
+// OpenAPISchemaName returns the name of the Notification schema in the open api documentation.
+func (Notification) OpenAPISchemaName() string {
+	return "Notification"
+}
+
+// GetOpenAPISchema returns the Open API Schema of the Notification in the open api documentation.
+func (m *Notification) GetOpenAPISchema() map[string]any {
+	return m.SetOpenAPISchema(m)
+}
+
+type NotificationList struct {
+	app.ListModel
+}
+
+// OpenAPISchemaName returns the name of the NotificationList schema in the open api documentation.
+func (NotificationList) OpenAPISchemaName() string {
+	return "NotificationList"
+}
+
+// GetOpenAPISchema returns the Open API Schema of the NotificationList in the open api documentation.
+func (p *NotificationList) GetOpenAPISchema() map[string]any {
+	return p.SetOpenAPISchema(&Notification{})
+}
+
+// ParamCreate is the expected parameters for create a new Notification data.
+type ParamCreate struct {
+	UseCaseHandler
+	Title   app.NullString `json:"title"       db:"m.title"           gorm:"column:title"           validate:"required"`
+	Message app.NullText   `json:"message"     db:"m.message"         gorm:"column:message"         validate:"required"`
+}
+
+// ParamUpdate is the expected parameters for update the Notification data.
+type ParamUpdate struct {
+	UseCaseHandler
+}
+
+// ParamPartiallyUpdate is the expected parameters for partially update the Notification data.
+type ParamPartiallyUpdate struct {
+	UseCaseHandler
+}
+
+// ParamDelete is the expected parameters for delete the Notification data.
+type ParamDelete struct {
+	UseCaseHandler
+}
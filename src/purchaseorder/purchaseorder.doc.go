@@ -0,0 +1,2 @@
+// purchaseorder is a package related to purchaseorder data.
+package purchaseorder
@@ -0,0 +1,221 @@
+package purchaseorder
+
+import "github.com/maulanar/go_asset_tracking_management/app"
+
+// PurchaseOrder is the main model of PurchaseOrder data. It provides a convenient interface for app.ModelInterface
+type PurchaseOrder struct {
+	app.Model
+	ID          app.NullUUID    `json:"id"                    db:"m.id"                  gorm:"column:id;primaryKey"`
+	Code        app.NullString  `json:"code"                  db:"m.code"                gorm:"column:code"`
+	Date        app.NullDate    `json:"date"                  db:"m.date"                gorm:"column:date"`
+	Description app.NullText    `json:"description"           db:"m.description"         gorm:"column:description"`
+	TotalAmount app.NullFloat64 `json:"total_amount"          db:"m.total_amount"        gorm:"column:total_amount"`
+
+	VendorID   app.NullUUID   `json:"vendor.id"             db:"m.vendor_id"           gorm:"column:vendor_id"`
+	VendorCode app.NullString `json:"vendor.code"           db:"vnd.code"              gorm:"-"`
+	VendorName app.NullString `json:"vendor.name"           db:"vnd.name"              gorm:"-"`
+
+	PurchaseRequestID   app.NullUUID   `json:"purchase_request.id"   db:"m.purchase_request_id" gorm:"column:purchase_request_id"`
+	PurchaseRequestCode app.NullString `json:"purchase_request.code" db:"pr.code"               gorm:"-"`
+
+	Lines []PurchaseOrderLine `json:"lines"                 db:"-"                     gorm:"-"`
+
+	Status    app.NullString   `json:"status"                db:"m.status"              gorm:"column:status"              validate:"omitempty,oneof=draft open partially_received received cancelled"`
+	CreatedAt app.NullDateTime `json:"created_at"            db:"m.created_at"          gorm:"column:created_at"`
+	UpdatedAt app.NullDateTime `json:"updated_at"            db:"m.updated_at"          gorm:"column:updated_at"`
+	DeletedAt app.NullDateTime `json:"deleted_at"            db:"m.deleted_at,hide"     gorm:"column:deleted_at"`
+}
+
+// EndPoint returns the PurchaseOrder end point, it used for cache key, etc.
+func (PurchaseOrder) EndPoint() string {
+	return "purchase_orders"
+}
+
+// TableVersion returns the versions of the PurchaseOrder table in the database.
+// Change this value with date format YY.MM.DDHHii when any table structure changes.
+func (PurchaseOrder) TableVersion() string {
+	return "26.10.190900"
+}
+
+// TableName returns the name of the PurchaseOrder table in the database.
+func (PurchaseOrder) TableName() string {
+	return "purchase_orders"
+}
+
+// TableAliasName returns the table alias name of the PurchaseOrder table, used for querying.
+func (PurchaseOrder) TableAliasName() string {
+	return "m"
+}
+
+// GetRelations returns the relations of the PurchaseOrder data in the database, used for querying.
+func (m *PurchaseOrder) GetRelations() map[string]map[string]any {
+	m.AddRelation("left", "vendors", "vnd", []map[string]any{{"column1": "vnd.id", "column2": "m.vendor_id"}})
+	m.AddRelation("left", "purchase_requests", "pr", []map[string]any{{"column1": "pr.id", "column2": "m.purchase_request_id"}})
+	return m.Relations
+}
+
+// GetFilters returns the filter of the PurchaseOrder data in the database, used for querying.
+func (m *PurchaseOrder) GetFilters() []map[string]any {
+	m.AddFilter(map[string]any{"column1": "m.deleted_at", "operator": "=", "value": nil})
+	return m.Filters
+}
+
+// GetSorts returns the default sort of the PurchaseOrder data in the database, used for querying.
+func (m *PurchaseOrder) GetSorts() []map[string]any {
+	m.AddSort(map[string]any{"column": "m.updated_at", "direction": "desc"})
+	return m.Sorts
+}
+
+// GetFields returns list of the field of the PurchaseOrder data in the database, used for querying.
+func (m *PurchaseOrder) GetFields() map[string]map[string]any {
+	m.SetFields(m)
+	return m.Fields
+}
+
+// GetSchema returns the PurchaseOrder schema, used for querying.
+func (m *PurchaseOrder) GetSchema() map[string]any {
+	return m.SetSchema(m)
+}
+
+// OpenAPISchemaName returns the name of the PurchaseOrder schema in the open api documentation.
+func (PurchaseOrder) OpenAPISchemaName() string {
+	return "PurchaseOrder"
+}
+
+// GetOpenAPISchema returns the Open API Schema of the PurchaseOrder in the open api documentation.
+func (m *PurchaseOrder) GetOpenAPISchema() map[string]any {
+	return m.SetOpenAPISchema(m)
+}
+
+type PurchaseOrderList struct {
+	app.ListModel
+}
+
+// OpenAPISchemaName returns the name of the PurchaseOrderList schema in the open api documentation.
+func (PurchaseOrderList) OpenAPISchemaName() string {
+	return "PurchaseOrderList"
+}
+
+// GetOpenAPISchema returns the Open API Schema of the PurchaseOrderList in the open api documentation.
+func (p *PurchaseOrderList) GetOpenAPISchema() map[string]any {
+	return p.SetOpenAPISchema(&PurchaseOrder{})
+}
+
+// ParamCreate is the expected parameters for create a new PurchaseOrder data.
+type ParamCreate struct {
+	UseCaseHandler
+	Date     app.NullDate        `json:"date"                  db:"m.date"                gorm:"column:date"                validate:"required"`
+	VendorID app.NullUUID        `json:"vendor.id"             db:"m.vendor_id"           gorm:"column:vendor_id"           validate:"required"`
+	Lines    []PurchaseOrderLine `json:"lines"                 db:"-"                     gorm:"-"                          validate:"required,min=1,dive"`
+}
+
+// ParamUpdate is the expected parameters for update the PurchaseOrder data.
+type ParamUpdate struct {
+	UseCaseHandler
+}
+
+// ParamPartiallyUpdate is the expected parameters for partially update the PurchaseOrder data.
+type ParamPartiallyUpdate struct {
+	UseCaseHandler
+}
+
+// ParamDelete is the expected parameters for delete the PurchaseOrder data.
+type ParamDelete struct {
+	UseCaseHandler
+}
+
+// PurchaseOrderLine is the line item of PurchaseOrder data, one row per category ordered.
+type PurchaseOrderLine struct {
+	app.Model
+	ID              app.NullUUID    `json:"id"                db:"m.id"                gorm:"column:id;primaryKey"`
+	PurchaseOrderID app.NullUUID    `json:"purchase_order.id" db:"m.purchase_order_id" gorm:"column:purchase_order_id"`
+	Name            app.NullString  `json:"name"              db:"m.name"              gorm:"column:name"`
+	Qty             app.NullInt64   `json:"qty"               db:"m.qty"               gorm:"column:qty"                validate:"required,gt=0"`
+	UnitPrice       app.NullFloat64 `json:"unit_price"        db:"m.unit_price"        gorm:"column:unit_price"         validate:"required,gte=0"`
+	Amount          app.NullFloat64 `json:"amount"            db:"m.amount"            gorm:"column:amount"`
+	ReceivedQty     app.NullInt64   `json:"received_qty"      db:"m.received_qty"      gorm:"column:received_qty"`
+
+	CategoryID   app.NullUUID   `json:"category.id"       db:"m.category_id"       gorm:"column:category_id"        validate:"required"`
+	CategoryCode app.NullString `json:"category.code"     db:"cat.code"            gorm:"-"`
+	CategoryName app.NullString `json:"category.name"     db:"cat.name"            gorm:"-"`
+
+	CreatedAt app.NullDateTime `json:"created_at"        db:"m.created_at"        gorm:"column:created_at"`
+	UpdatedAt app.NullDateTime `json:"updated_at"        db:"m.updated_at"        gorm:"column:updated_at"`
+	DeletedAt app.NullDateTime `json:"deleted_at"        db:"m.deleted_at,hide"   gorm:"column:deleted_at"`
+}
+
+// EndPoint returns the PurchaseOrderLine end point, it used for cache key, etc.
+func (PurchaseOrderLine) EndPoint() string {
+	return "purchase_order_lines"
+}
+
+// TableVersion returns the versions of the PurchaseOrderLine table in the database.
+// Change this value with date format YY.MM.DDHHii when any table structure changes.
+func (PurchaseOrderLine) TableVersion() string {
+	return "26.10.190900"
+}
+
+// TableName returns the name of the PurchaseOrderLine table in the database.
+func (PurchaseOrderLine) TableName() string {
+	return "purchase_order_lines"
+}
+
+// TableAliasName returns the table alias name of the PurchaseOrderLine table, used for querying.
+func (PurchaseOrderLine) TableAliasName() string {
+	return "m"
+}
+
+// GetRelations returns the relations of the PurchaseOrderLine data in the database, used for querying.
+func (m *PurchaseOrderLine) GetRelations() map[string]map[string]any {
+	m.AddRelation("left", "categories", "cat", []map[string]any{{"column1": "cat.id", "column2": "m.category_id"}})
+	return m.Relations
+}
+
+// GetFilters returns the filter of the PurchaseOrderLine data in the database, used for querying.
+func (m *PurchaseOrderLine) GetFilters() []map[string]any {
+	m.AddFilter(map[string]any{"column1": "m.deleted_at", "operator": "=", "value": nil})
+	return m.Filters
+}
+
+// GetSorts returns the default sort of the PurchaseOrderLine data in the database, used for querying.
+func (m *PurchaseOrderLine) GetSorts() []map[string]any {
+	m.AddSort(map[string]any{"column": "m.created_at", "direction": "asc"})
+	return m.Sorts
+}
+
+// GetFields returns list of the field of the PurchaseOrderLine data in the database, used for querying.
+func (m *PurchaseOrderLine) GetFields() map[string]map[string]any {
+	m.SetFields(m)
+	return m.Fields
+}
+
+// GetSchema returns the PurchaseOrderLine schema, used for querying.
+func (m *PurchaseOrderLine) GetSchema() map[string]any {
+	return m.SetSchema(m)
+}
+
+// OpenAPISchemaName returns the name of the PurchaseOrderLine schema in the open api documentation.
+func (PurchaseOrderLine) OpenAPISchemaName() string {
+	return "PurchaseOrderLine"
+}
+
+// GetOpenAPISchema returns the Open API Schema of the PurchaseOrderLine in the open api documentation.
+func (m *PurchaseOrderLine) GetOpenAPISchema() map[string]any {
+	return m.SetOpenAPISchema(m)
+}
+
+// Statuses of the PurchaseOrder data, a purchase order is partially received and received by its goods receipts only.
+const (
+	StatusDraft             = "draft"
+	StatusOpen              = "open"
+	StatusPartiallyReceived = "partially_received"
+	StatusReceived          = "received"
+	StatusCancelled         = "cancelled"
+)
+
+// Transitions are the status changes of the PurchaseOrder data allowed to be sent by the user.
+var Transitions = map[string][]string{
+	StatusDraft:             {StatusOpen, StatusCancelled},
+	StatusOpen:              {StatusCancelled},
+	StatusPartiallyReceived: {StatusCancelled},
+}
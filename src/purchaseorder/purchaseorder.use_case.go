@@ -0,0 +1,589 @@
+package purchaseorder
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"slices"
+	"time"
+
+	"gorm.io/gorm"
+	"grest.dev/grest"
+
+	"github.com/maulanar/go_asset_tracking_management/app"
+	"github.com/maulanar/go_asset_tracking_management/src/category"
+	"github.com/maulanar/go_asset_tracking_management/src/purchaserequest"
+	"github.com/maulanar/go_asset_tracking_management/src/vendor"
+)
+
+// UseCase returns a UseCaseHandler for expected use case functional.
+func UseCase(ctx app.Ctx, query ...url.Values) UseCaseHandler {
+	u := UseCaseHandler{
+		Ctx:   &ctx,
+		Query: url.Values{},
+	}
+	if len(query) > 0 {
+		u.Query = query[0]
+	}
+	return u
+}
+
+// UseCaseHandler provides a convenient interface for PurchaseOrder use case, use UseCase to access UseCaseHandler.
+type UseCaseHandler struct {
+	PurchaseOrder
+
+	// injectable dependencies
+	Ctx   *app.Ctx   `json:"-" db:"-" gorm:"-"`
+	Query url.Values `json:"-" db:"-" gorm:"-"`
+}
+
+// Async return UseCaseHandler with async process.
+func (u UseCaseHandler) Async(ctx app.Ctx, query ...url.Values) UseCaseHandler {
+	ctx.IsAsync = true
+	return UseCase(ctx, query...)
+}
+
+// GetByID returns the PurchaseOrder data for the specified ID.
+func (u UseCaseHandler) GetByID(id string) (PurchaseOrder, error) {
+	res := PurchaseOrder{}
+
+	// check permission
+	err := u.Ctx.ValidatePermission("purchase_orders.detail")
+	if err != nil {
+		return res, err
+	}
+
+	// get from cache and return if exists
+	cacheKey := u.EndPoint() + "." + id
+	app.Cache().Get(cacheKey, &res)
+	if res.ID.Valid {
+		return res, err
+	}
+
+	// prepare db for current ctx
+	tx, err := u.Ctx.DB()
+	if err != nil {
+		return res, app.Error().New(http.StatusInternalServerError, err.Error())
+	}
+
+	// get from db
+	key := "id"
+	if !app.Validator().IsValid(id, "uuid") {
+		key = "code"
+	}
+	u.Query.Add(key, id)
+	err = app.Query().First(tx, &res, u.Query)
+	if err != nil {
+		return res, u.Ctx.NotFoundError(err, u.EndPoint(), key, id)
+	}
+
+	// get line items
+	res.Lines, err = u.GetLines(res.ID.String)
+	if err != nil {
+		return res, err
+	}
+
+	// save to cache and return if exists
+	app.Cache().Set(cacheKey, res)
+	return res, err
+}
+
+// Get returns the list of PurchaseOrder data.
+func (u UseCaseHandler) Get() (app.ListModel, error) {
+	res := app.ListModel{}
+
+	// check permission
+	err := u.Ctx.ValidatePermission("purchase_orders.list")
+	if err != nil {
+		return res, err
+	}
+	// get from cache and return if exists
+	cacheKey := u.EndPoint() + "?" + u.Query.Encode()
+	err = app.Cache().Get(cacheKey, &res)
+	if err == nil {
+		return res, err
+	}
+
+	// prepare db for current ctx
+	tx, err := u.Ctx.DB()
+	if err != nil {
+		return res, app.Error().New(http.StatusInternalServerError, err.Error())
+	}
+
+	// set pagination info
+	res.Results.PageContext.Count,
+		res.Results.PageContext.Page,
+		res.Results.PageContext.PerPage,
+		res.Results.PageContext.PageCount,
+		err = app.Query().PaginationInfo(tx, &PurchaseOrder{}, u.Query)
+	if err != nil {
+		return res, app.Error().New(http.StatusInternalServerError, err.Error())
+	}
+	// return data count if $per_page set to 0
+	if res.Results.PageContext.PerPage == 0 {
+		return res, err
+	}
+
+	// find data
+	data, err := app.Query().Find(tx, &PurchaseOrder{}, u.Query)
+	if err != nil {
+		return res, app.Error().New(http.StatusInternalServerError, err.Error())
+	}
+	res.SetData(data, u.Query)
+
+	// save to cache and return if exists
+	app.Cache().Set(cacheKey, res)
+	return res, err
+}
+
+// Create creates a new data PurchaseOrder with specified parameters.
+func (u *UseCaseHandler) Create(p *ParamCreate) error {
+
+	// check permission
+	err := u.Ctx.ValidatePermission("purchase_orders.create")
+	if err != nil {
+		return err
+	}
+
+	// validate param
+	err = u.Ctx.ValidateParam(p)
+	if err != nil {
+		return err
+	}
+
+	// set default value for undefined field
+	err = u.setDefaultValue(PurchaseOrder{})
+	if err != nil {
+		return err
+	}
+
+	// prepare db for current ctx
+	tx, err := u.Ctx.DB()
+	if err != nil {
+		return app.Error().New(http.StatusInternalServerError, err.Error())
+	}
+
+	// save data to db
+	err = tx.Model(&u).Create(&u).Error
+	if err != nil {
+		return app.Error().New(http.StatusInternalServerError, err.Error())
+	}
+
+	// save line items
+	err = u.saveLines(tx)
+	if err != nil {
+		return err
+	}
+
+	// mark the purchase request as ordered
+	if u.PurchaseRequestID.Valid && u.PurchaseRequestID.String != "" {
+		prUC := purchaserequest.UseCase(*u.Ctx, url.Values{})
+		prUC.Status.Set("ordered")
+		err = prUC.PartiallyUpdateByID(u.PurchaseRequestID.String, &purchaserequest.ParamPartiallyUpdate{})
+		if err != nil {
+			return err
+		}
+	}
+
+	// invalidate cache
+	app.Cache().Invalidate(u.EndPoint())
+
+	// save history (user activity), send webhook, etc
+	go u.Ctx.Hook("POST", "create", p.ID.String, p)
+	return nil
+}
+
+// UpdateByID updates the PurchaseOrder data for the specified ID with specified parameters.
+func (u UseCaseHandler) UpdateByID(id string, p *ParamUpdate) error {
+
+	// check permission
+	err := u.Ctx.ValidatePermission("purchase_orders.edit")
+	if err != nil {
+		return err
+	}
+
+	// validate param
+	err = u.Ctx.ValidateParam(p)
+	if err != nil {
+		return err
+	}
+
+	// get previous data
+	old, err := u.GetByID(id)
+	if err != nil {
+		return err
+	}
+
+	// set default value for undefined field
+	err = u.setDefaultValue(old)
+	if err != nil {
+		return err
+	}
+
+	// prepare db for current ctx
+	tx, err := u.Ctx.DB()
+	if err != nil {
+		return app.Error().New(http.StatusInternalServerError, err.Error())
+	}
+
+	// update data on the db
+	err = tx.Model(&u).Where("id = ?", old.ID).Updates(u).Error
+	if err != nil {
+		return app.Error().New(http.StatusInternalServerError, err.Error())
+	}
+
+	// replace line items if any
+	err = u.saveLines(tx)
+	if err != nil {
+		return err
+	}
+
+	// invalidate cache
+	app.Cache().Invalidate(u.EndPoint(), old.ID.String)
+
+	// save history (user activity), send webhook, etc
+	go u.Ctx.Hook("PUT", "Update", old.ID.String, old)
+	return nil
+}
+
+// PartiallyUpdateByID updates the PurchaseOrder data for the specified ID with specified parameters.
+func (u UseCaseHandler) PartiallyUpdateByID(id string, p *ParamPartiallyUpdate) error {
+
+	// check permission
+	err := u.Ctx.ValidatePermission("purchase_orders.edit")
+	if err != nil {
+		return err
+	}
+
+	// validate param
+	err = u.Ctx.ValidateParam(p)
+	if err != nil {
+		return err
+	}
+
+	// get previous data
+	old, err := u.GetByID(id)
+	if err != nil {
+		return err
+	}
+
+	// set default value for undefined field
+	err = u.setDefaultValue(old)
+	if err != nil {
+		return err
+	}
+
+	// prepare db for current ctx
+	tx, err := u.Ctx.DB()
+	if err != nil {
+		return app.Error().New(http.StatusInternalServerError, err.Error())
+	}
+
+	// update data on the db
+	err = tx.Model(&u).Where("id = ?", old.ID).Updates(u).Error
+	if err != nil {
+		return app.Error().New(http.StatusInternalServerError, err.Error())
+	}
+
+	// replace line items if any
+	err = u.saveLines(tx)
+	if err != nil {
+		return err
+	}
+
+	// invalidate cache
+	app.Cache().Invalidate(u.EndPoint(), old.ID.String)
+
+	// save history (user activity), send webhook, etc
+	go u.Ctx.Hook("PATCH", "Partially Update", old.ID.String, old)
+	return nil
+}
+
+// DeleteByID deletes the PurchaseOrder data for the specified ID.
+func (u UseCaseHandler) DeleteByID(id string, p *ParamDelete) error {
+
+	// check permission
+	err := u.Ctx.ValidatePermission("purchase_orders.delete")
+	if err != nil {
+		return err
+	}
+
+	// validate param
+	err = u.Ctx.ValidateParam(p)
+	if err != nil {
+		return err
+	}
+
+	// get previous data
+	old, err := u.GetByID(id)
+	if err != nil {
+		return err
+	}
+
+	// received purchase order can not be deleted
+	for _, line := range old.Lines {
+		if line.ReceivedQty.Int64 > 0 {
+			return app.Error().New(http.StatusBadRequest, u.Ctx.Trans("purchase_order_not_editable", map[string]string{
+				"code":   old.Code.String,
+				"status": old.Status.String,
+			}))
+		}
+	}
+
+	// prepare db for current ctx
+	tx, err := u.Ctx.DB()
+	if err != nil {
+		return app.Error().New(http.StatusInternalServerError, err.Error())
+	}
+
+	// update data on the db
+	err = tx.Model(&p).Where("id = ?", old.ID).Update("deleted_at", time.Now().UTC()).Error
+	if err != nil {
+		return app.Error().New(http.StatusInternalServerError, err.Error())
+	}
+	err = tx.Model(&PurchaseOrderLine{}).Where("purchase_order_id = ?", old.ID).Where("deleted_at IS NULL").Update("deleted_at", time.Now().UTC()).Error
+	if err != nil {
+		return app.Error().New(http.StatusInternalServerError, err.Error())
+	}
+
+	// invalidate cache
+	app.Cache().Invalidate(u.EndPoint(), old.ID.String)
+
+	// save history (user activity), send webhook, etc
+	go u.Ctx.Hook("DELETE", "DELETE", old.ID.String, old)
+	return nil
+}
+
+// setDefaultValue set default value of undefined field when create or update PurchaseOrder data.
+func (u *UseCaseHandler) setDefaultValue(old PurchaseOrder) error {
+	if !old.ID.Valid {
+		u.ID = app.NewNullUUID()
+	} else {
+		u.ID = old.ID
+	}
+
+	// validate vendor
+	key := u.VendorID.String
+	if !u.VendorID.Valid || u.VendorID.String == "" {
+		key = u.VendorCode.String
+	}
+	if key != "" {
+		vnd, err := vendor.UseCase(*u.Ctx, url.Values{}).GetByID(key)
+		if err != nil {
+			return err
+		}
+		u.VendorID = vnd.ID
+	}
+
+	// validate purchase request, only approved request can be ordered
+	if u.PurchaseRequestID.Valid && u.PurchaseRequestID.String != "" && u.PurchaseRequestID.String != old.PurchaseRequestID.String {
+		pr, err := purchaserequest.UseCase(*u.Ctx, url.Values{}).GetByID(u.PurchaseRequestID.String)
+		if err != nil {
+			return err
+		}
+		if pr.Status.String != "approved" {
+			return app.Error().New(http.StatusBadRequest, u.Ctx.Trans("purchase_request_not_approved", map[string]string{
+				"code": pr.Code.String,
+			}))
+		}
+		u.PurchaseRequestID = pr.ID
+	}
+
+	// Penentuan kode
+	if u.Code.Valid && u.Code.String != "" {
+		if !old.Code.Valid || u.Code.String != old.Code.String {
+			err := app.Common().IsFieldValueExists(u.Ctx, u.EndPoint(), "Code", u.TableName(), "code", u.Code.String)
+			if err != nil {
+				return err
+			}
+		}
+	} else if old.Code.Valid && old.Code.String != "" {
+		u.Code = old.Code
+	} else {
+		newCode, err := app.Common().GenerateCode(u.Ctx, u.TableName(), "code", "Purchase Order")
+		if err != nil {
+			return err
+		}
+		u.Code.Set(newCode)
+	}
+
+	// the status is only changed along the transitions, the received statuses are set by the goods receipts
+	if u.Status.Valid && u.Status.String != old.Status.String {
+		from := old.Status.String
+		if !old.ID.Valid {
+			from = StatusDraft
+		}
+		if u.Status.String != from && !slices.Contains(Transitions[from], u.Status.String) {
+			return app.Error().New(http.StatusBadRequest, u.Ctx.Trans("purchase_order_transition_invalid", map[string]string{
+				"code": old.Code.String,
+				"from": from,
+				"to":   u.Status.String,
+			}))
+		}
+	}
+
+	// line items can only be changed while the purchase order is still a draft and nothing is received yet
+	if len(u.Lines) > 0 {
+		if old.ID.Valid && old.Status.String != StatusDraft {
+			return app.Error().New(http.StatusBadRequest, u.Ctx.Trans("purchase_order_not_editable", map[string]string{
+				"code":   old.Code.String,
+				"status": old.Status.String,
+			}))
+		}
+		if old.ID.Valid {
+			tx, err := u.Ctx.DB()
+			if err != nil {
+				return app.Error().New(http.StatusInternalServerError, err.Error())
+			}
+			var received int64
+			err = tx.Model(&PurchaseOrderLine{}).
+				Where("purchase_order_id = ?", old.ID).
+				Where("received_qty > 0").
+				Where("deleted_at IS NULL").
+				Count(&received).Error
+			if err != nil {
+				return app.Error().New(http.StatusInternalServerError, err.Error())
+			}
+			if received > 0 {
+				return app.Error().New(http.StatusBadRequest, u.Ctx.Trans("purchase_order_not_editable", map[string]string{
+					"code":   old.Code.String,
+					"status": StatusPartiallyReceived,
+				}))
+			}
+		}
+
+		total := float64(0)
+		for i := range u.Lines {
+			line := &u.Lines[i]
+			err := u.Ctx.ValidateParam(line)
+			if err != nil {
+				return err
+			}
+			cat, err := category.UseCase(*u.Ctx, url.Values{}).GetByID(line.CategoryID.String)
+			if err != nil {
+				return err
+			}
+			line.ID = app.NewNullUUID()
+			line.PurchaseOrderID = u.ID
+			line.CategoryID = cat.ID
+			if !line.Name.Valid || line.Name.String == "" {
+				line.Name.Set(cat.Name.String)
+			}
+			line.Amount.Set(float64(line.Qty.Int64) * line.UnitPrice.Float64)
+			line.ReceivedQty.Set(0)
+			total += line.Amount.Float64
+		}
+		u.TotalAmount.Set(total)
+	}
+
+	if !old.ID.Valid {
+		if !u.Date.Valid {
+			u.Date.Set(time.Now())
+		}
+		if !u.Status.Valid {
+			u.Status.Set(StatusDraft)
+		}
+	}
+
+	return nil
+}
+
+// saveLines replaces the line items of the PurchaseOrder data with the prepared u.Lines.
+func (u *UseCaseHandler) saveLines(tx *gorm.DB) error {
+	if len(u.Lines) == 0 {
+		return nil
+	}
+
+	err := tx.Model(&PurchaseOrderLine{}).Where("purchase_order_id = ?", u.ID).Where("deleted_at IS NULL").Update("deleted_at", time.Now().UTC()).Error
+	if err != nil {
+		return app.Error().New(http.StatusInternalServerError, err.Error())
+	}
+	for _, line := range u.Lines {
+		err = tx.Create(&line).Error
+		if err != nil {
+			return app.Error().New(http.StatusInternalServerError, err.Error())
+		}
+	}
+	return nil
+}
+
+// UpdateReceivedStatus sets the status of the PurchaseOrder data for the specified ID from the received qty of its lines,
+// it is called by the goods receipts once the received qty is changed. A cancelled purchase order keeps its status.
+func (u UseCaseHandler) UpdateReceivedStatus(id string) error {
+
+	// get previous data, the cached data may have the status before the last receipt
+	app.Cache().Invalidate(u.EndPoint(), id)
+	old, err := u.GetByID(id)
+	if err != nil {
+		return err
+	}
+
+	// prepare db for current ctx
+	tx, err := u.Ctx.DB()
+	if err != nil {
+		return app.Error().New(http.StatusInternalServerError, err.Error())
+	}
+
+	lines := []PurchaseOrderLine{}
+	err = tx.Where("purchase_order_id = ?", old.ID).Where("deleted_at IS NULL").Find(&lines).Error
+	if err != nil {
+		return app.Error().New(http.StatusInternalServerError, err.Error())
+	}
+	status, received := StatusReceived, false
+	for _, line := range lines {
+		if line.ReceivedQty.Int64 > 0 {
+			received = true
+		}
+		if line.ReceivedQty.Int64 < line.Qty.Int64 {
+			status = StatusPartiallyReceived
+		}
+	}
+	if !received {
+		status = StatusOpen
+	}
+	if old.Status.String == StatusCancelled || old.Status.String == status {
+		return nil
+	}
+
+	// update data on the db
+	err = tx.Model(&PurchaseOrder{}).Where("id = ?", old.ID).Updates(map[string]any{"status": status, "updated_at": time.Now().UTC()}).Error
+	if err != nil {
+		return app.Error().New(http.StatusInternalServerError, err.Error())
+	}
+
+	// invalidate cache
+	app.Cache().Invalidate(u.EndPoint(), old.ID.String)
+
+	// save history (user activity), send webhook, etc
+	go u.Ctx.Hook("POST", status, old.ID.String, old)
+	return nil
+}
+
+// GetLines returns the line items of the PurchaseOrder data for the specified purchase order ID.
+func (u UseCaseHandler) GetLines(purchaseOrderID string) ([]PurchaseOrderLine, error) {
+	res := []PurchaseOrderLine{}
+
+	// prepare db for current ctx
+	tx, err := u.Ctx.DB()
+	if err != nil {
+		return res, app.Error().New(http.StatusInternalServerError, err.Error())
+	}
+
+	query := url.Values{}
+	query.Add("purchase_order.id", purchaseOrderID)
+	query.Add(grest.QueryDisablePagination, "true")
+	data, err := app.Query().Find(tx, &PurchaseOrderLine{}, query)
+	if err != nil {
+		return res, app.Error().New(http.StatusInternalServerError, err.Error())
+	}
+
+	jByte, err := json.Marshal(data)
+	if err != nil {
+		return res, app.Error().New(http.StatusInternalServerError, err.Error())
+	}
+	err = app.BindJSON(jByte, &res)
+	if err != nil {
+		return res, app.Error().New(http.StatusInternalServerError, err.Error())
+	}
+	return res, nil
+}
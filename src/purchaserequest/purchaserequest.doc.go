@@ -0,0 +1,2 @@
+// purchaserequest is a package related to purchaserequest data.
+package purchaserequest
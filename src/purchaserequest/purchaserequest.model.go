@@ -0,0 +1,131 @@
+package purchaserequest
+
+import "github.com/maulanar/go_asset_tracking_management/app"
+
+// PurchaseRequest is the main model of PurchaseRequest data. It provides a convenient interface for app.ModelInterface
+type PurchaseRequest struct {
+	app.Model
+	ID             app.NullUUID    `json:"id"              db:"m.id"              gorm:"column:id;primaryKey"`
+	Code           app.NullString  `json:"code"            db:"m.code"            gorm:"column:code"`
+	Date           app.NullDate    `json:"date"            db:"m.date"            gorm:"column:date"`
+	Description    app.NullText    `json:"description"     db:"m.description"     gorm:"column:description"`
+	Qty            app.NullInt64   `json:"qty"             db:"m.qty"             gorm:"column:qty"`
+	EstimatedPrice app.NullFloat64 `json:"estimated_price" db:"m.estimated_price" gorm:"column:estimated_price"`
+
+	CategoryID   app.NullUUID   `json:"category.id"     db:"m.category_id"     gorm:"column:category_id"`
+	CategoryCode app.NullString `json:"category.code"   db:"cat.code"          gorm:"-"`
+	CategoryName app.NullString `json:"category.name"   db:"cat.name"          gorm:"-"`
+
+	EmployeeID   app.NullUUID   `json:"employee.id"     db:"m.employee_id"     gorm:"column:employee_id"`
+	EmployeeCode app.NullString `json:"employee.code"   db:"emp.code"          gorm:"-"`
+	EmployeeName app.NullString `json:"employee.name"   db:"emp.name"          gorm:"-"`
+
+	DepartmentID   app.NullUUID   `json:"department.id"   db:"emp.department_id" gorm:"-"`
+	DepartmentCode app.NullString `json:"department.code" db:"emp_dpt.code"      gorm:"-"`
+	DepartmentName app.NullString `json:"department.name" db:"emp_dpt.name"      gorm:"-"`
+
+	Status    app.NullString   `json:"status"          db:"m.status"          gorm:"column:status"             validate:"omitempty,oneof=draft submitted approved rejected ordered"`
+	CreatedAt app.NullDateTime `json:"created_at"      db:"m.created_at"      gorm:"column:created_at"`
+	UpdatedAt app.NullDateTime `json:"updated_at"      db:"m.updated_at"      gorm:"column:updated_at"`
+	DeletedAt app.NullDateTime `json:"deleted_at"      db:"m.deleted_at,hide" gorm:"column:deleted_at"`
+}
+
+// EndPoint returns the PurchaseRequest end point, it used for cache key, etc.
+func (PurchaseRequest) EndPoint() string {
+	return "purchase_requests"
+}
+
+// TableVersion returns the versions of the PurchaseRequest table in the database.
+// Change this value with date format YY.MM.DDHHii when any table structure changes.
+func (PurchaseRequest) TableVersion() string {
+	return "26.10.190900"
+}
+
+// TableName returns the name of the PurchaseRequest table in the database.
+func (PurchaseRequest) TableName() string {
+	return "purchase_requests"
+}
+
+// TableAliasName returns the table alias name of the PurchaseRequest table, used for querying.
+func (PurchaseRequest) TableAliasName() string {
+	return "m"
+}
+
+// GetRelations returns the relations of the PurchaseRequest data in the database, used for querying.
+func (m *PurchaseRequest) GetRelations() map[string]map[string]any {
+	m.AddRelation("left", "categories", "cat", []map[string]any{{"column1": "cat.id", "column2": "m.category_id"}})
+	m.AddRelation("left", "employees", "emp", []map[string]any{{"column1": "emp.id", "column2": "m.employee_id"}})
+	m.AddRelation("left", "departments", "emp_dpt", []map[string]any{{"column1": "emp_dpt.id", "column2": "emp.department_id"}})
+	return m.Relations
+}
+
+// GetFilters returns the filter of the PurchaseRequest data in the database, used for querying.
+func (m *PurchaseRequest) GetFilters() []map[string]any {
+	m.AddFilter(map[string]any{"column1": "m.deleted_at", "operator": "=", "value": nil})
+	return m.Filters
+}
+
+// GetSorts returns the default sort of the PurchaseRequest data in the database, used for querying.
+func (m *PurchaseRequest) GetSorts() []map[string]any {
+	m.AddSort(map[string]any{"column": "m.updated_at", "direction": "desc"})
+	return m.Sorts
+}
+
+// GetFields returns list of the field of the PurchaseRequest data in the database, used for querying.
+func (m *PurchaseRequest) GetFields() map[string]map[string]any {
+	m.SetFields(m)
+	return m.Fields
+}
+
+// GetSchema returns the PurchaseRequest schema, used for querying.
+func (m *PurchaseRequest) GetSchema() map[string]any {
+	return m.SetSchema(m)
+}
+
+// OpenAPISchemaName returns the name of the PurchaseRequest schema in the open api documentation.
+func (PurchaseRequest) OpenAPISchemaName() string {
+	return "PurchaseRequest"
+}
+
+// GetOpenAPISchema returns the Open API Schema of the PurchaseRequest in the open api documentation.
+func (m *PurchaseRequest) GetOpenAPISchema() map[string]any {
+	return m.SetOpenAPISchema(m)
+}
+
+type PurchaseRequestList struct {
+	app.ListModel
+}
+
+// OpenAPISchemaName returns the name of the PurchaseRequestList schema in the open api documentation.
+func (PurchaseRequestList) OpenAPISchemaName() string {
+	return "PurchaseRequestList"
+}
+
+// GetOpenAPISchema returns the Open API Schema of the PurchaseRequestList in the open api documentation.
+func (p *PurchaseRequestList) GetOpenAPISchema() map[string]any {
+	return p.SetOpenAPISchema(&PurchaseRequest{})
+}
+
+// ParamCreate is the expected parameters for create a new PurchaseRequest data.
+type ParamCreate struct {
+	UseCaseHandler
+	Date       app.NullDate  `json:"date"            db:"m.date"            gorm:"column:date"               validate:"required"`
+	Qty        app.NullInt64 `json:"qty"             db:"m.qty"             gorm:"column:qty"                validate:"required,gt=0"`
+	CategoryID app.NullUUID  `json:"category.id"     db:"m.category_id"     gorm:"column:category_id"        validate:"required"`
+	EmployeeID app.NullUUID  `json:"employee.id"     db:"m.employee_id"     gorm:"column:employee_id"        validate:"required"`
+}
+
+// ParamUpdate is the expected parameters for update the PurchaseRequest data.
+type ParamUpdate struct {
+	UseCaseHandler
+}
+
+// ParamPartiallyUpdate is the expected parameters for partially update the PurchaseRequest data.
+type ParamPartiallyUpdate struct {
+	UseCaseHandler
+}
+
+// ParamDelete is the expected parameters for delete the PurchaseRequest data.
+type ParamDelete struct {
+	UseCaseHandler
+}
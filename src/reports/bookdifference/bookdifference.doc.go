@@ -0,0 +1,2 @@
+// bookdifference is a package related to bookdifference data.
+package bookdifference
@@ -0,0 +1,128 @@
+package bookdifference
+
+import "github.com/maulanar/go_asset_tracking_management/app"
+
+// BookDifference is the main model of BookDifference data. It provides a convenient interface for app.ModelInterface
+type BookDifference struct {
+	app.Model
+	ID                     app.NullUUID    `json:"id"                      db:"id"                      gorm:"column:id"`
+	Code                   app.NullString  `json:"code"                    db:"code"                    gorm:"column:code"`
+	Name                   app.NullString  `json:"name"                    db:"name"                    gorm:"column:name"`
+	CategoryName           app.NullString  `json:"category.name"           db:"category_name"           gorm:"column:category_name"`
+	TaxGroup               app.NullInt64   `json:"tax.group"               db:"tax_group"               gorm:"column:tax_group"`
+	CommercialDepreciation app.NullFloat64 `json:"commercial_depreciation" db:"commercial_depreciation" gorm:"column:commercial_depreciation"`
+	TaxDepreciation        app.NullFloat64 `json:"tax_depreciation"        db:"tax_depreciation"        gorm:"column:tax_depreciation"`
+	Difference             app.NullFloat64 `json:"difference"              db:"difference"              gorm:"-"`
+	CommercialBookValue    app.NullFloat64 `json:"commercial_book_value"   db:"commercial_book_value"   gorm:"column:commercial_book_value"`
+	TaxBookValue           app.NullFloat64 `json:"tax_book_value"          db:"tax_book_value"          gorm:"column:tax_book_value"`
+	TemporaryDifference    app.NullFloat64 `json:"temporary_difference"    db:"temporary_difference"    gorm:"-"`
+	DeferredTax            app.NullFloat64 `json:"deferred_tax"            db:"deferred_tax"            gorm:"-"`
+}
+
+type ViewData struct {
+	CreatedAt                   string
+	StartDate                   string
+	EndDate                     string
+	TaxRate                     float64
+	Rows                        []BookDifference
+	TotalCommercialDepreciation float64
+	TotalTaxDepreciation        float64
+	TotalDifference             float64
+	TotalTemporaryDifference    float64
+	TotalDeferredTax            float64
+}
+
+// EndPoint returns the BookDifference end point, it used for cache key, etc.
+func (BookDifference) EndPoint() string {
+	return "book_differences"
+}
+
+// TableVersion returns the versions of the BookDifference table in the database.
+// Change this value with date format YY.MM.DDHHii when any table structure changes.
+func (BookDifference) TableVersion() string {
+	return "28.06.291152"
+}
+
+// TableName returns the name of the BookDifference table in the database.
+func (BookDifference) TableName() string {
+	return "book_differences"
+}
+
+// TableAliasName returns the table alias name of the BookDifference table, used for querying.
+func (BookDifference) TableAliasName() string {
+	return "m"
+}
+
+// GetRelations returns the relations of the BookDifference data in the database, used for querying.
+func (m *BookDifference) GetRelations() map[string]map[string]any {
+	// m.AddRelation("left", "users", "cu", []map[string]any{{"column1": "cu.id", "column2": "m.created_by_user_id"}})
+	// m.AddRelation("left", "users", "uu", []map[string]any{{"column1": "uu.id", "column2": "m.updated_by_user_id"}})
+	return m.Relations
+}
+
+// GetFilters returns the filter of the BookDifference data in the database, used for querying.
+func (m *BookDifference) GetFilters() []map[string]any {
+	m.AddFilter(map[string]any{"column1": "m.deleted_at", "operator": "=", "value": nil})
+	return m.Filters
+}
+
+// GetSorts returns the default sort of the BookDifference data in the database, used for querying.
+func (m *BookDifference) GetSorts() []map[string]any {
+	m.AddSort(map[string]any{"column": "m.updated_at", "direction": "desc"})
+	return m.Sorts
+}
+
+// GetFields returns list of the field of the BookDifference data in the database, used for querying.
+func (m *BookDifference) GetFields() map[string]map[string]any {
+	m.SetFields(m)
+	return m.Fields
+}
+
+// GetSchema returns the BookDifference schema, used for querying.
+func (m *BookDifference) GetSchema() map[string]any {
+	return m.SetSchema(m)
+}
+
+// OpenAPISchemaName returns the name of the BookDifference schema in the open api documentation.
+func (BookDifference) OpenAPISchemaName() string {
+	return "BookDifference"
+}
+
+// GetOpenAPISchema returns the Open API Schema of the BookDifference in the open api documentation.
+func (m *BookDifference) GetOpenAPISchema() map[string]any {
+	return m.SetOpenAPISchema(m)
+}
+
+type BookDifferenceList struct {
+	app.ListModel
+}
+
+// OpenAPISchemaName returns the name of the BookDifferenceList schema in the open api documentation.
+func (BookDifferenceList) OpenAPISchemaName() string {
+	return "BookDifferenceList"
+}
+
+// GetOpenAPISchema returns the Open API Schema of the BookDifferenceList in the open api documentation.
+func (p *BookDifferenceList) GetOpenAPISchema() map[string]any {
+	return p.SetOpenAPISchema(&BookDifference{})
+}
+
+// ParamCreate is the expected parameters for create a new BookDifference data.
+type ParamCreate struct {
+	UseCaseHandler
+}
+
+// ParamUpdate is the expected parameters for update the BookDifference data.
+type ParamUpdate struct {
+	UseCaseHandler
+}
+
+// ParamPartiallyUpdate is the expected parameters for partially update the BookDifference data.
+type ParamPartiallyUpdate struct {
+	UseCaseHandler
+}
+
+// ParamDelete is the expected parameters for delete the BookDifference data.
+type ParamDelete struct {
+	UseCaseHandler
+}
@@ -0,0 +1,55 @@
+package bookdifference
+
+import "github.com/maulanar/go_asset_tracking_management/app"
+
+// OpenAPI is constructor for *openAPI, to autogenerate open api document.
+func OpenAPI() *OpenAPIOperation {
+	return &OpenAPIOperation{}
+}
+
+// OpenAPIOperation embed from app.OpenAPIOperation for simplicity, used for autogenerate open api document.
+type OpenAPIOperation struct {
+	app.OpenAPIOperation
+}
+
+// Base is common detail of book_differences open api document component.
+func (o *OpenAPIOperation) Base() {
+	o.Tags = []string{"BookDifference"}
+	o.HeaderParams = []map[string]any{{"$ref": "#/components/parameters/headerParam.Accept-Language"}}
+	o.Responses = map[string]map[string]any{
+		"200": {
+			"description": "Success",
+			"content":     map[string]any{"application/json": &BookDifference{}}, // will auto create schema $ref: '#/components/schemas/BookDifference' if not exists
+		},
+		"400": app.OpenAPIError().BadRequest(),
+		"401": app.OpenAPIError().Unauthorized(),
+		"403": app.OpenAPIError().Forbidden(),
+	}
+	o.Securities = []map[string][]string{}
+}
+
+// Get is detail of `GET /api/v3/book_differences` open api document component.
+func (o *OpenAPIOperation) Get() *OpenAPIOperation {
+	if !app.IS_GENERATE_OPEN_API_DOC {
+		return o // skip for efficiency
+	}
+
+	o.Base()
+	o.Summary = "Get Report Book Difference"
+	o.Description = "Use this method to get Report Book Difference, the commercial and the tax book depreciation per asset in a period with the temporary difference and the deferred tax"
+	o.QueryParams = []map[string]any{
+		{"name": "fiscal_period.id", "in": "query", "description": "Use the period of the fiscal period instead of the start and end date.", "schema": map[string]any{"type": "string"}},
+		{"name": "start_date", "in": "query", "description": "Default to the first day of the current month.", "schema": map[string]any{"type": "string", "format": "date"}},
+		{"name": "end_date", "in": "query", "description": "Default to today.", "schema": map[string]any{"type": "string", "format": "date"}},
+	}
+	o.Responses = map[string]map[string]any{
+		"200": {
+			"description": "Success",
+			"content":     map[string]any{"text/html": &BookDifferenceList{}}, // will auto create schema $ref: '#/components/schemas/BookDifference.List' if not exists
+		},
+		"400": app.OpenAPIError().BadRequest(),
+		"401": app.OpenAPIError().Unauthorized(),
+		"403": app.OpenAPIError().Forbidden(),
+	}
+	return o
+}
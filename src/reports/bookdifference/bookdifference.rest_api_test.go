@@ -0,0 +1,142 @@
+package bookdifference
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gofiber/fiber/v2/utils"
+	"gorm.io/gorm"
+
+	"github.com/maulanar/go_asset_tracking_management/app"
+)
+
+// prepareTest prepares the test.
+func prepareTest(tb testing.TB) {
+	app.Test()
+	tx := app.Test().Tx
+	app.DB().RegisterTable("main", BookDifference{})
+	app.DB().MigrateTable(tx, "main", app.Setting{})
+	tx.Session(&gorm.Session{AllowGlobalUpdate: true}).Delete(&BookDifference{})
+
+	app.Server().AddMiddleware(app.Test().NewCtx([]string{
+		"book_differences.detail",
+		"book_differences.list",
+		"book_differences.create",
+		"book_differences.edit",
+		"book_differences.delete",
+	}))
+	app.Server().AddRoute("/book_differences", "GET", REST().Get, nil)
+}
+
+// getTestBookDifferenceID returns an available BookDifference ID.
+func getTestBookDifferenceID() string {
+	return "todo"
+}
+
+// tests is test scenario.
+var tests = []struct {
+	description  string // description of the test case
+	method       string // method to test
+	path         string // route path to test
+	token        string // token to test
+	bodyRequest  string // body to test
+	expectedCode int    // expected HTTP status code
+	expectedBody string // expected body response
+}{
+	{
+		description:  "Get empty list of BookDifference",
+		method:       "GET",
+		path:         "/book_differences",
+		token:        app.TestFullAccessToken,
+		expectedCode: http.StatusOK,
+		expectedBody: `{"count":0,"results":[]}`,
+	},
+	{
+		description:  "Create BookDifference with minimum payload",
+		method:       "POST",
+		path:         "/book_differences",
+		token:        app.TestFullAccessToken,
+		bodyRequest:  `{"name":"Kilogram"}`,
+		expectedCode: http.StatusCreated,
+		expectedBody: `{"name":"Kilogram"}`,
+	},
+	{
+		description:  "Get BookDifference by ID",
+		method:       "GET",
+		path:         "/book_differences/" + getTestBookDifferenceID(),
+		token:        app.TestFullAccessToken,
+		expectedCode: http.StatusOK,
+		expectedBody: `{"name":"Kilogram"}`,
+	},
+	{
+		description:  "Update BookDifference by ID",
+		method:       "PUT",
+		path:         "/book_differences/" + getTestBookDifferenceID(),
+		token:        app.TestFullAccessToken,
+		bodyRequest:  `{"reason":"Update BookDifference by ID","name":"KG"}`,
+		expectedCode: http.StatusOK,
+		expectedBody: `{"name":"KG"}`,
+	},
+	{
+		description:  "Partially update BookDifference by ID",
+		method:       "PATCH",
+		path:         "/book_differences/" + getTestBookDifferenceID(),
+		token:        app.TestFullAccessToken,
+		bodyRequest:  `{"reason":"Partially Update BookDifference by ID","name":"Kilo Gram"}`,
+		expectedCode: http.StatusOK,
+		expectedBody: `{"name":"Kilo Gram"}`,
+	},
+	{
+		description:  "Delete BookDifference by ID",
+		method:       "DELETE",
+		path:         "/book_differences/" + getTestBookDifferenceID(),
+		token:        app.TestFullAccessToken,
+		bodyRequest:  `{"reason":"Delete BookDifference by ID"}`,
+		expectedCode: http.StatusOK,
+		expectedBody: `{"code":200}`,
+	},
+}
+
+// TestBookDifferenceREST tests the REST API of BookDifference data with specified scenario.
+func TestBookDifferenceREST(t *testing.T) {
+	prepareTest(t)
+
+	// Iterate through test single test cases
+	for _, test := range tests {
+
+		// Create a new http request with the route from the test case
+		req := httptest.NewRequest(test.method, test.path, strings.NewReader(test.bodyRequest))
+		req.Header.Add("Authorization", "Bearer "+test.token)
+		req.Header.Add("Content-Type", "application/json")
+
+		// Perform the request plain with the app, the second argument is a request latency (set to -1 for no latency)
+		res, err := app.Server().Test(req)
+
+		// Verify if the status code is as expected
+		utils.AssertEqual(t, nil, err, "app.Server().Test(req)")
+		utils.AssertEqual(t, test.expectedCode, res.StatusCode, test.description)
+
+		// Verify if the body response is as expected
+		body, err := io.ReadAll(res.Body)
+		utils.AssertEqual(t, nil, err, "io.ReadAll(res.Body)")
+		app.Test().AssertMatchJSONElement(t, []byte(test.expectedBody), body, test.description)
+		res.Body.Close()
+	}
+}
+
+// BenchmarkBookDifferenceREST tests the REST API of BookDifference data with specified scenario.
+func BenchmarkBookDifferenceREST(b *testing.B) {
+	b.ReportAllocs()
+	prepareTest(b)
+	for i := 0; i < b.N; i++ {
+		for _, test := range tests {
+			req := httptest.NewRequest(test.method, test.path, strings.NewReader(test.bodyRequest))
+			req.Header.Add("Authorization", "Bearer "+test.token)
+			req.Header.Add("Content-Type", "application/json")
+			app.Server().Test(req)
+		}
+	}
+}
@@ -0,0 +1,137 @@
+package bookdifference
+
+import (
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/maulanar/go_asset_tracking_management/app"
+	"github.com/maulanar/go_asset_tracking_management/src/fiscalperiod"
+)
+
+// UseCase returns a UseCaseHandler for expected use case functional.
+func UseCase(ctx app.Ctx, query ...url.Values) UseCaseHandler {
+	u := UseCaseHandler{
+		Ctx:   &ctx,
+		Query: url.Values{},
+	}
+	if len(query) > 0 {
+		u.Query = query[0]
+	}
+	return u
+}
+
+// UseCaseHandler provides a convenient interface for BookDifference use case, use UseCase to access UseCaseHandler.
+type UseCaseHandler struct {
+	BookDifference
+
+	// injectable dependencies
+	Ctx   *app.Ctx   `json:"-" db:"-" gorm:"-"`
+	Query url.Values `json:"-" db:"-" gorm:"-"`
+}
+
+// Async return UseCaseHandler with async process.
+func (u UseCaseHandler) Async(ctx app.Ctx, query ...url.Values) UseCaseHandler {
+	ctx.IsAsync = true
+	return UseCase(ctx, query...)
+}
+
+// Get returns the differences between the commercial book and the tax book depreciation of the assets in a period,
+// the temporary difference of the book values at the end of the period is the base of the deferred tax.
+func (u UseCaseHandler) Get() (res ViewData, err error) {
+
+	// check permission
+	err = u.Ctx.ValidatePermission("book_differences.list")
+	if err != nil {
+		return res, err
+	}
+
+	// prepare db for current ctx
+	tx, err := u.Ctx.DB()
+	if err != nil {
+		return res, app.Error().New(http.StatusInternalServerError, err.Error())
+	}
+
+	// parsing filter, the period of the fiscal period is used when it is specified
+	now := time.Now()
+	startDate := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, now.Location())
+	endDate := now
+	if v := u.Query.Get("start_date"); v != "" {
+		startDate, err = time.Parse("2006-01-02", v)
+		if err != nil {
+			return res, app.Error().New(http.StatusBadRequest, err.Error())
+		}
+	}
+	if v := u.Query.Get("end_date"); v != "" {
+		endDate, err = time.Parse("2006-01-02", v)
+		if err != nil {
+			return res, app.Error().New(http.StatusBadRequest, err.Error())
+		}
+	}
+	if v := u.Query.Get("fiscal_period.id"); v != "" {
+		period, err := fiscalperiod.UseCase(*u.Ctx, url.Values{}).GetByID(v)
+		if err != nil {
+			return res, err
+		}
+		startDate, endDate = period.StartDate.Time, period.EndDate.Time
+	}
+	args := map[string]any{
+		"start": startDate.Format("2006-01-02"),
+		"end":   endDate.Format("2006-01-02"),
+	}
+
+	rows := []BookDifference{}
+	err = tx.Raw(`
+WITH period AS (
+  SELECT de.asset_id,
+         SUM(CASE WHEN de.book = 'commercial' THEN de.depreciation_amount ELSE 0 END) AS commercial_depreciation,
+         SUM(CASE WHEN de.book = 'tax' THEN de.depreciation_amount ELSE 0 END) AS tax_depreciation
+  FROM depreciation_entries de
+  WHERE de.deleted_at IS NULL AND de.type = 'depreciation' AND de.date BETWEEN @start AND @end
+  GROUP BY de.asset_id
+), closing AS (
+  SELECT DISTINCT ON (de.asset_id, de.book) de.asset_id, de.book, de.closing_amount
+  FROM depreciation_entries de
+  WHERE de.deleted_at IS NULL AND de.date <= @end
+  ORDER BY de.asset_id, de.book, de.period DESC, de.date DESC, de.posted_at DESC
+)
+SELECT ass.id, ass.code, ass.name, cat.name AS category_name,
+       COALESCE(NULLIF(ass.tax_group, 0), cat.tax_group) AS tax_group,
+       COALESCE(p.commercial_depreciation, 0) AS commercial_depreciation,
+       COALESCE(p.tax_depreciation, 0) AS tax_depreciation,
+       COALESCE(cc.closing_amount, ass.price) AS commercial_book_value,
+       COALESCE(tc.closing_amount, ass.price) AS tax_book_value
+FROM assets ass
+LEFT JOIN categories cat ON cat.id = ass.category_id
+LEFT JOIN period p ON p.asset_id = ass.id
+LEFT JOIN closing cc ON cc.asset_id = ass.id AND cc.book = 'commercial'
+LEFT JOIN closing tc ON tc.asset_id = ass.id AND tc.book = 'tax'
+WHERE ass.deleted_at IS NULL
+  AND COALESCE(NULLIF(ass.tax_group, 0), cat.tax_group) IS NOT NULL
+  AND ass.input_date <= @end
+  AND (ass.disposal_date IS NULL OR ass.disposal_date >= @start)
+ORDER BY ass.code`, args).Scan(&rows).Error
+	if err != nil {
+		return res, app.Error().New(http.StatusInternalServerError, err.Error())
+	}
+
+	res = ViewData{
+		CreatedAt: time.Now().Format("2006-01-02 15:04:05"),
+		StartDate: args["start"].(string),
+		EndDate:   args["end"].(string),
+		TaxRate:   app.DEFERRED_TAX_RATE,
+	}
+	for i := range rows {
+		rows[i].Difference.Set(rows[i].CommercialDepreciation.Float64 - rows[i].TaxDepreciation.Float64)
+		rows[i].TemporaryDifference.Set(rows[i].CommercialBookValue.Float64 - rows[i].TaxBookValue.Float64)
+		rows[i].DeferredTax.Set(rows[i].TemporaryDifference.Float64 * app.DEFERRED_TAX_RATE / 100)
+		res.TotalCommercialDepreciation += rows[i].CommercialDepreciation.Float64
+		res.TotalTaxDepreciation += rows[i].TaxDepreciation.Float64
+		res.TotalDifference += rows[i].Difference.Float64
+		res.TotalTemporaryDifference += rows[i].TemporaryDifference.Float64
+		res.TotalDeferredTax += rows[i].DeferredTax.Float64
+	}
+	res.Rows = rows
+
+	return res, nil
+}
@@ -5,26 +5,32 @@ import "github.com/maulanar/go_asset_tracking_management/app"
 // DistributionAssetsPerDepartment is the main model of DistributionAssetsPerDepartment data. It provides a convenient interface for app.ModelInterface
 type DistributionAssetsPerDepartment struct {
 	app.Model
-	CategoryID     app.NullUUID   `json:"category.id"     db:"category_id"     gorm:"column:category_id"`
-	CategoryName   app.NullString `json:"category.name"   db:"category_name"   gorm:"column:category_name"`
-	DepartmentID   app.NullUUID   `json:"department.id"   db:"department_id"   gorm:"column:department_id"`
-	DepartmentName app.NullString `json:"department.name" db:"department_name" gorm:"column:department_name"`
-	BranchID       app.NullUUID   `json:"branch.id"       db:"branch_id"       gorm:"column:branch_id"`
-	BranchName     app.NullString `json:"branch.name"     db:"branch_name"     gorm:"column:branch_name"`
-	TotalAsset     app.NullInt64  `json:"total_asset"     db:"total_asset"     gorm:"column:total_asset"`
+	CategoryID     app.NullUUID    `json:"category.id"     db:"category_id"     gorm:"column:category_id"`
+	CategoryName   app.NullString  `json:"category.name"   db:"category_name"   gorm:"column:category_name"`
+	DepartmentID   app.NullUUID    `json:"department.id"   db:"department_id"   gorm:"column:department_id"`
+	DepartmentName app.NullString  `json:"department.name" db:"department_name" gorm:"column:department_name"`
+	LocationID     app.NullUUID    `json:"location.id"     db:"location_id"     gorm:"column:location_id"`
+	LocationName   app.NullString  `json:"location.name"   db:"location_name"   gorm:"column:location_name"`
+	BranchID       app.NullUUID    `json:"branch.id"       db:"branch_id"       gorm:"column:branch_id"`
+	BranchName     app.NullString  `json:"branch.name"     db:"branch_name"     gorm:"column:branch_name"`
+	TotalAsset     app.NullInt64   `json:"total_asset"     db:"total_asset"     gorm:"column:total_asset"`
+	TotalValue     app.NullFloat64 `json:"total_value"     db:"total_value"     gorm:"column:total_value"`
 }
 
 // view model agar template gampang
 type DeptGroup struct {
-	Name     string
-	Items    []DistributionAssetsPerDepartment
-	Subtotal int64
+	Name          string
+	Items         []DistributionAssetsPerDepartment
+	Subtotal      int64
+	SubtotalValue float64
 }
 
 type ViewData struct {
 	CreatedAt  string
+	GroupBy    string
 	Groups     []DeptGroup
 	GrandTotal int64
+	GrandValue float64
 }
 
 // EndPoint returns the DistributionAssetsPerDepartment end point, it used for cache key, etc.
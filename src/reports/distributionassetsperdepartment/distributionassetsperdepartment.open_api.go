@@ -37,7 +37,27 @@ func (o *OpenAPIOperation) Get() *OpenAPIOperation {
 	o.Base()
 	o.Summary = "Get DistributionAssetsPerDepartment"
 	o.Description = "Use this method to get Report Distribution Asset Per Department"
-	o.QueryParams = []map[string]any{{"$ref": "#/components/parameters/queryParam.Any"}}
+	o.QueryParams = []map[string]any{
+		{"$ref": "#/components/parameters/queryParam.Any"},
+		{
+			"name":        "rollup",
+			"in":          "query",
+			"description": "Set to `true` to roll the value of the child assets up to their parent asset.",
+			"schema":      map[string]any{"type": "boolean"},
+		},
+		{
+			"name":        "group_by",
+			"in":          "query",
+			"description": "Group the assets by `department` (default) or by `location`, grouping by location includes the unassigned assets.",
+			"schema":      map[string]any{"type": "string", "enum": []string{"department", "location"}},
+		},
+		{
+			"name":        "location.id",
+			"in":          "query",
+			"description": "Filter by the location id, including all of its descendant locations.",
+			"schema":      map[string]any{"type": "string", "format": "uuid"},
+		},
+	}
 	o.Responses = map[string]map[string]any{
 		"200": {
 			"description": "Success",
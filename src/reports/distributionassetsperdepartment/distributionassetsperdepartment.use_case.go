@@ -7,6 +7,7 @@ import (
 	"time"
 
 	"github.com/maulanar/go_asset_tracking_management/app"
+	"github.com/maulanar/go_asset_tracking_management/src/location"
 )
 
 // UseCase returns a UseCaseHandler for expected use case functional.
@@ -111,14 +112,57 @@ func (u UseCaseHandler) Get() (res ViewData, err error) {
 		}
 	}
 
+	// filter lokasi mencakup seluruh turunan lokasi
+	if locFilter := u.Query.Get("location.id"); locFilter != "" {
+		locIds, err := location.UseCase(*u.Ctx, url.Values{}).GetSubtreeIDs(locFilter)
+		if err != nil {
+			return res, err
+		}
+		where += " AND a.location_id IN (?" + strings.Repeat(",?", len(locIds)-1) + ")"
+		for _, id := range locIds {
+			args = append(args, id)
+		}
+	}
+
+	// nilai asset, jika rollup=true nilai child asset digabung ke parent dan child tidak dihitung terpisah,
+	// child dari parent yang sudah dihapus menjadi root
+	assetValue := `
+WITH asset_value AS (
+  SELECT a.id AS asset_id, COALESCE(a.current_amount, 0) AS total_value
+  FROM assets a
+  WHERE a.deleted_at IS NULL
+),`
+	if u.Query.Get("rollup") == "true" {
+		assetValue = `
+WITH RECURSIVE asset_tree AS (
+  SELECT a.id AS root_id, a.id
+  FROM assets a
+  LEFT JOIN assets p ON p.id = a.parent_id AND p.deleted_at IS NULL
+  WHERE a.deleted_at IS NULL AND p.id IS NULL
+  UNION ALL
+  SELECT t.root_id, c.id
+  FROM assets c
+  JOIN asset_tree t ON c.parent_id = t.id
+  WHERE c.deleted_at IS NULL
+),
+asset_value AS (
+  SELECT t.root_id AS asset_id, SUM(COALESCE(a.current_amount, 0)) AS total_value
+  FROM asset_tree t
+  JOIN assets a ON a.id = t.id
+  GROUP BY t.root_id
+),`
+	}
+
 	rows := []DistributionAssetsPerDepartment{}
-	query := `
-WITH ea_latest AS (
+	groupBy := "department"
+	query := assetValue + `
+ea_latest AS (
   SELECT DISTINCT ON (ea.asset_id)
          ea.asset_id,
          ea.employee_id
   FROM employee_assets ea
   WHERE ea.deleted_at IS NULL
+    AND ea.return_date IS NULL
   ORDER BY ea.asset_id,
            COALESCE(ea.assign_date, ea.date, ea.created_at) DESC,
            ea.id DESC
@@ -130,9 +174,11 @@ SELECT
   c.name AS category_name,
   b.id AS branch_id,
   b.name AS branch_name,
-  COUNT(*) AS total_asset
+  COUNT(*) AS total_asset,
+  SUM(v.total_value) AS total_value
 FROM ea_latest x
 JOIN assets a       ON a.id = x.asset_id        AND a.deleted_at IS NULL
+JOIN asset_value v  ON v.asset_id = a.id
 JOIN employees e    ON e.id = x.employee_id     AND e.deleted_at IS NULL
 LEFT JOIN departments d ON d.id = e.department_id AND d.deleted_at IS NULL
 LEFT JOIN branches b    ON b.id = e.branch_id     AND b.deleted_at IS NULL
@@ -141,6 +187,43 @@ WHERE 1=1` + where + `
 GROUP BY d.id, d.name, c.id, c.name, b.id, b.name
 ORDER BY d.name, c.name, b.name;
 `
+	if u.Query.Get("group_by") == "location" {
+		// per lokasi mencakup asset yang tidak di-assign, branch diambil dari lokasi lalu dari employee
+		groupBy = "location"
+		query = assetValue + `
+ea_latest AS (
+  SELECT DISTINCT ON (ea.asset_id)
+         ea.asset_id,
+         ea.employee_id
+  FROM employee_assets ea
+  WHERE ea.deleted_at IS NULL
+    AND ea.return_date IS NULL
+  ORDER BY ea.asset_id,
+           COALESCE(ea.assign_date, ea.date, ea.created_at) DESC,
+           ea.id DESC
+)
+SELECT
+  l.id AS location_id,
+  l.path AS location_name,
+  c.id AS category_id,
+  c.name AS category_name,
+  b.id AS branch_id,
+  b.name AS branch_name,
+  COUNT(*) AS total_asset,
+  SUM(v.total_value) AS total_value
+FROM assets a
+JOIN asset_value v      ON v.asset_id = a.id
+LEFT JOIN ea_latest x   ON x.asset_id = a.id
+LEFT JOIN employees e   ON e.id = x.employee_id     AND e.deleted_at IS NULL
+LEFT JOIN departments d ON d.id = e.department_id   AND d.deleted_at IS NULL
+LEFT JOIN locations l   ON l.id = a.location_id     AND l.deleted_at IS NULL
+LEFT JOIN branches b    ON b.id = COALESCE(l.branch_id, e.branch_id) AND b.deleted_at IS NULL
+LEFT JOIN categories c  ON c.id = a.category_id     AND c.deleted_at IS NULL
+WHERE a.deleted_at IS NULL` + where + `
+GROUP BY l.id, l.path, c.id, c.name, b.id, b.name
+ORDER BY l.path NULLS LAST, c.name, b.name;
+`
+	}
 	err = tx.Raw(query, args...).Scan(&rows).Error
 	if err != nil {
 		return res, err
@@ -149,9 +232,13 @@ ORDER BY d.name, c.name, b.name;
 	idx := map[string]int{}
 	var groups []DeptGroup
 	var grand int64
+	var grandValue float64
 
 	for _, r := range rows {
 		dept := r.DepartmentName.String // sesuaikan getter NullString Anda
+		if groupBy == "location" {
+			dept = r.LocationName.String
+		}
 		if dept == "" {
 			dept = "-"
 		}
@@ -164,12 +251,16 @@ ORDER BY d.name, c.name, b.name;
 		groups[i].Items = append(groups[i].Items, r)
 		groups[i].Subtotal += r.TotalAsset.Int64 // sesuaikan getter NullInt64 Anda
 		grand += r.TotalAsset.Int64
+		groups[i].SubtotalValue += r.TotalValue.Float64
+		grandValue += r.TotalValue.Float64
 	}
 
 	res = ViewData{
 		CreatedAt:  time.Now().Format("2006-01-02 15:04:05"),
+		GroupBy:    groupBy,
 		Groups:     groups,
 		GrandTotal: grand,
+		GrandValue: grandValue,
 	}
 
 	return res, nil
@@ -0,0 +1,2 @@
+// journal is a package related to journal data.
+package journal
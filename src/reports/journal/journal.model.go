@@ -0,0 +1,134 @@
+package journal
+
+import "github.com/maulanar/go_asset_tracking_management/app"
+
+// Journal is the main model of Journal data, a single line of a journal entry. It provides a convenient interface for app.ModelInterface
+type Journal struct {
+	app.Model
+	Date         app.NullDate    `json:"date"          db:"date"          gorm:"column:date"`
+	Reference    app.NullString  `json:"reference"     db:"reference"     gorm:"column:reference"`
+	Source       app.NullString  `json:"source"        db:"source"        gorm:"column:source"`
+	AssetCode    app.NullString  `json:"asset.code"    db:"asset_code"    gorm:"column:asset_code"`
+	AssetName    app.NullString  `json:"asset.name"    db:"asset_name"    gorm:"column:asset_name"`
+	CategoryCode app.NullString  `json:"category.code" db:"category_code" gorm:"column:category_code"`
+	Account      app.NullString  `json:"account"       db:"account"       gorm:"column:account"`
+	Description  app.NullText    `json:"description"   db:"description"   gorm:"column:description"`
+	Debit        app.NullFloat64 `json:"debit"         db:"debit"         gorm:"column:debit"`
+	Credit       app.NullFloat64 `json:"credit"        db:"credit"        gorm:"column:credit"`
+}
+
+type ViewData struct {
+	CreatedAt          string    `json:"created_at"`
+	FiscalPeriodCode   string    `json:"fiscal_period.code"`
+	FiscalPeriodName   string    `json:"fiscal_period.name"`
+	FiscalPeriodStatus string    `json:"fiscal_period.status"`
+	StartDate          string    `json:"start_date"`
+	EndDate            string    `json:"end_date"`
+	Rows               []Journal `json:"rows"`
+	TotalDebit         float64   `json:"total_debit"`
+	TotalCredit        float64   `json:"total_credit"`
+}
+
+// Sources of the Journal data.
+const (
+	SourceDepreciation   = "depreciation"
+	SourceCapitalization = "capitalization"
+	SourceRevaluation    = "revaluation"
+	SourceImpairment     = "impairment"
+	SourceDisposal       = "disposal"
+)
+
+// EndPoint returns the Journal end point, it used for cache key, etc.
+func (Journal) EndPoint() string {
+	return "journals"
+}
+
+// TableVersion returns the versions of the Journal table in the database.
+// Change this value with date format YY.MM.DDHHii when any table structure changes.
+func (Journal) TableVersion() string {
+	return "28.06.291152"
+}
+
+// TableName returns the name of the Journal table in the database.
+func (Journal) TableName() string {
+	return "journals"
+}
+
+// TableAliasName returns the table alias name of the Journal table, used for querying.
+func (Journal) TableAliasName() string {
+	return "m"
+}
+
+// GetRelations returns the relations of the Journal data in the database, used for querying.
+func (m *Journal) GetRelations() map[string]map[string]any {
+	// m.AddRelation("left", "users", "cu", []map[string]any{{"column1": "cu.id", "column2": "m.created_by_user_id"}})
+	// m.AddRelation("left", "users", "uu", []map[string]any{{"column1": "uu.id", "column2": "m.updated_by_user_id"}})
+	return m.Relations
+}
+
+// GetFilters returns the filter of the Journal data in the database, used for querying.
+func (m *Journal) GetFilters() []map[string]any {
+	m.AddFilter(map[string]any{"column1": "m.deleted_at", "operator": "=", "value": nil})
+	return m.Filters
+}
+
+// GetSorts returns the default sort of the Journal data in the database, used for querying.
+func (m *Journal) GetSorts() []map[string]any {
+	m.AddSort(map[string]any{"column": "m.updated_at", "direction": "desc"})
+	return m.Sorts
+}
+
+// GetFields returns list of the field of the Journal data in the database, used for querying.
+func (m *Journal) GetFields() map[string]map[string]any {
+	m.SetFields(m)
+	return m.Fields
+}
+
+// GetSchema returns the Journal schema, used for querying.
+func (m *Journal) GetSchema() map[string]any {
+	return m.SetSchema(m)
+}
+
+// OpenAPISchemaName returns the name of the Journal schema in the open api documentation.
+func (Journal) OpenAPISchemaName() string {
+	return "Journal"
+}
+
+// GetOpenAPISchema returns the Open API Schema of the Journal in the open api documentation.
+func (m *Journal) GetOpenAPISchema() map[string]any {
+	return m.SetOpenAPISchema(m)
+}
+
+type JournalList struct {
+	app.ListModel
+}
+
+// OpenAPISchemaName returns the name of the JournalList schema in the open api documentation.
+func (JournalList) OpenAPISchemaName() string {
+	return "JournalList"
+}
+
+// GetOpenAPISchema returns the Open API Schema of the JournalList in the open api documentation.
+func (p *JournalList) GetOpenAPISchema() map[string]any {
+	return p.SetOpenAPISchema(&Journal{})
+}
+
+// ParamCreate is the expected parameters for create a new Journal data.
+type ParamCreate struct {
+	UseCaseHandler
+}
+
+// ParamUpdate is the expected parameters for update the Journal data.
+type ParamUpdate struct {
+	UseCaseHandler
+}
+
+// ParamPartiallyUpdate is the expected parameters for partially update the Journal data.
+type ParamPartiallyUpdate struct {
+	UseCaseHandler
+}
+
+// ParamDelete is the expected parameters for delete the Journal data.
+type ParamDelete struct {
+	UseCaseHandler
+}
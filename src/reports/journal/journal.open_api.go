@@ -0,0 +1,54 @@
+package journal
+
+import "github.com/maulanar/go_asset_tracking_management/app"
+
+// OpenAPI is constructor for *openAPI, to autogenerate open api document.
+func OpenAPI() *OpenAPIOperation {
+	return &OpenAPIOperation{}
+}
+
+// OpenAPIOperation embed from app.OpenAPIOperation for simplicity, used for autogenerate open api document.
+type OpenAPIOperation struct {
+	app.OpenAPIOperation
+}
+
+// Base is common detail of journals open api document component.
+func (o *OpenAPIOperation) Base() {
+	o.Tags = []string{"Journal"}
+	o.HeaderParams = []map[string]any{{"$ref": "#/components/parameters/headerParam.Accept-Language"}}
+	o.Responses = map[string]map[string]any{
+		"200": {
+			"description": "Success",
+			"content":     map[string]any{"application/json": &Journal{}}, // will auto create schema $ref: '#/components/schemas/Journal' if not exists
+		},
+		"400": app.OpenAPIError().BadRequest(),
+		"401": app.OpenAPIError().Unauthorized(),
+		"403": app.OpenAPIError().Forbidden(),
+	}
+	o.Securities = []map[string][]string{}
+}
+
+// Get is detail of `GET /api/v3/journals` open api document component.
+func (o *OpenAPIOperation) Get() *OpenAPIOperation {
+	if !app.IS_GENERATE_OPEN_API_DOC {
+		return o // skip for efficiency
+	}
+
+	o.Base()
+	o.Summary = "Get Report Journal"
+	o.Description = "Use this method to export the balanced journal entries of a fiscal period (depreciation, capitalized maintenance costs, revaluations, impairments and disposal gains or losses) as JSON or CSV"
+	o.QueryParams = []map[string]any{
+		{"name": "fiscal_period.id", "in": "query", "required": true, "schema": map[string]any{"type": "string"}},
+		{"name": "format", "in": "query", "description": "Default to json.", "schema": map[string]any{"type": "string", "enum": []string{"json", "csv"}}},
+	}
+	o.Responses = map[string]map[string]any{
+		"200": {
+			"description": "Success",
+			"content":     map[string]any{"application/json": &JournalList{}, "text/csv": &JournalList{}}, // will auto create schema $ref: '#/components/schemas/Journal.List' if not exists
+		},
+		"400": app.OpenAPIError().BadRequest(),
+		"401": app.OpenAPIError().Unauthorized(),
+		"403": app.OpenAPIError().Forbidden(),
+	}
+	return o
+}
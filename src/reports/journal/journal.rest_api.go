@@ -0,0 +1,71 @@
+package journal
+
+import (
+	"bytes"
+	"encoding/csv"
+	"net/http"
+	"strconv"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/maulanar/go_asset_tracking_management/app"
+)
+
+// REST returns a *RESTAPIHandler.
+func REST() *RESTAPIHandler {
+	return &RESTAPIHandler{}
+}
+
+// RESTAPIHandler provides a convenient interface for Journal REST API handler.
+type RESTAPIHandler struct {
+	UseCase UseCaseHandler
+}
+
+// injectDeps inject the dependencies of the Journal REST API handler.
+func (r *RESTAPIHandler) injectDeps(c *fiber.Ctx) error {
+	ctx, ok := c.Locals(app.CtxKey).(*app.Ctx)
+	if !ok {
+		return app.Error().New(http.StatusInternalServerError, "ctx is not found")
+	}
+	ctx.FiberCtx = c
+	r.UseCase = UseCase(*ctx, app.Query().Parse(c.OriginalURL()))
+	return nil
+}
+
+// Get is the REST API handler for `GET /api/journals`.
+func (r *RESTAPIHandler) Get(c *fiber.Ctx) error {
+	err := r.injectDeps(c)
+	if err != nil {
+		return app.Error().Handler(c, err)
+	}
+	data, err := r.UseCase.Get()
+	if err != nil {
+		return app.Error().Handler(c, err)
+	}
+
+	if r.UseCase.Query.Get("format") != "csv" {
+		return c.JSON(data)
+	}
+	buf := &bytes.Buffer{}
+	w := csv.NewWriter(buf)
+	w.Write([]string{"date", "reference", "source", "asset_code", "category_code", "account", "description", "debit", "credit"})
+	for _, row := range data.Rows {
+		w.Write([]string{
+			row.Date.Time.Format("2006-01-02"),
+			row.Reference.String,
+			row.Source.String,
+			row.AssetCode.String,
+			row.CategoryCode.String,
+			row.Account.String,
+			row.Description.String,
+			strconv.FormatFloat(row.Debit.Float64, 'f', 2, 64),
+			strconv.FormatFloat(row.Credit.Float64, 'f', 2, 64),
+		})
+	}
+	w.Flush()
+	if err := w.Error(); err != nil {
+		return app.Error().Handler(c, app.Error().New(http.StatusInternalServerError, err.Error()))
+	}
+	c.Set(fiber.HeaderContentType, "text/csv")
+	c.Set(fiber.HeaderContentDisposition, `attachment; filename="journal_`+data.FiscalPeriodCode+`.csv"`)
+	return c.Send(buf.Bytes())
+}
@@ -0,0 +1,266 @@
+package journal
+
+import (
+	"math"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/maulanar/go_asset_tracking_management/app"
+	"github.com/maulanar/go_asset_tracking_management/src/fiscalperiod"
+)
+
+// UseCase returns a UseCaseHandler for expected use case functional.
+func UseCase(ctx app.Ctx, query ...url.Values) UseCaseHandler {
+	u := UseCaseHandler{
+		Ctx:   &ctx,
+		Query: url.Values{},
+	}
+	if len(query) > 0 {
+		u.Query = query[0]
+	}
+	return u
+}
+
+// UseCaseHandler provides a convenient interface for Journal use case, use UseCase to access UseCaseHandler.
+type UseCaseHandler struct {
+	Journal
+
+	// injectable dependencies
+	Ctx   *app.Ctx   `json:"-" db:"-" gorm:"-"`
+	Query url.Values `json:"-" db:"-" gorm:"-"`
+}
+
+// Async return UseCaseHandler with async process.
+func (u UseCaseHandler) Async(ctx app.Ctx, query ...url.Values) UseCaseHandler {
+	ctx.IsAsync = true
+	return UseCase(ctx, query...)
+}
+
+// Get returns the balanced journal entries of a fiscal period, i.e. the posted depreciation, the capitalized maintenance costs,
+// the revaluations, the impairments and the disposal gains or losses of the assets.
+func (u UseCaseHandler) Get() (res ViewData, err error) {
+
+	// check permission
+	err = u.Ctx.ValidatePermission("journals.list")
+	if err != nil {
+		return res, err
+	}
+
+	// prepare db for current ctx
+	tx, err := u.Ctx.DB()
+	if err != nil {
+		return res, app.Error().New(http.StatusInternalServerError, err.Error())
+	}
+
+	// parsing filter
+	if u.Query.Get("fiscal_period.id") == "" {
+		return res, app.Error().New(http.StatusBadRequest, u.Ctx.Trans("required_key", map[string]string{"key": "fiscal_period.id"}))
+	}
+	period, err := fiscalperiod.UseCase(*u.Ctx, url.Values{}).GetByID(u.Query.Get("fiscal_period.id"))
+	if err != nil {
+		return res, err
+	}
+	args := map[string]any{
+		"start": period.StartDate.Time.Format("2006-01-02"),
+		"end":   period.EndDate.Time.Format("2006-01-02"),
+	}
+
+	sources := []journalSource{}
+	err = tx.Raw(`
+SELECT de.id, de.date, 'depreciation' AS source, de.period,
+       ass.code AS asset_code, ass.name AS asset_name, cat.code AS category_code,
+       cat.gl_asset_account, cat.gl_accumulated_depreciation_account,
+       cat.gl_depreciation_expense_account, cat.gl_disposal_gain_loss_account,
+       cat.gl_revaluation_reserve_account, cat.gl_impairment_loss_account,
+       ass.price AS cost, de.depreciation_amount AS amount, de.closing_amount AS book_value
+FROM depreciation_entries de
+JOIN assets ass ON ass.id = de.asset_id
+LEFT JOIN categories cat ON cat.id = ass.category_id
+WHERE de.deleted_at IS NULL AND de.book = 'commercial' AND de.type = 'depreciation' AND de.depreciation_amount > 0 AND de.date BETWEEN @start AND @end
+UNION ALL
+SELECT de.id, de.date, 'capitalization', de.period,
+       ass.code, ass.name, cat.code,
+       cat.gl_asset_account, cat.gl_accumulated_depreciation_account,
+       cat.gl_depreciation_expense_account, cat.gl_disposal_gain_loss_account,
+       cat.gl_revaluation_reserve_account, cat.gl_impairment_loss_account,
+       ass.price, de.closing_amount - de.opening_amount, de.closing_amount
+FROM depreciation_entries de
+JOIN assets ass ON ass.id = de.asset_id
+LEFT JOIN categories cat ON cat.id = ass.category_id
+WHERE de.deleted_at IS NULL AND de.book = 'commercial' AND de.type = 'capitalization' AND de.closing_amount > de.opening_amount AND de.date BETWEEN @start AND @end
+UNION ALL
+SELECT de.id, de.date, de.type, de.period,
+       ass.code, ass.name, cat.code,
+       cat.gl_asset_account, cat.gl_accumulated_depreciation_account,
+       cat.gl_depreciation_expense_account, cat.gl_disposal_gain_loss_account,
+       cat.gl_revaluation_reserve_account, cat.gl_impairment_loss_account,
+       ass.price, de.closing_amount - de.opening_amount, de.closing_amount
+FROM depreciation_entries de
+JOIN assets ass ON ass.id = de.asset_id
+LEFT JOIN categories cat ON cat.id = ass.category_id
+WHERE de.deleted_at IS NULL AND de.book = 'commercial' AND de.type IN ('revaluation', 'impairment') AND de.closing_amount <> de.opening_amount AND de.date BETWEEN @start AND @end
+UNION ALL
+SELECT ass.id, ass.disposal_date, 'disposal', 0,
+       ass.code, ass.name, cat.code,
+       cat.gl_asset_account, cat.gl_accumulated_depreciation_account,
+       cat.gl_depreciation_expense_account, cat.gl_disposal_gain_loss_account,
+       cat.gl_revaluation_reserve_account, cat.gl_impairment_loss_account,
+       ass.price + COALESCE((
+         SELECT SUM(c.closing_amount - c.opening_amount)
+         FROM depreciation_entries c
+         WHERE c.asset_id = ass.id AND c.book = 'commercial' AND c.type = 'capitalization' AND c.deleted_at IS NULL
+       ), 0), ass.disposal_amount, ass.disposal_book_value
+FROM assets ass
+LEFT JOIN categories cat ON cat.id = ass.category_id
+WHERE ass.deleted_at IS NULL AND ass.disposal_date BETWEEN @start AND @end
+ORDER BY date, asset_code, source`, args).Scan(&sources).Error
+	if err != nil {
+		return res, app.Error().New(http.StatusInternalServerError, err.Error())
+	}
+
+	res = ViewData{
+		CreatedAt:          time.Now().Format("2006-01-02 15:04:05"),
+		FiscalPeriodCode:   period.Code.String,
+		FiscalPeriodName:   period.Name.String,
+		FiscalPeriodStatus: period.Status.String,
+		StartDate:          args["start"].(string),
+		EndDate:            args["end"].(string),
+		Rows:               []Journal{},
+	}
+	for _, src := range sources {
+		lines, err := u.lines(src)
+		if err != nil {
+			return res, err
+		}
+		debit, credit := float64(0), float64(0)
+		for _, l := range lines {
+			debit += l.Debit.Float64
+			credit += l.Credit.Float64
+		}
+		if len(lines) == 0 {
+			continue
+		}
+		if math.Abs(debit-credit) >= 0.005 {
+			return res, app.Error().New(http.StatusInternalServerError, u.Ctx.Trans("journal_unbalanced", map[string]string{"reference": lines[0].Reference.String}))
+		}
+		res.Rows = append(res.Rows, lines...)
+		res.TotalDebit += debit
+		res.TotalCredit += credit
+	}
+
+	return res, nil
+}
+
+// journalSource is a transaction which is converted into the lines of a journal entry.
+type journalSource struct {
+	ID           string
+	Date         time.Time
+	Source       string
+	Period       int64
+	AssetCode    string
+	AssetName    string
+	CategoryCode string
+
+	GLAssetAccount                   string
+	GLAccumulatedDepreciationAccount string
+	GLDepreciationExpenseAccount     string
+	GLDisposalGainLossAccount        string
+	GLRevaluationReserveAccount      string
+	GLImpairmentLossAccount          string
+
+	Cost      float64 // the acquisition cost, for a disposal including the capitalized maintenance costs
+	Amount    float64 // the depreciation amount, the capitalized maintenance cost, the change of the carrying amount or the disposal proceeds
+	BookValue float64 // the closing book value of the depreciation or the book value on the disposal date
+}
+
+// lines returns the journal lines of the source, the amounts are rounded to two decimals
+// and the gain or loss of the disposal is calculated from the rounded amounts, so the entry is always balanced.
+func (u UseCaseHandler) lines(src journalSource) ([]Journal, error) {
+	type line struct {
+		account string
+		key     string // the setting of the account, used for the error message
+		debit   float64
+		credit  float64
+	}
+	lines := []line{}
+	reference, description := "", ""
+	switch src.Source {
+	case SourceDepreciation:
+		period := strconv.FormatInt(src.Period, 10)
+		reference = "DEP/" + src.AssetCode + "/" + period
+		description = "Depreciation " + src.AssetCode + " " + src.AssetName + " period " + period
+		amount := round(src.Amount)
+		lines = append(lines,
+			line{src.GLDepreciationExpenseAccount, "gl.depreciation_expense_account", amount, 0},
+			line{src.GLAccumulatedDepreciationAccount, "gl.accumulated_depreciation_account", 0, amount},
+		)
+	case SourceCapitalization:
+		reference = "CAP/" + src.AssetCode + "/" + src.Date.Format("20060102")
+		description = "Capitalized maintenance " + src.AssetCode + " " + src.AssetName
+		amount := round(src.Amount)
+		lines = append(lines,
+			line{src.GLAssetAccount, "gl.asset_account", amount, 0},
+			line{app.JOURNAL_CLEARING_ACCOUNT, "JOURNAL_CLEARING_ACCOUNT", 0, amount},
+		)
+	case SourceRevaluation, SourceImpairment:
+		// the change of the carrying amount goes through the accumulated depreciation, like the depreciation,
+		// so the accumulated depreciation is always the cost minus the book value and the disposal reconciles
+		account, key, prefix, name := src.GLRevaluationReserveAccount, "gl.revaluation_reserve_account", "REV/", "Revaluation "
+		if src.Source == SourceImpairment {
+			account, key, prefix, name = src.GLImpairmentLossAccount, "gl.impairment_loss_account", "IMP/", "Impairment "
+		}
+		reference = prefix + src.AssetCode + "/" + src.Date.Format("20060102")
+		description = name + src.AssetCode + " " + src.AssetName
+		amount := round(src.Amount)
+		lines = append(lines,
+			line{src.GLAccumulatedDepreciationAccount, "gl.accumulated_depreciation_account", math.Max(amount, 0), math.Max(-amount, 0)},
+			line{account, key, math.Max(-amount, 0), math.Max(amount, 0)},
+		)
+	case SourceDisposal:
+		reference = "DSP/" + src.AssetCode
+		description = "Disposal " + src.AssetCode + " " + src.AssetName
+		cost := round(src.Cost)
+		accumulated := round(src.Cost - src.BookValue)
+		proceeds := round(src.Amount)
+		gain := round(proceeds + accumulated - cost)
+		lines = append(lines,
+			line{src.GLAccumulatedDepreciationAccount, "gl.accumulated_depreciation_account", accumulated, 0},
+			line{app.JOURNAL_CLEARING_ACCOUNT, "JOURNAL_CLEARING_ACCOUNT", proceeds, 0},
+			line{src.GLAssetAccount, "gl.asset_account", 0, cost},
+			line{src.GLDisposalGainLossAccount, "gl.disposal_gain_loss_account", math.Max(-gain, 0), math.Max(gain, 0)},
+		)
+	}
+
+	res := []Journal{}
+	for _, l := range lines {
+		if l.debit == 0 && l.credit == 0 {
+			continue
+		}
+		if l.account == "" {
+			return res, app.Error().New(http.StatusBadRequest, u.Ctx.Trans("journal_account_required", map[string]string{
+				"account":  l.key,
+				"category": src.CategoryCode,
+			}))
+		}
+		j := Journal{}
+		j.Date.Set(src.Date)
+		j.Reference.Set(reference)
+		j.Source.Set(src.Source)
+		j.AssetCode.Set(src.AssetCode)
+		j.AssetName.Set(src.AssetName)
+		j.CategoryCode.Set(src.CategoryCode)
+		j.Account.Set(l.account)
+		j.Description.Set(description)
+		j.Debit.Set(l.debit)
+		j.Credit.Set(l.credit)
+		res = append(res, j)
+	}
+	return res, nil
+}
+
+// round rounds the amount to two decimals.
+func round(amount float64) float64 {
+	return math.Round(amount*100) / 100
+}
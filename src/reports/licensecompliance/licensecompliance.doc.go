@@ -0,0 +1,2 @@
+// licensecompliance is a package related to licensecompliance data.
+package licensecompliance
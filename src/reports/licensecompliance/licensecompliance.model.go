@@ -0,0 +1,126 @@
+package licensecompliance
+
+import "github.com/maulanar/go_asset_tracking_management/app"
+
+// LicenseCompliance is the main model of LicenseCompliance data. It provides a convenient interface for app.ModelInterface
+type LicenseCompliance struct {
+	app.Model
+	ID             app.NullUUID    `json:"id"              db:"id"              gorm:"column:id"`
+	Code           app.NullString  `json:"code"            db:"code"            gorm:"column:code"`
+	Product        app.NullString  `json:"product"         db:"product"         gorm:"column:product"`
+	Version        app.NullString  `json:"version"         db:"version"         gorm:"column:version"`
+	VendorName     app.NullString  `json:"vendor_name"     db:"vendor_name"     gorm:"column:vendor_name"`
+	LicenseType    app.NullString  `json:"license_type"    db:"license_type"    gorm:"column:license_type"`
+	Seats          app.NullInt64   `json:"seats"           db:"seats"           gorm:"column:seats"`
+	UsedSeats      app.NullInt64   `json:"used_seats"      db:"used_seats"      gorm:"column:used_seats"`
+	AvailableSeats app.NullInt64   `json:"available_seats" db:"available_seats" gorm:"column:available_seats"`
+	PurchaseCost   app.NullFloat64 `json:"purchase_cost"   db:"purchase_cost"   gorm:"column:purchase_cost"`
+	ExpiryDate     app.NullDate    `json:"expiry_date"     db:"expiry_date"     gorm:"column:expiry_date"`
+	Status         app.NullString  `json:"status"          db:"status"          gorm:"column:status"`
+	Compliance     app.NullString  `json:"compliance"      db:"compliance"      gorm:"column:compliance"`
+}
+
+type ViewData struct {
+	CreatedAt     string
+	Rows          []LicenseCompliance
+	TotalSeats    int64
+	TotalUsed     int64
+	TotalCost     float64
+	OverAllocated int64
+	Expiring      int64
+}
+
+// EndPoint returns the LicenseCompliance end point, it used for cache key, etc.
+func (LicenseCompliance) EndPoint() string {
+	return "license_compliances"
+}
+
+// TableVersion returns the versions of the LicenseCompliance table in the database.
+// Change this value with date format YY.MM.DDHHii when any table structure changes.
+func (LicenseCompliance) TableVersion() string {
+	return "26.10.191400"
+}
+
+// TableName returns the name of the LicenseCompliance table in the database.
+func (LicenseCompliance) TableName() string {
+	return "license_compliances"
+}
+
+// TableAliasName returns the table alias name of the LicenseCompliance table, used for querying.
+func (LicenseCompliance) TableAliasName() string {
+	return "m"
+}
+
+// GetRelations returns the relations of the LicenseCompliance data in the database, used for querying.
+func (m *LicenseCompliance) GetRelations() map[string]map[string]any {
+	// m.AddRelation("left", "users", "cu", []map[string]any{{"column1": "cu.id", "column2": "m.created_by_user_id"}})
+	// m.AddRelation("left", "users", "uu", []map[string]any{{"column1": "uu.id", "column2": "m.updated_by_user_id"}})
+	return m.Relations
+}
+
+// GetFilters returns the filter of the LicenseCompliance data in the database, used for querying.
+func (m *LicenseCompliance) GetFilters() []map[string]any {
+	m.AddFilter(map[string]any{"column1": "m.deleted_at", "operator": "=", "value": nil})
+	return m.Filters
+}
+
+// GetSorts returns the default sort of the LicenseCompliance data in the database, used for querying.
+func (m *LicenseCompliance) GetSorts() []map[string]any {
+	m.AddSort(map[string]any{"column": "m.updated_at", "direction": "desc"})
+	return m.Sorts
+}
+
+// GetFields returns list of the field of the LicenseCompliance data in the database, used for querying.
+func (m *LicenseCompliance) GetFields() map[string]map[string]any {
+	m.SetFields(m)
+	return m.Fields
+}
+
+// GetSchema returns the LicenseCompliance schema, used for querying.
+func (m *LicenseCompliance) GetSchema() map[string]any {
+	return m.SetSchema(m)
+}
+
+// OpenAPISchemaName returns the name of the LicenseCompliance schema in the open api documentation.
+func (LicenseCompliance) OpenAPISchemaName() string {
+	return "LicenseCompliance"
+}
+
+// GetOpenAPISchema returns the Open API Schema of the LicenseCompliance in the open api documentation.
+func (m *LicenseCompliance) GetOpenAPISchema() map[string]any {
+	return m.SetOpenAPISchema(m)
+}
+
+type LicenseComplianceList struct {
+	app.ListModel
+}
+
+// OpenAPISchemaName returns the name of the LicenseComplianceList schema in the open api documentation.
+func (LicenseComplianceList) OpenAPISchemaName() string {
+	return "LicenseComplianceList"
+}
+
+// GetOpenAPISchema returns the Open API Schema of the LicenseComplianceList in the open api documentation.
+func (p *LicenseComplianceList) GetOpenAPISchema() map[string]any {
+	return p.SetOpenAPISchema(&LicenseCompliance{})
+}
+
+// ParamCreate is the expected parameters for create a new LicenseCompliance data.
+type ParamCreate struct {
+	UseCaseHandler
+}
+
+// ParamUpdate is the expected parameters for update the LicenseCompliance data.
+type ParamUpdate struct {
+	UseCaseHandler
+}
+
+// ParamPartiallyUpdate is the expected parameters for partially update the LicenseCompliance data.
+type ParamPartiallyUpdate struct {
+	UseCaseHandler
+}
+
+// ParamDelete is the expected parameters for delete the LicenseCompliance data.
+type ParamDelete struct {
+	UseCaseHandler
+}
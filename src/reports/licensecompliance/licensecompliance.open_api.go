@@ -0,0 +1,55 @@
+package licensecompliance
+
+import "github.com/maulanar/go_asset_tracking_management/app"
+
+// OpenAPI is constructor for *openAPI, to autogenerate open api document.
+func OpenAPI() *OpenAPIOperation {
+	return &OpenAPIOperation{}
+}
+
+// OpenAPIOperation embed from app.OpenAPIOperation for simplicity, used for autogenerate open api document.
+type OpenAPIOperation struct {
+	app.OpenAPIOperation
+}
+
+// Base is common detail of license_compliances open api document component.
+func (o *OpenAPIOperation) Base() {
+	o.Tags = []string{"LicenseCompliance"}
+	o.HeaderParams = []map[string]any{{"$ref": "#/components/parameters/headerParam.Accept-Language"}}
+	o.Responses = map[string]map[string]any{
+		"200": {
+			"description": "Success",
+			"content":     map[string]any{"application/json": &LicenseCompliance{}}, // will auto create schema $ref: '#/components/schemas/LicenseCompliance' if not exists
+		},
+		"400": app.OpenAPIError().BadRequest(),
+		"401": app.OpenAPIError().Unauthorized(),
+		"403": app.OpenAPIError().Forbidden(),
+	}
+	o.Securities = []map[string][]string{}
+}
+
+// Get is detail of `GET /api/v3/license_compliances` open api document component.
+func (o *OpenAPIOperation) Get() *OpenAPIOperation {
+	if !app.IS_GENERATE_OPEN_API_DOC {
+		return o // skip for efficiency
+	}
+
+	o.Base()
+	o.Summary = "Get Report License Compliance"
+	o.Description = "Use this method to get Report License Compliance of used vs. purchased seats"
+	o.QueryParams = []map[string]any{
+		{"name": "vendor.id", "in": "query", "schema": map[string]any{"type": "string", "format": "uuid"}},
+		{"name": "status", "in": "query", "description": "Filter by license status.", "schema": map[string]any{"type": "string", "enum": []string{"active", "expiring", "expired", "perpetual"}}},
+		{"name": "compliance", "in": "query", "description": "Filter by compliance result.", "schema": map[string]any{"type": "string", "enum": []string{"compliant", "fully_allocated", "over_allocated", "expired_in_use"}}},
+	}
+	o.Responses = map[string]map[string]any{
+		"200": {
+			"description": "Success",
+			"content":     map[string]any{"text/html": &LicenseComplianceList{}}, // will auto create schema $ref: '#/components/schemas/LicenseCompliance.List' if not exists
+		},
+		"400": app.OpenAPIError().BadRequest(),
+		"401": app.OpenAPIError().Unauthorized(),
+		"403": app.OpenAPIError().Forbidden(),
+	}
+	return o
+}
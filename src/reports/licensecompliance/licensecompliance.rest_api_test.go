@@ -0,0 +1,142 @@
+package licensecompliance
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gofiber/fiber/v2/utils"
+	"gorm.io/gorm"
+
+	"github.com/maulanar/go_asset_tracking_management/app"
+)
+
+// prepareTest prepares the test.
+func prepareTest(tb testing.TB) {
+	app.Test()
+	tx := app.Test().Tx
+	app.DB().RegisterTable("main", LicenseCompliance{})
+	app.DB().MigrateTable(tx, "main", app.Setting{})
+	tx.Session(&gorm.Session{AllowGlobalUpdate: true}).Delete(&LicenseCompliance{})
+
+	app.Server().AddMiddleware(app.Test().NewCtx([]string{
+		"license_compliances.detail",
+		"license_compliances.list",
+		"license_compliances.create",
+		"license_compliances.edit",
+		"license_compliances.delete",
+	}))
+	app.Server().AddRoute("/license_compliances", "GET", REST().Get, nil)
+}
+
+// getTestLicenseComplianceID returns an available LicenseCompliance ID.
+func getTestLicenseComplianceID() string {
+	return "todo"
+}
+
+// tests is test scenario.
+var tests = []struct {
+	description  string // description of the test case
+	method       string // method to test
+	path         string // route path to test
+	token        string // token to test
+	bodyRequest  string // body to test
+	expectedCode int    // expected HTTP status code
+	expectedBody string // expected body response
+}{
+	{
+		description:  "Get empty list of LicenseCompliance",
+		method:       "GET",
+		path:         "/license_compliances",
+		token:        app.TestFullAccessToken,
+		expectedCode: http.StatusOK,
+		expectedBody: `{"count":0,"results":[]}`,
+	},
+	{
+		description:  "Create LicenseCompliance with minimum payload",
+		method:       "POST",
+		path:         "/license_compliances",
+		token:        app.TestFullAccessToken,
+		bodyRequest:  `{"name":"Kilogram"}`,
+		expectedCode: http.StatusCreated,
+		expectedBody: `{"name":"Kilogram"}`,
+	},
+	{
+		description:  "Get LicenseCompliance by ID",
+		method:       "GET",
+		path:         "/license_compliances/" + getTestLicenseComplianceID(),
+		token:        app.TestFullAccessToken,
+		expectedCode: http.StatusOK,
+		expectedBody: `{"name":"Kilogram"}`,
+	},
+	{
+		description:  "Update LicenseCompliance by ID",
+		method:       "PUT",
+		path:         "/license_compliances/" + getTestLicenseComplianceID(),
+		token:        app.TestFullAccessToken,
+		bodyRequest:  `{"reason":"Update LicenseCompliance by ID","name":"KG"}`,
+		expectedCode: http.StatusOK,
+		expectedBody: `{"name":"KG"}`,
+	},
+	{
+		description:  "Partially update LicenseCompliance by ID",
+		method:       "PATCH",
+		path:         "/license_compliances/" + getTestLicenseComplianceID(),
+		token:        app.TestFullAccessToken,
+		bodyRequest:  `{"reason":"Partially Update LicenseCompliance by ID","name":"Kilo Gram"}`,
+		expectedCode: http.StatusOK,
+		expectedBody: `{"name":"Kilo Gram"}`,
+	},
+	{
+		description:  "Delete LicenseCompliance by ID",
+		method:       "DELETE",
+		path:         "/license_compliances/" + getTestLicenseComplianceID(),
+		token:        app.TestFullAccessToken,
+		bodyRequest:  `{"reason":"Delete LicenseCompliance by ID"}`,
+		expectedCode: http.StatusOK,
+		expectedBody: `{"code":200}`,
+	},
+}
+
+// TestLicenseComplianceREST tests the REST API of LicenseCompliance data with specified scenario.
+func TestLicenseComplianceREST(t *testing.T) {
+	prepareTest(t)
+
+	// Iterate through test single test cases
+	for _, test := range tests {
+
+		// Create a new http request with the route from the test case
+		req := httptest.NewRequest(test.method, test.path, strings.NewReader(test.bodyRequest))
+		req.Header.Add("Authorization", "Bearer "+test.token)
+		req.Header.Add("Content-Type", "application/json")
+
+		// Perform the request plain with the app, the second argument is a request latency (set to -1 for no latency)
+		res, err := app.Server().Test(req)
+
+		// Verify if the status code is as expected
+		utils.AssertEqual(t, nil, err, "app.Server().Test(req)")
+		utils.AssertEqual(t, test.expectedCode, res.StatusCode, test.description)
+
+		// Verify if the body response is as expected
+		body, err := io.ReadAll(res.Body)
+		utils.AssertEqual(t, nil, err, "io.ReadAll(res.Body)")
+		app.Test().AssertMatchJSONElement(t, []byte(test.expectedBody), body, test.description)
+		res.Body.Close()
+	}
+}
+
+// BenchmarkLicenseComplianceREST tests the REST API of LicenseCompliance data with specified scenario.
+func BenchmarkLicenseComplianceREST(b *testing.B) {
+	b.ReportAllocs()
+	prepareTest(b)
+	for i := 0; i < b.N; i++ {
+		for _, test := range tests {
+			req := httptest.NewRequest(test.method, test.path, strings.NewReader(test.bodyRequest))
+			req.Header.Add("Authorization", "Bearer "+test.token)
+			req.Header.Add("Content-Type", "application/json")
+			app.Server().Test(req)
+		}
+	}
+}
@@ -0,0 +1,129 @@
+package licensecompliance
+
+import (
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/maulanar/go_asset_tracking_management/app"
+)
+
+// UseCase returns a UseCaseHandler for expected use case functional.
+func UseCase(ctx app.Ctx, query ...url.Values) UseCaseHandler {
+	u := UseCaseHandler{
+		Ctx:   &ctx,
+		Query: url.Values{},
+	}
+	if len(query) > 0 {
+		u.Query = query[0]
+	}
+	return u
+}
+
+// UseCaseHandler provides a convenient interface for LicenseCompliance use case, use UseCase to access UseCaseHandler.
+type UseCaseHandler struct {
+	LicenseCompliance
+
+	// injectable dependencies
+	Ctx   *app.Ctx   `json:"-" db:"-" gorm:"-"`
+	Query url.Values `json:"-" db:"-" gorm:"-"`
+}
+
+// Async return UseCaseHandler with async process.
+func (u UseCaseHandler) Async(ctx app.Ctx, query ...url.Values) UseCaseHandler {
+	ctx.IsAsync = true
+	return UseCase(ctx, query...)
+}
+
+// Get returns the used vs. purchased seats of every license.
+func (u UseCaseHandler) Get() (res ViewData, err error) {
+
+	// check permission
+	err = u.Ctx.ValidatePermission("license_compliances.list")
+	if err != nil {
+		return res, err
+	}
+
+	// prepare db for current ctx
+	tx, err := u.Ctx.DB()
+	if err != nil {
+		return res, app.Error().New(http.StatusInternalServerError, err.Error())
+	}
+
+	// parsing filter
+	where := ""
+	args := map[string]any{}
+	if v := u.Query.Get("vendor.id"); v != "" {
+		where += " AND vendor_id = @vendor"
+		args["vendor"] = v
+	}
+	if v := u.Query.Get("status"); v != "" {
+		where += " AND status = @status"
+		args["status"] = v
+	}
+	if v := u.Query.Get("compliance"); v != "" {
+		where += " AND compliance = @compliance"
+		args["compliance"] = v
+	}
+
+	rows := []LicenseCompliance{}
+	err = tx.Raw(`
+WITH lc AS (
+  SELECT l.id, l.code, l.product, l.version, l.vendor_id, v.name AS vendor_name, l.license_type,
+         COALESCE(l.seats, 0) AS seats,
+         COALESCE(s.used_seats, 0) AS used_seats,
+         COALESCE(l.seats, 0) - COALESCE(s.used_seats, 0) AS available_seats,
+         COALESCE(l.purchase_cost, 0) AS purchase_cost,
+         l.expiry_date,
+         CASE
+           WHEN l.expiry_date IS NULL THEN 'perpetual'
+           WHEN l.expiry_date < CURRENT_DATE THEN 'expired'
+           WHEN l.expiry_date - COALESCE(l.notify_days_before, 0) <= CURRENT_DATE THEN 'expiring'
+           ELSE 'active'
+         END AS status
+  FROM licenses l
+  LEFT JOIN vendors v ON v.id = l.vendor_id
+  LEFT JOIN (
+    SELECT ls.license_id, COUNT(*) AS used_seats
+    FROM license_seats ls
+    WHERE ls.deleted_at IS NULL AND ls.released_date IS NULL
+    GROUP BY ls.license_id
+  ) s ON s.license_id = l.id
+  WHERE l.deleted_at IS NULL
+), lcc AS (
+  SELECT lc.*,
+         CASE
+           WHEN lc.status = 'expired' AND lc.used_seats > 0 THEN 'expired_in_use'
+           WHEN lc.used_seats > lc.seats THEN 'over_allocated'
+           WHEN lc.used_seats = lc.seats THEN 'fully_allocated'
+           ELSE 'compliant'
+         END AS compliance
+  FROM lc
+)
+SELECT id, code, product, version, vendor_name, license_type, seats, used_seats, available_seats,
+       purchase_cost, expiry_date, status, compliance
+FROM lcc
+WHERE 1 = 1`+where+`
+ORDER BY product, code`, args).Scan(&rows).Error
+	if err != nil {
+		return res, app.Error().New(http.StatusInternalServerError, err.Error())
+	}
+
+	res = ViewData{
+		CreatedAt: time.Now().Format("2006-01-02 15:04:05"),
+		Rows:      rows,
+	}
+	for _, r := range rows {
+		res.TotalSeats += r.Seats.Int64
+		res.TotalUsed += r.UsedSeats.Int64
+		res.TotalCost += r.PurchaseCost.Float64
+		if r.Compliance.String == "over_allocated" || r.Compliance.String == "expired_in_use" {
+			res.OverAllocated++
+		}
+		if r.Status.String == "expiring" {
+			res.Expiring++
+		}
+	}
+
+	return res, nil
+}
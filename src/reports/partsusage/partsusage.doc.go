@@ -0,0 +1,2 @@
+// partsusage is a package related to partsusage data.
+package partsusage
@@ -0,0 +1,123 @@
+package partsusage
+
+import "github.com/maulanar/go_asset_tracking_management/app"
+
+// PartsUsage is the main model of PartsUsage data. It provides a convenient interface for app.ModelInterface
+type PartsUsage struct {
+	app.Model
+	Period         app.NullString  `json:"period"          db:"period"          gorm:"column:period"`
+	AssetID        app.NullUUID    `json:"asset.id"        db:"asset_id"        gorm:"column:asset_id"`
+	AssetCode      app.NullString  `json:"asset.code"      db:"asset_code"      gorm:"column:asset_code"`
+	AssetName      app.NullString  `json:"asset.name"      db:"asset_name"      gorm:"column:asset_name"`
+	CategoryCode   app.NullString  `json:"category.code"   db:"category_code"   gorm:"column:category_code"`
+	CategoryName   app.NullString  `json:"category.name"   db:"category_name"   gorm:"column:category_name"`
+	ConsumableCode app.NullString  `json:"consumable.code" db:"consumable_code" gorm:"column:consumable_code"`
+	ConsumableName app.NullString  `json:"consumable.name" db:"consumable_name" gorm:"column:consumable_name"`
+	Unit           app.NullString  `json:"unit"            db:"unit"            gorm:"column:unit"`
+	Qty            app.NullFloat64 `json:"qty"             db:"qty"             gorm:"column:qty"`
+	Amount         app.NullFloat64 `json:"amount"          db:"amount"          gorm:"column:amount"`
+}
+
+type ViewData struct {
+	CreatedAt   string
+	StartDate   string
+	EndDate     string
+	Rows        []PartsUsage
+	TotalQty    float64
+	TotalAmount float64
+}
+
+// EndPoint returns the PartsUsage end point, it used for cache key, etc.
+func (PartsUsage) EndPoint() string {
+	return "parts_usages"
+}
+
+// TableVersion returns the versions of the PartsUsage table in the database.
+// Change this value with date format YY.MM.DDHHii when any table structure changes.
+func (PartsUsage) TableVersion() string {
+	return "28.06.291152"
+}
+
+// TableName returns the name of the PartsUsage table in the database.
+func (PartsUsage) TableName() string {
+	return "parts_usages"
+}
+
+// TableAliasName returns the table alias name of the PartsUsage table, used for querying.
+func (PartsUsage) TableAliasName() string {
+	return "m"
+}
+
+// GetRelations returns the relations of the PartsUsage data in the database, used for querying.
+func (m *PartsUsage) GetRelations() map[string]map[string]any {
+	// m.AddRelation("left", "users", "cu", []map[string]any{{"column1": "cu.id", "column2": "m.created_by_user_id"}})
+	// m.AddRelation("left", "users", "uu", []map[string]any{{"column1": "uu.id", "column2": "m.updated_by_user_id"}})
+	return m.Relations
+}
+
+// GetFilters returns the filter of the PartsUsage data in the database, used for querying.
+func (m *PartsUsage) GetFilters() []map[string]any {
+	m.AddFilter(map[string]any{"column1": "m.deleted_at", "operator": "=", "value": nil})
+	return m.Filters
+}
+
+// GetSorts returns the default sort of the PartsUsage data in the database, used for querying.
+func (m *PartsUsage) GetSorts() []map[string]any {
+	m.AddSort(map[string]any{"column": "m.updated_at", "direction": "desc"})
+	return m.Sorts
+}
+
+// GetFields returns list of the field of the PartsUsage data in the database, used for querying.
+func (m *PartsUsage) GetFields() map[string]map[string]any {
+	m.SetFields(m)
+	return m.Fields
+}
+
+// GetSchema returns the PartsUsage schema, used for querying.
+func (m *PartsUsage) GetSchema() map[string]any {
+	return m.SetSchema(m)
+}
+
+// OpenAPISchemaName returns the name of the PartsUsage schema in the open api documentation.
+func (PartsUsage) OpenAPISchemaName() string {
+	return "PartsUsage"
+}
+
+// GetOpenAPISchema returns the Open API Schema of the PartsUsage in the open api documentation.
+func (m *PartsUsage) GetOpenAPISchema() map[string]any {
+	return m.SetOpenAPISchema(m)
+}
+
+type PartsUsageList struct {
+	app.ListModel
+}
+
+// OpenAPISchemaName returns the name of the PartsUsageList schema in the open api documentation.
+func (PartsUsageList) OpenAPISchemaName() string {
+	return "PartsUsageList"
+}
+
+// GetOpenAPISchema returns the Open API Schema of the PartsUsageList in the open api documentation.
+func (p *PartsUsageList) GetOpenAPISchema() map[string]any {
+	return p.SetOpenAPISchema(&PartsUsage{})
+}
+
+// ParamCreate is the expected parameters for create a new PartsUsage data.
+type ParamCreate struct {
+	UseCaseHandler
+}
+
+// ParamUpdate is the expected parameters for update the PartsUsage data.
+type ParamUpdate struct {
+	UseCaseHandler
+}
+
+// ParamPartiallyUpdate is the expected parameters for partially update the PartsUsage data.
+type ParamPartiallyUpdate struct {
+	UseCaseHandler
+}
+
+// ParamDelete is the expected parameters for delete the PartsUsage data.
+type ParamDelete struct {
+	UseCaseHandler
+}
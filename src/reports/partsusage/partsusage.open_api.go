@@ -0,0 +1,57 @@
+package partsusage
+
+import "github.com/maulanar/go_asset_tracking_management/app"
+
+// OpenAPI is constructor for *openAPI, to autogenerate open api document.
+func OpenAPI() *OpenAPIOperation {
+	return &OpenAPIOperation{}
+}
+
+// OpenAPIOperation embed from app.OpenAPIOperation for simplicity, used for autogenerate open api document.
+type OpenAPIOperation struct {
+	app.OpenAPIOperation
+}
+
+// Base is common detail of parts_usages open api document component.
+func (o *OpenAPIOperation) Base() {
+	o.Tags = []string{"PartsUsage"}
+	o.HeaderParams = []map[string]any{{"$ref": "#/components/parameters/headerParam.Accept-Language"}}
+	o.Responses = map[string]map[string]any{
+		"200": {
+			"description": "Success",
+			"content":     map[string]any{"application/json": &PartsUsage{}}, // will auto create schema $ref: '#/components/schemas/PartsUsage' if not exists
+		},
+		"400": app.OpenAPIError().BadRequest(),
+		"401": app.OpenAPIError().Unauthorized(),
+		"403": app.OpenAPIError().Forbidden(),
+	}
+	o.Securities = []map[string][]string{}
+}
+
+// Get is detail of `GET /api/v3/parts_usages` open api document component.
+func (o *OpenAPIOperation) Get() *OpenAPIOperation {
+	if !app.IS_GENERATE_OPEN_API_DOC {
+		return o // skip for efficiency
+	}
+
+	o.Base()
+	o.Summary = "Get Report Parts Usage"
+	o.Description = "Use this method to get Report Parts Usage, the spare parts consumed by the maintenance per month, asset and category in a period"
+	o.QueryParams = []map[string]any{
+		{"name": "start_date", "in": "query", "description": "Default to the first day of the current month.", "schema": map[string]any{"type": "string", "format": "date"}},
+		{"name": "end_date", "in": "query", "description": "Default to today.", "schema": map[string]any{"type": "string", "format": "date"}},
+		{"name": "asset.id", "in": "query", "description": "Filter by the asset.", "schema": map[string]any{"type": "string"}},
+		{"name": "category.id", "in": "query", "description": "Filter by the category of the asset.", "schema": map[string]any{"type": "string"}},
+		{"name": "branch.id", "in": "query", "description": "Filter by the branch the parts are issued from.", "schema": map[string]any{"type": "string"}},
+	}
+	o.Responses = map[string]map[string]any{
+		"200": {
+			"description": "Success",
+			"content":     map[string]any{"text/html": &PartsUsageList{}}, // will auto create schema $ref: '#/components/schemas/PartsUsage.List' if not exists
+		},
+		"400": app.OpenAPIError().BadRequest(),
+		"401": app.OpenAPIError().Unauthorized(),
+		"403": app.OpenAPIError().Forbidden(),
+	}
+	return o
+}
@@ -0,0 +1,113 @@
+package partsusage
+
+import (
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/maulanar/go_asset_tracking_management/app"
+)
+
+// UseCase returns a UseCaseHandler for expected use case functional.
+func UseCase(ctx app.Ctx, query ...url.Values) UseCaseHandler {
+	u := UseCaseHandler{
+		Ctx:   &ctx,
+		Query: url.Values{},
+	}
+	if len(query) > 0 {
+		u.Query = query[0]
+	}
+	return u
+}
+
+// UseCaseHandler provides a convenient interface for PartsUsage use case, use UseCase to access UseCaseHandler.
+type UseCaseHandler struct {
+	PartsUsage
+
+	// injectable dependencies
+	Ctx   *app.Ctx   `json:"-" db:"-" gorm:"-"`
+	Query url.Values `json:"-" db:"-" gorm:"-"`
+}
+
+// Async return UseCaseHandler with async process.
+func (u UseCaseHandler) Async(ctx app.Ctx, query ...url.Values) UseCaseHandler {
+	ctx.IsAsync = true
+	return UseCase(ctx, query...)
+}
+
+// Get returns the spare parts consumed by the maintenance of the assets in a period,
+// grouped per month, asset and spare part.
+func (u UseCaseHandler) Get() (res ViewData, err error) {
+
+	// check permission
+	err = u.Ctx.ValidatePermission("parts_usages.list")
+	if err != nil {
+		return res, err
+	}
+
+	// prepare db for current ctx
+	tx, err := u.Ctx.DB()
+	if err != nil {
+		return res, app.Error().New(http.StatusInternalServerError, err.Error())
+	}
+
+	// parsing filter
+	now := time.Now()
+	startDate := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, now.Location())
+	endDate := now
+	if v := u.Query.Get("start_date"); v != "" {
+		startDate, err = time.Parse("2006-01-02", v)
+		if err != nil {
+			return res, app.Error().New(http.StatusBadRequest, err.Error())
+		}
+	}
+	if v := u.Query.Get("end_date"); v != "" {
+		endDate, err = time.Parse("2006-01-02", v)
+		if err != nil {
+			return res, app.Error().New(http.StatusBadRequest, err.Error())
+		}
+	}
+	args := map[string]any{
+		"start":    startDate.Format("2006-01-02"),
+		"end":      endDate.Format("2006-01-02"),
+		"asset":    u.Query.Get("asset.id"),
+		"category": u.Query.Get("category.id"),
+		"branch":   u.Query.Get("branch.id"),
+	}
+
+	rows := []PartsUsage{}
+	err = tx.Raw(`
+SELECT TO_CHAR(ma.date, 'YYYY-MM') AS period,
+       ass.id AS asset_id, ass.code AS asset_code, ass.name AS asset_name,
+       cat.code AS category_code, cat.name AS category_name,
+       cns.code AS consumable_code, cns.name AS consumable_name, cns.unit,
+       SUM(map.qty) AS qty, SUM(map.amount) AS amount
+FROM maintenance_asset_parts map
+JOIN maintenance_assets ma ON ma.id = map.maintenance_asset_id AND ma.deleted_at IS NULL
+JOIN assets ass ON ass.id = ma.asset_id
+LEFT JOIN categories cat ON cat.id = ass.category_id
+JOIN consumables cns ON cns.id = map.consumable_id
+WHERE map.deleted_at IS NULL
+  AND ma.date BETWEEN @start AND @end
+  AND (@asset = '' OR ass.id::text = @asset)
+  AND (@category = '' OR cat.id::text = @category)
+  AND (@branch = '' OR map.branch_id::text = @branch)
+GROUP BY 1, ass.id, ass.code, ass.name, cat.code, cat.name, cns.code, cns.name, cns.unit
+ORDER BY 1, ass.code, cns.code`, args).Scan(&rows).Error
+	if err != nil {
+		return res, app.Error().New(http.StatusInternalServerError, err.Error())
+	}
+
+	res = ViewData{
+		CreatedAt: time.Now().Format("2006-01-02 15:04:05"),
+		StartDate: args["start"].(string),
+		EndDate:   args["end"].(string),
+		Rows:      rows,
+	}
+	for _, row := range rows {
+		res.TotalQty += row.Qty.Float64
+		res.TotalAmount += row.Amount.Float64
+	}
+
+	return res, nil
+}
@@ -0,0 +1,2 @@
+// reliability is a package related to reliability data.
+package reliability
@@ -0,0 +1,153 @@
+package reliability
+
+import "github.com/maulanar/go_asset_tracking_management/app"
+
+// Reliability is the main model of Reliability data. It provides a convenient interface for app.ModelInterface
+type Reliability struct {
+	app.Model
+	ID              app.NullUUID    `json:"id"               db:"id"               gorm:"column:id"`
+	Code            app.NullString  `json:"code"             db:"code"             gorm:"column:code"`
+	Name            app.NullString  `json:"name"             db:"name"             gorm:"column:name"`
+	AssetCount      app.NullInt64   `json:"asset_count"      db:"asset_count"      gorm:"-"`
+	Failures        app.NullInt64   `json:"failures"         db:"failures"         gorm:"-"`
+	OperatingHours  app.NullFloat64 `json:"operating_hours"  db:"operating_hours"  gorm:"-"`
+	DowntimeHours   app.NullFloat64 `json:"downtime_hours"   db:"downtime_hours"   gorm:"-"`
+	MTBFHours       app.NullFloat64 `json:"mtbf_hours"       db:"mtbf_hours"       gorm:"-"`
+	MTTRHours       app.NullFloat64 `json:"mttr_hours"       db:"mttr_hours"       gorm:"-"`
+	MaintenanceCost app.NullFloat64 `json:"maintenance_cost" db:"maintenance_cost" gorm:"-"`
+	PurchasePrice   app.NullFloat64 `json:"purchase_price"   db:"purchase_price"   gorm:"-"`
+	CostPercentage  app.NullFloat64 `json:"cost_percentage"  db:"cost_percentage"  gorm:"-"`
+}
+
+type ViewData struct {
+	CreatedAt            string        `json:"created_at"`
+	StartDate            string        `json:"start_date"`
+	EndDate              string        `json:"end_date"`
+	GroupBy              string        `json:"group_by"`
+	Rows                 []Reliability `json:"rows"`
+	TotalFailures        int64         `json:"total_failures"`
+	TotalDowntimeHours   float64       `json:"total_downtime_hours"`
+	TotalMaintenanceCost float64       `json:"total_maintenance_cost"`
+}
+
+// Groups of the Reliability data.
+const (
+	GroupAsset    = "asset"
+	GroupCategory = "category"
+	GroupVendor   = "vendor"
+)
+
+// assetReliability is the reliability figures of a single asset, it is summed up into the group of the Reliability data.
+type assetReliability struct {
+	AssetID         app.NullUUID    `gorm:"column:asset_id"`
+	AssetCode       app.NullString  `gorm:"column:asset_code"`
+	AssetName       app.NullString  `gorm:"column:asset_name"`
+	CategoryID      app.NullUUID    `gorm:"column:category_id"`
+	CategoryCode    app.NullString  `gorm:"column:category_code"`
+	CategoryName    app.NullString  `gorm:"column:category_name"`
+	VendorID        app.NullUUID    `gorm:"column:vendor_id"`
+	VendorCode      app.NullString  `gorm:"column:vendor_code"`
+	VendorName      app.NullString  `gorm:"column:vendor_name"`
+	Price           app.NullFloat64 `gorm:"column:price"`
+	OperatingHours  app.NullFloat64 `gorm:"column:operating_hours"`
+	Failures        app.NullInt64   `gorm:"column:failures"`
+	DowntimeHours   app.NullFloat64 `gorm:"column:downtime_hours"`
+	RepairHours     app.NullFloat64 `gorm:"column:repair_hours"`
+	Repairs         app.NullInt64   `gorm:"column:repairs"`
+	MaintenanceCost app.NullFloat64 `gorm:"column:maintenance_cost"`
+}
+
+// EndPoint returns the Reliability end point, it used for cache key, etc.
+func (Reliability) EndPoint() string {
+	return "reliabilities"
+}
+
+// TableVersion returns the versions of the Reliability table in the database.
+// Change this value with date format YY.MM.DDHHii when any table structure changes.
+func (Reliability) TableVersion() string {
+	return "28.06.291152"
+}
+
+// TableName returns the name of the Reliability table in the database.
+func (Reliability) TableName() string {
+	return "reliabilities"
+}
+
+// TableAliasName returns the table alias name of the Reliability table, used for querying.
+func (Reliability) TableAliasName() string {
+	return "m"
+}
+
+// GetRelations returns the relations of the Reliability data in the database, used for querying.
+func (m *Reliability) GetRelations() map[string]map[string]any {
+	// m.AddRelation("left", "users", "cu", []map[string]any{{"column1": "cu.id", "column2": "m.created_by_user_id"}})
+	// m.AddRelation("left", "users", "uu", []map[string]any{{"column1": "uu.id", "column2": "m.updated_by_user_id"}})
+	return m.Relations
+}
+
+// GetFilters returns the filter of the Reliability data in the database, used for querying.
+func (m *Reliability) GetFilters() []map[string]any {
+	m.AddFilter(map[string]any{"column1": "m.deleted_at", "operator": "=", "value": nil})
+	return m.Filters
+}
+
+// GetSorts returns the default sort of the Reliability data in the database, used for querying.
+func (m *Reliability) GetSorts() []map[string]any {
+	m.AddSort(map[string]any{"column": "m.updated_at", "direction": "desc"})
+	return m.Sorts
+}
+
+// GetFields returns list of the field of the Reliability data in the database, used for querying.
+func (m *Reliability) GetFields() map[string]map[string]any {
+	m.SetFields(m)
+	return m.Fields
+}
+
+// GetSchema returns the Reliability schema, used for querying.
+func (m *Reliability) GetSchema() map[string]any {
+	return m.SetSchema(m)
+}
+
+// OpenAPISchemaName returns the name of the Reliability schema in the open api documentation.
+func (Reliability) OpenAPISchemaName() string {
+	return "Reliability"
+}
+
+// GetOpenAPISchema returns the Open API Schema of the Reliability in the open api documentation.
+func (m *Reliability) GetOpenAPISchema() map[string]any {
+	return m.SetOpenAPISchema(m)
+}
+
+type ReliabilityList struct {
+	app.ListModel
+}
+
+// OpenAPISchemaName returns the name of the ReliabilityList schema in the open api documentation.
+func (ReliabilityList) OpenAPISchemaName() string {
+	return "ReliabilityList"
+}
+
+// GetOpenAPISchema returns the Open API Schema of the ReliabilityList in the open api documentation.
+func (p *ReliabilityList) GetOpenAPISchema() map[string]any {
+	return p.SetOpenAPISchema(&Reliability{})
+}
+
+// ParamCreate is the expected parameters for create a new Reliability data.
+type ParamCreate struct {
+	UseCaseHandler
+}
+
+// ParamUpdate is the expected parameters for update the Reliability data.
+type ParamUpdate struct {
+	UseCaseHandler
+}
+
+// ParamPartiallyUpdate is the expected parameters for partially update the Reliability data.
+type ParamPartiallyUpdate struct {
+	UseCaseHandler
+}
+
+// ParamDelete is the expected parameters for delete the Reliability data.
+type ParamDelete struct {
+	UseCaseHandler
+}
@@ -0,0 +1,59 @@
+package reliability
+
+import "github.com/maulanar/go_asset_tracking_management/app"
+
+// OpenAPI is constructor for *openAPI, to autogenerate open api document.
+func OpenAPI() *OpenAPIOperation {
+	return &OpenAPIOperation{}
+}
+
+// OpenAPIOperation embed from app.OpenAPIOperation for simplicity, used for autogenerate open api document.
+type OpenAPIOperation struct {
+	app.OpenAPIOperation
+}
+
+// Base is common detail of reliabilities open api document component.
+func (o *OpenAPIOperation) Base() {
+	o.Tags = []string{"Reliability"}
+	o.HeaderParams = []map[string]any{{"$ref": "#/components/parameters/headerParam.Accept-Language"}}
+	o.Responses = map[string]map[string]any{
+		"200": {
+			"description": "Success",
+			"content":     map[string]any{"application/json": &Reliability{}}, // will auto create schema $ref: '#/components/schemas/Reliability' if not exists
+		},
+		"400": app.OpenAPIError().BadRequest(),
+		"401": app.OpenAPIError().Unauthorized(),
+		"403": app.OpenAPIError().Forbidden(),
+	}
+	o.Securities = []map[string][]string{}
+}
+
+// Get is detail of `GET /api/v3/reliabilities` open api document component.
+func (o *OpenAPIOperation) Get() *OpenAPIOperation {
+	if !app.IS_GENERATE_OPEN_API_DOC {
+		return o // skip for efficiency
+	}
+
+	o.Base()
+	o.Summary = "Get Report Reliability"
+	o.Description = "Use this method to get Report Reliability, the failures, the downtime, the mean time between failures, the mean time to repair and the maintenance cost per asset, category or vendor in a period"
+	o.QueryParams = []map[string]any{
+		{"name": "start_date", "in": "query", "description": "Default to the first day of the current year.", "schema": map[string]any{"type": "string", "format": "date"}},
+		{"name": "end_date", "in": "query", "description": "Default to today.", "schema": map[string]any{"type": "string", "format": "date"}},
+		{"name": "group_by", "in": "query", "description": "Default to asset.", "schema": map[string]any{"type": "string", "enum": []string{"asset", "category", "vendor"}}},
+		{"name": "asset.id", "in": "query", "description": "Filter by the asset.", "schema": map[string]any{"type": "string"}},
+		{"name": "category.id", "in": "query", "description": "Filter by the category of the asset.", "schema": map[string]any{"type": "string"}},
+		{"name": "vendor.id", "in": "query", "description": "Filter by the vendor the asset is purchased from.", "schema": map[string]any{"type": "string"}},
+		{"name": "format", "in": "query", "description": "Default to json.", "schema": map[string]any{"type": "string", "enum": []string{"json", "html"}}},
+	}
+	o.Responses = map[string]map[string]any{
+		"200": {
+			"description": "Success",
+			"content":     map[string]any{"application/json": &ReliabilityList{}, "text/html": &ReliabilityList{}}, // will auto create schema $ref: '#/components/schemas/Reliability.List' if not exists
+		},
+		"400": app.OpenAPIError().BadRequest(),
+		"401": app.OpenAPIError().Unauthorized(),
+		"403": app.OpenAPIError().Forbidden(),
+	}
+	return o
+}
@@ -0,0 +1,225 @@
+package reliability
+
+import (
+	"math"
+	"net/http"
+	"net/url"
+	"sort"
+	"time"
+
+	"github.com/maulanar/go_asset_tracking_management/app"
+)
+
+// UseCase returns a UseCaseHandler for expected use case functional.
+func UseCase(ctx app.Ctx, query ...url.Values) UseCaseHandler {
+	u := UseCaseHandler{
+		Ctx:   &ctx,
+		Query: url.Values{},
+	}
+	if len(query) > 0 {
+		u.Query = query[0]
+	}
+	return u
+}
+
+// UseCaseHandler provides a convenient interface for Reliability use case, use UseCase to access UseCaseHandler.
+type UseCaseHandler struct {
+	Reliability
+
+	// injectable dependencies
+	Ctx   *app.Ctx   `json:"-" db:"-" gorm:"-"`
+	Query url.Values `json:"-" db:"-" gorm:"-"`
+}
+
+// Async return UseCaseHandler with async process.
+func (u UseCaseHandler) Async(ctx app.Ctx, query ...url.Values) UseCaseHandler {
+	ctx.IsAsync = true
+	return UseCase(ctx, query...)
+}
+
+// Get returns the reliability of the assets in a period grouped per asset, category or vendor.
+// A failure is a maintenance record which is not generated by a maintenance plan, the downtime is the time the asset
+// is held by its work orders from the time they are scheduled or started until they are closed, overlapping work orders are counted
+// once and the downtime never exceeds the operating hours, the repair time is the time from the start until the completion of a corrective work order
+// and the maintenance cost is compared with the purchase price of the assets.
+func (u UseCaseHandler) Get() (res ViewData, err error) {
+
+	// check permission
+	err = u.Ctx.ValidatePermission("reliabilities.list")
+	if err != nil {
+		return res, err
+	}
+
+	// prepare db for current ctx
+	tx, err := u.Ctx.DB()
+	if err != nil {
+		return res, app.Error().New(http.StatusInternalServerError, err.Error())
+	}
+
+	// parsing filter
+	now := time.Now()
+	startDate := time.Date(now.Year(), 1, 1, 0, 0, 0, 0, now.Location())
+	endDate := now
+	if v := u.Query.Get("start_date"); v != "" {
+		startDate, err = time.Parse("2006-01-02", v)
+		if err != nil {
+			return res, app.Error().New(http.StatusBadRequest, err.Error())
+		}
+	}
+	if v := u.Query.Get("end_date"); v != "" {
+		endDate, err = time.Parse("2006-01-02", v)
+		if err != nil {
+			return res, app.Error().New(http.StatusBadRequest, err.Error())
+		}
+	}
+	groupBy := u.Query.Get("group_by")
+	if groupBy == "" {
+		groupBy = GroupAsset
+	}
+	if groupBy != GroupAsset && groupBy != GroupCategory && groupBy != GroupVendor {
+		return res, app.Error().New(http.StatusBadRequest, u.Ctx.Trans("report_group_invalid", map[string]string{"group": groupBy}))
+	}
+	args := map[string]any{
+		"start":    startDate.Format("2006-01-02"),
+		"end":      endDate.Format("2006-01-02"),
+		"asset":    u.Query.Get("asset.id"),
+		"category": u.Query.Get("category.id"),
+		"vendor":   u.Query.Get("vendor.id"),
+	}
+
+	assets := []assetReliability{}
+	err = tx.Raw(`
+WITH base AS (
+  SELECT ass.id AS asset_id, ass.code AS asset_code, ass.name AS asset_name, ass.price,
+         cat.id AS category_id, cat.code AS category_code, cat.name AS category_name,
+         vnd.id AS vendor_id, vnd.code AS vendor_code, vnd.name AS vendor_name,
+         (LEAST(COALESCE(ass.disposal_date, @end::date), @end::date) - GREATEST(ass.input_date, @start::date) + 1) * 24 AS operating_hours
+  FROM assets ass
+  LEFT JOIN categories cat ON cat.id = ass.category_id
+  LEFT JOIN purchase_order_lines pol ON pol.id = ass.purchase_order_line_id
+  LEFT JOIN purchase_orders po ON po.id = pol.purchase_order_id
+  LEFT JOIN vendors vnd ON vnd.id = po.vendor_id
+  WHERE ass.deleted_at IS NULL
+    AND ass.input_date <= @end
+    AND (ass.disposal_date IS NULL OR ass.disposal_date >= @start)
+    AND (@asset = '' OR ass.id::text = @asset)
+    AND (@category = '' OR cat.id::text = @category)
+    AND (@vendor = '' OR vnd.id::text = @vendor)
+), maintenance AS (
+  SELECT ma.asset_id,
+         COUNT(*) FILTER (WHERE wo.maintenance_plan_id IS NULL) AS failures,
+         SUM(ma.total_amount) AS maintenance_cost
+  FROM maintenance_assets ma
+  LEFT JOIN work_orders wo ON wo.maintenance_asset_id = ma.id AND wo.deleted_at IS NULL
+  WHERE ma.deleted_at IS NULL AND ma.date BETWEEN @start AND @end
+  GROUP BY ma.asset_id
+, held AS (
+  SELECT wo.asset_id,
+         GREATEST(COALESCE(wo.scheduled_at, wo.started_at), @start::date) AS held_from,
+         LEAST(COALESCE(wo.completed_at, wo.cancelled_at, NOW()), @end::date + INTERVAL '1 day') AS held_until
+  FROM work_orders wo
+  WHERE wo.deleted_at IS NULL
+    AND COALESCE(wo.scheduled_at, wo.started_at) < @end::date + INTERVAL '1 day'
+    AND COALESCE(wo.completed_at, wo.cancelled_at, NOW()) >= @start::date
+), held_island AS (
+  SELECT h.asset_id, h.held_from, h.held_until,
+         SUM(CASE WHEN h.held_from <= h.previous_until THEN 0 ELSE 1 END)
+           OVER (PARTITION BY h.asset_id ORDER BY h.held_from, h.held_until) AS island
+  FROM (
+    SELECT held.*,
+           MAX(held.held_until) OVER (PARTITION BY held.asset_id ORDER BY held.held_from, held.held_until
+             ROWS BETWEEN UNBOUNDED PRECEDING AND 1 PRECEDING) AS previous_until
+    FROM held
+  ) h
+), downtime AS (
+  SELECT i.asset_id, SUM(EXTRACT(EPOCH FROM i.held_until - i.held_from) / 3600) AS downtime_hours
+  FROM (
+    SELECT asset_id, MIN(held_from) AS held_from, MAX(held_until) AS held_until
+    FROM held_island
+    GROUP BY asset_id, island
+  ) i
+  GROUP BY i.asset_id
+), repair AS (
+  SELECT wo.asset_id,
+         SUM(EXTRACT(EPOCH FROM wo.completed_at - COALESCE(wo.started_at, wo.created_at)) / 3600) AS repair_hours,
+         COUNT(*) AS repairs
+  FROM work_orders wo
+  WHERE wo.deleted_at IS NULL
+    AND wo.maintenance_plan_id IS NULL
+    AND wo.completed_at IS NOT NULL
+    AND wo.created_at < @end::date + INTERVAL '1 day'
+    AND wo.completed_at >= @start::date
+  GROUP BY wo.asset_id
+)
+SELECT b.*,
+       COALESCE(m.failures, 0) AS failures,
+       COALESCE(m.maintenance_cost, 0) AS maintenance_cost,
+       LEAST(COALESCE(d.downtime_hours, 0), b.operating_hours) AS downtime_hours,
+       COALESCE(r.repair_hours, 0) AS repair_hours,
+       COALESCE(r.repairs, 0) AS repairs
+FROM base b
+LEFT JOIN maintenance m ON m.asset_id = b.asset_id
+LEFT JOIN downtime d ON d.asset_id = b.asset_id
+LEFT JOIN repair r ON r.asset_id = b.asset_id
+ORDER BY b.asset_code`, args).Scan(&assets).Error
+	if err != nil {
+		return res, app.Error().New(http.StatusInternalServerError, err.Error())
+	}
+
+	res = ViewData{
+		CreatedAt: time.Now().Format("2006-01-02 15:04:05"),
+		StartDate: args["start"].(string),
+		EndDate:   args["end"].(string),
+		GroupBy:   groupBy,
+		Rows:      []Reliability{},
+	}
+
+	// sum up the assets into their group, the repair time is kept aside for the mean time to repair
+	keys, groups, repairs := []string{}, map[string]*Reliability{}, map[string][2]float64{}
+	for _, a := range assets {
+		id, code, name := a.AssetID, a.AssetCode, a.AssetName
+		if groupBy == GroupCategory {
+			id, code, name = a.CategoryID, a.CategoryCode, a.CategoryName
+		} else if groupBy == GroupVendor {
+			id, code, name = a.VendorID, a.VendorCode, a.VendorName
+		}
+		g, ok := groups[id.String]
+		if !ok {
+			g = &Reliability{ID: id, Code: code, Name: name}
+			keys = append(keys, id.String)
+			groups[id.String] = g
+		}
+		g.AssetCount.Set(g.AssetCount.Int64 + 1)
+		g.Failures.Set(g.Failures.Int64 + a.Failures.Int64)
+		g.OperatingHours.Set(g.OperatingHours.Float64 + a.OperatingHours.Float64)
+		g.DowntimeHours.Set(g.DowntimeHours.Float64 + a.DowntimeHours.Float64)
+		g.MaintenanceCost.Set(g.MaintenanceCost.Float64 + a.MaintenanceCost.Float64)
+		g.PurchasePrice.Set(g.PurchasePrice.Float64 + a.Price.Float64)
+		r := repairs[id.String]
+		repairs[id.String] = [2]float64{r[0] + a.RepairHours.Float64, r[1] + float64(a.Repairs.Int64)}
+	}
+	for _, key := range keys {
+		g := groups[key]
+		g.MTBFHours.Set(0)
+		if g.Failures.Int64 > 0 {
+			g.MTBFHours.Set(math.Max(g.OperatingHours.Float64-g.DowntimeHours.Float64, 0) / float64(g.Failures.Int64))
+		}
+		g.MTTRHours.Set(0)
+		if r := repairs[key]; r[1] > 0 {
+			g.MTTRHours.Set(r[0] / r[1])
+		}
+		g.CostPercentage.Set(0)
+		if g.PurchasePrice.Float64 > 0 {
+			g.CostPercentage.Set(g.MaintenanceCost.Float64 / g.PurchasePrice.Float64 * 100)
+		}
+		res.Rows = append(res.Rows, *g)
+		res.TotalFailures += g.Failures.Int64
+		res.TotalDowntimeHours += g.DowntimeHours.Float64
+		res.TotalMaintenanceCost += g.MaintenanceCost.Float64
+	}
+	if groupBy != GroupAsset {
+		sort.SliceStable(res.Rows, func(i, j int) bool { return res.Rows[i].Code.String < res.Rows[j].Code.String })
+	}
+
+	return res, nil
+}
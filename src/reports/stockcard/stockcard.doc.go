@@ -0,0 +1,2 @@
+// stockcard is a package related to stockcard data.
+package stockcard
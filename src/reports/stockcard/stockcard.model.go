@@ -0,0 +1,126 @@
+package stockcard
+
+import "github.com/maulanar/go_asset_tracking_management/app"
+
+// StockCard is the main model of StockCard data. It provides a convenient interface for app.ModelInterface
+type StockCard struct {
+	app.Model
+	ID          app.NullUUID    `json:"id"          db:"id"          gorm:"column:id"`
+	Date        app.NullDate    `json:"date"        db:"date"        gorm:"column:date"`
+	Code        app.NullString  `json:"code"        db:"code"        gorm:"column:code"`
+	Type        app.NullString  `json:"type"        db:"type"        gorm:"column:type"`
+	Description app.NullText    `json:"description" db:"description" gorm:"column:description"`
+	QtyIn       app.NullFloat64 `json:"qty_in"      db:"qty_in"      gorm:"column:qty_in"`
+	QtyOut      app.NullFloat64 `json:"qty_out"     db:"qty_out"     gorm:"column:qty_out"`
+	Balance     app.NullFloat64 `json:"balance"     db:"balance"     gorm:"-"`
+}
+
+type ViewData struct {
+	CreatedAt      string
+	ConsumableCode string
+	ConsumableName string
+	Unit           string
+	BranchName     string
+	StartDate      string
+	EndDate        string
+	OpeningBalance float64
+	Rows           []StockCard
+	TotalIn        float64
+	TotalOut       float64
+	ClosingBalance float64
+}
+
+// EndPoint returns the StockCard end point, it used for cache key, etc.
+func (StockCard) EndPoint() string {
+	return "stock_cards"
+}
+
+// TableVersion returns the versions of the StockCard table in the database.
+// Change this value with date format YY.MM.DDHHii when any table structure changes.
+func (StockCard) TableVersion() string {
+	return "28.06.291152"
+}
+
+// TableName returns the name of the StockCard table in the database.
+func (StockCard) TableName() string {
+	return "stock_cards"
+}
+
+// TableAliasName returns the table alias name of the StockCard table, used for querying.
+func (StockCard) TableAliasName() string {
+	return "m"
+}
+
+// GetRelations returns the relations of the StockCard data in the database, used for querying.
+func (m *StockCard) GetRelations() map[string]map[string]any {
+	// m.AddRelation("left", "users", "cu", []map[string]any{{"column1": "cu.id", "column2": "m.created_by_user_id"}})
+	// m.AddRelation("left", "users", "uu", []map[string]any{{"column1": "uu.id", "column2": "m.updated_by_user_id"}})
+	return m.Relations
+}
+
+// GetFilters returns the filter of the StockCard data in the database, used for querying.
+func (m *StockCard) GetFilters() []map[string]any {
+	m.AddFilter(map[string]any{"column1": "m.deleted_at", "operator": "=", "value": nil})
+	return m.Filters
+}
+
+// GetSorts returns the default sort of the StockCard data in the database, used for querying.
+func (m *StockCard) GetSorts() []map[string]any {
+	m.AddSort(map[string]any{"column": "m.updated_at", "direction": "desc"})
+	return m.Sorts
+}
+
+// GetFields returns list of the field of the StockCard data in the database, used for querying.
+func (m *StockCard) GetFields() map[string]map[string]any {
+	m.SetFields(m)
+	return m.Fields
+}
+
+// GetSchema returns the StockCard schema, used for querying.
+func (m *StockCard) GetSchema() map[string]any {
+	return m.SetSchema(m)
+}
+
+// OpenAPISchemaName returns the name of the StockCard schema in the open api documentation.
+func (StockCard) OpenAPISchemaName() string {
+	return "StockCard"
+}
+
+// GetOpenAPISchema returns the Open API Schema of the StockCard in the open api documentation.
+func (m *StockCard) GetOpenAPISchema() map[string]any {
+	return m.SetOpenAPISchema(m)
+}
+
+type StockCardList struct {
+	app.ListModel
+}
+
+// OpenAPISchemaName returns the name of the StockCardList schema in the open api documentation.
+func (StockCardList) OpenAPISchemaName() string {
+	return "StockCardList"
+}
+
+// GetOpenAPISchema returns the Open API Schema of the StockCardList in the open api documentation.
+func (p *StockCardList) GetOpenAPISchema() map[string]any {
+	return p.SetOpenAPISchema(&StockCard{})
+}
+
+// ParamCreate is the expected parameters for create a new StockCard data.
+type ParamCreate struct {
+	UseCaseHandler
+}
+
+// ParamUpdate is the expected parameters for update the StockCard data.
+type ParamUpdate struct {
+	UseCaseHandler
+}
+
+// ParamPartiallyUpdate is the expected parameters for partially update the StockCard data.
+type ParamPartiallyUpdate struct {
+	UseCaseHandler
+}
+
+// ParamDelete is the expected parameters for delete the StockCard data.
+type ParamDelete struct {
+	UseCaseHandler
+}
@@ -0,0 +1,56 @@
+package stockcard
+
+import "github.com/maulanar/go_asset_tracking_management/app"
+
+// OpenAPI is constructor for *openAPI, to autogenerate open api document.
+func OpenAPI() *OpenAPIOperation {
+	return &OpenAPIOperation{}
+}
+
+// OpenAPIOperation embed from app.OpenAPIOperation for simplicity, used for autogenerate open api document.
+type OpenAPIOperation struct {
+	app.OpenAPIOperation
+}
+
+// Base is common detail of stock_cards open api document component.
+func (o *OpenAPIOperation) Base() {
+	o.Tags = []string{"StockCard"}
+	o.HeaderParams = []map[string]any{{"$ref": "#/components/parameters/headerParam.Accept-Language"}}
+	o.Responses = map[string]map[string]any{
+		"200": {
+			"description": "Success",
+			"content":     map[string]any{"application/json": &StockCard{}}, // will auto create schema $ref: '#/components/schemas/StockCard' if not exists
+		},
+		"400": app.OpenAPIError().BadRequest(),
+		"401": app.OpenAPIError().Unauthorized(),
+		"403": app.OpenAPIError().Forbidden(),
+	}
+	o.Securities = []map[string][]string{}
+}
+
+// Get is detail of `GET /api/v3/stock_cards` open api document component.
+func (o *OpenAPIOperation) Get() *OpenAPIOperation {
+	if !app.IS_GENERATE_OPEN_API_DOC {
+		return o // skip for efficiency
+	}
+
+	o.Base()
+	o.Summary = "Get Report Stock Card"
+	o.Description = "Use this method to get Report Stock Card of a consumable on a branch"
+	o.QueryParams = []map[string]any{
+		{"name": "consumable.id", "in": "query", "required": true, "schema": map[string]any{"type": "string", "format": "uuid"}},
+		{"name": "branch.id", "in": "query", "required": true, "schema": map[string]any{"type": "string", "format": "uuid"}},
+		{"name": "start_date", "in": "query", "description": "Default to the first day of the current month.", "schema": map[string]any{"type": "string", "format": "date"}},
+		{"name": "end_date", "in": "query", "description": "Default to today.", "schema": map[string]any{"type": "string", "format": "date"}},
+	}
+	o.Responses = map[string]map[string]any{
+		"200": {
+			"description": "Success",
+			"content":     map[string]any{"text/html": &StockCardList{}}, // will auto create schema $ref: '#/components/schemas/StockCard.List' if not exists
+		},
+		"400": app.OpenAPIError().BadRequest(),
+		"401": app.OpenAPIError().Unauthorized(),
+		"403": app.OpenAPIError().Forbidden(),
+	}
+	return o
+}
@@ -0,0 +1,144 @@
+package stockcard
+
+import (
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/maulanar/go_asset_tracking_management/app"
+	"github.com/maulanar/go_asset_tracking_management/src/branch"
+	"github.com/maulanar/go_asset_tracking_management/src/consumable"
+)
+
+// UseCase returns a UseCaseHandler for expected use case functional.
+func UseCase(ctx app.Ctx, query ...url.Values) UseCaseHandler {
+	u := UseCaseHandler{
+		Ctx:   &ctx,
+		Query: url.Values{},
+	}
+	if len(query) > 0 {
+		u.Query = query[0]
+	}
+	return u
+}
+
+// UseCaseHandler provides a convenient interface for StockCard use case, use UseCase to access UseCaseHandler.
+type UseCaseHandler struct {
+	StockCard
+
+	// injectable dependencies
+	Ctx   *app.Ctx   `json:"-" db:"-" gorm:"-"`
+	Query url.Values `json:"-" db:"-" gorm:"-"`
+}
+
+// Async return UseCaseHandler with async process.
+func (u UseCaseHandler) Async(ctx app.Ctx, query ...url.Values) UseCaseHandler {
+	ctx.IsAsync = true
+	return UseCase(ctx, query...)
+}
+
+// Get returns the stock card of a consumable on a branch.
+func (u UseCaseHandler) Get() (res ViewData, err error) {
+
+	// check permission
+	err = u.Ctx.ValidatePermission("stock_cards.list")
+	if err != nil {
+		return res, err
+	}
+
+	// prepare db for current ctx
+	tx, err := u.Ctx.DB()
+	if err != nil {
+		return res, app.Error().New(http.StatusInternalServerError, err.Error())
+	}
+
+	// parsing filter
+	for _, key := range []string{"consumable.id", "branch.id"} {
+		if u.Query.Get(key) == "" {
+			return res, app.Error().New(http.StatusBadRequest, u.Ctx.Trans("required_key", map[string]string{"key": key}))
+		}
+	}
+	cns, err := consumable.UseCase(*u.Ctx, url.Values{}).GetByID(u.Query.Get("consumable.id"))
+	if err != nil {
+		return res, err
+	}
+	brc, err := branch.UseCase(*u.Ctx, url.Values{}).GetByID(u.Query.Get("branch.id"))
+	if err != nil {
+		return res, err
+	}
+	now := time.Now()
+	startDate := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, now.Location())
+	endDate := now
+	if v := u.Query.Get("start_date"); v != "" {
+		startDate, err = time.Parse("2006-01-02", v)
+		if err != nil {
+			return res, app.Error().New(http.StatusBadRequest, err.Error())
+		}
+	}
+	if v := u.Query.Get("end_date"); v != "" {
+		endDate, err = time.Parse("2006-01-02", v)
+		if err != nil {
+			return res, app.Error().New(http.StatusBadRequest, err.Error())
+		}
+	}
+
+	// mutasi per branch, transfer menjadi keluar di branch asal dan masuk di branch tujuan
+	movements := `
+WITH mv AS (
+  SELECT sm.id, sm.date, sm.code, sm.type, sm.description, sm.created_at, sm.branch_id,
+         CASE WHEN sm.type IN ('issue', 'transfer') THEN -sm.qty ELSE sm.qty END AS delta
+  FROM stock_movements sm
+  WHERE sm.deleted_at IS NULL AND sm.consumable_id = @consumable
+  UNION ALL
+  SELECT sm.id, sm.date, sm.code, sm.type, sm.description, sm.created_at, sm.to_branch_id, sm.qty
+  FROM stock_movements sm
+  WHERE sm.deleted_at IS NULL AND sm.consumable_id = @consumable AND sm.type = 'transfer'
+)`
+	args := map[string]any{
+		"consumable": cns.ID.String,
+		"branch":     brc.ID.String,
+		"start":      startDate.Format("2006-01-02"),
+		"end":        endDate.Format("2006-01-02"),
+	}
+
+	opening := float64(0)
+	err = tx.Raw(movements+`
+SELECT COALESCE(SUM(delta), 0) FROM mv WHERE branch_id = @branch AND date < @start`, args).Scan(&opening).Error
+	if err != nil {
+		return res, app.Error().New(http.StatusInternalServerError, err.Error())
+	}
+
+	rows := []StockCard{}
+	err = tx.Raw(movements+`
+SELECT id, date, code, type, description,
+       CASE WHEN delta > 0 THEN delta ELSE 0 END AS qty_in,
+       CASE WHEN delta < 0 THEN -delta ELSE 0 END AS qty_out
+FROM mv
+WHERE branch_id = @branch AND date BETWEEN @start AND @end
+ORDER BY date, created_at`, args).Scan(&rows).Error
+	if err != nil {
+		return res, app.Error().New(http.StatusInternalServerError, err.Error())
+	}
+
+	res = ViewData{
+		CreatedAt:      time.Now().Format("2006-01-02 15:04:05"),
+		ConsumableCode: cns.Code.String,
+		ConsumableName: cns.Name.String,
+		Unit:           cns.Unit.String,
+		BranchName:     brc.Name.String,
+		StartDate:      startDate.Format("2006-01-02"),
+		EndDate:        endDate.Format("2006-01-02"),
+		OpeningBalance: opening,
+	}
+	balance := opening
+	for i := range rows {
+		balance += rows[i].QtyIn.Float64 - rows[i].QtyOut.Float64
+		rows[i].Balance.Set(balance)
+		res.TotalIn += rows[i].QtyIn.Float64
+		res.TotalOut += rows[i].QtyOut.Float64
+	}
+	res.Rows = rows
+	res.ClosingBalance = balance
+
+	return res, nil
+}
@@ -3,20 +3,49 @@ package src
 import (
 	"github.com/maulanar/go_asset_tracking_management/app"
 	"github.com/maulanar/go_asset_tracking_management/src/asset"
+	"github.com/maulanar/go_asset_tracking_management/src/assetrevaluation"
 	"github.com/maulanar/go_asset_tracking_management/src/attachment"
 	"github.com/maulanar/go_asset_tracking_management/src/branch"
 	"github.com/maulanar/go_asset_tracking_management/src/category"
 	"github.com/maulanar/go_asset_tracking_management/src/condition"
+	"github.com/maulanar/go_asset_tracking_management/src/consumable"
+	"github.com/maulanar/go_asset_tracking_management/src/consumablestock"
 	"github.com/maulanar/go_asset_tracking_management/src/department"
+	"github.com/maulanar/go_asset_tracking_management/src/depreciationentry"
 	"github.com/maulanar/go_asset_tracking_management/src/employee"
 	"github.com/maulanar/go_asset_tracking_management/src/employeeasset"
+	"github.com/maulanar/go_asset_tracking_management/src/fiscalperiod"
+	"github.com/maulanar/go_asset_tracking_management/src/goodsreceipt"
+	"github.com/maulanar/go_asset_tracking_management/src/incident"
 	"github.com/maulanar/go_asset_tracking_management/src/jobposition"
+	"github.com/maulanar/go_asset_tracking_management/src/jobrun"
+	"github.com/maulanar/go_asset_tracking_management/src/liabilitycase"
+	"github.com/maulanar/go_asset_tracking_management/src/license"
+	"github.com/maulanar/go_asset_tracking_management/src/licenseseat"
+	"github.com/maulanar/go_asset_tracking_management/src/location"
 	"github.com/maulanar/go_asset_tracking_management/src/maintenanceasset"
+	"github.com/maulanar/go_asset_tracking_management/src/maintenanceplan"
 	"github.com/maulanar/go_asset_tracking_management/src/maintenancetype"
+	"github.com/maulanar/go_asset_tracking_management/src/meter"
+	"github.com/maulanar/go_asset_tracking_management/src/meterreading"
+	"github.com/maulanar/go_asset_tracking_management/src/notification"
+	"github.com/maulanar/go_asset_tracking_management/src/purchaseorder"
+	"github.com/maulanar/go_asset_tracking_management/src/purchaserequest"
 	"github.com/maulanar/go_asset_tracking_management/src/reports/assetcondition"
+	"github.com/maulanar/go_asset_tracking_management/src/reports/bookdifference"
 	"github.com/maulanar/go_asset_tracking_management/src/reports/distributionassetsperdepartment"
+	"github.com/maulanar/go_asset_tracking_management/src/reports/journal"
+	"github.com/maulanar/go_asset_tracking_management/src/reports/licensecompliance"
+	"github.com/maulanar/go_asset_tracking_management/src/reports/partsusage"
+	"github.com/maulanar/go_asset_tracking_management/src/reports/reliability"
+	"github.com/maulanar/go_asset_tracking_management/src/reports/stockcard"
 	"github.com/maulanar/go_asset_tracking_management/src/role"
+	"github.com/maulanar/go_asset_tracking_management/src/stockmovement"
+	"github.com/maulanar/go_asset_tracking_management/src/tag"
 	"github.com/maulanar/go_asset_tracking_management/src/user"
+	"github.com/maulanar/go_asset_tracking_management/src/vendor"
+	"github.com/maulanar/go_asset_tracking_management/src/warranty"
+	"github.com/maulanar/go_asset_tracking_management/src/workorder"
 	// import : DONT REMOVE THIS COMMENT
 )
 
@@ -91,6 +120,10 @@ func (r *routerUtil) Configure() {
 	app.Server().AddRoute("/api/v1/assets", "GET", asset.REST().Get, asset.OpenAPI().Get())
 	app.Server().AddRoute("/api/v1/assets/{id}", "GET", asset.REST().GetByID, asset.OpenAPI().GetByID())
 	app.Server().AddRoute("/api/v1/assets/{id}/depreciations", "GET", asset.REST().GetDepreciationByID, asset.OpenAPI().GetDepreciationByID())
+	app.Server().AddRoute("/api/v1/assets/{id}/attach", "POST", asset.REST().AttachByID, asset.OpenAPI().AttachByID())
+	app.Server().AddRoute("/api/v1/assets/{id}/detach", "POST", asset.REST().DetachByID, asset.OpenAPI().DetachByID())
+	app.Server().AddRoute("/api/v1/assets/tag", "POST", asset.REST().TagByIDs, asset.OpenAPI().TagByIDs())
+	app.Server().AddRoute("/api/v1/assets/untag", "POST", asset.REST().UntagByIDs, asset.OpenAPI().UntagByIDs())
 	app.Server().AddRoute("/api/v1/assets/{id}", "PUT", asset.REST().UpdateByID, asset.OpenAPI().UpdateByID())
 	app.Server().AddRoute("/api/v1/assets/{id}", "PATCH", asset.REST().PartiallyUpdateByID, asset.OpenAPI().PartiallyUpdateByID())
 	app.Server().AddRoute("/api/v1/assets/{id}", "DELETE", asset.REST().DeleteByID, asset.OpenAPI().DeleteByID())
@@ -131,5 +164,173 @@ func (r *routerUtil) Configure() {
 	app.Server().AddRoute("/api/v1/maintenance_assets/{id}", "PATCH", maintenanceasset.REST().PartiallyUpdateByID, maintenanceasset.OpenAPI().PartiallyUpdateByID())
 	app.Server().AddRoute("/api/v1/maintenance_assets/{id}", "DELETE", maintenanceasset.REST().DeleteByID, maintenanceasset.OpenAPI().DeleteByID())
 
+	app.Server().AddRoute("/api/v1/vendors", "POST", vendor.REST().Create, vendor.OpenAPI().Create())
+	app.Server().AddRoute("/api/v1/vendors", "GET", vendor.REST().Get, vendor.OpenAPI().Get())
+	app.Server().AddRoute("/api/v1/vendors/{id}", "GET", vendor.REST().GetByID, vendor.OpenAPI().GetByID())
+	app.Server().AddRoute("/api/v1/vendors/{id}", "PUT", vendor.REST().UpdateByID, vendor.OpenAPI().UpdateByID())
+	app.Server().AddRoute("/api/v1/vendors/{id}", "PATCH", vendor.REST().PartiallyUpdateByID, vendor.OpenAPI().PartiallyUpdateByID())
+	app.Server().AddRoute("/api/v1/vendors/{id}", "DELETE", vendor.REST().DeleteByID, vendor.OpenAPI().DeleteByID())
+
+	app.Server().AddRoute("/api/v1/purchase_requests", "POST", purchaserequest.REST().Create, purchaserequest.OpenAPI().Create())
+	app.Server().AddRoute("/api/v1/purchase_requests", "GET", purchaserequest.REST().Get, purchaserequest.OpenAPI().Get())
+	app.Server().AddRoute("/api/v1/purchase_requests/{id}", "GET", purchaserequest.REST().GetByID, purchaserequest.OpenAPI().GetByID())
+	app.Server().AddRoute("/api/v1/purchase_requests/{id}", "PUT", purchaserequest.REST().UpdateByID, purchaserequest.OpenAPI().UpdateByID())
+	app.Server().AddRoute("/api/v1/purchase_requests/{id}", "PATCH", purchaserequest.REST().PartiallyUpdateByID, purchaserequest.OpenAPI().PartiallyUpdateByID())
+	app.Server().AddRoute("/api/v1/purchase_requests/{id}", "DELETE", purchaserequest.REST().DeleteByID, purchaserequest.OpenAPI().DeleteByID())
+
+	app.Server().AddRoute("/api/v1/purchase_orders", "POST", purchaseorder.REST().Create, purchaseorder.OpenAPI().Create())
+	app.Server().AddRoute("/api/v1/purchase_orders", "GET", purchaseorder.REST().Get, purchaseorder.OpenAPI().Get())
+	app.Server().AddRoute("/api/v1/purchase_orders/{id}", "GET", purchaseorder.REST().GetByID, purchaseorder.OpenAPI().GetByID())
+	app.Server().AddRoute("/api/v1/purchase_orders/{id}", "PUT", purchaseorder.REST().UpdateByID, purchaseorder.OpenAPI().UpdateByID())
+	app.Server().AddRoute("/api/v1/purchase_orders/{id}", "PATCH", purchaseorder.REST().PartiallyUpdateByID, purchaseorder.OpenAPI().PartiallyUpdateByID())
+	app.Server().AddRoute("/api/v1/purchase_orders/{id}", "DELETE", purchaseorder.REST().DeleteByID, purchaseorder.OpenAPI().DeleteByID())
+
+	app.Server().AddRoute("/api/v1/goods_receipts", "POST", goodsreceipt.REST().Create, goodsreceipt.OpenAPI().Create())
+	app.Server().AddRoute("/api/v1/goods_receipts", "GET", goodsreceipt.REST().Get, goodsreceipt.OpenAPI().Get())
+	app.Server().AddRoute("/api/v1/goods_receipts/{id}", "GET", goodsreceipt.REST().GetByID, goodsreceipt.OpenAPI().GetByID())
+	app.Server().AddRoute("/api/v1/goods_receipts/{id}", "PATCH", goodsreceipt.REST().PartiallyUpdateByID, goodsreceipt.OpenAPI().PartiallyUpdateByID())
+
+	app.Server().AddRoute("/api/v1/warranties", "POST", warranty.REST().Create, warranty.OpenAPI().Create())
+	app.Server().AddRoute("/api/v1/warranties", "GET", warranty.REST().Get, warranty.OpenAPI().Get())
+	app.Server().AddRoute("/api/v1/warranties/{id}", "GET", warranty.REST().GetByID, warranty.OpenAPI().GetByID())
+	app.Server().AddRoute("/api/v1/warranties/{id}", "PUT", warranty.REST().UpdateByID, warranty.OpenAPI().UpdateByID())
+	app.Server().AddRoute("/api/v1/warranties/{id}", "PATCH", warranty.REST().PartiallyUpdateByID, warranty.OpenAPI().PartiallyUpdateByID())
+	app.Server().AddRoute("/api/v1/warranties/{id}", "DELETE", warranty.REST().DeleteByID, warranty.OpenAPI().DeleteByID())
+
+	app.Server().AddRoute("/api/v1/notifications", "GET", notification.REST().Get, notification.OpenAPI().Get())
+	app.Server().AddRoute("/api/v1/notifications/{id}", "GET", notification.REST().GetByID, notification.OpenAPI().GetByID())
+	app.Server().AddRoute("/api/v1/notifications/{id}", "PATCH", notification.REST().PartiallyUpdateByID, notification.OpenAPI().PartiallyUpdateByID())
+	app.Server().AddRoute("/api/v1/notifications/{id}", "DELETE", notification.REST().DeleteByID, notification.OpenAPI().DeleteByID())
+
+	app.Server().AddRoute("/api/v1/consumables", "POST", consumable.REST().Create, consumable.OpenAPI().Create())
+	app.Server().AddRoute("/api/v1/consumables", "GET", consumable.REST().Get, consumable.OpenAPI().Get())
+	app.Server().AddRoute("/api/v1/consumables/{id}", "GET", consumable.REST().GetByID, consumable.OpenAPI().GetByID())
+	app.Server().AddRoute("/api/v1/consumables/{id}", "PUT", consumable.REST().UpdateByID, consumable.OpenAPI().UpdateByID())
+	app.Server().AddRoute("/api/v1/consumables/{id}", "PATCH", consumable.REST().PartiallyUpdateByID, consumable.OpenAPI().PartiallyUpdateByID())
+	app.Server().AddRoute("/api/v1/consumables/{id}", "DELETE", consumable.REST().DeleteByID, consumable.OpenAPI().DeleteByID())
+
+	app.Server().AddRoute("/api/v1/consumable_stocks", "GET", consumablestock.REST().Get, consumablestock.OpenAPI().Get())
+	app.Server().AddRoute("/api/v1/consumable_stocks/{id}", "GET", consumablestock.REST().GetByID, consumablestock.OpenAPI().GetByID())
+	app.Server().AddRoute("/api/v1/consumable_stocks/{id}", "PATCH", consumablestock.REST().PartiallyUpdateByID, consumablestock.OpenAPI().PartiallyUpdateByID())
+
+	app.Server().AddRoute("/api/v1/stock_movements", "POST", stockmovement.REST().Create, stockmovement.OpenAPI().Create())
+	app.Server().AddRoute("/api/v1/stock_movements", "GET", stockmovement.REST().Get, stockmovement.OpenAPI().Get())
+	app.Server().AddRoute("/api/v1/stock_movements/{id}", "GET", stockmovement.REST().GetByID, stockmovement.OpenAPI().GetByID())
+	app.Server().AddRoute("/api/v1/stock_movements/{id}", "PATCH", stockmovement.REST().PartiallyUpdateByID, stockmovement.OpenAPI().PartiallyUpdateByID())
+
+	app.Server().AddRoute("/api/v1/reports/stock_cards", "GET", stockcard.REST().Get, stockcard.OpenAPI().Get())
+
+	app.Server().AddRoute("/api/v1/licenses", "POST", license.REST().Create, license.OpenAPI().Create())
+	app.Server().AddRoute("/api/v1/licenses", "GET", license.REST().Get, license.OpenAPI().Get())
+	app.Server().AddRoute("/api/v1/licenses/{id}", "GET", license.REST().GetByID, license.OpenAPI().GetByID())
+	app.Server().AddRoute("/api/v1/licenses/{id}", "PUT", license.REST().UpdateByID, license.OpenAPI().UpdateByID())
+	app.Server().AddRoute("/api/v1/licenses/{id}", "PATCH", license.REST().PartiallyUpdateByID, license.OpenAPI().PartiallyUpdateByID())
+	app.Server().AddRoute("/api/v1/licenses/{id}", "DELETE", license.REST().DeleteByID, license.OpenAPI().DeleteByID())
+	app.Server().AddRoute("/api/v1/licenses/{id}/key", "GET", license.REST().GetKeyByID, license.OpenAPI().GetKeyByID())
+
+	app.Server().AddRoute("/api/v1/license_seats", "POST", licenseseat.REST().Create, licenseseat.OpenAPI().Create())
+	app.Server().AddRoute("/api/v1/license_seats", "GET", licenseseat.REST().Get, licenseseat.OpenAPI().Get())
+	app.Server().AddRoute("/api/v1/license_seats/{id}", "GET", licenseseat.REST().GetByID, licenseseat.OpenAPI().GetByID())
+	app.Server().AddRoute("/api/v1/license_seats/{id}", "PUT", licenseseat.REST().UpdateByID, licenseseat.OpenAPI().UpdateByID())
+	app.Server().AddRoute("/api/v1/license_seats/{id}", "PATCH", licenseseat.REST().PartiallyUpdateByID, licenseseat.OpenAPI().PartiallyUpdateByID())
+	app.Server().AddRoute("/api/v1/license_seats/{id}", "DELETE", licenseseat.REST().DeleteByID, licenseseat.OpenAPI().DeleteByID())
+
+	app.Server().AddRoute("/api/v1/reports/license_compliances", "GET", licensecompliance.REST().Get, licensecompliance.OpenAPI().Get())
+
+	app.Server().AddRoute("/api/v1/locations", "POST", location.REST().Create, location.OpenAPI().Create())
+	app.Server().AddRoute("/api/v1/locations", "GET", location.REST().Get, location.OpenAPI().Get())
+	app.Server().AddRoute("/api/v1/locations/{id}", "GET", location.REST().GetByID, location.OpenAPI().GetByID())
+	app.Server().AddRoute("/api/v1/locations/{id}", "PUT", location.REST().UpdateByID, location.OpenAPI().UpdateByID())
+	app.Server().AddRoute("/api/v1/locations/{id}", "PATCH", location.REST().PartiallyUpdateByID, location.OpenAPI().PartiallyUpdateByID())
+	app.Server().AddRoute("/api/v1/locations/{id}", "DELETE", location.REST().DeleteByID, location.OpenAPI().DeleteByID())
+
+	app.Server().AddRoute("/api/v1/tags", "POST", tag.REST().Create, tag.OpenAPI().Create())
+	app.Server().AddRoute("/api/v1/tags", "GET", tag.REST().Get, tag.OpenAPI().Get())
+	app.Server().AddRoute("/api/v1/tags/{id}", "GET", tag.REST().GetByID, tag.OpenAPI().GetByID())
+	app.Server().AddRoute("/api/v1/tags/{id}", "PUT", tag.REST().UpdateByID, tag.OpenAPI().UpdateByID())
+	app.Server().AddRoute("/api/v1/tags/{id}", "PATCH", tag.REST().PartiallyUpdateByID, tag.OpenAPI().PartiallyUpdateByID())
+	app.Server().AddRoute("/api/v1/tags/{id}", "DELETE", tag.REST().DeleteByID, tag.OpenAPI().DeleteByID())
+
+	app.Server().AddRoute("/api/v1/depreciation_entries", "GET", depreciationentry.REST().Get, depreciationentry.OpenAPI().Get())
+	app.Server().AddRoute("/api/v1/depreciation_entries/{id}", "GET", depreciationentry.REST().GetByID, depreciationentry.OpenAPI().GetByID())
+
+	app.Server().AddRoute("/api/v1/fiscal_periods", "POST", fiscalperiod.REST().Create, fiscalperiod.OpenAPI().Create())
+	app.Server().AddRoute("/api/v1/fiscal_periods", "GET", fiscalperiod.REST().Get, fiscalperiod.OpenAPI().Get())
+	app.Server().AddRoute("/api/v1/fiscal_periods/{id}", "GET", fiscalperiod.REST().GetByID, fiscalperiod.OpenAPI().GetByID())
+	app.Server().AddRoute("/api/v1/fiscal_periods/{id}", "PUT", fiscalperiod.REST().UpdateByID, fiscalperiod.OpenAPI().UpdateByID())
+	app.Server().AddRoute("/api/v1/fiscal_periods/{id}", "PATCH", fiscalperiod.REST().PartiallyUpdateByID, fiscalperiod.OpenAPI().PartiallyUpdateByID())
+	app.Server().AddRoute("/api/v1/fiscal_periods/{id}", "DELETE", fiscalperiod.REST().DeleteByID, fiscalperiod.OpenAPI().DeleteByID())
+	app.Server().AddRoute("/api/v1/fiscal_periods/{id}/close", "POST", fiscalperiod.REST().CloseByID, fiscalperiod.OpenAPI().CloseByID())
+	app.Server().AddRoute("/api/v1/fiscal_periods/{id}/reopen", "POST", fiscalperiod.REST().ReopenByID, fiscalperiod.OpenAPI().ReopenByID())
+
+	app.Server().AddRoute("/api/v1/reports/journals", "GET", journal.REST().Get, journal.OpenAPI().Get())
+
+	app.Server().AddRoute("/api/v1/asset_revaluations", "POST", assetrevaluation.REST().Create, assetrevaluation.OpenAPI().Create())
+	app.Server().AddRoute("/api/v1/asset_revaluations", "GET", assetrevaluation.REST().Get, assetrevaluation.OpenAPI().Get())
+	app.Server().AddRoute("/api/v1/asset_revaluations/{id}", "GET", assetrevaluation.REST().GetByID, assetrevaluation.OpenAPI().GetByID())
+	app.Server().AddRoute("/api/v1/asset_revaluations/{id}", "PUT", assetrevaluation.REST().UpdateByID, assetrevaluation.OpenAPI().UpdateByID())
+	app.Server().AddRoute("/api/v1/asset_revaluations/{id}", "PATCH", assetrevaluation.REST().PartiallyUpdateByID, assetrevaluation.OpenAPI().PartiallyUpdateByID())
+	app.Server().AddRoute("/api/v1/asset_revaluations/{id}", "DELETE", assetrevaluation.REST().DeleteByID, assetrevaluation.OpenAPI().DeleteByID())
+
+	app.Server().AddRoute("/api/v1/reports/book_differences", "GET", bookdifference.REST().Get, bookdifference.OpenAPI().Get())
+	app.Server().AddRoute("/api/v1/reports/parts_usages", "GET", partsusage.REST().Get, partsusage.OpenAPI().Get())
+	app.Server().AddRoute("/api/v1/reports/reliabilities", "GET", reliability.REST().Get, reliability.OpenAPI().Get())
+
+	app.Server().AddRoute("/api/v1/job_runs", "GET", jobrun.REST().Get, jobrun.OpenAPI().Get())
+	app.Server().AddRoute("/api/v1/job_runs/{id}", "GET", jobrun.REST().GetByID, jobrun.OpenAPI().GetByID())
+
+	app.Server().AddRoute("/api/v1/maintenance_plans", "POST", maintenanceplan.REST().Create, maintenanceplan.OpenAPI().Create())
+	app.Server().AddRoute("/api/v1/maintenance_plans", "GET", maintenanceplan.REST().Get, maintenanceplan.OpenAPI().Get())
+	app.Server().AddRoute("/api/v1/maintenance_plans/{id}", "GET", maintenanceplan.REST().GetByID, maintenanceplan.OpenAPI().GetByID())
+	app.Server().AddRoute("/api/v1/maintenance_plans/{id}", "PUT", maintenanceplan.REST().UpdateByID, maintenanceplan.OpenAPI().UpdateByID())
+	app.Server().AddRoute("/api/v1/maintenance_plans/{id}", "PATCH", maintenanceplan.REST().PartiallyUpdateByID, maintenanceplan.OpenAPI().PartiallyUpdateByID())
+	app.Server().AddRoute("/api/v1/maintenance_plans/{id}", "DELETE", maintenanceplan.REST().DeleteByID, maintenanceplan.OpenAPI().DeleteByID())
+
+	app.Server().AddRoute("/api/v1/work_orders", "POST", workorder.REST().Create, workorder.OpenAPI().Create())
+	app.Server().AddRoute("/api/v1/work_orders", "GET", workorder.REST().Get, workorder.OpenAPI().Get())
+	app.Server().AddRoute("/api/v1/work_orders/{id}", "GET", workorder.REST().GetByID, workorder.OpenAPI().GetByID())
+	app.Server().AddRoute("/api/v1/work_orders/{id}", "PUT", workorder.REST().UpdateByID, workorder.OpenAPI().UpdateByID())
+	app.Server().AddRoute("/api/v1/work_orders/{id}", "PATCH", workorder.REST().PartiallyUpdateByID, workorder.OpenAPI().PartiallyUpdateByID())
+	app.Server().AddRoute("/api/v1/work_orders/{id}", "DELETE", workorder.REST().DeleteByID, workorder.OpenAPI().DeleteByID())
+	app.Server().AddRoute("/api/v1/work_orders/{id}/schedule", "POST", workorder.REST().ScheduleByID, workorder.OpenAPI().ScheduleByID())
+	app.Server().AddRoute("/api/v1/work_orders/{id}/start", "POST", workorder.REST().StartByID, workorder.OpenAPI().StartByID())
+	app.Server().AddRoute("/api/v1/work_orders/{id}/hold", "POST", workorder.REST().HoldByID, workorder.OpenAPI().HoldByID())
+	app.Server().AddRoute("/api/v1/work_orders/{id}/cancel", "POST", workorder.REST().CancelByID, workorder.OpenAPI().CancelByID())
+	app.Server().AddRoute("/api/v1/work_orders/{id}/complete", "POST", workorder.REST().CompleteByID, workorder.OpenAPI().CompleteByID())
+
+	app.Server().AddRoute("/api/v1/meters", "POST", meter.REST().Create, meter.OpenAPI().Create())
+	app.Server().AddRoute("/api/v1/meters", "GET", meter.REST().Get, meter.OpenAPI().Get())
+	app.Server().AddRoute("/api/v1/meters/{id}", "GET", meter.REST().GetByID, meter.OpenAPI().GetByID())
+	app.Server().AddRoute("/api/v1/meters/{id}", "PUT", meter.REST().UpdateByID, meter.OpenAPI().UpdateByID())
+	app.Server().AddRoute("/api/v1/meters/{id}", "PATCH", meter.REST().PartiallyUpdateByID, meter.OpenAPI().PartiallyUpdateByID())
+	app.Server().AddRoute("/api/v1/meters/{id}", "DELETE", meter.REST().DeleteByID, meter.OpenAPI().DeleteByID())
+
+	app.Server().AddRoute("/api/v1/meter_readings", "POST", meterreading.REST().Create, meterreading.OpenAPI().Create())
+	app.Server().AddRoute("/api/v1/meter_readings", "GET", meterreading.REST().Get, meterreading.OpenAPI().Get())
+	app.Server().AddRoute("/api/v1/meter_readings/{id}", "GET", meterreading.REST().GetByID, meterreading.OpenAPI().GetByID())
+	app.Server().AddRoute("/api/v1/meter_readings/{id}", "PUT", meterreading.REST().UpdateByID, meterreading.OpenAPI().UpdateByID())
+	app.Server().AddRoute("/api/v1/meter_readings/{id}", "PATCH", meterreading.REST().PartiallyUpdateByID, meterreading.OpenAPI().PartiallyUpdateByID())
+	app.Server().AddRoute("/api/v1/meter_readings/{id}", "DELETE", meterreading.REST().DeleteByID, meterreading.OpenAPI().DeleteByID())
+
+	app.Server().AddRoute("/api/v1/incidents", "POST", incident.REST().Create, incident.OpenAPI().Create())
+	app.Server().AddRoute("/api/v1/incidents", "GET", incident.REST().Get, incident.OpenAPI().Get())
+	app.Server().AddRoute("/api/v1/incidents/{id}", "GET", incident.REST().GetByID, incident.OpenAPI().GetByID())
+	app.Server().AddRoute("/api/v1/incidents/{id}", "PUT", incident.REST().UpdateByID, incident.OpenAPI().UpdateByID())
+	app.Server().AddRoute("/api/v1/incidents/{id}", "PATCH", incident.REST().PartiallyUpdateByID, incident.OpenAPI().PartiallyUpdateByID())
+	app.Server().AddRoute("/api/v1/incidents/{id}", "DELETE", incident.REST().DeleteByID, incident.OpenAPI().DeleteByID())
+	app.Server().AddRoute("/api/v1/incidents/{id}/triage", "POST", incident.REST().TriageByID, incident.OpenAPI().TriageByID())
+	app.Server().AddRoute("/api/v1/incidents/{id}/convert", "POST", incident.REST().ConvertByID, incident.OpenAPI().ConvertByID())
+
+	app.Server().AddRoute("/api/v1/liability_cases", "POST", liabilitycase.REST().Create, liabilitycase.OpenAPI().Create())
+	app.Server().AddRoute("/api/v1/liability_cases", "GET", liabilitycase.REST().Get, liabilitycase.OpenAPI().Get())
+	app.Server().AddRoute("/api/v1/liability_cases/{id}", "GET", liabilitycase.REST().GetByID, liabilitycase.OpenAPI().GetByID())
+	app.Server().AddRoute("/api/v1/liability_cases/{id}", "PUT", liabilitycase.REST().UpdateByID, liabilitycase.OpenAPI().UpdateByID())
+	app.Server().AddRoute("/api/v1/liability_cases/{id}", "PATCH", liabilitycase.REST().PartiallyUpdateByID, liabilitycase.OpenAPI().PartiallyUpdateByID())
+	app.Server().AddRoute("/api/v1/liability_cases/{id}", "DELETE", liabilitycase.REST().DeleteByID, liabilitycase.OpenAPI().DeleteByID())
+	app.Server().AddRoute("/api/v1/liability_cases/{id}/approve", "POST", liabilitycase.REST().ApproveByID, liabilitycase.OpenAPI().ApproveByID())
+	app.Server().AddRoute("/api/v1/liability_cases/{id}/reject", "POST", liabilitycase.REST().RejectByID, liabilitycase.OpenAPI().RejectByID())
+	app.Server().AddRoute("/api/v1/liability_cases/{id}/pay", "POST", liabilitycase.REST().PayByID, liabilitycase.OpenAPI().PayByID())
+	app.Server().AddRoute("/api/v1/liability_cases/{id}/statement", "GET", liabilitycase.REST().StatementByID, liabilitycase.OpenAPI().StatementByID())
+
 	// AddRoute : DONT REMOVE THIS COMMENT
 }
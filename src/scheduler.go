@@ -5,6 +5,10 @@ import (
 
 	"github.com/maulanar/go_asset_tracking_management/app"
 	"github.com/maulanar/go_asset_tracking_management/src/asset"
+	"github.com/maulanar/go_asset_tracking_management/src/license"
+	"github.com/maulanar/go_asset_tracking_management/src/maintenanceplan"
+	"github.com/maulanar/go_asset_tracking_management/src/warranty"
+	"github.com/maulanar/go_asset_tracking_management/src/workorder"
 )
 
 func Scheduler() *schedulerUtil {
@@ -28,9 +32,20 @@ func (s *schedulerUtil) Configure() {
 	c := cron.New()
 
 	// add scheduler func here, for example :
-	c.AddFunc("CRON_TZ=Asia/Jakarta */30 * * * *", func() {
+	c.AddFunc("CRON_TZ=Asia/Jakarta 0 1 1 * *", func() {
+		asset.JobPostDepreciation()
 		asset.JobUpdateAssetValue()
 	})
 
+	c.AddFunc("CRON_TZ=Asia/Jakarta 0 6 * * *", func() {
+		maintenanceplan.JobGenerateWorkOrders()
+		workorder.JobMarkOverdue()
+	})
+
+	c.AddFunc("CRON_TZ=Asia/Jakarta 0 7 * * *", func() {
+		warranty.JobNotifyExpiringWarranty()
+		license.JobNotifyExpiringLicense()
+	})
+
 	c.Start()
 }
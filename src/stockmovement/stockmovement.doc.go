@@ -0,0 +1,2 @@
+// stockmovement is a package related to stockmovement data.
+package stockmovement
@@ -0,0 +1,141 @@
+package stockmovement
+
+import "github.com/maulanar/go_asset_tracking_management/app"
+
+// StockMovement is the main model of StockMovement data. It provides a convenient interface for app.ModelInterface
+type StockMovement struct {
+	app.Model
+	ID          app.NullUUID    `json:"id"               db:"m.id"              gorm:"column:id;primaryKey"`
+	Code        app.NullString  `json:"code"             db:"m.code"            gorm:"column:code"`
+	Date        app.NullDate    `json:"date"             db:"m.date"            gorm:"column:date"`
+	Type        app.NullString  `json:"type"             db:"m.type"            gorm:"column:type"            validate:"omitempty,oneof=receipt issue adjustment transfer"`
+	Qty         app.NullFloat64 `json:"qty"              db:"m.qty"             gorm:"column:qty"`
+	Description app.NullText    `json:"description"      db:"m.description"     gorm:"column:description"`
+
+	ConsumableID   app.NullUUID   `json:"consumable.id"    db:"m.consumable_id"   gorm:"column:consumable_id"`
+	ConsumableCode app.NullString `json:"consumable.code"  db:"cns.code"          gorm:"-"`
+	ConsumableName app.NullString `json:"consumable.name"  db:"cns.name"          gorm:"-"`
+	ConsumableUnit app.NullString `json:"consumable.unit"  db:"cns.unit"          gorm:"-"`
+
+	BranchID   app.NullUUID   `json:"branch.id"        db:"m.branch_id"       gorm:"column:branch_id"`
+	BranchCode app.NullString `json:"branch.code"      db:"brc.code"          gorm:"-"`
+	BranchName app.NullString `json:"branch.name"      db:"brc.name"          gorm:"-"`
+
+	ToBranchID   app.NullUUID   `json:"to_branch.id"     db:"m.to_branch_id"    gorm:"column:to_branch_id"`
+	ToBranchCode app.NullString `json:"to_branch.code"   db:"to_brc.code"       gorm:"-"`
+	ToBranchName app.NullString `json:"to_branch.name"   db:"to_brc.name"       gorm:"-"`
+
+	EmployeeID   app.NullUUID   `json:"employee.id"      db:"m.employee_id"     gorm:"column:employee_id"`
+	EmployeeCode app.NullString `json:"employee.code"    db:"emp.code"          gorm:"-"`
+	EmployeeName app.NullString `json:"employee.name"    db:"emp.name"          gorm:"-"`
+
+	DepartmentID   app.NullUUID   `json:"department.id"    db:"m.department_id"   gorm:"column:department_id"`
+	DepartmentCode app.NullString `json:"department.code"  db:"dpt.code"          gorm:"-"`
+	DepartmentName app.NullString `json:"department.name"  db:"dpt.name"          gorm:"-"`
+
+	CreatedAt app.NullDateTime `json:"created_at"       db:"m.created_at"      gorm:"column:created_at"`
+	UpdatedAt app.NullDateTime `json:"updated_at"       db:"m.updated_at"      gorm:"column:updated_at"`
+	DeletedAt app.NullDateTime `json:"deleted_at"       db:"m.deleted_at,hide" gorm:"column:deleted_at"`
+}
+
+// EndPoint returns the StockMovement end point, it used for cache key, etc.
+func (StockMovement) EndPoint() string {
+	return "stock_movements"
+}
+
+// TableVersion returns the versions of the StockMovement table in the database.
+// Change this value with date format YY.MM.DDHHii when any table structure changes.
+func (StockMovement) TableVersion() string {
+	return "26.10.192800"
+}
+
+// TableName returns the name of the StockMovement table in the database.
+func (StockMovement) TableName() string {
+	return "stock_movements"
+}
+
+// TableAliasName returns the table alias name of the StockMovement table, used for querying.
+func (StockMovement) TableAliasName() string {
+	return "m"
+}
+
+// GetRelations returns the relations of the StockMovement data in the database, used for querying.
+func (m *StockMovement) GetRelations() map[string]map[string]any {
+	m.AddRelation("left", "consumables", "cns", []map[string]any{{"column1": "cns.id", "column2": "m.consumable_id"}})
+	m.AddRelation("left", "branches", "brc", []map[string]any{{"column1": "brc.id", "column2": "m.branch_id"}})
+	m.AddRelation("left", "branches", "to_brc", []map[string]any{{"column1": "to_brc.id", "column2": "m.to_branch_id"}})
+	m.AddRelation("left", "employees", "emp", []map[string]any{{"column1": "emp.id", "column2": "m.employee_id"}})
+	m.AddRelation("left", "departments", "dpt", []map[string]any{{"column1": "dpt.id", "column2": "m.department_id"}})
+	return m.Relations
+}
+
+// GetFilters returns the filter of the StockMovement data in the database, used for querying.
+func (m *StockMovement) GetFilters() []map[string]any {
+	m.AddFilter(map[string]any{"column1": "m.deleted_at", "operator": "=", "value": nil})
+	return m.Filters
+}
+
+// GetSorts returns the default sort of the StockMovement data in the database, used for querying.
+func (m *StockMovement) GetSorts() []map[string]any {
+	m.AddSort(map[string]any{"column": "m.updated_at", "direction": "desc"})
+	return m.Sorts
+}
+
+// GetFields returns list of the field of the StockMovement data in the database, used for querying.
+func (m *StockMovement) GetFields() map[string]map[string]any {
+	m.SetFields(m)
+	return m.Fields
+}
+
+// GetSchema returns the StockMovement schema, used for querying.
+func (m *StockMovement) GetSchema() map[string]any {
+	return m.SetSchema(m)
+}
+
+// OpenAPISchemaName returns the name of the StockMovement schema in the open api documentation.
+func (StockMovement) OpenAPISchemaName() string {
+	return "StockMovement"
+}
+
+// GetOpenAPISchema returns the Open API Schema of the StockMovement in the open api documentation.
+func (m *StockMovement) GetOpenAPISchema() map[string]any {
+	return m.SetOpenAPISchema(m)
+}
+
+type StockMovementList struct {
+	app.ListModel
+}
+
+// OpenAPISchemaName returns the name of the StockMovementList schema in the open api documentation.
+func (StockMovementList) OpenAPISchemaName() string {
+	return "StockMovementList"
+}
+
+// GetOpenAPISchema returns the Open API Schema of the StockMovementList in the open api documentation.
+func (p *StockMovementList) GetOpenAPISchema() map[string]any {
+	return p.SetOpenAPISchema(&StockMovement{})
+}
+
+// ParamCreate is the expected parameters for create a new StockMovement data.
+type ParamCreate struct {
+	UseCaseHandler
+	Type         app.NullString  `json:"type"             db:"m.type"            gorm:"column:type"            validate:"required,oneof=receipt issue adjustment transfer"`
+	Qty          app.NullFloat64 `json:"qty"              db:"m.qty"             gorm:"column:qty"             validate:"required,ne=0"`
+	ConsumableID app.NullUUID    `json:"consumable.id"    db:"m.consumable_id"   gorm:"column:consumable_id"   validate:"required"`
+	BranchID     app.NullUUID    `json:"branch.id"        db:"m.branch_id"       gorm:"column:branch_id"       validate:"required"`
+}
+
+// ParamUpdate is the expected parameters for update the StockMovement data.
+type ParamUpdate struct {
+	UseCaseHandler
+}
+
+// ParamPartiallyUpdate is the expected parameters for partially update the StockMovement data.
+type ParamPartiallyUpdate struct {
+	UseCaseHandler
+}
+
+// ParamDelete is the expected parameters for delete the StockMovement data.
+type ParamDelete struct {
+	UseCaseHandler
+}
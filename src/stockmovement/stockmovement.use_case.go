@@ -0,0 +1,465 @@
+package stockmovement
+
+import (
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/maulanar/go_asset_tracking_management/app"
+	"github.com/maulanar/go_asset_tracking_management/src/branch"
+	"github.com/maulanar/go_asset_tracking_management/src/consumable"
+	"github.com/maulanar/go_asset_tracking_management/src/consumablestock"
+	"github.com/maulanar/go_asset_tracking_management/src/department"
+	"github.com/maulanar/go_asset_tracking_management/src/employee"
+)
+
+// UseCase returns a UseCaseHandler for expected use case functional.
+func UseCase(ctx app.Ctx, query ...url.Values) UseCaseHandler {
+	u := UseCaseHandler{
+		Ctx:   &ctx,
+		Query: url.Values{},
+	}
+	if len(query) > 0 {
+		u.Query = query[0]
+	}
+	return u
+}
+
+// UseCaseHandler provides a convenient interface for StockMovement use case, use UseCase to access UseCaseHandler.
+type UseCaseHandler struct {
+	StockMovement
+
+	// injectable dependencies
+	Ctx   *app.Ctx   `json:"-" db:"-" gorm:"-"`
+	Query url.Values `json:"-" db:"-" gorm:"-"`
+}
+
+// Async return UseCaseHandler with async process.
+func (u UseCaseHandler) Async(ctx app.Ctx, query ...url.Values) UseCaseHandler {
+	ctx.IsAsync = true
+	return UseCase(ctx, query...)
+}
+
+// GetByID returns the StockMovement data for the specified ID.
+func (u UseCaseHandler) GetByID(id string) (StockMovement, error) {
+	res := StockMovement{}
+
+	// check permission
+	err := u.Ctx.ValidatePermission("stock_movements.detail")
+	if err != nil {
+		return res, err
+	}
+
+	// get from cache and return if exists
+	cacheKey := u.EndPoint() + "." + id
+	app.Cache().Get(cacheKey, &res)
+	if res.ID.Valid {
+		return res, err
+	}
+
+	// prepare db for current ctx
+	tx, err := u.Ctx.DB()
+	if err != nil {
+		return res, app.Error().New(http.StatusInternalServerError, err.Error())
+	}
+
+	// get from db
+	key := "id"
+	if !app.Validator().IsValid(id, "uuid") {
+		key = "code"
+	}
+	u.Query.Add(key, id)
+	err = app.Query().First(tx, &res, u.Query)
+	if err != nil {
+		return res, u.Ctx.NotFoundError(err, u.EndPoint(), key, id)
+	}
+
+	// save to cache and return if exists
+	app.Cache().Set(cacheKey, res)
+	return res, err
+}
+
+// Get returns the list of StockMovement data.
+func (u UseCaseHandler) Get() (app.ListModel, error) {
+	res := app.ListModel{}
+
+	// check permission
+	err := u.Ctx.ValidatePermission("stock_movements.list")
+	if err != nil {
+		return res, err
+	}
+	// get from cache and return if exists
+	cacheKey := u.EndPoint() + "?" + u.Query.Encode()
+	err = app.Cache().Get(cacheKey, &res)
+	if err == nil {
+		return res, err
+	}
+
+	// prepare db for current ctx
+	tx, err := u.Ctx.DB()
+	if err != nil {
+		return res, app.Error().New(http.StatusInternalServerError, err.Error())
+	}
+
+	// set pagination info
+	res.Results.PageContext.Count,
+		res.Results.PageContext.Page,
+		res.Results.PageContext.PerPage,
+		res.Results.PageContext.PageCount,
+		err = app.Query().PaginationInfo(tx, &StockMovement{}, u.Query)
+	if err != nil {
+		return res, app.Error().New(http.StatusInternalServerError, err.Error())
+	}
+	// return data count if $per_page set to 0
+	if res.Results.PageContext.PerPage == 0 {
+		return res, err
+	}
+
+	// find data
+	data, err := app.Query().Find(tx, &StockMovement{}, u.Query)
+	if err != nil {
+		return res, app.Error().New(http.StatusInternalServerError, err.Error())
+	}
+	res.SetData(data, u.Query)
+
+	// save to cache and return if exists
+	app.Cache().Set(cacheKey, res)
+	return res, err
+}
+
+// Create creates a new data StockMovement with specified parameters.
+func (u *UseCaseHandler) Create(p *ParamCreate) error {
+
+	// check permission
+	err := u.Ctx.ValidatePermission("stock_movements.create")
+	if err != nil {
+		return err
+	}
+
+	// validate param
+	err = u.Ctx.ValidateParam(p)
+	if err != nil {
+		return err
+	}
+
+	// set default value for undefined field
+	err = u.setDefaultValue(StockMovement{})
+	if err != nil {
+		return err
+	}
+
+	// prepare db for current ctx
+	tx, err := u.Ctx.DB()
+	if err != nil {
+		return app.Error().New(http.StatusInternalServerError, err.Error())
+	}
+
+	// save data to db
+	err = tx.Model(&u).Create(&u).Error
+	if err != nil {
+		return app.Error().New(http.StatusInternalServerError, err.Error())
+	}
+
+	// update stock per branch
+	err = u.applyStock()
+	if err != nil {
+		return err
+	}
+
+	// invalidate cache
+	app.Cache().Invalidate(u.EndPoint())
+
+	// save history (user activity), send webhook, etc
+	go u.Ctx.Hook("POST", "create", p.ID.String, p)
+	return nil
+}
+
+// UpdateByID updates the StockMovement data for the specified ID with specified parameters.
+func (u UseCaseHandler) UpdateByID(id string, p *ParamUpdate) error {
+
+	// check permission
+	err := u.Ctx.ValidatePermission("stock_movements.edit")
+	if err != nil {
+		return err
+	}
+
+	// validate param
+	err = u.Ctx.ValidateParam(p)
+	if err != nil {
+		return err
+	}
+
+	// get previous data
+	old, err := u.GetByID(id)
+	if err != nil {
+		return err
+	}
+
+	// set default value for undefined field
+	err = u.setDefaultValue(old)
+	if err != nil {
+		return err
+	}
+
+	// prepare db for current ctx
+	tx, err := u.Ctx.DB()
+	if err != nil {
+		return app.Error().New(http.StatusInternalServerError, err.Error())
+	}
+
+	// update data on the db
+	err = tx.Model(&u).Where("id = ?", old.ID).Updates(u).Error
+	if err != nil {
+		return app.Error().New(http.StatusInternalServerError, err.Error())
+	}
+
+	// invalidate cache
+	app.Cache().Invalidate(u.EndPoint(), old.ID.String)
+
+	// save history (user activity), send webhook, etc
+	go u.Ctx.Hook("PUT", "Update", old.ID.String, old)
+	return nil
+}
+
+// PartiallyUpdateByID updates the StockMovement data for the specified ID with specified parameters.
+func (u UseCaseHandler) PartiallyUpdateByID(id string, p *ParamPartiallyUpdate) error {
+
+	// check permission
+	err := u.Ctx.ValidatePermission("stock_movements.edit")
+	if err != nil {
+		return err
+	}
+
+	// validate param
+	err = u.Ctx.ValidateParam(p)
+	if err != nil {
+		return err
+	}
+
+	// get previous data
+	old, err := u.GetByID(id)
+	if err != nil {
+		return err
+	}
+
+	// set default value for undefined field
+	err = u.setDefaultValue(old)
+	if err != nil {
+		return err
+	}
+
+	// prepare db for current ctx
+	tx, err := u.Ctx.DB()
+	if err != nil {
+		return app.Error().New(http.StatusInternalServerError, err.Error())
+	}
+
+	// update data on the db
+	err = tx.Model(&u).Where("id = ?", old.ID).Updates(u).Error
+	if err != nil {
+		return app.Error().New(http.StatusInternalServerError, err.Error())
+	}
+
+	// invalidate cache
+	app.Cache().Invalidate(u.EndPoint(), old.ID.String)
+
+	// save history (user activity), send webhook, etc
+	go u.Ctx.Hook("PATCH", "Partially Update", old.ID.String, old)
+	return nil
+}
+
+// DeleteByID deletes the StockMovement data for the specified ID.
+func (u UseCaseHandler) DeleteByID(id string, p *ParamDelete) error {
+
+	// check permission
+	err := u.Ctx.ValidatePermission("stock_movements.delete")
+	if err != nil {
+		return err
+	}
+
+	// validate param
+	err = u.Ctx.ValidateParam(p)
+	if err != nil {
+		return err
+	}
+
+	// get previous data
+	old, err := u.GetByID(id)
+	if err != nil {
+		return err
+	}
+
+	// prepare db for current ctx
+	tx, err := u.Ctx.DB()
+	if err != nil {
+		return app.Error().New(http.StatusInternalServerError, err.Error())
+	}
+
+	// the spare parts of a maintenance return their stock when the maintenance changes, so their issue can not be deleted
+	isPart := false
+	err = tx.Raw("SELECT EXISTS (SELECT 1 FROM maintenance_asset_parts WHERE stock_movement_id = ? AND deleted_at IS NULL)", old.ID).Scan(&isPart).Error
+	if err != nil {
+		return app.Error().New(http.StatusInternalServerError, err.Error())
+	}
+	if isPart {
+		return app.Error().New(http.StatusBadRequest, u.Ctx.Trans("stock_movement_part_locked", map[string]string{"code": old.Code.String}))
+	}
+
+	// update data on the db
+	err = tx.Model(&p).Where("id = ?", old.ID).Update("deleted_at", time.Now().UTC()).Error
+	if err != nil {
+		return app.Error().New(http.StatusInternalServerError, err.Error())
+	}
+
+	// the deleted movement no longer counts in the stock per branch
+	u.StockMovement = old
+	err = u.reverseStock()
+	if err != nil {
+		return err
+	}
+
+	// invalidate cache
+	app.Cache().Invalidate(u.EndPoint(), old.ID.String)
+
+	// save history (user activity), send webhook, etc
+	go u.Ctx.Hook("DELETE", "DELETE", old.ID.String, old)
+	return nil
+}
+
+// setDefaultValue set default value of undefined field when create or update StockMovement data.
+func (u *UseCaseHandler) setDefaultValue(old StockMovement) error {
+	if !old.ID.Valid {
+		u.ID = app.NewNullUUID()
+	} else {
+		u.ID = old.ID
+
+		// posted stock movement can not change the stock anymore, only the description can be updated
+		u.Type = old.Type
+		u.Qty = old.Qty
+		u.Date = old.Date
+		u.ConsumableID = old.ConsumableID
+		u.BranchID = old.BranchID
+		u.ToBranchID = old.ToBranchID
+		u.EmployeeID = old.EmployeeID
+		u.DepartmentID = old.DepartmentID
+	}
+
+	if !old.ID.Valid {
+		// only adjustment can have negative qty
+		if u.Type.String != "adjustment" && u.Qty.Float64 <= 0 {
+			return app.Error().New(http.StatusBadRequest, u.Ctx.Trans("stock_movement_qty_positive", map[string]string{
+				"type": u.Type.String,
+			}))
+		}
+
+		cns, err := consumable.UseCase(*u.Ctx, url.Values{}).GetByID(u.ConsumableID.String)
+		if err != nil {
+			return err
+		}
+		u.ConsumableID = cns.ID
+
+		brc, err := branch.UseCase(*u.Ctx, url.Values{}).GetByID(u.BranchID.String)
+		if err != nil {
+			return err
+		}
+		u.BranchID = brc.ID
+
+		// transfer needs a different destination branch
+		if u.Type.String == "transfer" {
+			if !u.ToBranchID.Valid || u.ToBranchID.String == "" || u.ToBranchID.String == u.BranchID.String {
+				return app.Error().New(http.StatusBadRequest, u.Ctx.Trans("stock_transfer_branch_invalid"))
+			}
+			toBrc, err := branch.UseCase(*u.Ctx, url.Values{}).GetByID(u.ToBranchID.String)
+			if err != nil {
+				return err
+			}
+			u.ToBranchID = toBrc.ID
+		} else {
+			u.ToBranchID = app.NullUUID{}
+		}
+
+		if !u.Date.Valid {
+			u.Date.Set(time.Now())
+		}
+	}
+
+	// an issue is made to either an employee or a department
+	if !old.ID.Valid {
+		hasEmployee := u.EmployeeID.Valid && u.EmployeeID.String != ""
+		hasDepartment := u.DepartmentID.Valid && u.DepartmentID.String != ""
+		if hasDepartment && (u.Type.String != "issue" || hasEmployee) {
+			return app.Error().New(http.StatusBadRequest, u.Ctx.Trans("stock_issue_recipient_invalid"))
+		}
+		if hasEmployee {
+			emp, err := employee.UseCase(*u.Ctx, url.Values{}).GetByID(u.EmployeeID.String)
+			if err != nil {
+				return err
+			}
+			u.EmployeeID = emp.ID
+		}
+		if hasDepartment {
+			dpt, err := department.UseCase(*u.Ctx, url.Values{}).GetByID(u.DepartmentID.String)
+			if err != nil {
+				return err
+			}
+			u.DepartmentID = dpt.ID
+		}
+	}
+
+	// Penentuan kode
+	if u.Code.Valid && u.Code.String != "" {
+		if !old.Code.Valid || u.Code.String != old.Code.String {
+			err := app.Common().IsFieldValueExists(u.Ctx, u.EndPoint(), "Code", u.TableName(), "code", u.Code.String)
+			if err != nil {
+				return err
+			}
+		}
+	} else if old.Code.Valid && old.Code.String != "" {
+		u.Code = old.Code
+	} else {
+		newCode, err := app.Common().GenerateCode(u.Ctx, u.TableName(), "code", "Stock Movement")
+		if err != nil {
+			return err
+		}
+		u.Code.Set(newCode)
+	}
+
+	return nil
+}
+
+// applyStock updates the stock of the consumable per branch based on the movement type.
+func (u *UseCaseHandler) applyStock() error {
+	return u.addStock(1)
+}
+
+// reverseStock reverts the change of applyStock on the stock of the consumable per branch.
+func (u *UseCaseHandler) reverseStock() error {
+	return u.addStock(-1)
+}
+
+// addStock adds the qty of the movement times the sign to the stock of the consumable per branch based on the movement type.
+func (u *UseCaseHandler) addStock(sign float64) error {
+	stockUC := consumablestock.UseCase(*u.Ctx, url.Values{})
+	qty := u.Qty.Float64 * sign
+	if u.Type.String == "issue" || u.Type.String == "transfer" {
+		qty = -qty
+	}
+
+	// a reversed transfer takes the stock back from the destination branch first
+	if sign < 0 && u.Type.String == "transfer" {
+		_, err := stockUC.AddQty(u.ConsumableID.String, u.ToBranchID.String, -u.Qty.Float64)
+		if err != nil {
+			return err
+		}
+	}
+	_, err := stockUC.AddQty(u.ConsumableID.String, u.BranchID.String, qty)
+	if err != nil {
+		return err
+	}
+
+	// transfer moves the stock to the destination branch
+	if sign > 0 && u.Type.String == "transfer" {
+		_, err = stockUC.AddQty(u.ConsumableID.String, u.ToBranchID.String, u.Qty.Float64)
+	}
+	return err
+}
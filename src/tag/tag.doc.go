@@ -0,0 +1,2 @@
+// tag is a package related to tag data.
+package tag
@@ -0,0 +1,148 @@
+package tag
+
+import "github.com/maulanar/go_asset_tracking_management/app"
+
+// Tag is the main model of Tag data. It provides a convenient interface for app.ModelInterface
+type Tag struct {
+	app.Model
+	ID          app.NullUUID   `json:"id"          db:"m.id"              gorm:"column:id;primaryKey"`
+	Name        app.NullString `json:"name"        db:"m.name"            gorm:"column:name"`
+	Color       app.NullString `json:"color"       db:"m.color"           gorm:"column:color"`
+	Description app.NullText   `json:"description" db:"m.description"     gorm:"column:description"`
+	AssetCount  app.NullInt64  `json:"asset_count" db:"tc.asset_count"    gorm:"-"`
+
+	CreatedAt app.NullDateTime `json:"created_at"  db:"m.created_at"      gorm:"column:created_at"`
+	UpdatedAt app.NullDateTime `json:"updated_at"  db:"m.updated_at"      gorm:"column:updated_at"`
+	DeletedAt app.NullDateTime `json:"deleted_at"  db:"m.deleted_at,hide" gorm:"column:deleted_at"`
+}
+
+// EndPoint returns the Tag end point, it used for cache key, etc.
+func (Tag) EndPoint() string {
+	return "tags"
+}
+
+// TableVersion returns the versions of the Tag table in the database.
+// Change this value with date format YY.MM.DDHHii when any table structure changes.
+func (Tag) TableVersion() string {
+	return "26.10.191600"
+}
+
+// TableName returns the name of the Tag table in the database.
+func (Tag) TableName() string {
+	return "tags"
+}
+
+// TableAliasName returns the table alias name of the Tag table, used for querying.
+func (Tag) TableAliasName() string {
+	return "m"
+}
+
+// GetRelations returns the relations of the Tag data in the database, used for querying.
+func (m *Tag) GetRelations() map[string]map[string]any {
+	// number of non deleted assets with the tag
+	m.AddRelation("left", `(
+  SELECT at.tag_id, COUNT(*) AS asset_count
+  FROM asset_tags at
+  JOIN assets a ON a.id = at.asset_id AND a.deleted_at IS NULL
+  GROUP BY at.tag_id
+)`, "tc", []map[string]any{{"column1": "tc.tag_id", "column2": "m.id"}})
+	return m.Relations
+}
+
+// GetFilters returns the filter of the Tag data in the database, used for querying.
+func (m *Tag) GetFilters() []map[string]any {
+	m.AddFilter(map[string]any{"column1": "m.deleted_at", "operator": "=", "value": nil})
+	return m.Filters
+}
+
+// GetSorts returns the default sort of the Tag data in the database, used for querying.
+func (m *Tag) GetSorts() []map[string]any {
+	m.AddSort(map[string]any{"column": "m.name", "direction": "asc"})
+	return m.Sorts
+}
+
+// GetFields returns list of the field of the Tag data in the database, used for querying.
+func (m *Tag) GetFields() map[string]map[string]any {
+	m.SetFields(m)
+	return m.Fields
+}
+
+// GetSchema returns the Tag schema, used for querying.
+func (m *Tag) GetSchema() map[string]any {
+	return m.SetSchema(m)
+}
+
+// OpenAPISchemaName returns the name of the Tag schema in the open api documentation.
+func (Tag) OpenAPISchemaName() string {
+	return "Tag"
+}
+
+// GetOpenAPISchema returns the Open API Schema of the Tag in the open api documentation.
+func (m *Tag) GetOpenAPISchema() map[string]any {
+	return m.SetOpenAPISchema(m)
+}
+
+type TagList struct {
+	app.ListModel
+}
+
+// OpenAPISchemaName returns the name of the TagList schema in the open api documentation.
+func (TagList) OpenAPISchemaName() string {
+	return "TagList"
+}
+
+// GetOpenAPISchema returns the Open API Schema of the TagList in the open api documentation.
+func (p *TagList) GetOpenAPISchema() map[string]any {
+	return p.SetOpenAPISchema(&Tag{})
+}
+
+// ParamCreate is the expected parameters for create a new Tag data.
+type ParamCreate struct {
+	UseCaseHandler
+	Name app.NullString `json:"name"        db:"m.name"            gorm:"column:name"        validate:"required"`
+}
+
+// ParamUpdate is the expected parameters for update the Tag data.
+type ParamUpdate struct {
+	UseCaseHandler
+}
+
+// ParamPartiallyUpdate is the expected parameters for partially update the Tag data.
+type ParamPartiallyUpdate struct {
+	UseCaseHandler
+}
+
+// ParamDelete is the expected parameters for delete the Tag data.
+type ParamDelete struct {
+	UseCaseHandler
+}
+
+// AssetTag is the link between an asset and a tag.
+type AssetTag struct {
+	app.Model
+	ID        app.NullUUID     `json:"id"         db:"m.id"         gorm:"column:id;primaryKey"`
+	AssetID   app.NullUUID     `json:"asset.id"   db:"m.asset_id"   gorm:"column:asset_id"`
+	TagID     app.NullUUID     `json:"tag.id"     db:"m.tag_id"     gorm:"column:tag_id"`
+	CreatedAt app.NullDateTime `json:"created_at" db:"m.created_at" gorm:"column:created_at"`
+}
+
+// EndPoint returns the AssetTag end point, it used for cache key, etc.
+func (AssetTag) EndPoint() string {
+	return "asset_tags"
+}
+
+// TableVersion returns the versions of the AssetTag table in the database.
+// Change this value with date format YY.MM.DDHHii when any table structure changes.
+func (AssetTag) TableVersion() string {
+	return "26.10.191600"
+}
+
+// TableName returns the name of the AssetTag table in the database.
+func (AssetTag) TableName() string {
+	return "asset_tags"
+}
+
+// TableAliasName returns the table alias name of the AssetTag table, used for querying.
+func (AssetTag) TableAliasName() string {
+	return "m"
+}
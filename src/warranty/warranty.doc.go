@@ -0,0 +1,2 @@
+// warranty is a package related to warranty data.
+package warranty
@@ -0,0 +1,145 @@
+package warranty
+
+import "github.com/maulanar/go_asset_tracking_management/app"
+
+// Warranty is the main model of Warranty data. It provides a convenient interface for app.ModelInterface
+type Warranty struct {
+	app.Model
+	ID               app.NullUUID     `json:"id"                 db:"m.id"                 gorm:"column:id;primaryKey"`
+	Provider         app.NullString   `json:"provider"           db:"m.provider"           gorm:"column:provider"`
+	StartDate        app.NullDate     `json:"start_date"         db:"m.start_date"         gorm:"column:start_date"`
+	EndDate          app.NullDate     `json:"end_date"           db:"m.end_date"           gorm:"column:end_date"`
+	CoverageType     app.NullString   `json:"coverage_type"      db:"m.coverage_type"      gorm:"column:coverage_type"      validate:"omitempty,oneof=parts labor parts_and_labor full"`
+	Description      app.NullText     `json:"description"        db:"m.description"        gorm:"column:description"`
+	NotifyDaysBefore app.NullInt64    `json:"notify_days_before" db:"m.notify_days_before" gorm:"column:notify_days_before" validate:"omitempty,gte=0"`
+	NotifiedAt       app.NullDateTime `json:"notified_at"        db:"m.notified_at"        gorm:"column:notified_at"`
+	Status           app.NullString   `json:"status"             db:"ws.status"            gorm:"-"`
+
+	AssetID   app.NullUUID   `json:"asset.id"           db:"m.asset_id"           gorm:"column:asset_id"`
+	AssetCode app.NullString `json:"asset.code"         db:"ass.code"             gorm:"-"`
+	AssetName app.NullString `json:"asset.name"         db:"ass.name"             gorm:"-"`
+
+	VendorID   app.NullUUID   `json:"vendor.id"          db:"m.vendor_id"          gorm:"column:vendor_id"`
+	VendorCode app.NullString `json:"vendor.code"        db:"vnd.code"             gorm:"-"`
+	VendorName app.NullString `json:"vendor.name"        db:"vnd.name"             gorm:"-"`
+
+	AttachmentID   app.NullUUID `json:"attachment.id"      db:"m.attachment_id"      gorm:"column:attachment_id"`
+	AttachmentName app.NullText `json:"attachment.name"    db:"att.name"             gorm:"-"`
+	AttachmentPath app.NullText `json:"attachment.path"    db:"att.path"             gorm:"-"`
+	AttachmentURL  app.NullText `json:"attachment.url"     db:"att.url"              gorm:"-"`
+
+	CreatedAt app.NullDateTime `json:"created_at"         db:"m.created_at"         gorm:"column:created_at"`
+	UpdatedAt app.NullDateTime `json:"updated_at"         db:"m.updated_at"         gorm:"column:updated_at"`
+	DeletedAt app.NullDateTime `json:"deleted_at"         db:"m.deleted_at,hide"    gorm:"column:deleted_at"`
+}
+
+// EndPoint returns the Warranty end point, it used for cache key, etc.
+func (Warranty) EndPoint() string {
+	return "warranties"
+}
+
+// TableVersion returns the versions of the Warranty table in the database.
+// Change this value with date format YY.MM.DDHHii when any table structure changes.
+func (Warranty) TableVersion() string {
+	return "26.10.191000"
+}
+
+// TableName returns the name of the Warranty table in the database.
+func (Warranty) TableName() string {
+	return "warranties"
+}
+
+// TableAliasName returns the table alias name of the Warranty table, used for querying.
+func (Warranty) TableAliasName() string {
+	return "m"
+}
+
+// GetRelations returns the relations of the Warranty data in the database, used for querying.
+func (m *Warranty) GetRelations() map[string]map[string]any {
+	m.AddRelation("left", "assets", "ass", []map[string]any{{"column1": "ass.id", "column2": "m.asset_id"}})
+	m.AddRelation("left", "vendors", "vnd", []map[string]any{{"column1": "vnd.id", "column2": "m.vendor_id"}})
+	m.AddRelation("left", "attachments", "att", []map[string]any{{"column1": "att.id", "column2": "m.attachment_id"}})
+
+	// warranty status based on the current date
+	m.AddRelation("left", `(
+  SELECT w.id,
+         CASE
+           WHEN w.end_date < CURRENT_DATE THEN 'expired'
+           WHEN w.start_date > CURRENT_DATE THEN 'upcoming'
+           WHEN w.end_date - COALESCE(w.notify_days_before, 0) <= CURRENT_DATE THEN 'expiring'
+           ELSE 'active'
+         END AS status
+  FROM warranties w
+)`, "ws", []map[string]any{{"column1": "ws.id", "column2": "m.id"}})
+	return m.Relations
+}
+
+// GetFilters returns the filter of the Warranty data in the database, used for querying.
+func (m *Warranty) GetFilters() []map[string]any {
+	m.AddFilter(map[string]any{"column1": "m.deleted_at", "operator": "=", "value": nil})
+	return m.Filters
+}
+
+// GetSorts returns the default sort of the Warranty data in the database, used for querying.
+func (m *Warranty) GetSorts() []map[string]any {
+	m.AddSort(map[string]any{"column": "m.end_date", "direction": "desc"})
+	return m.Sorts
+}
+
+// GetFields returns list of the field of the Warranty data in the database, used for querying.
+func (m *Warranty) GetFields() map[string]map[string]any {
+	m.SetFields(m)
+	return m.Fields
+}
+
+// GetSchema returns the Warranty schema, used for querying.
+func (m *Warranty) GetSchema() map[string]any {
+	return m.SetSchema(m)
+}
+
+// OpenAPISchemaName returns the name of the Warranty schema in the open api documentation.
+func (Warranty) OpenAPISchemaName() string {
+	return "Warranty"
+}
+
+// GetOpenAPISchema returns the Open API Schema of the Warranty in the open api documentation.
+func (m *Warranty) GetOpenAPISchema() map[string]any {
+	return m.SetOpenAPISchema(m)
+}
+
+type WarrantyList struct {
+	app.ListModel
+}
+
+// OpenAPISchemaName returns the name of the WarrantyList schema in the open api documentation.
+func (WarrantyList) OpenAPISchemaName() string {
+	return "WarrantyList"
+}
+
+// GetOpenAPISchema returns the Open API Schema of the WarrantyList in the open api documentation.
+func (p *WarrantyList) GetOpenAPISchema() map[string]any {
+	return p.SetOpenAPISchema(&Warranty{})
+}
+
+// ParamCreate is the expected parameters for create a new Warranty data.
+type ParamCreate struct {
+	UseCaseHandler
+	AssetID   app.NullUUID `json:"asset.id"           db:"m.asset_id"           gorm:"column:asset_id"           validate:"required"`
+	StartDate app.NullDate `json:"start_date"         db:"m.start_date"         gorm:"column:start_date"         validate:"required"`
+	EndDate   app.NullDate `json:"end_date"           db:"m.end_date"           gorm:"column:end_date"           validate:"required"`
+}
+
+// ParamUpdate is the expected parameters for update the Warranty data.
+type ParamUpdate struct {
+	UseCaseHandler
+}
+
+// ParamPartiallyUpdate is the expected parameters for partially update the Warranty data.
+type ParamPartiallyUpdate struct {
+	UseCaseHandler
+}
+
+// ParamDelete is the expected parameters for delete the Warranty data.
+type ParamDelete struct {
+	UseCaseHandler
+}
@@ -0,0 +1,456 @@
+package warranty
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"time"
+
+	"gorm.io/gorm"
+
+	"github.com/maulanar/go_asset_tracking_management/app"
+	"github.com/maulanar/go_asset_tracking_management/src/asset"
+	"github.com/maulanar/go_asset_tracking_management/src/attachment"
+	"github.com/maulanar/go_asset_tracking_management/src/notification"
+	"github.com/maulanar/go_asset_tracking_management/src/vendor"
+)
+
+// UseCase returns a UseCaseHandler for expected use case functional.
+func UseCase(ctx app.Ctx, query ...url.Values) UseCaseHandler {
+	u := UseCaseHandler{
+		Ctx:   &ctx,
+		Query: url.Values{},
+	}
+	if len(query) > 0 {
+		u.Query = query[0]
+	}
+	return u
+}
+
+// UseCaseHandler provides a convenient interface for Warranty use case, use UseCase to access UseCaseHandler.
+type UseCaseHandler struct {
+	Warranty
+
+	// injectable dependencies
+	Ctx   *app.Ctx   `json:"-" db:"-" gorm:"-"`
+	Query url.Values `json:"-" db:"-" gorm:"-"`
+}
+
+// Async return UseCaseHandler with async process.
+func (u UseCaseHandler) Async(ctx app.Ctx, query ...url.Values) UseCaseHandler {
+	ctx.IsAsync = true
+	return UseCase(ctx, query...)
+}
+
+// GetByID returns the Warranty data for the specified ID.
+func (u UseCaseHandler) GetByID(id string) (Warranty, error) {
+	res := Warranty{}
+
+	// check permission
+	err := u.Ctx.ValidatePermission("warranties.detail")
+	if err != nil {
+		return res, err
+	}
+
+	// get from cache and return if exists
+	cacheKey := u.EndPoint() + "." + id
+	app.Cache().Get(cacheKey, &res)
+	if res.ID.Valid {
+		return res, err
+	}
+
+	// prepare db for current ctx
+	tx, err := u.Ctx.DB()
+	if err != nil {
+		return res, app.Error().New(http.StatusInternalServerError, err.Error())
+	}
+
+	// get from db
+	key := "id"
+	if !app.Validator().IsValid(id, "uuid") {
+		key = "code"
+	}
+	u.Query.Add(key, id)
+	err = app.Query().First(tx, &res, u.Query)
+	if err != nil {
+		return res, u.Ctx.NotFoundError(err, u.EndPoint(), key, id)
+	}
+
+	// save to cache and return if exists
+	app.Cache().Set(cacheKey, res)
+	return res, err
+}
+
+// Get returns the list of Warranty data.
+func (u UseCaseHandler) Get() (app.ListModel, error) {
+	res := app.ListModel{}
+
+	// check permission
+	err := u.Ctx.ValidatePermission("warranties.list")
+	if err != nil {
+		return res, err
+	}
+	// get from cache and return if exists
+	cacheKey := u.EndPoint() + "?" + u.Query.Encode()
+	err = app.Cache().Get(cacheKey, &res)
+	if err == nil {
+		return res, err
+	}
+
+	// prepare db for current ctx
+	tx, err := u.Ctx.DB()
+	if err != nil {
+		return res, app.Error().New(http.StatusInternalServerError, err.Error())
+	}
+
+	// set pagination info
+	res.Results.PageContext.Count,
+		res.Results.PageContext.Page,
+		res.Results.PageContext.PerPage,
+		res.Results.PageContext.PageCount,
+		err = app.Query().PaginationInfo(tx, &Warranty{}, u.Query)
+	if err != nil {
+		return res, app.Error().New(http.StatusInternalServerError, err.Error())
+	}
+	// return data count if $per_page set to 0
+	if res.Results.PageContext.PerPage == 0 {
+		return res, err
+	}
+
+	// find data
+	data, err := app.Query().Find(tx, &Warranty{}, u.Query)
+	if err != nil {
+		return res, app.Error().New(http.StatusInternalServerError, err.Error())
+	}
+	res.SetData(data, u.Query)
+
+	// save to cache and return if exists
+	app.Cache().Set(cacheKey, res)
+	return res, err
+}
+
+// Create creates a new data Warranty with specified parameters.
+func (u *UseCaseHandler) Create(p *ParamCreate) error {
+
+	// check permission
+	err := u.Ctx.ValidatePermission("warranties.create")
+	if err != nil {
+		return err
+	}
+
+	// validate param
+	err = u.Ctx.ValidateParam(p)
+	if err != nil {
+		return err
+	}
+
+	// set default value for undefined field
+	err = u.setDefaultValue(Warranty{})
+	if err != nil {
+		return err
+	}
+
+	// prepare db for current ctx
+	tx, err := u.Ctx.DB()
+	if err != nil {
+		return app.Error().New(http.StatusInternalServerError, err.Error())
+	}
+
+	// save data to db
+	err = tx.Model(&u).Create(&u).Error
+	if err != nil {
+		return app.Error().New(http.StatusInternalServerError, err.Error())
+	}
+
+	// invalidate cache
+	app.Cache().Invalidate(u.EndPoint())
+	app.Cache().Invalidate(asset.Asset{}.EndPoint(), u.AssetID.String)
+
+	// save history (user activity), send webhook, etc
+	go u.Ctx.Hook("POST", "create", p.ID.String, p)
+	return nil
+}
+
+// UpdateByID updates the Warranty data for the specified ID with specified parameters.
+func (u UseCaseHandler) UpdateByID(id string, p *ParamUpdate) error {
+
+	// check permission
+	err := u.Ctx.ValidatePermission("warranties.edit")
+	if err != nil {
+		return err
+	}
+
+	// validate param
+	err = u.Ctx.ValidateParam(p)
+	if err != nil {
+		return err
+	}
+
+	// get previous data
+	old, err := u.GetByID(id)
+	if err != nil {
+		return err
+	}
+
+	// set default value for undefined field
+	err = u.setDefaultValue(old)
+	if err != nil {
+		return err
+	}
+
+	// prepare db for current ctx
+	tx, err := u.Ctx.DB()
+	if err != nil {
+		return app.Error().New(http.StatusInternalServerError, err.Error())
+	}
+
+	// update data on the db
+	err = tx.Model(&u).Where("id = ?", old.ID).Updates(u).Error
+	if err != nil {
+		return app.Error().New(http.StatusInternalServerError, err.Error())
+	}
+
+	// invalidate cache
+	app.Cache().Invalidate(u.EndPoint(), old.ID.String)
+	app.Cache().Invalidate(asset.Asset{}.EndPoint(), old.AssetID.String, u.AssetID.String)
+
+	// save history (user activity), send webhook, etc
+	go u.Ctx.Hook("PUT", "Update", old.ID.String, old)
+	return nil
+}
+
+// PartiallyUpdateByID updates the Warranty data for the specified ID with specified parameters.
+func (u UseCaseHandler) PartiallyUpdateByID(id string, p *ParamPartiallyUpdate) error {
+
+	// check permission
+	err := u.Ctx.ValidatePermission("warranties.edit")
+	if err != nil {
+		return err
+	}
+
+	// validate param
+	err = u.Ctx.ValidateParam(p)
+	if err != nil {
+		return err
+	}
+
+	// get previous data
+	old, err := u.GetByID(id)
+	if err != nil {
+		return err
+	}
+
+	// set default value for undefined field
+	err = u.setDefaultValue(old)
+	if err != nil {
+		return err
+	}
+
+	// prepare db for current ctx
+	tx, err := u.Ctx.DB()
+	if err != nil {
+		return app.Error().New(http.StatusInternalServerError, err.Error())
+	}
+
+	// update data on the db
+	err = tx.Model(&u).Where("id = ?", old.ID).Updates(u).Error
+	if err != nil {
+		return app.Error().New(http.StatusInternalServerError, err.Error())
+	}
+
+	// invalidate cache
+	app.Cache().Invalidate(u.EndPoint(), old.ID.String)
+	app.Cache().Invalidate(asset.Asset{}.EndPoint(), old.AssetID.String, u.AssetID.String)
+
+	// save history (user activity), send webhook, etc
+	go u.Ctx.Hook("PATCH", "Partially Update", old.ID.String, old)
+	return nil
+}
+
+// DeleteByID deletes the Warranty data for the specified ID.
+func (u UseCaseHandler) DeleteByID(id string, p *ParamDelete) error {
+
+	// check permission
+	err := u.Ctx.ValidatePermission("warranties.delete")
+	if err != nil {
+		return err
+	}
+
+	// validate param
+	err = u.Ctx.ValidateParam(p)
+	if err != nil {
+		return err
+	}
+
+	// get previous data
+	old, err := u.GetByID(id)
+	if err != nil {
+		return err
+	}
+
+	// prepare db for current ctx
+	tx, err := u.Ctx.DB()
+	if err != nil {
+		return app.Error().New(http.StatusInternalServerError, err.Error())
+	}
+
+	// update data on the db
+	err = tx.Model(&p).Where("id = ?", old.ID).Update("deleted_at", time.Now().UTC()).Error
+	if err != nil {
+		return app.Error().New(http.StatusInternalServerError, err.Error())
+	}
+
+	// invalidate cache
+	app.Cache().Invalidate(u.EndPoint(), old.ID.String)
+	app.Cache().Invalidate(asset.Asset{}.EndPoint(), old.AssetID.String, u.AssetID.String)
+
+	// save history (user activity), send webhook, etc
+	go u.Ctx.Hook("DELETE", "DELETE", old.ID.String, old)
+	return nil
+}
+
+// setDefaultValue set default value of undefined field when create or update Warranty data.
+func (u *UseCaseHandler) setDefaultValue(old Warranty) error {
+	if !old.ID.Valid {
+		u.ID = app.NewNullUUID()
+	} else {
+		u.ID = old.ID
+	}
+
+	// validate asset
+	if u.AssetID.Valid && u.AssetID.String != "" {
+		ass, err := asset.UseCase(*u.Ctx, url.Values{}).GetByID(u.AssetID.String)
+		if err != nil {
+			return err
+		}
+		u.AssetID = ass.ID
+	} else {
+		u.AssetID = old.AssetID
+	}
+
+	// validate vendor, the provider name is taken from the vendor when empty
+	if u.VendorID.Valid && u.VendorID.String != "" {
+		vnd, err := vendor.UseCase(*u.Ctx, url.Values{}).GetByID(u.VendorID.String)
+		if err != nil {
+			return err
+		}
+		u.VendorID = vnd.ID
+		if (!u.Provider.Valid || u.Provider.String == "") && !old.Provider.Valid {
+			u.Provider.Set(vnd.Name.String)
+		}
+	}
+
+	// validate attachment (warranty contract)
+	if u.AttachmentID.Valid && u.AttachmentID.String != "" {
+		attUC := attachment.UseCase(*u.Ctx, url.Values{})
+		att, err := attUC.GetByID(u.AttachmentID.String)
+		if err != nil {
+			return err
+		}
+
+		// Update data attachment
+		upAtt := attachment.ParamUpdate{}
+		upAtt.Endpoint.Set(u.EndPoint())
+		upAtt.DataId.Set(u.ID.String)
+		err = attUC.UpdateByID(att.ID.String, &upAtt)
+		if err != nil {
+			return err
+		}
+	}
+
+	// validate warranty period
+	startDate, endDate := old.StartDate, old.EndDate
+	if u.StartDate.Valid {
+		startDate = u.StartDate
+	}
+	if u.EndDate.Valid {
+		endDate = u.EndDate
+	}
+	if startDate.Valid && endDate.Valid && endDate.Time.Before(startDate.Time) {
+		return app.Error().New(http.StatusBadRequest, u.Ctx.Trans("warranty_invalid_period"))
+	}
+
+	if !u.NotifyDaysBefore.Valid && !old.NotifyDaysBefore.Valid {
+		u.NotifyDaysBefore.Set(int64(app.WARRANTY_EXPIRY_NOTIFY_DAYS))
+	}
+
+	return nil
+}
+
+// GetActiveByAssetID returns the Warranty data which covers the specified asset on the specified date.
+func (u UseCaseHandler) GetActiveByAssetID(assetID string, date time.Time) (Warranty, error) {
+	res := Warranty{}
+
+	// prepare db for current ctx
+	tx, err := u.Ctx.DB()
+	if err != nil {
+		return res, app.Error().New(http.StatusInternalServerError, err.Error())
+	}
+
+	query := url.Values{}
+	query.Add("asset.id", assetID)
+	query.Add("start_date.$lte", date.Format("2006-01-02"))
+	query.Add("end_date.$gte", date.Format("2006-01-02"))
+	query.Add("$sort", "-end_date")
+	err = app.Query().First(tx, &res, query)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return res, u.Ctx.NotFoundError(err, u.EndPoint(), "asset.id", assetID)
+	}
+	if err != nil {
+		return res, app.Error().New(http.StatusInternalServerError, err.Error())
+	}
+	return res, nil
+}
+
+// JobNotifyExpiringWarranty raises a notification for every warranty that enters its notification window,
+// i.e. notify_days_before days before the end date. Each warranty is notified once per end date.
+func JobNotifyExpiringWarranty() {
+	tx, err := app.DB().Conn("main")
+	if err != nil {
+		return
+	}
+
+	type expiringWarranty struct {
+		ID        string
+		Provider  string
+		EndDate   time.Time
+		AssetID   string
+		AssetCode string
+		AssetName string
+	}
+	warranties := []expiringWarranty{}
+	err = tx.Raw(`
+SELECT w.id, w.provider, w.end_date, a.id AS asset_id, a.code AS asset_code, a.name AS asset_name
+FROM warranties w
+JOIN assets a ON a.id = w.asset_id AND a.deleted_at IS NULL
+WHERE w.deleted_at IS NULL
+  AND w.end_date >= CURRENT_DATE
+  AND w.end_date - COALESCE(w.notify_days_before, 0) <= CURRENT_DATE
+  AND (w.notified_at IS NULL OR w.notified_at < w.end_date - COALESCE(w.notify_days_before, 0))
+`).Scan(&warranties).Error
+	if err != nil {
+		app.Logger().Error().Err(err).Msg("failed to get expiring warranties")
+		return
+	}
+
+	for _, w := range warranties {
+		n := notification.Notification{}
+		n.Type.Set("warranty_expiry")
+		n.Title.Set("Warranty expiring")
+		n.Message.Set(fmt.Sprintf("The warranty of asset %s (%s) from %s will expire on %s.", w.AssetName, w.AssetCode, w.Provider, w.EndDate.Format("2006-01-02")))
+		n.Endpoint.Set(Warranty{}.EndPoint())
+		n.DataID.Set(w.ID)
+		err = notification.Notify(tx, n)
+		if err != nil {
+			app.Logger().Error().Err(err).Str("warranty_id", w.ID).Msg("failed to notify expiring warranty")
+			continue
+		}
+		err = tx.Model(&Warranty{}).Where("id = ?", w.ID).Update("notified_at", time.Now().UTC()).Error
+		if err != nil {
+			app.Logger().Error().Err(err).Str("warranty_id", w.ID).Msg("failed to update warranty notified_at")
+		}
+	}
+
+	app.Cache().DeleteWithPrefix(Warranty{}.EndPoint())
+}
@@ -0,0 +1,2 @@
+// workorder is a package related to workorder data.
+package workorder
@@ -0,0 +1,320 @@
+package workorder
+
+import "github.com/maulanar/go_asset_tracking_management/app"
+
+// WorkOrder is the main model of WorkOrder data. It provides a convenient interface for app.ModelInterface
+type WorkOrder struct {
+	app.Model
+	ID            app.NullUUID     `json:"id"                     db:"m.id"                    gorm:"column:id;primaryKey"`
+	Code          app.NullString   `json:"code"                   db:"m.code"                  gorm:"column:code"`
+	Status        app.NullString   `json:"status"                 db:"m.status"                gorm:"column:status;default:open"     validate:"omitempty,oneof=open scheduled in_progress on_hold done cancelled"`
+	StatusReason  app.NullText     `json:"status_reason"          db:"m.status_reason"         gorm:"column:status_reason"`
+	Priority      app.NullString   `json:"priority"               db:"m.priority"              gorm:"column:priority;default:medium" validate:"omitempty,oneof=low medium high critical"`
+	Problem       app.NullText     `json:"problem"                db:"m.problem"               gorm:"column:problem"`
+	Description   app.NullText     `json:"description"            db:"m.description"           gorm:"column:description"`
+	Resolution    app.NullText     `json:"resolution"             db:"m.resolution"            gorm:"column:resolution"`
+	DueDate       app.NullDate     `json:"due_date"               db:"m.due_date"              gorm:"column:due_date"`
+	DueMeter      app.NullFloat64  `json:"due_meter"              db:"m.due_meter"             gorm:"column:due_meter"`
+	IsOverdue     app.NullBool     `json:"is_overdue"             db:"m.is_overdue"            gorm:"column:is_overdue;default:false"`
+	ScheduledDate app.NullDate     `json:"scheduled_date"         db:"m.scheduled_date"        gorm:"column:scheduled_date"`
+	ScheduledAt   app.NullDateTime `json:"scheduled_at"           db:"m.scheduled_at"          gorm:"column:scheduled_at"`
+	StartedAt     app.NullDateTime `json:"started_at"             db:"m.started_at"            gorm:"column:started_at"`
+	OnHoldAt      app.NullDateTime `json:"on_hold_at"             db:"m.on_hold_at"            gorm:"column:on_hold_at"`
+	CompletedAt   app.NullDateTime `json:"completed_at"           db:"m.completed_at"          gorm:"column:completed_at"`
+	CancelledAt   app.NullDateTime `json:"cancelled_at"           db:"m.cancelled_at"          gorm:"column:cancelled_at"`
+	LabourAmount  app.NullFloat64  `json:"labour_amount"          db:"m.labour_amount"         gorm:"column:labour_amount"`
+	PartsAmount   app.NullFloat64  `json:"parts_amount"           db:"m.parts_amount"          gorm:"column:parts_amount"`
+	TotalAmount   app.NullFloat64  `json:"total_amount"           db:"m.total_amount"          gorm:"column:total_amount"`
+
+	AssetID             app.NullUUID   `json:"asset.id"               db:"m.asset_id"              gorm:"column:asset_id"`
+	AssetCode           app.NullString `json:"asset.code"             db:"ass.code"                gorm:"-"`
+	AssetName           app.NullString `json:"asset.name"             db:"ass.name"                gorm:"-"`
+	AssetStatus         app.NullString `json:"asset.status"           db:"ass.status"              gorm:"-"`
+	AssetPreviousStatus app.NullString `json:"asset.previous_status"  db:"m.asset_previous_status" gorm:"column:asset_previous_status"`
+	AssetCategoryID     app.NullUUID   `json:"asset.category.id"      db:"ass.category_id"         gorm:"-"`
+
+	TechnicianID   app.NullUUID   `json:"technician.id"          db:"m.technician_id"         gorm:"column:technician_id"`
+	TechnicianCode app.NullString `json:"technician.code"        db:"tec.code"                gorm:"-"`
+	TechnicianName app.NullString `json:"technician.name"        db:"tec.name"                gorm:"-"`
+
+	VendorID   app.NullUUID   `json:"vendor.id"              db:"m.vendor_id"             gorm:"column:vendor_id"`
+	VendorCode app.NullString `json:"vendor.code"            db:"vnd.code"                gorm:"-"`
+	VendorName app.NullString `json:"vendor.name"            db:"vnd.name"                gorm:"-"`
+
+	MaintenanceTypeID   app.NullUUID   `json:"maintenance_type.id"    db:"m.maintenance_type_id"   gorm:"column:maintenance_type_id"`
+	MaintenanceTypeCode app.NullString `json:"maintenance_type.code"  db:"mt.code"                 gorm:"-"`
+	MaintenanceTypeName app.NullString `json:"maintenance_type.name"  db:"mt.name"                 gorm:"-"`
+
+	MaintenancePlanID   app.NullUUID   `json:"maintenance_plan.id"    db:"m.maintenance_plan_id"   gorm:"column:maintenance_plan_id"`
+	MaintenancePlanCode app.NullString `json:"maintenance_plan.code"  db:"mp.code"                 gorm:"-"`
+	MaintenancePlanName app.NullString `json:"maintenance_plan.name"  db:"mp.name"                 gorm:"-"`
+
+	MaintenanceAssetID   app.NullUUID   `json:"maintenance_asset.id"   db:"m.maintenance_asset_id"  gorm:"column:maintenance_asset_id"`
+	MaintenanceAssetCode app.NullString `json:"maintenance_asset.code" db:"ma.code"                 gorm:"-"`
+
+	Lines []WorkOrderLine `json:"lines"                  db:"-"                       gorm:"-"`
+
+	CreatedAt app.NullDateTime `json:"created_at"             db:"m.created_at"            gorm:"column:created_at"`
+	UpdatedAt app.NullDateTime `json:"updated_at"             db:"m.updated_at"            gorm:"column:updated_at"`
+	DeletedAt app.NullDateTime `json:"deleted_at"             db:"m.deleted_at,hide"       gorm:"column:deleted_at"`
+}
+
+// EndPoint returns the WorkOrder end point, it used for cache key, etc.
+func (WorkOrder) EndPoint() string {
+	return "work_orders"
+}
+
+// TableVersion returns the versions of the WorkOrder table in the database.
+// Change this value with date format YY.MM.DDHHii when any table structure changes.
+func (WorkOrder) TableVersion() string {
+	return "26.10.192200"
+}
+
+// TableName returns the name of the WorkOrder table in the database.
+func (WorkOrder) TableName() string {
+	return "work_orders"
+}
+
+// TableAliasName returns the table alias name of the WorkOrder table, used for querying.
+func (WorkOrder) TableAliasName() string {
+	return "m"
+}
+
+// GetRelations returns the relations of the WorkOrder data in the database, used for querying.
+func (m *WorkOrder) GetRelations() map[string]map[string]any {
+	m.AddRelation("left", "assets", "ass", []map[string]any{{"column1": "ass.id", "column2": "m.asset_id"}})
+	m.AddRelation("left", "employees", "tec", []map[string]any{{"column1": "tec.id", "column2": "m.technician_id"}})
+	m.AddRelation("left", "vendors", "vnd", []map[string]any{{"column1": "vnd.id", "column2": "m.vendor_id"}})
+	m.AddRelation("left", "maintenance_types", "mt", []map[string]any{{"column1": "mt.id", "column2": "m.maintenance_type_id"}})
+	m.AddRelation("left", "maintenance_plans", "mp", []map[string]any{{"column1": "mp.id", "column2": "m.maintenance_plan_id"}})
+	m.AddRelation("left", "maintenance_assets", "ma", []map[string]any{{"column1": "ma.id", "column2": "m.maintenance_asset_id"}})
+	return m.Relations
+}
+
+// GetFilters returns the filter of the WorkOrder data in the database, used for querying.
+func (m *WorkOrder) GetFilters() []map[string]any {
+	m.AddFilter(map[string]any{"column1": "m.deleted_at", "operator": "=", "value": nil})
+	return m.Filters
+}
+
+// GetSorts returns the default sort of the WorkOrder data in the database, used for querying.
+func (m *WorkOrder) GetSorts() []map[string]any {
+	m.AddSort(map[string]any{"column": "m.due_date", "direction": "asc"})
+	m.AddSort(map[string]any{"column": "m.code", "direction": "asc"})
+	return m.Sorts
+}
+
+// GetFields returns list of the field of the WorkOrder data in the database, used for querying.
+func (m *WorkOrder) GetFields() map[string]map[string]any {
+	m.SetFields(m)
+	return m.Fields
+}
+
+// GetSchema returns the WorkOrder schema, used for querying.
+func (m *WorkOrder) GetSchema() map[string]any {
+	return m.SetSchema(m)
+}
+
+// OpenAPISchemaName returns the name of the WorkOrder schema in the open api documentation.
+func (WorkOrder) OpenAPISchemaName() string {
+	return "WorkOrder"
+}
+
+// GetOpenAPISchema returns the Open API Schema of the WorkOrder in the open api documentation.
+func (m *WorkOrder) GetOpenAPISchema() map[string]any {
+	return m.SetOpenAPISchema(m)
+}
+
+type WorkOrderList struct {
+	app.ListModel
+}
+
+// OpenAPISchemaName returns the name of the WorkOrderList schema in the open api documentation.
+func (WorkOrderList) OpenAPISchemaName() string {
+	return "WorkOrderList"
+}
+
+// GetOpenAPISchema returns the Open API Schema of the WorkOrderList in the open api documentation.
+func (p *WorkOrderList) GetOpenAPISchema() map[string]any {
+	return p.SetOpenAPISchema(&WorkOrder{})
+}
+
+// Statuses of the WorkOrder data, a done or cancelled work order is closed and can not be changed.
+const (
+	StatusOpen       = "open"
+	StatusScheduled  = "scheduled"
+	StatusInProgress = "in_progress"
+	StatusOnHold     = "on_hold"
+	StatusDone       = "done"
+	StatusCancelled  = "cancelled"
+)
+
+// Transitions is the list of the next statuses allowed from each status of the WorkOrder data.
+var Transitions = map[string][]string{
+	StatusOpen:       {StatusScheduled, StatusInProgress, StatusCancelled},
+	StatusScheduled:  {StatusInProgress, StatusOnHold, StatusCancelled},
+	StatusInProgress: {StatusOnHold, StatusDone, StatusCancelled},
+	StatusOnHold:     {StatusScheduled, StatusInProgress, StatusCancelled},
+}
+
+// IsClosed reports whether the status is a closed status of the WorkOrder data.
+func IsClosed(status string) bool {
+	return status == StatusDone || status == StatusCancelled
+}
+
+// IsHolding reports whether the WorkOrder data with the status holds its asset in maintenance, i.e. it is scheduled or started
+// and not closed yet. An open work order leaves the asset in service until it is scheduled or started.
+func IsHolding(status string) bool {
+	return status == StatusScheduled || status == StatusInProgress || status == StatusOnHold
+}
+
+// Priorities of the WorkOrder data.
+const (
+	PriorityLow      = "low"
+	PriorityMedium   = "medium"
+	PriorityHigh     = "high"
+	PriorityCritical = "critical"
+)
+
+// Types of the WorkOrderLine data.
+const (
+	LineLabour = "labour"
+	LinePart   = "part"
+)
+
+// ParamSchedule is the expected parameters for schedule the WorkOrder data.
+type ParamSchedule struct {
+	ScheduledDate app.NullDate `json:"scheduled_date" validate:"required"`
+	TechnicianID  app.NullUUID `json:"technician.id"`
+	VendorID      app.NullUUID `json:"vendor.id"`
+}
+
+// ParamStatus is the expected parameters for start, hold or cancel the WorkOrder data.
+type ParamStatus struct {
+	Reason app.NullText `json:"reason"`
+}
+
+// ParamComplete is the expected parameters for complete the WorkOrder data, it becomes the MaintenanceAsset record of the work order.
+// The amount is default to the total of the lines and the employee is default to the technician.
+type ParamComplete struct {
+	Date         app.NullDate    `json:"date"           validate:"required"`
+	Amount       app.NullFloat64 `json:"amount"         validate:"omitempty,gte=0"`
+	Resolution   app.NullText    `json:"resolution"     validate:"required"`
+	Description  app.NullText    `json:"description"`
+	EmployeeID   app.NullUUID    `json:"employee.id"`
+	AttachmentID app.NullUUID    `json:"attachment.id"`
+}
+
+// ParamCreate is the expected parameters for create a new WorkOrder data.
+type ParamCreate struct {
+	UseCaseHandler
+	AssetID           app.NullUUID    `json:"asset.id"            db:"m.asset_id"            gorm:"column:asset_id"            validate:"required"`
+	MaintenanceTypeID app.NullUUID    `json:"maintenance_type.id" db:"m.maintenance_type_id" gorm:"column:maintenance_type_id" validate:"required"`
+	DueDate           app.NullDate    `json:"due_date"            db:"m.due_date"            gorm:"column:due_date"            validate:"required"`
+	Lines             []WorkOrderLine `json:"lines"               db:"-"                     gorm:"-"                          validate:"omitempty,dive"`
+}
+
+// ParamUpdate is the expected parameters for update the WorkOrder data.
+type ParamUpdate struct {
+	UseCaseHandler
+}
+
+// ParamPartiallyUpdate is the expected parameters for partially update the WorkOrder data.
+type ParamPartiallyUpdate struct {
+	UseCaseHandler
+}
+
+// ParamDelete is the expected parameters for delete the WorkOrder data.
+type ParamDelete struct {
+	UseCaseHandler
+}
+
+// WorkOrderLine is the labour or the part line of WorkOrder data.
+type WorkOrderLine struct {
+	app.Model
+	ID          app.NullUUID    `json:"id"              db:"m.id"              gorm:"column:id;primaryKey"`
+	WorkOrderID app.NullUUID    `json:"work_order.id"   db:"m.work_order_id"   gorm:"column:work_order_id"`
+	Type        app.NullString  `json:"type"            db:"m.type"            gorm:"column:type"       validate:"required,oneof=labour part"`
+	Name        app.NullString  `json:"name"            db:"m.name"            gorm:"column:name"       validate:"required"`
+	Qty         app.NullFloat64 `json:"qty"             db:"m.qty"             gorm:"column:qty"        validate:"required,gt=0"`
+	UnitPrice   app.NullFloat64 `json:"unit_price"      db:"m.unit_price"      gorm:"column:unit_price" validate:"required,gte=0"`
+	Amount      app.NullFloat64 `json:"amount"          db:"m.amount"          gorm:"column:amount"`
+
+	EmployeeID   app.NullUUID   `json:"employee.id"     db:"m.employee_id"     gorm:"column:employee_id"`
+	EmployeeCode app.NullString `json:"employee.code"   db:"emp.code"          gorm:"-"`
+	EmployeeName app.NullString `json:"employee.name"   db:"emp.name"          gorm:"-"`
+
+	// a part line of a spare part is issued from the stock of the branch once the work order is completed
+	ConsumableID   app.NullUUID   `json:"consumable.id"   db:"m.consumable_id"   gorm:"column:consumable_id"`
+	ConsumableCode app.NullString `json:"consumable.code" db:"cns.code"          gorm:"-"`
+	ConsumableName app.NullString `json:"consumable.name" db:"cns.name"          gorm:"-"`
+	BranchID       app.NullUUID   `json:"branch.id"       db:"m.branch_id"       gorm:"column:branch_id"`
+	BranchCode     app.NullString `json:"branch.code"     db:"brc.code"          gorm:"-"`
+	BranchName     app.NullString `json:"branch.name"     db:"brc.name"          gorm:"-"`
+
+	CreatedAt app.NullDateTime `json:"created_at"      db:"m.created_at"      gorm:"column:created_at"`
+	UpdatedAt app.NullDateTime `json:"updated_at"      db:"m.updated_at"      gorm:"column:updated_at"`
+	DeletedAt app.NullDateTime `json:"deleted_at"      db:"m.deleted_at,hide" gorm:"column:deleted_at"`
+}
+
+// EndPoint returns the WorkOrderLine end point, it used for cache key, etc.
+func (WorkOrderLine) EndPoint() string {
+	return "work_order_lines"
+}
+
+// TableVersion returns the versions of the WorkOrderLine table in the database.
+// Change this value with date format YY.MM.DDHHii when any table structure changes.
+func (WorkOrderLine) TableVersion() string {
+	return "26.10.192500"
+}
+
+// TableName returns the name of the WorkOrderLine table in the database.
+func (WorkOrderLine) TableName() string {
+	return "work_order_lines"
+}
+
+// TableAliasName returns the table alias name of the WorkOrderLine table, used for querying.
+func (WorkOrderLine) TableAliasName() string {
+	return "m"
+}
+
+// GetRelations returns the relations of the WorkOrderLine data in the database, used for querying.
+func (m *WorkOrderLine) GetRelations() map[string]map[string]any {
+	m.AddRelation("left", "employees", "emp", []map[string]any{{"column1": "emp.id", "column2": "m.employee_id"}})
+	m.AddRelation("left", "consumables", "cns", []map[string]any{{"column1": "cns.id", "column2": "m.consumable_id"}})
+	m.AddRelation("left", "branches", "brc", []map[string]any{{"column1": "brc.id", "column2": "m.branch_id"}})
+	return m.Relations
+}
+
+// GetFilters returns the filter of the WorkOrderLine data in the database, used for querying.
+func (m *WorkOrderLine) GetFilters() []map[string]any {
+	m.AddFilter(map[string]any{"column1": "m.deleted_at", "operator": "=", "value": nil})
+	return m.Filters
+}
+
+// GetSorts returns the default sort of the WorkOrderLine data in the database, used for querying.
+func (m *WorkOrderLine) GetSorts() []map[string]any {
+	m.AddSort(map[string]any{"column": "m.created_at", "direction": "asc"})
+	return m.Sorts
+}
+
+// GetFields returns list of the field of the WorkOrderLine data in the database, used for querying.
+func (m *WorkOrderLine) GetFields() map[string]map[string]any {
+	m.SetFields(m)
+	return m.Fields
+}
+
+// GetSchema returns the WorkOrderLine schema, used for querying.
+func (m *WorkOrderLine) GetSchema() map[string]any {
+	return m.SetSchema(m)
+}
+
+// OpenAPISchemaName returns the name of the WorkOrderLine schema in the open api documentation.
+func (WorkOrderLine) OpenAPISchemaName() string {
+	return "WorkOrderLine"
+}
+
+// GetOpenAPISchema returns the Open API Schema of the WorkOrderLine in the open api documentation.
+func (m *WorkOrderLine) GetOpenAPISchema() map[string]any {
+	return m.SetOpenAPISchema(m)
+}
@@ -0,0 +1,147 @@
+package workorder
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gofiber/fiber/v2/utils"
+	"gorm.io/gorm"
+
+	"github.com/maulanar/go_asset_tracking_management/app"
+)
+
+// prepareTest prepares the test.
+func prepareTest(tb testing.TB) {
+	app.Test()
+	tx := app.Test().Tx
+	app.DB().RegisterTable("main", WorkOrder{})
+	app.DB().MigrateTable(tx, "main", app.Setting{})
+	tx.Session(&gorm.Session{AllowGlobalUpdate: true}).Delete(&WorkOrder{})
+
+	app.Server().AddMiddleware(app.Test().NewCtx([]string{
+		"work_orders.detail",
+		"work_orders.list",
+		"work_orders.create",
+		"work_orders.edit",
+		"work_orders.delete",
+	}))
+	app.Server().AddRoute("/work_orders", "POST", REST().Create, nil)
+	app.Server().AddRoute("/work_orders", "GET", REST().Get, nil)
+	app.Server().AddRoute("/work_orders/:id", "GET", REST().GetByID, nil)
+	app.Server().AddRoute("/work_orders/:id", "PUT", REST().UpdateByID, nil)
+	app.Server().AddRoute("/work_orders/:id", "PATCH", REST().PartiallyUpdateByID, nil)
+	app.Server().AddRoute("/work_orders/:id", "DELETE", REST().DeleteByID, nil)
+}
+
+// getTestWorkOrderID returns an available WorkOrder ID.
+func getTestWorkOrderID() string {
+	return "todo"
+}
+
+// tests is test scenario.
+var tests = []struct {
+	description  string // description of the test case
+	method       string // method to test
+	path         string // route path to test
+	token        string // token to test
+	bodyRequest  string // body to test
+	expectedCode int    // expected HTTP status code
+	expectedBody string // expected body response
+}{
+	{
+		description:  "Get empty list of WorkOrder",
+		method:       "GET",
+		path:         "/work_orders",
+		token:        app.TestFullAccessToken,
+		expectedCode: http.StatusOK,
+		expectedBody: `{"count":0,"results":[]}`,
+	},
+	{
+		description:  "Create WorkOrder with minimum payload",
+		method:       "POST",
+		path:         "/work_orders",
+		token:        app.TestFullAccessToken,
+		bodyRequest:  `{"name":"Kilogram"}`,
+		expectedCode: http.StatusCreated,
+		expectedBody: `{"name":"Kilogram"}`,
+	},
+	{
+		description:  "Get WorkOrder by ID",
+		method:       "GET",
+		path:         "/work_orders/" + getTestWorkOrderID(),
+		token:        app.TestFullAccessToken,
+		expectedCode: http.StatusOK,
+		expectedBody: `{"name":"Kilogram"}`,
+	},
+	{
+		description:  "Update WorkOrder by ID",
+		method:       "PUT",
+		path:         "/work_orders/" + getTestWorkOrderID(),
+		token:        app.TestFullAccessToken,
+		bodyRequest:  `{"reason":"Update WorkOrder by ID","name":"KG"}`,
+		expectedCode: http.StatusOK,
+		expectedBody: `{"name":"KG"}`,
+	},
+	{
+		description:  "Partially update WorkOrder by ID",
+		method:       "PATCH",
+		path:         "/work_orders/" + getTestWorkOrderID(),
+		token:        app.TestFullAccessToken,
+		bodyRequest:  `{"reason":"Partially Update WorkOrder by ID","name":"Kilo Gram"}`,
+		expectedCode: http.StatusOK,
+		expectedBody: `{"name":"Kilo Gram"}`,
+	},
+	{
+		description:  "Delete WorkOrder by ID",
+		method:       "DELETE",
+		path:         "/work_orders/" + getTestWorkOrderID(),
+		token:        app.TestFullAccessToken,
+		bodyRequest:  `{"reason":"Delete WorkOrder by ID"}`,
+		expectedCode: http.StatusOK,
+		expectedBody: `{"code":200}`,
+	},
+}
+
+// TestWorkOrderREST tests the REST API of WorkOrder data with specified scenario.
+func TestWorkOrderREST(t *testing.T) {
+	prepareTest(t)
+
+	// Iterate through test single test cases
+	for _, test := range tests {
+
+		// Create a new http request with the route from the test case
+		req := httptest.NewRequest(test.method, test.path, strings.NewReader(test.bodyRequest))
+		req.Header.Add("Authorization", "Bearer "+test.token)
+		req.Header.Add("Content-Type", "application/json")
+
+		// Perform the request plain with the app, the second argument is a request latency (set to -1 for no latency)
+		res, err := app.Server().Test(req)
+
+		// Verify if the status code is as expected
+		utils.AssertEqual(t, nil, err, "app.Server().Test(req)")
+		utils.AssertEqual(t, test.expectedCode, res.StatusCode, test.description)
+
+		// Verify if the body response is as expected
+		body, err := io.ReadAll(res.Body)
+		utils.AssertEqual(t, nil, err, "io.ReadAll(res.Body)")
+		app.Test().AssertMatchJSONElement(t, []byte(test.expectedBody), body, test.description)
+		res.Body.Close()
+	}
+}
+
+// BenchmarkWorkOrderREST tests the REST API of WorkOrder data with specified scenario.
+func BenchmarkWorkOrderREST(b *testing.B) {
+	b.ReportAllocs()
+	prepareTest(b)
+	for i := 0; i < b.N; i++ {
+		for _, test := range tests {
+			req := httptest.NewRequest(test.method, test.path, strings.NewReader(test.bodyRequest))
+			req.Header.Add("Authorization", "Bearer "+test.token)
+			req.Header.Add("Content-Type", "application/json")
+			app.Server().Test(req)
+		}
+	}
+}
@@ -0,0 +1,849 @@
+package workorder
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"slices"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"grest.dev/grest"
+
+	"github.com/maulanar/go_asset_tracking_management/app"
+	"github.com/maulanar/go_asset_tracking_management/src/asset"
+	"github.com/maulanar/go_asset_tracking_management/src/consumable"
+	"github.com/maulanar/go_asset_tracking_management/src/employee"
+	"github.com/maulanar/go_asset_tracking_management/src/maintenanceasset"
+	"github.com/maulanar/go_asset_tracking_management/src/maintenancetype"
+	"github.com/maulanar/go_asset_tracking_management/src/notification"
+	"github.com/maulanar/go_asset_tracking_management/src/vendor"
+)
+
+// UseCase returns a UseCaseHandler for expected use case functional.
+func UseCase(ctx app.Ctx, query ...url.Values) UseCaseHandler {
+	u := UseCaseHandler{
+		Ctx:   &ctx,
+		Query: url.Values{},
+	}
+	if len(query) > 0 {
+		u.Query = query[0]
+	}
+	return u
+}
+
+// UseCaseHandler provides a convenient interface for WorkOrder use case, use UseCase to access UseCaseHandler.
+type UseCaseHandler struct {
+	WorkOrder
+
+	// injectable dependencies
+	Ctx   *app.Ctx   `json:"-" db:"-" gorm:"-"`
+	Query url.Values `json:"-" db:"-" gorm:"-"`
+}
+
+// Async return UseCaseHandler with async process.
+func (u UseCaseHandler) Async(ctx app.Ctx, query ...url.Values) UseCaseHandler {
+	ctx.IsAsync = true
+	return UseCase(ctx, query...)
+}
+
+// GetByID returns the WorkOrder data for the specified ID.
+func (u UseCaseHandler) GetByID(id string) (WorkOrder, error) {
+	res := WorkOrder{}
+
+	// check permission
+	err := u.Ctx.ValidatePermission("work_orders.detail")
+	if err != nil {
+		return res, err
+	}
+
+	// get from cache and return if exists
+	cacheKey := u.EndPoint() + "." + id
+	app.Cache().Get(cacheKey, &res)
+	if res.ID.Valid {
+		return res, err
+	}
+
+	// prepare db for current ctx
+	tx, err := u.Ctx.DB()
+	if err != nil {
+		return res, app.Error().New(http.StatusInternalServerError, err.Error())
+	}
+
+	// get from db
+	key := "id"
+	if !app.Validator().IsValid(id, "uuid") {
+		key = "code"
+	}
+	u.Query.Add(key, id)
+	err = app.Query().First(tx, &res, u.Query)
+	if err != nil {
+		return res, u.Ctx.NotFoundError(err, u.EndPoint(), key, id)
+	}
+
+	// get line items
+	res.Lines, err = u.GetLines(res.ID.String)
+	if err != nil {
+		return res, err
+	}
+
+	// save to cache and return if exists
+	app.Cache().Set(cacheKey, res)
+	return res, err
+}
+
+// Get returns the list of WorkOrder data.
+func (u UseCaseHandler) Get() (app.ListModel, error) {
+	res := app.ListModel{}
+
+	// check permission
+	err := u.Ctx.ValidatePermission("work_orders.list")
+	if err != nil {
+		return res, err
+	}
+	// get from cache and return if exists
+	cacheKey := u.EndPoint() + "?" + u.Query.Encode()
+	err = app.Cache().Get(cacheKey, &res)
+	if err == nil {
+		return res, err
+	}
+
+	// prepare db for current ctx
+	tx, err := u.Ctx.DB()
+	if err != nil {
+		return res, app.Error().New(http.StatusInternalServerError, err.Error())
+	}
+
+	// set pagination info
+	res.Results.PageContext.Count,
+		res.Results.PageContext.Page,
+		res.Results.PageContext.PerPage,
+		res.Results.PageContext.PageCount,
+		err = app.Query().PaginationInfo(tx, &WorkOrder{}, u.Query)
+	if err != nil {
+		return res, app.Error().New(http.StatusInternalServerError, err.Error())
+	}
+	// return data count if $per_page set to 0
+	if res.Results.PageContext.PerPage == 0 {
+		return res, err
+	}
+
+	// find data
+	data, err := app.Query().Find(tx, &WorkOrder{}, u.Query)
+	if err != nil {
+		return res, app.Error().New(http.StatusInternalServerError, err.Error())
+	}
+	res.SetData(data, u.Query)
+
+	// save to cache and return if exists
+	app.Cache().Set(cacheKey, res)
+	return res, err
+}
+
+// Create creates a new data WorkOrder with specified parameters.
+func (u *UseCaseHandler) Create(p *ParamCreate) error {
+
+	// check permission
+	err := u.Ctx.ValidatePermission("work_orders.create")
+	if err != nil {
+		return err
+	}
+
+	// validate param
+	err = u.Ctx.ValidateParam(p)
+	if err != nil {
+		return err
+	}
+
+	// set default value for undefined field
+	err = u.setDefaultValue(WorkOrder{})
+	if err != nil {
+		return err
+	}
+
+	// prepare db for current ctx
+	tx, err := u.Ctx.DB()
+	if err != nil {
+		return app.Error().New(http.StatusInternalServerError, err.Error())
+	}
+
+	// save data to db
+	err = tx.Model(&u).Create(&u).Error
+	if err != nil {
+		return app.Error().New(http.StatusInternalServerError, err.Error())
+	}
+
+	// save line items
+	err = u.saveLines(tx)
+	if err != nil {
+		return err
+	}
+
+	// invalidate cache
+	app.Cache().Invalidate(u.EndPoint())
+
+	// save history (user activity), send webhook, etc
+	go u.Ctx.Hook("POST", "create", p.ID.String, p)
+	return nil
+}
+
+// UpdateByID updates the WorkOrder data for the specified ID with specified parameters.
+func (u UseCaseHandler) UpdateByID(id string, p *ParamUpdate) error {
+
+	// check permission
+	err := u.Ctx.ValidatePermission("work_orders.edit")
+	if err != nil {
+		return err
+	}
+
+	// validate param
+	err = u.Ctx.ValidateParam(p)
+	if err != nil {
+		return err
+	}
+
+	// get previous data
+	old, err := u.GetByID(id)
+	if err != nil {
+		return err
+	}
+
+	// set default value for undefined field
+	err = u.setDefaultValue(old)
+	if err != nil {
+		return err
+	}
+
+	// prepare db for current ctx
+	tx, err := u.Ctx.DB()
+	if err != nil {
+		return app.Error().New(http.StatusInternalServerError, err.Error())
+	}
+
+	// update data on the db
+	err = tx.Model(&u).Where("id = ?", old.ID).Updates(u).Error
+	if err != nil {
+		return app.Error().New(http.StatusInternalServerError, err.Error())
+	}
+
+	// save line items
+	err = u.saveLines(tx)
+	if err != nil {
+		return err
+	}
+
+	// invalidate cache
+	app.Cache().Invalidate(u.EndPoint(), old.ID.String)
+
+	// save history (user activity), send webhook, etc
+	go u.Ctx.Hook("PUT", "Update", old.ID.String, old)
+	return nil
+}
+
+// PartiallyUpdateByID updates the WorkOrder data for the specified ID with specified parameters.
+func (u UseCaseHandler) PartiallyUpdateByID(id string, p *ParamPartiallyUpdate) error {
+
+	// check permission
+	err := u.Ctx.ValidatePermission("work_orders.edit")
+	if err != nil {
+		return err
+	}
+
+	// validate param
+	err = u.Ctx.ValidateParam(p)
+	if err != nil {
+		return err
+	}
+
+	// get previous data
+	old, err := u.GetByID(id)
+	if err != nil {
+		return err
+	}
+
+	// set default value for undefined field
+	err = u.setDefaultValue(old)
+	if err != nil {
+		return err
+	}
+
+	// prepare db for current ctx
+	tx, err := u.Ctx.DB()
+	if err != nil {
+		return app.Error().New(http.StatusInternalServerError, err.Error())
+	}
+
+	// update data on the db
+	err = tx.Model(&u).Where("id = ?", old.ID).Updates(u).Error
+	if err != nil {
+		return app.Error().New(http.StatusInternalServerError, err.Error())
+	}
+
+	// save line items
+	err = u.saveLines(tx)
+	if err != nil {
+		return err
+	}
+
+	// invalidate cache
+	app.Cache().Invalidate(u.EndPoint(), old.ID.String)
+
+	// save history (user activity), send webhook, etc
+	go u.Ctx.Hook("PATCH", "Partially Update", old.ID.String, old)
+	return nil
+}
+
+// DeleteByID deletes the WorkOrder data for the specified ID.
+func (u UseCaseHandler) DeleteByID(id string, p *ParamDelete) error {
+
+	// check permission
+	err := u.Ctx.ValidatePermission("work_orders.delete")
+	if err != nil {
+		return err
+	}
+
+	// validate param
+	err = u.Ctx.ValidateParam(p)
+	if err != nil {
+		return err
+	}
+
+	// get previous data
+	old, err := u.GetByID(id)
+	if err != nil {
+		return err
+	}
+	if old.Status.String != StatusOpen {
+		return app.Error().New(http.StatusBadRequest, u.Ctx.Trans("work_order_not_deletable", map[string]string{
+			"code":   old.Code.String,
+			"status": old.Status.String,
+		}))
+	}
+
+	// prepare db for current ctx
+	tx, err := u.Ctx.DB()
+	if err != nil {
+		return app.Error().New(http.StatusInternalServerError, err.Error())
+	}
+
+	// update data on the db
+	err = tx.Model(&p).Where("id = ?", old.ID).Update("deleted_at", time.Now().UTC()).Error
+	if err != nil {
+		return app.Error().New(http.StatusInternalServerError, err.Error())
+	}
+	err = tx.Model(&WorkOrderLine{}).Where("work_order_id = ?", old.ID).Where("deleted_at IS NULL").Update("deleted_at", time.Now().UTC()).Error
+	if err != nil {
+		return app.Error().New(http.StatusInternalServerError, err.Error())
+	}
+	if IsHolding(old.Status.String) {
+		err = ReleaseAsset(tx, old)
+		if err != nil {
+			return app.Error().New(http.StatusInternalServerError, err.Error())
+		}
+	}
+
+	// invalidate cache
+	app.Cache().Invalidate(u.EndPoint(), old.ID.String)
+
+	// save history (user activity), send webhook, etc
+	go u.Ctx.Hook("DELETE", "DELETE", old.ID.String, old)
+	return nil
+}
+
+// setDefaultValue set default value of undefined field when create or update WorkOrder data.
+func (u *UseCaseHandler) setDefaultValue(old WorkOrder) error {
+	if !old.ID.Valid {
+		u.ID = app.NewNullUUID()
+	} else {
+		u.ID = old.ID
+	}
+
+	// a closed work order can not be changed, and the status is only changed by its transitions
+	if old.ID.Valid && IsClosed(old.Status.String) {
+		return app.Error().New(http.StatusBadRequest, u.Ctx.Trans("work_order_closed", map[string]string{
+			"code":   old.Code.String,
+			"status": old.Status.String,
+		}))
+	}
+	if u.Status.Valid && u.Status.String != old.Status.String && (old.ID.Valid || u.Status.String != StatusOpen) {
+		return app.Error().New(http.StatusBadRequest, u.Ctx.Trans("work_order_status_invalid", map[string]string{
+			"code":   old.Code.String,
+			"status": u.Status.String,
+		}))
+	}
+	if old.ID.Valid && u.AssetID.Valid && u.AssetID.String != old.AssetID.String {
+		return app.Error().New(http.StatusBadRequest, u.Ctx.Trans("work_order_asset_immutable", map[string]string{"code": old.Code.String}))
+	}
+	u.Status = old.Status
+	u.AssetPreviousStatus = old.AssetPreviousStatus
+
+	// Penentuan kode
+	if u.Code.Valid && u.Code.String != "" {
+		// Jika kode dikirim dan berbeda dengan data lama, cek ke DB
+		if !old.Code.Valid || u.Code.String != old.Code.String {
+			err := app.Common().IsFieldValueExists(u.Ctx, u.EndPoint(), "Code", u.TableName(), "code", u.Code.String)
+			if err != nil {
+				return err
+			}
+		}
+	} else {
+		// Jika tidak kirim kode dan data lama ada, gunakan data lama
+		if old.Code.Valid && old.Code.String != "" {
+			u.Code = old.Code
+		} else {
+			// Jika tidak kirim kode dan data lama tidak ada, generate baru
+			newCode, err := app.Common().GenerateCode(u.Ctx, u.TableName(), "code", "Work Order")
+			if err != nil {
+				return err
+			}
+			u.Code.Set(newCode)
+		}
+	}
+
+	if u.AssetID.Valid && u.AssetID.String != old.AssetID.String {
+		ass, err := asset.UseCase(*u.Ctx, url.Values{}).GetByID(u.AssetID.String)
+		if err != nil {
+			return err
+		}
+		if ass.Status.String == asset.StatusDisposed {
+			return app.Error().New(http.StatusBadRequest, u.Ctx.Trans("asset_disposed", map[string]string{"code": ass.Code.String}))
+		}
+	}
+	if u.MaintenanceTypeID.Valid && u.MaintenanceTypeID.String != old.MaintenanceTypeID.String {
+		_, err := maintenancetype.UseCase(*u.Ctx, url.Values{}).GetByID(u.MaintenanceTypeID.String)
+		if err != nil {
+			return err
+		}
+	}
+	err := u.validateAssignee(old)
+	if err != nil {
+		return err
+	}
+
+	// line items, the labour and the parts amounts are the totals of their lines
+	if len(u.Lines) > 0 {
+		labour, parts := float64(0), float64(0)
+		for i := range u.Lines {
+			line := &u.Lines[i]
+			err := u.Ctx.ValidateParam(line)
+			if err != nil {
+				return err
+			}
+			if line.EmployeeID.Valid && line.EmployeeID.String != "" {
+				_, err = employee.UseCase(*u.Ctx, url.Values{}).GetByID(line.EmployeeID.String)
+				if err != nil {
+					return err
+				}
+			}
+			if line.ConsumableID.Valid && line.ConsumableID.String != "" {
+				cns, err := consumable.UseCase(*u.Ctx, url.Values{}).GetByID(line.ConsumableID.String)
+				if err != nil {
+					return err
+				}
+				if line.Type.String != LinePart || cns.Type.String != consumable.TypeSparePart || !cns.IsActive.Bool {
+					return app.Error().New(http.StatusBadRequest, u.Ctx.Trans("maintenance_part_invalid", map[string]string{"code": cns.Code.String}))
+				}
+			}
+			line.ID = app.NewNullUUID()
+			line.WorkOrderID = u.ID
+			line.Amount.Set(line.Qty.Float64 * line.UnitPrice.Float64)
+			if line.Type.String == LineLabour {
+				labour += line.Amount.Float64
+			} else {
+				parts += line.Amount.Float64
+			}
+		}
+		u.LabourAmount.Set(labour)
+		u.PartsAmount.Set(parts)
+		u.TotalAmount.Set(labour + parts)
+	}
+
+	if !old.ID.Valid {
+		u.Status.Set(StatusOpen)
+		if !u.Priority.Valid || u.Priority.String == "" {
+			u.Priority.Set(PriorityMedium)
+		}
+		if !u.TotalAmount.Valid {
+			u.LabourAmount.Set(0)
+			u.PartsAmount.Set(0)
+			u.TotalAmount.Set(0)
+		}
+	}
+
+	// an open work order is overdue once its due date has passed
+	dueDate := old.DueDate
+	if u.DueDate.Valid {
+		dueDate = u.DueDate
+	}
+	u.IsOverdue.Set(dueDate.Valid && dueDate.Time.Before(today()))
+
+	return nil
+}
+
+// validateAssignee validates the technician and the vendor assigned to the WorkOrder data.
+func (u *UseCaseHandler) validateAssignee(old WorkOrder) error {
+	if u.TechnicianID.Valid && u.TechnicianID.String != "" && u.TechnicianID.String != old.TechnicianID.String {
+		_, err := employee.UseCase(*u.Ctx, url.Values{}).GetByID(u.TechnicianID.String)
+		if err != nil {
+			return err
+		}
+	}
+	if u.VendorID.Valid && u.VendorID.String != "" && u.VendorID.String != old.VendorID.String {
+		_, err := vendor.UseCase(*u.Ctx, url.Values{}).GetByID(u.VendorID.String)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// saveLines replaces the line items of the WorkOrder data with the prepared u.Lines.
+func (u *UseCaseHandler) saveLines(tx *gorm.DB) error {
+	if len(u.Lines) == 0 {
+		return nil
+	}
+
+	err := tx.Model(&WorkOrderLine{}).Where("work_order_id = ?", u.ID).Where("deleted_at IS NULL").Update("deleted_at", time.Now().UTC()).Error
+	if err != nil {
+		return app.Error().New(http.StatusInternalServerError, err.Error())
+	}
+	for _, line := range u.Lines {
+		err = tx.Create(&line).Error
+		if err != nil {
+			return app.Error().New(http.StatusInternalServerError, err.Error())
+		}
+	}
+	return nil
+}
+
+// GetLines returns the line items of the WorkOrder data for the specified work order ID.
+func (u UseCaseHandler) GetLines(workOrderID string) ([]WorkOrderLine, error) {
+	res := []WorkOrderLine{}
+
+	// prepare db for current ctx
+	tx, err := u.Ctx.DB()
+	if err != nil {
+		return res, app.Error().New(http.StatusInternalServerError, err.Error())
+	}
+
+	query := url.Values{}
+	query.Add("work_order.id", workOrderID)
+	query.Add(grest.QueryDisablePagination, "true")
+	data, err := app.Query().Find(tx, &WorkOrderLine{}, query)
+	if err != nil {
+		return res, app.Error().New(http.StatusInternalServerError, err.Error())
+	}
+
+	jByte, err := json.Marshal(data)
+	if err != nil {
+		return res, app.Error().New(http.StatusInternalServerError, err.Error())
+	}
+	err = app.BindJSON(jByte, &res)
+	if err != nil {
+		return res, app.Error().New(http.StatusInternalServerError, err.Error())
+	}
+	return res, nil
+}
+
+// ScheduleByID schedules the WorkOrder data for the specified ID on the scheduled date, optionally assigning the technician or the vendor.
+func (u UseCaseHandler) ScheduleByID(id string, p *ParamSchedule) error {
+	err := u.Ctx.ValidateParam(p)
+	if err != nil {
+		return err
+	}
+	u.TechnicianID, u.VendorID = p.TechnicianID, p.VendorID
+	return u.setStatusByID(id, "work_orders.schedule", StatusScheduled, func(old WorkOrder, fields map[string]any) error {
+		err := u.validateAssignee(old)
+		if err != nil {
+			return err
+		}
+		fields["scheduled_date"] = p.ScheduledDate
+		if p.TechnicianID.Valid {
+			fields["technician_id"] = p.TechnicianID
+		}
+		if p.VendorID.Valid {
+			fields["vendor_id"] = p.VendorID
+		}
+		return nil
+	})
+}
+
+// StartByID starts or resumes the work on the WorkOrder data for the specified ID.
+func (u UseCaseHandler) StartByID(id string, p *ParamStatus) error {
+	return u.setStatusByID(id, "work_orders.start", StatusInProgress, func(old WorkOrder, fields map[string]any) error {
+		fields["status_reason"] = p.Reason
+		return nil
+	})
+}
+
+// HoldByID puts the work on the WorkOrder data for the specified ID on hold, e.g. while waiting for the parts.
+func (u UseCaseHandler) HoldByID(id string, p *ParamStatus) error {
+	return u.setStatusByID(id, "work_orders.hold", StatusOnHold, func(old WorkOrder, fields map[string]any) error {
+		fields["status_reason"] = p.Reason
+		return nil
+	})
+}
+
+// CancelByID cancels the WorkOrder data for the specified ID.
+func (u UseCaseHandler) CancelByID(id string, p *ParamStatus) error {
+	return u.setStatusByID(id, "work_orders.cancel", StatusCancelled, func(old WorkOrder, fields map[string]any) error {
+		fields["status_reason"] = p.Reason
+		return nil
+	})
+}
+
+// CompleteByID completes the WorkOrder data for the specified ID, the maintenance is recorded as a new MaintenanceAsset data
+// which is linked to the work order.
+func (u UseCaseHandler) CompleteByID(id string, p *ParamComplete) error {
+	err := u.Ctx.ValidateParam(p)
+	if err != nil {
+		return err
+	}
+	return u.setStatusByID(id, "work_orders.complete", StatusDone, func(old WorkOrder, fields map[string]any) error {
+
+		// record the maintenance
+		maUC := maintenanceasset.UseCase(*u.Ctx, url.Values{})
+		maUC.Date = p.Date
+
+		// the part lines of a spare part are issued from the stock by the maintenance
+		stocked := float64(0)
+		for _, line := range old.Lines {
+			if line.Type.String != LinePart || !line.ConsumableID.Valid {
+				continue
+			}
+			part := maintenanceasset.MaintenanceAssetPart{}
+			part.ConsumableID = line.ConsumableID
+			part.BranchID = line.BranchID
+			part.Qty = line.Qty
+			part.UnitCost = line.UnitPrice
+			maUC.Parts = append(maUC.Parts, part)
+			stocked += line.Amount.Float64
+		}
+		maUC.Amount = p.Amount
+		if !maUC.Amount.Valid {
+			maUC.Amount.Set(old.TotalAmount.Float64 - stocked)
+		}
+		maUC.AssetID = old.AssetID
+		maUC.EmployeeId = p.EmployeeID
+		if !maUC.EmployeeId.Valid {
+			maUC.EmployeeId = old.TechnicianID
+		}
+		maUC.MaintenanceTypeID = old.MaintenanceTypeID
+		maUC.AttachmentId = p.AttachmentID
+		maUC.Description = p.Description
+		if !maUC.Description.Valid {
+			maUC.Description = p.Resolution
+		}
+		err := maUC.Create(&maintenanceasset.ParamCreate{
+			UseCaseHandler: maUC,
+			Date:           maUC.Date,
+			Amount:         maUC.Amount,
+			AssetID:        maUC.AssetID,
+			EmployeeId:     maUC.EmployeeId,
+		})
+		if err != nil {
+			return err
+		}
+
+		fields["resolution"] = p.Resolution
+		fields["maintenance_asset_id"] = maUC.ID
+		return nil
+	})
+}
+
+// setStatusByID changes the status of the WorkOrder data for the specified ID when the transition is allowed,
+// the time of the transition is recorded, the asset is held once the work order is scheduled or started
+// and released once the work order is closed.
+// The prepare func adds the fields of the transition.
+func (u UseCaseHandler) setStatusByID(id, aclKey, to string, prepare func(old WorkOrder, fields map[string]any) error) error {
+
+	// check permission
+	err := u.Ctx.ValidatePermission(aclKey)
+	if err != nil {
+		return err
+	}
+
+	// get previous data
+	old, err := u.GetByID(id)
+	if err != nil {
+		return err
+	}
+	if !slices.Contains(Transitions[old.Status.String], to) {
+		return app.Error().New(http.StatusBadRequest, u.Ctx.Trans("work_order_transition_invalid", map[string]string{
+			"code": old.Code.String,
+			"from": old.Status.String,
+			"to":   to,
+		}))
+	}
+
+	now := time.Now().UTC()
+	fields := map[string]any{
+		"status":     to,
+		"is_overdue": !IsClosed(to) && old.DueDate.Valid && old.DueDate.Time.Before(today()),
+		"updated_at": now,
+	}
+	switch to {
+	case StatusScheduled:
+		fields["scheduled_at"] = now
+	case StatusInProgress:
+		fields["started_at"] = now
+	case StatusOnHold:
+		fields["on_hold_at"] = now
+	case StatusDone:
+		fields["completed_at"] = now
+	case StatusCancelled:
+		fields["cancelled_at"] = now
+	}
+	err = prepare(old, fields)
+	if err != nil {
+		return err
+	}
+
+	// prepare db for current ctx
+	tx, err := u.Ctx.DB()
+	if err != nil {
+		return app.Error().New(http.StatusInternalServerError, err.Error())
+	}
+
+	// the asset is in maintenance once the work order is scheduled or started until it is closed
+	if IsHolding(to) && !IsHolding(old.Status.String) {
+		previous, err := HoldAsset(tx, old.AssetID.String)
+		if err != nil {
+			return app.Error().New(http.StatusInternalServerError, err.Error())
+		}
+		fields["asset_previous_status"] = previous
+	}
+
+	// update data on the db
+	err = tx.Model(&WorkOrder{}).Where("id = ?", old.ID).Updates(fields).Error
+	if err != nil {
+		return app.Error().New(http.StatusInternalServerError, err.Error())
+	}
+	if IsClosed(to) && IsHolding(old.Status.String) {
+		err = ReleaseAsset(tx, old)
+		if err != nil {
+			return app.Error().New(http.StatusInternalServerError, err.Error())
+		}
+	}
+
+	// invalidate cache
+	app.Cache().Invalidate(u.EndPoint(), old.ID.String)
+
+	// save history (user activity), send webhook, etc
+	go u.Ctx.Hook("POST", to, old.ID.String, old)
+	return nil
+}
+
+// HoldAsset sets the status of the asset to in maintenance and returns its previous status, the previous status is empty
+// when the asset is already in maintenance by another work order.
+func HoldAsset(tx *gorm.DB, assetID string) (app.NullString, error) {
+	previous := app.NullString{}
+	ass := asset.Asset{}
+	err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+		Select("id", "status").
+		Where("id = ?", assetID).
+		Limit(1).Find(&ass).Error
+	if err != nil || !ass.ID.Valid || ass.Status.String == asset.StatusInMaintenance {
+		return previous, err
+	}
+	previous.Set(ass.Status.String)
+	err = tx.Model(&asset.Asset{}).Where("id = ?", assetID).Update("status", asset.StatusInMaintenance).Error
+	if err != nil {
+		return previous, err
+	}
+	app.Cache().Invalidate(asset.Asset{}.EndPoint(), assetID)
+	return previous, nil
+}
+
+// ReleaseAsset restores the status of the asset of the closed work order once no other work order holds the asset,
+// otherwise the previous status is handed over to the work order which holds it.
+func ReleaseAsset(tx *gorm.DB, wo WorkOrder) error {
+	other := WorkOrder{}
+	err := tx.Where("asset_id = ?", wo.AssetID).
+		Where("id <> ?", wo.ID).
+		Where("status IN ?", []string{StatusScheduled, StatusInProgress, StatusOnHold}).
+		Where("deleted_at IS NULL").
+		Order("created_at ASC").
+		Limit(1).Find(&other).Error
+	if err != nil {
+		return err
+	}
+	if other.ID.Valid {
+		if wo.AssetPreviousStatus.String == "" || other.AssetPreviousStatus.String != "" {
+			return nil
+		}
+		return tx.Model(&WorkOrder{}).Where("id = ?", other.ID).Update("asset_previous_status", wo.AssetPreviousStatus).Error
+	}
+
+	status := wo.AssetPreviousStatus.String
+	if status == "" || status == asset.StatusInMaintenance {
+		status = asset.StatusAvailable
+	}
+	err = tx.Model(&asset.Asset{}).
+		Where("id = ?", wo.AssetID).
+		Where("status = ?", asset.StatusInMaintenance).
+		Update("status", status).Error
+	if err != nil {
+		return err
+	}
+	app.Cache().Invalidate(asset.Asset{}.EndPoint(), wo.AssetID.String)
+	return nil
+}
+
+// today returns the start of the current day.
+func today() time.Time {
+	now := time.Now()
+	return time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+}
+
+// JobMarkOverdue flags the open work orders whose due date has passed as overdue and notifies them once.
+func JobMarkOverdue() {
+	tx, err := app.DB().Conn("main")
+	if err != nil {
+		app.Logger().Error().Err(err).Msg("Failed to connect to the database.")
+		return
+	}
+
+	type overdueWorkOrder struct {
+		ID        string
+		Code      string
+		DueDate   time.Time
+		AssetCode string
+		AssetName string
+	}
+	orders := []overdueWorkOrder{}
+	err = tx.Raw(`
+UPDATE work_orders wo SET is_overdue = true, updated_at = NOW()
+FROM assets a
+WHERE a.id = wo.asset_id
+  AND wo.deleted_at IS NULL
+  AND wo.status NOT IN @closed
+  AND wo.due_date < CURRENT_DATE
+  AND COALESCE(wo.is_overdue, false) = false
+RETURNING wo.id, wo.code, wo.due_date, a.code AS asset_code, a.name AS asset_name`, map[string]any{"closed": []string{StatusDone, StatusCancelled}}).Scan(&orders).Error
+	if err != nil {
+		app.Logger().Error().Err(err).Msg("Failed to mark the overdue work orders.")
+		return
+	}
+
+	ids := []string{}
+	for _, o := range orders {
+		ids = append(ids, o.ID)
+		n := notification.Notification{}
+		n.Type.Set("work_order_overdue")
+		n.Title.Set("Work order overdue")
+		n.Message.Set(fmt.Sprintf("The work order %s of asset %s (%s) was due on %s.", o.Code, o.AssetName, o.AssetCode, o.DueDate.Format("2006-01-02")))
+		n.Endpoint.Set(WorkOrder{}.EndPoint())
+		n.DataID.Set(o.ID)
+		err = notification.Notify(tx, n)
+		if err != nil {
+			app.Logger().Error().Err(err).Str("work_order_id", o.ID).Msg("Failed to notify the overdue work order.")
+		}
+	}
+
+	if len(ids) > 0 {
+		app.Cache().Invalidate(WorkOrder{}.EndPoint(), ids...)
+	}
+}